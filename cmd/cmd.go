@@ -3,13 +3,19 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/net/http/httpguts"
+
 	"github.com/richardwooding/feed-mcp/mcpserver"
 	"github.com/richardwooding/feed-mcp/model"
 	"github.com/richardwooding/feed-mcp/store"
@@ -17,12 +23,14 @@ import (
 
 // RunCmd holds the command line arguments and flags for the run command
 type RunCmd struct {
-	Transport       string        `name:"transport" default:"stdio" enum:"stdio,http-with-sse,streamable-http" help:"Transport to use for the MCP server (streamable-http is recommended for HTTP)."`
-	Feeds           []string      `arg:"" name:"feeds" optional:"" help:"Feeds to list (cannot be used with --opml)."`
-	OPML            string        `name:"opml" help:"OPML file path or URL to load feed URLs from (cannot be used with feeds)."`
-	ExpireAfter     time.Duration `name:"expire-after" default:"1h" help:"Expire feeds after this duration."`
-	Timeout         time.Duration `name:"timeout" default:"30s" help:"Timeout for fetching feed."`
-	ShutdownTimeout time.Duration `name:"shutdown-timeout" default:"30s" help:"Timeout for graceful shutdown."`
+	Transport           string        `name:"transport" default:"stdio" enum:"stdio,http-with-sse,streamable-http" help:"Transport to use for the MCP server (streamable-http is recommended for HTTP)."`
+	Feeds               []string      `arg:"" name:"feeds" optional:"" help:"Feeds to list (cannot be used with --opml)."`
+	OPML                string        `name:"opml" help:"OPML file path or URL to load feed URLs from (cannot be used with feeds)."`
+	ExpireAfter         time.Duration `name:"expire-after" default:"1h" help:"Expire feeds after this duration."`
+	Timeout             time.Duration `name:"timeout" default:"30s" help:"Timeout for fetching feed."`
+	OverallFetchTimeout time.Duration `name:"overall-fetch-timeout" default:"0s" help:"Cap on total time fetching one feed, including retries and backoff (0 for no cap)."`
+	ParseTimeout        time.Duration `name:"parse-timeout" default:"0s" help:"Cap on time spent parsing a received feed body, separate from the network timeout (0 for no cap). A JSON Feed still decodes to the end in the background after the cap; only the wait is cut short."`
+	ShutdownTimeout     time.Duration `name:"shutdown-timeout" default:"30s" help:"Timeout for graceful shutdown."`
 	// HTTP connection pooling settings
 	MaxIdleConns        int           `name:"max-idle-conns" default:"100" help:"Maximum number of idle HTTP connections across all hosts."`
 	MaxConnsPerHost     int           `name:"max-conns-per-host" default:"10" help:"Maximum number of connections per host."`
@@ -33,18 +41,81 @@ type RunCmd struct {
 	BurstCapacity          int           `name:"burst-capacity" default:"5" help:"Per-host rate-limit burst capacity (max immediate requests before throttling)."`
 	RateLimiterIdleTimeout time.Duration `name:"rate-limiter-idle-timeout" default:"1h" help:"Evict a host's rate limiter after this idle period, bounding memory under runtime feed churn (0 disables eviction)."`
 	// Retry mechanism settings
-	RetryMaxAttempts int           `name:"retry-max-attempts" default:"3" help:"Maximum number of retry attempts for failed feed fetches."`
-	RetryBaseDelay   time.Duration `name:"retry-base-delay" default:"1s" help:"Base delay for exponential backoff between retry attempts."`
-	RetryMaxDelay    time.Duration `name:"retry-max-delay" default:"30s" help:"Maximum delay between retry attempts."`
-	RetryJitter      bool          `name:"retry-jitter" default:"true" help:"Enable jitter in retry delays to avoid thundering herd."`
+	RetryMaxAttempts    int           `name:"retry-max-attempts" default:"3" help:"Maximum number of retry attempts for failed feed fetches."`
+	RetryBaseDelay      time.Duration `name:"retry-base-delay" default:"1s" help:"Base delay for exponential backoff between retry attempts."`
+	RetryMaxDelay       time.Duration `name:"retry-max-delay" default:"30s" help:"Maximum delay between retry attempts."`
+	RetryJitter         bool          `name:"retry-jitter" default:"true" help:"Enable jitter in retry delays to avoid thundering herd."`
+	RetryMaxElapsedTime time.Duration `name:"retry-max-elapsed-time" default:"0s" help:"Stop retrying a failing feed once its attempts and backoff would take longer than this, even with attempts left (0 for no budget)."`
+	RetryParseErrors    bool          `name:"retry-parse-errors" default:"false" help:"Retry feeds whose body fails to parse because it was cut short (other parse errors are never retried). Only matters with --verify-body-completeness=false, since cut-short bodies are otherwise caught and retried before parsing."`
+	// Response completeness
+	VerifyBodyCompleteness bool `name:"verify-body-completeness" default:"true" help:"Check each feed body is complete (Content-Length received, document closed) before parsing, retrying truncated responses instead of failing them as parse errors (disable with --verify-body-completeness=false)."`
+	// Unhealthy feed backoff
+	FailedFeedBackoff []time.Duration `name:"failed-feed-backoff" help:"Escalating waits before re-checking a feed after consecutive failures, e.g. 1m,5m,30m (the last repeats; empty disables)."`
+	// Scheduled refresh settings
+	RefreshCron                string        `name:"refresh-cron" help:"Re-fetch every feed on this cron schedule, e.g. '0 8-18 * * 1-5' for hourly during weekday business hours (prefix CRON_TZ=<zone> for a time zone)."`
+	FeedRefreshCron            []string      `name:"feed-refresh-cron" sep:"none" help:"Cron schedule for one feed, as URL=EXPR, overriding --refresh-cron for that feed (repeatable)."`
+	DuplicateFeedCheckInterval time.Duration `name:"duplicate-feed-check-interval" default:"1h" help:"How often to compare feeds for identical content, flagging likely mirrors in diagnostics and list_duplicate_feeds (0 disables)."`
+	// Item normalization settings
+	MissingDateStrategy  string        `name:"missing-date-strategy" default:"include" enum:"include,exclude,use_updated,use_now" help:"How to treat items without a publish date: include (sorted last, pass date filters), exclude, use_updated (fall back to the updated date), or use_now (stamp the fetch time)."`
+	HideFutureItems      bool          `name:"hide-future-items" default:"false" help:"Leave out items published in the future (scheduled posts, clock skew) when feeds are read; responses report how many were hidden."`
+	FutureItemTolerance  time.Duration `name:"future-item-tolerance" default:"5m" help:"How far past now an item's publish date may be before --hide-future-items hides it (0 uses the default)."`
+	EnableSearchIndex    bool          `name:"enable-search-index" default:"false" help:"Index item text in memory so search filters are answered without scanning every item."`
+	LenientXML           bool          `name:"lenient-xml" default:"false" help:"Retry feeds that fail to parse after repairing undeclared HTML entities, invalid control characters, and invalid UTF-8."`
+	StrictParsing        bool          `name:"strict-parsing" default:"false" help:"Reject feeds that parse but lack a title, items, or other expected structure (e.g. HTML served in place of a feed)."`
+	AcceptedContentTypes []string      `name:"accepted-content-types" help:"Reject feed responses whose Content-Type isn't in this list, before parsing; 'default' stands for the RSS, Atom, JSON Feed, and XML types (e.g. default,text/plain)."`
+	ResolveRelativeURLs  bool          `name:"resolve-relative-urls" default:"true" help:"Make relative item links, enclosure URLs, and content image URLs absolute, resolved against the feed's link (disable with --resolve-relative-urls=false)."`
+	// Category normalization settings
+	NormalizeCategories bool              `name:"normalize-categories" default:"false" help:"Lowercase and trim item categories so filters and facets match across feeds (originals are kept)."`
+	CategorySynonyms    map[string]string `name:"category-synonyms" help:"Map category aliases onto a canonical name, e.g. 'tech=technology;ai=artificial intelligence' (implies --normalize-categories)."`
+	// Item identity settings
+	StableIDFallback      []string `name:"stable-id-fallback" default:"guid,link,hash" help:"Order of sources for each item's stable_id, used for deduplication: guid, link (normalized), and hash (of title and publish date)."`
+	DeduplicateWithinFeed bool     `name:"deduplicate-within-feed" default:"true" help:"Drop items a feed repeats within one response, matched by stable_id (disable with --deduplicate-within-feed=false)."`
+	// Enclosure settings
+	VerifyEnclosures bool `name:"verify-enclosures" default:"false" help:"Send a rate-limited HEAD request for item enclosures at fetch time to report reachability, size, and type (verified_size/verified_type in item media)."`
+	// Per-feed request settings
+	FeedHeaders          []string `name:"feed-header" sep:"none" help:"Extra request header for one feed, as URL:Name=Value, e.g. 'https://example.com/feed:X-API-Key=abc' (repeatable). Sent only to that exact URL; values are never logged."`
+	UserAgents           []string `name:"user-agent" sep:"none" help:"User-Agent to send with feed requests (repeatable); with several, requests rotate through them per --user-agent-rotation. A User-Agent set with --feed-header still wins for its feed."`
+	UserAgentRotation    string   `name:"user-agent-rotation" default:"round-robin" enum:"round-robin,random" help:"How requests pick from --user-agent values: round-robin or random."`
+	FeedFallbackURLs     []string `name:"feed-fallback-url" sep:"none" help:"Alternative URL for one feed, as URL=FALLBACK, tried when the feed URL fails after retries (repeatable; fallbacks are tried in the order given)."`
+	FeedStrip            []string `name:"feed-strip" sep:"none" help:"Cleaning rule for one feed's item content, as URL=css:SELECTOR (remove matching elements) or URL=regex:PATTERN (delete matching text), e.g. 'https://example.com/feed=css:div.ad' (repeatable; rules apply in the order given)."`
+	UpgradeInsecureFeeds bool     `name:"upgrade-insecure-feeds" default:"false" help:"Fetch http:// feeds over https:// first, falling back to http:// only when HTTPS fails."`
 	// Security settings
-	AllowPrivateIPs bool `name:"allow-private-ips" default:"false" help:"Allow feed URLs that resolve to private IP ranges or localhost (disabled by default for security)."`
+	AllowPrivateIPs bool   `name:"allow-private-ips" default:"false" help:"Allow feed URLs that resolve to private IP ranges or localhost (disabled by default for security)."`
+	MinTLSVersion   string `name:"min-tls-version" default:"1.2" enum:"1.0,1.1,1.2,1.3" help:"Oldest TLS version accepted when fetching feeds; feeds on servers that only support older versions fail with a TLS error."`
 	// Runtime feed management settings
-	AllowRuntimeFeeds bool `name:"allow-runtime-feeds" default:"false" help:"Enable runtime feed management tools (add_feed, remove_feed, list_managed_feeds)."`
+	AllowRuntimeFeeds bool   `name:"allow-runtime-feeds" default:"false" help:"Enable runtime feed management tools (add_feed, remove_feed, list_managed_feeds)."`
+	FeedStoreFile     string `name:"feed-store-file" type:"path" help:"JSON file that persists runtime-added feeds across restarts (requires --allow-runtime-feeds)."`
+	MaxFeeds          int    `name:"max-feeds" default:"0" help:"Maximum feeds managed at once, counting startup and runtime-added feeds (0 for no limit)."`
+	// Startup feed list settings
+	KeepDuplicateFeeds bool     `name:"keep-duplicate-feeds" default:"false" help:"Register every startup feed URL even when two name the same feed (differing only in host case, default port, fragment, or trailing slash); by default only the first is kept."`
+	FeedTags           []string `name:"feed-tag" sep:"none" help:"Tags for one startup feed, as URL=TAG[,TAG...], e.g. 'https://example.com/feed=priority:high,team:infra' (repeatable). Bulk tools can select feeds by tag."`
+	// Fetch prioritization settings
+	FeedPriorities       []string `name:"feed-priority" sep:"none" help:"Fetch priority for one feed, as URL=N, e.g. 'https://example.com/feed=10' (repeatable). When fetches queue for a slot, higher-priority feeds go first; others have priority 0."`
+	MaxConcurrentFetches int      `name:"max-concurrent-fetches" default:"0" help:"Maximum upstream fetches that listing all feeds and scheduled refreshes run at once, queued by --feed-priority (0 for no limit)."`
+	// Tool selection settings
+	EnableTools  []string `name:"enable-tools" help:"Register only these tools (comma-separated); all tools when unset."`
+	DisableTools []string `name:"disable-tools" help:"Do not register these tools (comma-separated), e.g. fetch_link."`
+	// fetch_link settings
+	FetchLinkTimeout     time.Duration `name:"fetch-link-timeout" default:"30s" help:"Timeout for each fetch_link request."`
+	FetchLinkMaxAttempts int           `name:"fetch-link-max-attempts" default:"3" help:"Attempts fetch_link makes for a page that fails transiently (network errors, 429, 5xx)."`
+	// Tool result cache settings
+	ToolResultCacheTTL time.Duration `name:"tool-result-cache-ttl" default:"0s" help:"Cache the results of aggregation tools (estimate_feed_frequency, get_feed_categories, list_feeds_by_activity, feed_overlap) for this long, until a feed refreshes (0 disables)."`
+	// Item classification settings
+	SubstantiveMinLength int `name:"substantive-min-length" default:"100" help:"Content length (title, description, and content, in characters) below which an item is a stub; get_syndication_feed_items flags stubs and its substantive filter drops them. 0 uses the default."`
+	ThumbnailSize        int `name:"thumbnail-size" default:"300" help:"Width in pixels get_syndication_feed_items picks each item's thumbnail for among the media:thumbnail and media:content sizes a feed offers: the smallest at least this wide, else the largest. 0 uses the default."`
+	// File export settings
+	AllowFileExport bool   `name:"allow-file-export" default:"false" help:"Let export_feed_data write exports to files (its outputPath parameter) inside --file-export-dir."`
+	FileExportDir   string `name:"file-export-dir" type:"path" help:"Directory export_feed_data writes export files into (requires --allow-file-export); paths can't escape it."`
+	// Read state settings
+	ReadStateFile string `name:"read-state-file" type:"path" help:"JSON file that persists which items have been marked read (mark_read, mark_all_read) across restarts; in memory only when unset. Only clients without a session ID, such as stdio, are persisted, since HTTP session IDs change on restart."`
+	// Resource settings
+	MaxResourceFetches      int           `name:"max-concurrent-resource-fetches" default:"8" help:"Maximum upstream fetches resource reads run at once, across all reads and the feeds of one feeds://all read; further fetches wait. 0 uses the default."`
+	ResourceRefreshInterval time.Duration `name:"resource-refresh-interval" default:"30s" help:"Minimum time between two forced refreshes of one feed by resource reads with refresh=true; such reads within it are served from the cache. 0 uses the default."`
 	// HTTP server settings (for streamable-http transport)
 	HTTPPort           string        `name:"http-port" default:"8080" env:"PORT" help:"Port for HTTP server (streamable-http transport)."`
 	HTTPStateless      bool          `name:"http-stateless" default:"false" help:"Run HTTP server in stateless mode (no session tracking)."`
 	HTTPSessionTimeout time.Duration `name:"http-session-timeout" default:"30m" help:"Timeout for idle HTTP sessions."`
+	HTTPCompression    bool          `name:"http-compression" default:"true" help:"Gzip HTTP responses for clients that send Accept-Encoding: gzip (disable with --http-compression=false)."`
 }
 
 // validateStartupFeedURLs runs up-front SSRF validation over the configured feed
@@ -127,9 +198,391 @@ func storeRateLimiterIdleTimeout(flag time.Duration) time.Duration {
 	return flag
 }
 
+// parseFeedHeaders parses --feed-header values of the form URL:Name=Value into
+// the store's per-feed headers. The URL itself contains colons, so the split is
+// at the first colon that leaves an absolute http(s) URL before it and a valid
+// header name before the following '='. Values may be secrets, so errors name
+// the flag's position and the header, never the value.
+func parseFeedHeaders(flags []string) (map[string]map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]map[string]string)
+	for i, flag := range flags {
+		feedURL, name, value, ok := splitFeedHeader(flag)
+		if !ok {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-header #%d must be URL:Name=Value with an http(s) URL and a valid header name", i+1)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-header #%d: the value of %s contains invalid characters", i+1, name)).
+				WithURL(feedURL).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		if headers[feedURL] == nil {
+			headers[feedURL] = make(map[string]string)
+		}
+		headers[feedURL][name] = value
+	}
+	return headers, nil
+}
+
+// parseFeedFallbackURLs parses --feed-fallback-url values of the form
+// URL=FALLBACK into the store's fallback URLs, keeping each feed's fallbacks in
+// flag order. Either URL may contain '=' in its query string, so the split is
+// at the first '=' that leaves absolute http(s) URLs on both sides.
+func parseFeedFallbackURLs(flags []string) (map[string][]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	fallbacks := make(map[string][]string)
+	for i, flag := range flags {
+		feedURL, fallback, ok := splitFeedFallbackURL(flag)
+		if !ok {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-fallback-url #%d must be URL=FALLBACK with two http(s) URLs, got %q", i+1, flag)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		fallbacks[feedURL] = append(fallbacks[feedURL], fallback)
+	}
+	return fallbacks, nil
+}
+
+// splitFeedFallbackURL splits one --feed-fallback-url value; see
+// parseFeedFallbackURLs.
+func splitFeedFallbackURL(flag string) (feedURL, fallback string, ok bool) {
+	for i := range len(flag) {
+		if flag[i] != '=' {
+			continue
+		}
+		candidate, rest := flag[:i], flag[i+1:]
+		if isHTTPURL(candidate) && isHTTPURL(rest) {
+			return candidate, rest, true
+		}
+	}
+	return "", "", false
+}
+
+// isHTTPURL reports whether s is an absolute http(s) URL with a host.
+func isHTTPURL(s string) bool {
+	parsed, err := url.Parse(s)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// parseFeedRefreshCron parses --feed-refresh-cron values of the form URL=EXPR.
+// Both halves may contain '=' (a query string, a CRON_TZ= prefix), so the
+// split is at the first '=' that leaves an absolute http(s) URL before it and
+// a valid cron expression after it.
+func parseFeedRefreshCron(flags []string) (map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	schedules := make(map[string]string, len(flags))
+	for i, flag := range flags {
+		feedURL, expr, ok := splitFeedRefreshCron(flag)
+		if !ok {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-refresh-cron #%d must be URL=EXPR with an http(s) URL and a valid cron expression, got %q", i+1, flag)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		schedules[feedURL] = expr
+	}
+	return schedules, nil
+}
+
+// splitFeedRefreshCron splits one --feed-refresh-cron value; see
+// parseFeedRefreshCron.
+func splitFeedRefreshCron(flag string) (feedURL, expr string, ok bool) {
+	for i := range len(flag) {
+		if flag[i] != '=' {
+			continue
+		}
+		candidate, rest := flag[:i], strings.TrimSpace(flag[i+1:])
+		parsed, err := url.Parse(candidate)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			continue
+		}
+		if store.ValidateRefreshCron(rest) != nil {
+			continue
+		}
+		return candidate, rest, true
+	}
+	return "", "", false
+}
+
+// parseFeedStrip parses --feed-strip values of the form URL=RULE into the
+// store's content cleaning rules, keeping each feed's rules in flag order.
+// Both halves may contain '=' (a query string, an attribute selector), so the
+// split is at the first '=' that leaves an absolute http(s) URL before it and
+// a valid rule after it.
+func parseFeedStrip(flags []string) (map[string][]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	rules := make(map[string][]string)
+	for i, flag := range flags {
+		feedURL, rule, ok := splitFeedStrip(flag)
+		if !ok {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-strip #%d must be URL=css:SELECTOR or URL=regex:PATTERN with an http(s) URL and a valid selector or pattern, got %q", i+1, flag)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		rules[feedURL] = append(rules[feedURL], rule)
+	}
+	return rules, nil
+}
+
+// splitFeedStrip splits one --feed-strip value; see parseFeedStrip.
+func splitFeedStrip(flag string) (feedURL, rule string, ok bool) {
+	for i := range len(flag) {
+		if flag[i] != '=' {
+			continue
+		}
+		candidate, rest := flag[:i], flag[i+1:]
+		if isHTTPURL(candidate) && store.ValidateContentCleaningRule(rest) == nil {
+			return candidate, rest, true
+		}
+	}
+	return "", "", false
+}
+
+// parseFeedTags parses --feed-tag values of the form URL=TAG[,TAG...] into the
+// store's feed tags, merging repeated flags for the same feed. The URL may
+// contain '=' in its query string and tags may not, so the split is at the
+// last '='.
+func parseFeedTags(flags []string) (map[string][]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string][]string)
+	for i, flag := range flags {
+		eq := strings.LastIndexByte(flag, '=')
+		if eq < 0 || !isHTTPURL(flag[:eq]) || strings.TrimSpace(strings.ReplaceAll(flag[eq+1:], ",", "")) == "" {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-tag #%d must be URL=TAG[,TAG...] with an http(s) URL and at least one tag, got %q", i+1, flag)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		feedURL, feedTags := flag[:eq], strings.Split(flag[eq+1:], ",")
+		if err := model.ValidateFeedTags(feedTags); err != nil {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-tag #%d: %v", i+1, err)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		tags[feedURL] = append(tags[feedURL], feedTags...)
+	}
+	return tags, nil
+}
+
+// parseFeedPriorities parses --feed-priority values of the form URL=N. The URL
+// may contain '=' in its query string and the priority may not, so the split
+// is at the last '='. A repeated feed takes its last priority.
+func parseFeedPriorities(flags []string) (map[string]int, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	priorities := make(map[string]int, len(flags))
+	for i, flag := range flags {
+		eq := strings.LastIndexByte(flag, '=')
+		var priority int
+		var err error
+		if eq >= 0 {
+			priority, err = strconv.Atoi(strings.TrimSpace(flag[eq+1:]))
+		}
+		if eq < 0 || err != nil || !isHTTPURL(flag[:eq]) {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--feed-priority #%d must be URL=N with an http(s) URL and an integer priority, got %q", i+1, flag)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+		priorities[flag[:eq]] = priority
+	}
+	return priorities, nil
+}
+
+// tlsVersions maps --min-tls-version values to crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// splitFeedHeader splits one --feed-header value; see parseFeedHeaders.
+func splitFeedHeader(flag string) (feedURL, name, value string, ok bool) {
+	for i := range len(flag) {
+		if flag[i] != ':' {
+			continue
+		}
+		candidate, rest := flag[:i], flag[i+1:]
+		headerName, headerValue, found := strings.Cut(rest, "=")
+		if !found || !httpguts.ValidHeaderFieldName(headerName) {
+			continue
+		}
+		parsed, err := url.Parse(candidate)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			continue
+		}
+		return candidate, headerName, headerValue, true
+	}
+	return "", "", "", false
+}
+
+// parseTransport resolves the --transport flag, falling back to
+// model.DefaultTransport when it is unset (e.g. a RunCmd built in code rather
+// than parsed by Kong, which applies the flag default itself).
+func (c *RunCmd) parseTransport() (model.Transport, error) {
+	if c.Transport == "" {
+		return model.DefaultTransport, nil
+	}
+	return model.ParseTransport(c.Transport)
+}
+
+// Validate is called by Kong after parsing, so an unknown transport,
+// missing-date strategy, stable ID source, or tool name fails at parse time
+// (with the list of valid options) rather than when the server runs.
+func (c *RunCmd) Validate() error {
+	if _, err := c.parseTransport(); err != nil {
+		return err
+	}
+	if _, err := model.ParseMissingDateStrategy(c.MissingDateStrategy); err != nil {
+		return err
+	}
+	if _, err := model.ParseStableIDChain(c.StableIDFallback); err != nil {
+		return err
+	}
+	if err := mcpserver.ValidateToolNames(c.EnableTools); err != nil {
+		return err
+	}
+	if c.MaxFeeds < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--max-feeds must not be negative, got %d", c.MaxFeeds)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.ToolResultCacheTTL < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--tool-result-cache-ttl must not be negative, got %s", c.ToolResultCacheTTL)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.DuplicateFeedCheckInterval < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--duplicate-feed-check-interval must not be negative, got %s", c.DuplicateFeedCheckInterval)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.RetryMaxElapsedTime < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--retry-max-elapsed-time must not be negative, got %s", c.RetryMaxElapsedTime)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.ResourceRefreshInterval < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--resource-refresh-interval must not be negative, got %s", c.ResourceRefreshInterval)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.MaxResourceFetches < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--max-concurrent-resource-fetches must not be negative, got %d", c.MaxResourceFetches)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.SubstantiveMinLength < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--substantive-min-length must not be negative, got %d", c.SubstantiveMinLength)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.ThumbnailSize < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--thumbnail-size must not be negative, got %d", c.ThumbnailSize)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.AllowFileExport && c.FileExportDir == "" {
+		return model.NewFeedError(model.ErrorTypeConfiguration, "--allow-file-export requires --file-export-dir").
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.FileExportDir != "" && !c.AllowFileExport {
+		return model.NewFeedError(model.ErrorTypeConfiguration, "--file-export-dir requires --allow-file-export").
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if _, err := parseFeedHeaders(c.FeedHeaders); err != nil {
+		return err
+	}
+	if c.RefreshCron != "" {
+		if err := store.ValidateRefreshCron(c.RefreshCron); err != nil {
+			return err
+		}
+	}
+	if _, err := parseFeedRefreshCron(c.FeedRefreshCron); err != nil {
+		return err
+	}
+	if _, err := parseFeedFallbackURLs(c.FeedFallbackURLs); err != nil {
+		return err
+	}
+	if _, err := parseFeedStrip(c.FeedStrip); err != nil {
+		return err
+	}
+	if _, err := parseFeedTags(c.FeedTags); err != nil {
+		return err
+	}
+	if _, err := parseFeedPriorities(c.FeedPriorities); err != nil {
+		return err
+	}
+	if c.FutureItemTolerance < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--future-item-tolerance must not be negative, got %s", c.FutureItemTolerance)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	if c.MaxConcurrentFetches < 0 {
+		return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--max-concurrent-fetches must not be negative, got %d", c.MaxConcurrentFetches)).
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+	for _, interval := range c.FailedFeedBackoff {
+		if interval <= 0 {
+			return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("--failed-feed-backoff must be positive, got %s", interval)).
+				WithOperation("run_command").
+				WithComponent("cli")
+		}
+	}
+	return mcpserver.ValidateToolNames(c.DisableTools)
+}
+
 // Run executes the feed MCP server with the given configuration
 func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
-	transport, err := model.ParseTransport(c.Transport)
+	transport, err := c.parseTransport()
+	if err != nil {
+		return err
+	}
+
+	missingDateStrategy, err := model.ParseMissingDateStrategy(c.MissingDateStrategy)
+	if err != nil {
+		return err
+	}
+	stableIDChain, err := model.ParseStableIDChain(c.StableIDFallback)
+	if err != nil {
+		return err
+	}
+	feedHeaders, err := parseFeedHeaders(c.FeedHeaders)
+	if err != nil {
+		return err
+	}
+	feedRefreshCron, err := parseFeedRefreshCron(c.FeedRefreshCron)
+	if err != nil {
+		return err
+	}
+	feedFallbackURLs, err := parseFeedFallbackURLs(c.FeedFallbackURLs)
+	if err != nil {
+		return err
+	}
+	contentCleaning, err := parseFeedStrip(c.FeedStrip)
+	if err != nil {
+		return err
+	}
+	feedTags, err := parseFeedTags(c.FeedTags)
+	if err != nil {
+		return err
+	}
+	feedPriorities, err := parseFeedPriorities(c.FeedPriorities)
 	if err != nil {
 		return err
 	}
@@ -144,6 +597,12 @@ func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
 			WithComponent("cli")
 	}
 
+	if c.FeedStoreFile != "" && !c.AllowRuntimeFeeds {
+		return model.NewFeedError(model.ErrorTypeConfiguration, "--feed-store-file requires --allow-runtime-feeds").
+			WithOperation("run_command").
+			WithComponent("cli")
+	}
+
 	if c.OPML != "" {
 		// Load feed URLs from OPML
 		feedURLs, err = model.LoadFeedURLsFromOPML(c.OPML)
@@ -169,22 +628,57 @@ func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
 	}
 
 	storeConfig := store.Config{
-		Feeds:                  feedURLs,
-		OPML:                   c.OPML, // Pass OPML path for metadata source detection
-		Timeout:                c.Timeout,
-		ExpireAfter:            c.ExpireAfter,
-		RequestsPerSecond:      c.RequestsPerSecond,
-		BurstCapacity:          c.BurstCapacity,
-		RateLimiterIdleTimeout: storeRateLimiterIdleTimeout(c.RateLimiterIdleTimeout),
-		MaxIdleConns:           c.MaxIdleConns,
-		MaxConnsPerHost:        c.MaxConnsPerHost,
-		MaxIdleConnsPerHost:    c.MaxIdleConnsPerHost,
-		IdleConnTimeout:        c.IdleConnTimeout,
-		RetryMaxAttempts:       c.RetryMaxAttempts,
-		RetryBaseDelay:         c.RetryBaseDelay,
-		RetryMaxDelay:          c.RetryMaxDelay,
-		RetryJitter:            c.RetryJitter,
-		AllowPrivateIPs:        c.AllowPrivateIPs,
+		Feeds:                      feedURLs,
+		OPML:                       c.OPML, // Pass OPML path for metadata source detection
+		Timeout:                    c.Timeout,
+		OverallFetchTimeout:        c.OverallFetchTimeout,
+		ParseTimeout:               c.ParseTimeout,
+		FailedFeedBackoff:          c.FailedFeedBackoff,
+		ExpireAfter:                c.ExpireAfter,
+		RequestsPerSecond:          c.RequestsPerSecond,
+		BurstCapacity:              c.BurstCapacity,
+		RateLimiterIdleTimeout:     storeRateLimiterIdleTimeout(c.RateLimiterIdleTimeout),
+		MaxIdleConns:               c.MaxIdleConns,
+		MaxConnsPerHost:            c.MaxConnsPerHost,
+		MaxIdleConnsPerHost:        c.MaxIdleConnsPerHost,
+		IdleConnTimeout:            c.IdleConnTimeout,
+		RetryMaxAttempts:           c.RetryMaxAttempts,
+		RetryBaseDelay:             c.RetryBaseDelay,
+		RetryMaxDelay:              c.RetryMaxDelay,
+		RetryJitter:                c.RetryJitter,
+		RetryMaxElapsedTime:        c.RetryMaxElapsedTime,
+		RetryParseErrors:           c.RetryParseErrors,
+		AllowPrivateIPs:            c.AllowPrivateIPs,
+		MissingDateStrategy:        missingDateStrategy,
+		HideFutureItems:            c.HideFutureItems,
+		FutureItemTolerance:        c.FutureItemTolerance,
+		StrictParsing:              c.StrictParsing,
+		LenientXML:                 c.LenientXML,
+		AcceptedContentTypes:       expandAcceptedContentTypes(c.AcceptedContentTypes),
+		FeedStoreFile:              c.FeedStoreFile,
+		EnableSearchIndex:          c.EnableSearchIndex,
+		NormalizeCategories:        c.NormalizeCategories,
+		CategorySynonyms:           c.CategorySynonyms,
+		StableIDChain:              stableIDChain,
+		ResolveRelativeURLs:        &c.ResolveRelativeURLs,
+		DeduplicateWithinFeed:      &c.DeduplicateWithinFeed,
+		VerifyBodyCompleteness:     &c.VerifyBodyCompleteness,
+		VerifyEnclosures:           c.VerifyEnclosures,
+		UpgradeInsecureFeeds:       c.UpgradeInsecureFeeds,
+		MaxFeeds:                   c.MaxFeeds,
+		KeepDuplicateFeeds:         c.KeepDuplicateFeeds,
+		PerFeedHeaders:             feedHeaders,
+		UserAgents:                 c.UserAgents,
+		UserAgentRotation:          store.UserAgentRotation(c.UserAgentRotation),
+		FallbackURLs:               feedFallbackURLs,
+		MinTLSVersion:              tlsVersions[c.MinTLSVersion],
+		RefreshCron:                c.RefreshCron,
+		FeedRefreshCron:            feedRefreshCron,
+		DuplicateFeedCheckInterval: c.DuplicateFeedCheckInterval,
+		ContentCleaning:            contentCleaning,
+		FeedTags:                   feedTags,
+		FeedPriorities:             feedPriorities,
+		MaxConcurrentFetches:       c.MaxConcurrentFetches,
 	}
 
 	serverConfig := mcpserver.Config{
@@ -192,6 +686,20 @@ func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
 		HTTPPort:           c.HTTPPort,
 		HTTPStateless:      c.HTTPStateless,
 		HTTPSessionTimeout: c.HTTPSessionTimeout,
+		HTTPCompression:    &c.HTTPCompression,
+		EnabledTools:       c.EnableTools,
+		DisabledTools:      c.DisableTools,
+
+		MaxConcurrentResourceFetches: c.MaxResourceFetches,
+		ResourceRefreshInterval:      c.ResourceRefreshInterval,
+		FetchLinkTimeout:             c.FetchLinkTimeout,
+		FetchLinkMaxAttempts:         c.FetchLinkMaxAttempts,
+		AllowPrivateIPs:              c.AllowPrivateIPs,
+		ToolResultCacheTTL:           c.ToolResultCacheTTL,
+		FileExportDir:                c.FileExportDir,
+		ReadStateFile:                c.ReadStateFile,
+		SubstantiveMinLength:         c.SubstantiveMinLength,
+		ThumbnailSize:                c.ThumbnailSize,
 	}
 
 	if c.AllowRuntimeFeeds {
@@ -200,17 +708,19 @@ func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		serverConfig.AllFeedsGetter = dynamicStore
-		serverConfig.FeedAndItemsGetter = dynamicStore
+		useStore(&serverConfig, dynamicStore)
 		serverConfig.DynamicFeedManager = dynamicStore
+		dynamicStore.StartRefreshSchedule(ctx)
+		dynamicStore.StartDuplicateFeedCheck(ctx)
 	} else {
 		// Use regular Store
 		feedStore, err := store.NewStore(&storeConfig)
 		if err != nil {
 			return err
 		}
-		serverConfig.AllFeedsGetter = feedStore
-		serverConfig.FeedAndItemsGetter = feedStore
+		useStore(&serverConfig, feedStore)
+		feedStore.StartRefreshSchedule(ctx)
+		feedStore.StartDuplicateFeedCheck(ctx)
 	}
 
 	server, err := mcpserver.NewServer(&serverConfig)
@@ -219,3 +729,57 @@ func (c *RunCmd) Run(globals *model.Globals, ctx context.Context) error {
 	}
 	return server.Run(ctx)
 }
+
+// serverStore is what the feed stores offer the MCP server: the feeds
+// themselves and every optional store capability.
+type serverStore interface {
+	mcpserver.AllFeedsGetter
+	mcpserver.FeedAndItemsGetter
+	mcpserver.CircuitBreakerResetter
+	mcpserver.FeedHistoryProvider
+	mcpserver.FeedPinger
+	mcpserver.FeedCachePurger
+	mcpserver.DuplicateFeedsProvider
+	mcpserver.DiagnosticsProvider
+	mcpserver.ConfigProvider
+	mcpserver.FeedTagsProvider
+	mcpserver.FeedIconResolver
+	mcpserver.ItemSearcher
+	mcpserver.FeedGenerationReporter
+}
+
+// useStore serves feeds, and every optional store capability, from feedStore.
+func useStore(config *mcpserver.Config, feedStore serverStore) {
+	config.AllFeedsGetter = feedStore
+	config.FeedAndItemsGetter = feedStore
+	config.CircuitBreakerResetter = feedStore
+	config.FeedHistoryProvider = feedStore
+	config.FeedPinger = feedStore
+	config.FeedCachePurger = feedStore
+	config.DuplicateFeedsProvider = feedStore
+	config.DiagnosticsProvider = feedStore
+	config.ConfigProvider = feedStore
+	config.FeedTagsProvider = feedStore
+	config.FeedIconResolver = feedStore
+	config.ItemSearcher = feedStore
+	config.FeedGenerationReporter = feedStore
+}
+
+// expandAcceptedContentTypes replaces each "default" entry in types with
+// store.DefaultAcceptedContentTypes and drops blanks and duplicates.
+func expandAcceptedContentTypes(types []string) []string {
+	var expanded []string
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		candidates := []string{t}
+		if t == "default" {
+			candidates = store.DefaultAcceptedContentTypes
+		}
+		for _, candidate := range candidates {
+			if candidate != "" && !slices.Contains(expanded, candidate) {
+				expanded = append(expanded, candidate)
+			}
+		}
+	}
+	return expanded
+}
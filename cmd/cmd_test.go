@@ -2,12 +2,18 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/alecthomas/kong"
 
+	"github.com/richardwooding/feed-mcp/mcpserver"
 	"github.com/richardwooding/feed-mcp/model"
+	"github.com/richardwooding/feed-mcp/store"
 )
 
 func TestRunCmd_Run_InvalidTransport(t *testing.T) {
@@ -126,3 +132,519 @@ func TestRunCmd_RateLimitFlagsOverride(t *testing.T) {
 		t.Errorf("BurstCapacity = %v, want 20", c.Run.BurstCapacity)
 	}
 }
+
+// TestRunCmd_TransportRejectedAtParse verifies that an unknown --transport fails
+// during CLI parsing and that the error lists the valid options.
+func TestRunCmd_TransportRejectedAtParse(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	_, err = parser.Parse([]string{"run", "--transport=carrier-pigeon", "http://example.com/feed"})
+	if err == nil {
+		t.Fatal("expected parse error for unknown transport")
+	}
+	for _, name := range model.TransportNames() {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("parse error %q does not list valid transport %q", err, name)
+		}
+	}
+}
+
+// TestRunCmd_TransportDefault verifies that omitting --transport selects stdio,
+// both through Kong's flag default and for a RunCmd built without one.
+func TestRunCmd_TransportDefault(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"run", "http://example.com/feed"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got, err := c.Run.parseTransport()
+	if err != nil {
+		t.Fatalf("parseTransport: %v", err)
+	}
+	if got != model.StdioTransport {
+		t.Errorf("default transport = %v, want %v", got, model.StdioTransport)
+	}
+
+	unset := &RunCmd{}
+	if err := unset.Validate(); err != nil {
+		t.Errorf("Validate with no transport: %v", err)
+	}
+	if got, _ := unset.parseTransport(); got != model.DefaultTransport {
+		t.Errorf("unset transport = %v, want %v", got, model.DefaultTransport)
+	}
+}
+
+// TestRunCmd_ValidateInvalidTransport verifies that Validate rejects a
+// programmatically-set unknown transport with ErrInvalidTransport.
+func TestRunCmd_ValidateInvalidTransport(t *testing.T) {
+	c := &RunCmd{Transport: "invalid"}
+	err := c.Validate()
+	if !errors.Is(err, model.ErrInvalidTransport) {
+		t.Fatalf("Validate() = %v, want ErrInvalidTransport", err)
+	}
+	if !strings.Contains(err.Error(), "streamable-http") {
+		t.Errorf("error %q does not list valid options", err)
+	}
+}
+
+// TestRunCmd_ToolSelectionFlags verifies that --enable-tools/--disable-tools
+// split comma-separated lists and that unknown tool names fail at parse time.
+func TestRunCmd_ToolSelectionFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"run", "--disable-tools", "fetch_link,export_feed_data", "http://example.com/feed"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(c.Run.DisableTools) != 2 || c.Run.DisableTools[0] != "fetch_link" {
+		t.Errorf("DisableTools = %v, want [fetch_link export_feed_data]", c.Run.DisableTools)
+	}
+
+	c = &cli{}
+	parser, err = kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	_, err = parser.Parse([]string{"run", "--enable-tools", "fetch_everything", "http://example.com/feed"})
+	if !errors.Is(err, mcpserver.ErrUnknownTool) {
+		t.Fatalf("parse error = %v, want ErrUnknownTool", err)
+	}
+}
+
+// TestRunCmd_CategorySynonymsFlag verifies that --category-synonyms parses
+// ;-separated alias=canonical pairs and merges repeated flags.
+func TestRunCmd_CategorySynonymsFlag(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	args := []string{"run", "--category-synonyms", "tech=technology;ai=artificial intelligence", "--category-synonyms", "ml=machine learning", "http://example.com/feed"}
+	if _, err := parser.Parse(args); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := map[string]string{"tech": "technology", "ai": "artificial intelligence", "ml": "machine learning"}
+	if !maps.Equal(c.Run.CategorySynonyms, want) {
+		t.Errorf("CategorySynonyms = %v, want %v", c.Run.CategorySynonyms, want)
+	}
+}
+
+// TestRunCmd_StableIDFallbackFlag verifies the --stable-id-fallback default and
+// that unknown sources fail at parse time.
+func TestRunCmd_StableIDFallbackFlag(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"run", "http://example.com/feed"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := []string{"guid", "link", "hash"}; !slices.Equal(c.Run.StableIDFallback, want) {
+		t.Errorf("StableIDFallback = %v, want %v", c.Run.StableIDFallback, want)
+	}
+
+	c = &cli{}
+	parser, err = kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	_, err = parser.Parse([]string{"run", "--stable-id-fallback", "link,title", "http://example.com/feed"})
+	if !errors.Is(err, model.ErrInvalidStableIDSource) {
+		t.Fatalf("parse error = %v, want ErrInvalidStableIDSource", err)
+	}
+}
+
+// TestRunCmd_FeedHeaderFlag verifies that --feed-header splits URL:Name=Value
+// past the colons in the URL, groups headers by feed, and that malformed values
+// fail at parse time without echoing the header value.
+func TestRunCmd_FeedHeaderFlag(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--feed-header", "https://example.com:8443/feed?a=b:X-API-Key=abc:def,ghi",
+		"--feed-header", "https://example.com:8443/feed?a=b:Accept=application/atom+xml",
+		"--feed-header", "http://other.example/rss:X-Token=t=1",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	headers, err := parseFeedHeaders(c.Run.FeedHeaders)
+	if err != nil {
+		t.Fatalf("parseFeedHeaders: %v", err)
+	}
+	want := map[string]map[string]string{
+		"https://example.com:8443/feed?a=b": {"X-API-Key": "abc:def,ghi", "Accept": "application/atom+xml"},
+		"http://other.example/rss":          {"X-Token": "t=1"},
+	}
+	if len(headers) != len(want) {
+		t.Fatalf("headers = %v, want %v", headers, want)
+	}
+	for feedURL, wantHeaders := range want {
+		if !maps.Equal(headers[feedURL], wantHeaders) {
+			t.Errorf("headers[%q] = %v, want %v", feedURL, headers[feedURL], wantHeaders)
+		}
+	}
+
+	for _, bad := range []string{
+		"X-API-Key=secret-value",
+		"ftp://example.com/feed:X-API-Key=secret-value",
+		"https://example.com/feed:Bad Name=secret-value",
+		"https://example.com/feed:X-API-Key=secret-value\n",
+	} {
+		_, err := parse("--feed-header", bad)
+		if err == nil {
+			t.Errorf("--feed-header %q: expected an error", bad)
+			continue
+		}
+		if strings.Contains(err.Error(), "secret-value") {
+			t.Errorf("--feed-header error leaks the header value: %v", err)
+		}
+	}
+}
+
+// TestRunCmd_RefreshCronFlags verifies that --feed-refresh-cron splits
+// URL=EXPR past '=' in the URL and in a CRON_TZ prefix, and that invalid cron
+// expressions fail at parse time.
+func TestRunCmd_RefreshCronFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--refresh-cron", "0 8-18 * * 1-5",
+		"--feed-refresh-cron", "https://example.com/feed?page=1=CRON_TZ=Europe/London 30 7 * * *",
+		"--feed-refresh-cron", "http://other.example/rss=@hourly",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	schedules, err := parseFeedRefreshCron(c.Run.FeedRefreshCron)
+	if err != nil {
+		t.Fatalf("parseFeedRefreshCron: %v", err)
+	}
+	want := map[string]string{
+		"https://example.com/feed?page=1": "CRON_TZ=Europe/London 30 7 * * *",
+		"http://other.example/rss":        "@hourly",
+	}
+	if !maps.Equal(schedules, want) {
+		t.Errorf("schedules = %v, want %v", schedules, want)
+	}
+
+	for _, args := range [][]string{
+		{"--refresh-cron", "weekdays at 8"},
+		{"--refresh-cron", "0 25 * * *"},
+		{"--feed-refresh-cron", "https://example.com/feed=* * *"},
+		{"--feed-refresh-cron", "@hourly"},
+		{"--feed-refresh-cron", "ftp://example.com/feed=@hourly"},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+// TestRunCmd_FeedFallbackURLFlags verifies that --feed-fallback-url splits
+// URL=FALLBACK past '=' in query strings and keeps each feed's fallbacks in
+// flag order.
+func TestRunCmd_FeedFallbackURLFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--feed-fallback-url", "https://example.com/feed?format=rss=https://example.com/feed?format=atom",
+		"--feed-fallback-url", "https://example.com/feed?format=rss=https://example.com/feed.json",
+		"--feed-fallback-url", "http://other.example/rss=http://mirror.example/rss",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	fallbacks, err := parseFeedFallbackURLs(c.Run.FeedFallbackURLs)
+	if err != nil {
+		t.Fatalf("parseFeedFallbackURLs: %v", err)
+	}
+	want := map[string][]string{
+		"https://example.com/feed?format=rss": {"https://example.com/feed?format=atom", "https://example.com/feed.json"},
+		"http://other.example/rss":            {"http://mirror.example/rss"},
+	}
+	if !maps.EqualFunc(fallbacks, want, slices.Equal) {
+		t.Errorf("fallbacks = %v, want %v", fallbacks, want)
+	}
+
+	for _, args := range [][]string{
+		{"--feed-fallback-url", "https://example.com/feed"},
+		{"--feed-fallback-url", "https://example.com/feed=feed.json"},
+		{"--feed-fallback-url", "ftp://example.com/feed=https://example.com/feed.json"},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestRunCmd_FeedStripFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--feed-strip", "https://example.com/feed?format=rss=css:div[class=ad]",
+		"--feed-strip", "https://example.com/feed?format=rss=regex:<p>Read more.*?</p>",
+		"--feed-strip", "http://other.example/rss=css:aside",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rules, err := parseFeedStrip(c.Run.FeedStrip)
+	if err != nil {
+		t.Fatalf("parseFeedStrip: %v", err)
+	}
+	want := map[string][]string{
+		"https://example.com/feed?format=rss": {"css:div[class=ad]", "regex:<p>Read more.*?</p>"},
+		"http://other.example/rss":            {"css:aside"},
+	}
+	if !maps.EqualFunc(rules, want, slices.Equal) {
+		t.Errorf("rules = %v, want %v", rules, want)
+	}
+
+	for _, args := range [][]string{
+		{"--feed-strip", "https://example.com/feed"},
+		{"--feed-strip", "https://example.com/feed=div.ad"},
+		{"--feed-strip", "https://example.com/feed=regex:("},
+		{"--feed-strip", "feed=css:div.ad"},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestRunCmd_FeedTagFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--feed-tag", "https://example.com/feed?format=rss=priority:high,team:infra",
+		"--feed-tag", "https://example.com/feed?format=rss=weekly",
+		"--feed-tag", "http://other.example/rss=news",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	tags, err := parseFeedTags(c.Run.FeedTags)
+	if err != nil {
+		t.Fatalf("parseFeedTags: %v", err)
+	}
+	want := map[string][]string{
+		"https://example.com/feed?format=rss": {"priority:high", "team:infra", "weekly"},
+		"http://other.example/rss":            {"news"},
+	}
+	if !maps.EqualFunc(tags, want, slices.Equal) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	for _, args := range [][]string{
+		{"--feed-tag", "https://example.com/feed"},
+		{"--feed-tag", "https://example.com/feed="},
+		{"--feed-tag", "https://example.com/feed=,"},
+		{"--feed-tag", "feed=news"},
+		{"--feed-tag", "https://example.com/feed=" + strings.Repeat("x", 65)},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestRunCmd_FeedPriorityFlags(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse(
+		"--feed-priority", "https://example.com/feed?format=rss=10",
+		"--feed-priority", "http://other.example/rss=-5",
+		"--max-concurrent-fetches", "4",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	priorities, err := parseFeedPriorities(c.Run.FeedPriorities)
+	if err != nil {
+		t.Fatalf("parseFeedPriorities: %v", err)
+	}
+	want := map[string]int{"https://example.com/feed?format=rss": 10, "http://other.example/rss": -5}
+	if !maps.Equal(priorities, want) || c.Run.MaxConcurrentFetches != 4 {
+		t.Errorf("priorities = %v, max fetches %d; want %v, 4", priorities, c.Run.MaxConcurrentFetches, want)
+	}
+
+	for _, args := range [][]string{
+		{"--feed-priority", "https://example.com/feed"},
+		{"--feed-priority", "https://example.com/feed=high"},
+		{"--feed-priority", "feed=1"},
+		{"--max-concurrent-fetches", "-1"},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestRunCmd_AcceptedContentTypesFlag(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	c := &cli{}
+	parser, err := kong.New(c)
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse([]string{"run", "--accepted-content-types", "default,Text/Plain,text/xml", "http://example.com/feed"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := expandAcceptedContentTypes(c.Run.AcceptedContentTypes)
+	want := append(slices.Clone(store.DefaultAcceptedContentTypes), "text/plain")
+	if !slices.Equal(got, want) {
+		t.Errorf("accepted types = %v, want %v", got, want)
+	}
+	if got := expandAcceptedContentTypes(nil); got != nil {
+		t.Errorf("no flag = %v, want nil (any type accepted)", got)
+	}
+}
+
+func TestRunCmd_MinTLSVersionFlag(t *testing.T) {
+	type cli struct {
+		Run RunCmd `cmd:""`
+	}
+	parse := func(args ...string) (*cli, error) {
+		c := &cli{}
+		parser, err := kong.New(c)
+		if err != nil {
+			t.Fatalf("kong.New: %v", err)
+		}
+		_, err = parser.Parse(append(append([]string{"run"}, args...), "http://example.com/feed"))
+		return c, err
+	}
+
+	c, err := parse()
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := tlsVersions[c.Run.MinTLSVersion]; got != tls.VersionTLS12 {
+		t.Errorf("default minimum = %#x, want TLS 1.2", got)
+	}
+	c, err = parse("--min-tls-version", "1.3")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := tlsVersions[c.Run.MinTLSVersion]; got != tls.VersionTLS13 {
+		t.Errorf("--min-tls-version 1.3 = %#x, want TLS 1.3", got)
+	}
+	if _, err := parse("--min-tls-version", "1.4"); err == nil {
+		t.Error("--min-tls-version 1.4: expected an error")
+	}
+}
+
+// TestRunCmd_FileExportFlags verifies that --allow-file-export and
+// --file-export-dir must be given together.
+func TestRunCmd_FileExportFlags(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		cmd     RunCmd
+		wantErr bool
+	}{
+		{"both", RunCmd{AllowFileExport: true, FileExportDir: dir}, false},
+		{"neither", RunCmd{}, false},
+		{"allow without dir", RunCmd{AllowFileExport: true}, true},
+		{"dir without allow", RunCmd{FileExportDir: dir}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package mcpserver
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// articleSkipTags are elements whose text is never part of an article.
+var articleSkipTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+}
+
+// articleBlockTags are elements whose text becomes one paragraph of the
+// extracted article.
+var articleBlockTags = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Li:         true,
+	atom.Pre:        true,
+	atom.Blockquote: true,
+	atom.Figcaption: true,
+}
+
+// extractArticleText returns the readable text of an HTML page, one paragraph
+// per block element, separated by blank lines. In the style of readability, it
+// reads the page's <article>, else its <main>, else the element holding the
+// most paragraph text, and ignores navigation, headers, footers, asides,
+// forms, and scripts.
+func extractArticleText(page []byte) string {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return ""
+	}
+	root := findElement(doc, atom.Article)
+	if root == nil {
+		root = findElement(doc, atom.Main)
+	}
+	if root == nil {
+		root = densestParagraphContainer(doc)
+	}
+	if root == nil {
+		root = doc
+	}
+
+	var paragraphs []string
+	collectArticleBlocks(root, &paragraphs)
+	if len(paragraphs) == 0 {
+		return collapseSpace(nodeText(root))
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// findElement returns the first element with the given tag, depth first.
+func findElement(n *html.Node, tag atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == tag {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// densestParagraphContainer returns the element whose direct <p> children hold
+// the most text, or nil when the page has no paragraphs.
+func densestParagraphContainer(doc *html.Node) *html.Node {
+	scores := make(map[*html.Node]int)
+	var best *html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && articleSkipTags[n.DataAtom] {
+			return
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.P && n.Parent != nil {
+			scores[n.Parent] += len(collapseSpace(nodeText(n)))
+			if best == nil || scores[n.Parent] > scores[best] {
+				best = n.Parent
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	return best
+}
+
+// collectArticleBlocks appends the text of each block element under n.
+func collectArticleBlocks(n *html.Node, paragraphs *[]string) {
+	if n.Type == html.ElementNode {
+		if articleSkipTags[n.DataAtom] {
+			return
+		}
+		if articleBlockTags[n.DataAtom] {
+			if text := collapseSpace(nodeText(n)); text != "" {
+				*paragraphs = append(*paragraphs, text)
+			}
+			return
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		collectArticleBlocks(child, paragraphs)
+	}
+}
+
+// nodeText concatenates the text under n, skipping non-article elements.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && articleSkipTags[n.DataAtom]:
+			return
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			b.WriteByte(' ')
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// collapseSpace trims s and collapses each run of whitespace to one space.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,69 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CircuitBreakerResetter closes feed circuit breakers on demand.
+type CircuitBreakerResetter interface {
+	// ResetCircuitBreakers replaces the circuit breaker of the feed with the
+	// given ID, or of every feed when feedID is empty, with a closed one.
+	ResetCircuitBreakers(ctx context.Context, feedID string) ([]CircuitBreakerReset, error)
+}
+
+// CircuitBreakerReset reports one feed's circuit breaker state before and
+// after a reset.
+type CircuitBreakerReset struct {
+	FeedID        string `json:"feed_id"`
+	URL           string `json:"url"`
+	PreviousState string `json:"previous_state"` // closed, half-open, or open
+	State         string `json:"state"`
+}
+
+// ResetCircuitBreakerParams contains parameters for the reset_circuit_breaker
+// tool.
+type ResetCircuitBreakerParams struct {
+	FeedID string `json:"feedId,omitempty"`
+	All    bool   `json:"all,omitempty"`
+}
+
+// addResetCircuitBreakerTool adds the reset_circuit_breaker tool
+func (s *Server) addResetCircuitBreakerTool(srv *mcp.Server, resetter CircuitBreakerResetter) {
+	resetTool := &mcp.Tool{
+		Name:        toolResetCircuitBreaker,
+		Description: "Close the circuit breaker of a feed (feedId) or of every feed (all=true) without waiting for its timeout, e.g. once a failing feed's server is fixed. Returns each breaker's previous and new state.",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+				"all": {
+					Type:        typeBoolean,
+					Description: "Reset every feed's circuit breaker instead of one",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, resetTool, func(ctx context.Context, req *mcp.CallToolRequest, args ResetCircuitBreakerParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		resets, err := resetter.ResetCircuitBreakers(ctx, args.FeedID)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(map[string]any{"reset": resets})
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
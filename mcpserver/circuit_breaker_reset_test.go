@@ -0,0 +1,83 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockCircuitBreakerResetter is a feed getter whose breakers are all open
+// until reset.
+type mockCircuitBreakerResetter struct {
+	mockFeedAndItemsGetter
+	calls []string
+}
+
+func (m *mockCircuitBreakerResetter) ResetCircuitBreakers(ctx context.Context, feedID string) ([]CircuitBreakerReset, error) {
+	m.calls = append(m.calls, feedID)
+	ids := []string{"feed-1", "feed-2"}
+	if feedID != "" {
+		ids = []string{feedID}
+	}
+	resets := make([]CircuitBreakerReset, 0, len(ids))
+	for _, id := range ids {
+		resets = append(resets, CircuitBreakerReset{FeedID: id, URL: "https://example.com/" + id, PreviousState: "open", State: "closed"})
+	}
+	return resets, nil
+}
+
+func TestResetCircuitBreakerTool(t *testing.T) {
+	resetter := &mockCircuitBreakerResetter{}
+	session := newTestClientSession(t, &Config{
+		Transport:              model.StdioTransport,
+		AllFeedsGetter:         &mockAllFeedsGetter{},
+		FeedAndItemsGetter:     resetter,
+		CircuitBreakerResetter: resetter,
+	})
+	ctx := context.Background()
+
+	call := func(args map[string]any) (*mcp.CallToolResult, []CircuitBreakerReset) {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolResetCircuitBreaker, Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool(%v): %v", args, err)
+		}
+		if result.IsError {
+			return result, nil
+		}
+		var body struct {
+			Reset []CircuitBreakerReset `json:"reset"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &body); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return result, body.Reset
+	}
+
+	if _, resets := call(map[string]any{keyFeedID: "feed-2"}); len(resets) != 1 || resets[0].FeedID != "feed-2" || resets[0].PreviousState != "open" || resets[0].State != "closed" {
+		t.Errorf("reset feed-2 = %+v", resets)
+	}
+	if _, resets := call(map[string]any{"all": true}); len(resets) != 2 {
+		t.Errorf("reset all = %+v, want 2 feeds", resets)
+	}
+	if !slices.Equal(resetter.calls, []string{"feed-2", ""}) {
+		t.Errorf("resetter called with %q, want feed-2 then all", resetter.calls)
+	}
+
+	// One of feedId and all is required, and not both.
+	for _, args := range []map[string]any{{}, {keyFeedID: "feed-2", "all": true}} {
+		result, _ := call(args)
+		if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Field: feedId") {
+			t.Errorf("CallTool(%v) = %+v, want a feedId parameter error", args, result)
+		}
+	}
+	if len(resetter.calls) != 2 {
+		t.Errorf("invalid calls reached the resetter: %q", resetter.calls)
+	}
+}
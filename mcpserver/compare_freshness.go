@@ -0,0 +1,178 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// staleCadenceFactor is how many of a feed's usual gaps between items it may
+// fall behind (or go quiet for) before it counts as stale or overdue.
+const staleCadenceFactor = 3
+
+// compare_freshness verdicts.
+const (
+	freshnessFresh   = "fresh"
+	freshnessStale   = "stale"
+	freshnessUnknown = "unknown"
+)
+
+// CompareFreshnessParams contains parameters for the compare_freshness tool.
+type CompareFreshnessParams struct {
+	FeedID          string `json:"feedId"`
+	ReferenceFeedID string `json:"referenceFeedId"`
+}
+
+// FeedFreshness describes how recently and how regularly one feed publishes.
+// Overdue is set when its newest item is older than staleCadenceFactor of
+// its median interval. FetchError is set when the feed couldn't be fetched.
+type FeedFreshness struct {
+	FeedID                string     `json:"feed_id"`
+	Title                 string     `json:"title"`
+	FetchError            string     `json:"fetch_error,omitempty"`
+	DatedItems            int        `json:"dated_items"`
+	Newest                *time.Time `json:"newest,omitempty"`
+	AgeSeconds            float64    `json:"age_seconds,omitempty"`
+	MedianIntervalSeconds float64    `json:"median_interval_seconds"`
+	ItemsPerDay           float64    `json:"items_per_day"`
+	Overdue               bool       `json:"overdue"`
+}
+
+// FreshnessComparison is the JSON body returned by compare_freshness.
+// LagSeconds is how far the target's newest item trails the reference's;
+// it is negative when the target published more recently.
+type FreshnessComparison struct {
+	Target     FeedFreshness `json:"target"`
+	Reference  FeedFreshness `json:"reference"`
+	LagSeconds float64       `json:"lag_seconds"`
+	Verdict    string        `json:"verdict"`
+	Stale      bool          `json:"stale"`
+	Reason     string        `json:"reason"`
+}
+
+// addCompareFreshnessTool adds the compare_freshness tool
+func (s *Server) addCompareFreshnessTool(srv *mcp.Server) {
+	compareFreshnessTool := &mcp.Tool{
+		Name:        toolCompareFreshness,
+		Description: "Check whether a feed has silently stopped updating by comparing it with a known-active reference feed: newest item dates, ages, and publish cadences, with a verdict (fresh, stale, or unknown). The target is stale when its newest item trails the reference's by more than three of its usual gaps between items.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyFeedID, "referenceFeedId"},
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID to check, from all_syndication_feeds tool",
+				},
+				"referenceFeedId": {
+					Type:        typeString,
+					Description: "Feed ID of an actively updated feed to compare against",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, compareFreshnessTool, func(ctx context.Context, req *mcp.CallToolRequest, args CompareFreshnessParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		target, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.FeedID)
+		if err != nil {
+			return nil, nil, err
+		}
+		reference, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.ReferenceFeedID)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(compareFreshness(target, reference, time.Now()))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// compareFreshness judges whether target is stale relative to reference as of
+// now. The yardstick is the target's median interval, or the reference's when
+// the target has too few dated items to have one; without either the verdict
+// is unknown. A reference with no dated items can't vouch for anything, and a
+// feed that failed to fetch says nothing about whether it is still updating,
+// so either failing to fetch also makes the verdict unknown.
+func compareFreshness(target, reference *model.FeedAndItemsResult, now time.Time) *FreshnessComparison {
+	result := &FreshnessComparison{
+		Target:    feedFreshness(target, now),
+		Reference: feedFreshness(reference, now),
+		Verdict:   freshnessUnknown,
+	}
+	t, r := &result.Target, &result.Reference
+	switch {
+	case t.FetchError != "":
+		result.Reason = "the target feed could not be fetched: " + t.FetchError
+		return result
+	case r.FetchError != "":
+		result.Reason = "the reference feed could not be fetched: " + r.FetchError
+		return result
+	case r.Newest == nil:
+		result.Reason = "the reference feed has no dated items"
+		return result
+	case t.Newest == nil:
+		result.Verdict, result.Stale = freshnessStale, true
+		result.Reason = fmt.Sprintf("the target feed has no dated items, while the reference published %s ago", roundedDuration(r.AgeSeconds))
+		return result
+	}
+
+	lag := r.Newest.Sub(*t.Newest)
+	result.LagSeconds = roundTo(lag.Seconds(), 2)
+	cadence := t.MedianIntervalSeconds
+	if cadence == 0 {
+		cadence = r.MedianIntervalSeconds
+	}
+	if cadence == 0 {
+		result.Reason = "neither feed has enough dated items to establish a publishing cadence"
+		return result
+	}
+	if lag.Seconds() > staleCadenceFactor*cadence {
+		result.Verdict, result.Stale = freshnessStale, true
+		result.Reason = fmt.Sprintf("the target's newest item is %s behind the reference's, more than %d times its usual gap of %s",
+			roundedDuration(lag.Seconds()), staleCadenceFactor, roundedDuration(cadence))
+		return result
+	}
+	result.Verdict = freshnessFresh
+	result.Reason = fmt.Sprintf("the target's newest item is within %d times its usual gap of %s of the reference's",
+		staleCadenceFactor, roundedDuration(cadence))
+	return result
+}
+
+// feedFreshness summarizes a feed's newest item and cadence as of now.
+func feedFreshness(feedResult *model.FeedAndItemsResult, now time.Time) FeedFreshness {
+	frequency := estimateFeedFrequency(feedResult.Items)
+	freshness := FeedFreshness{
+		FeedID:                feedResult.ID,
+		Title:                 feedResult.Title,
+		FetchError:            feedResult.FetchError,
+		DatedItems:            frequency.DatedItems,
+		Newest:                frequency.Newest,
+		MedianIntervalSeconds: frequency.MedianIntervalSeconds,
+		ItemsPerDay:           frequency.ItemsPerDay,
+	}
+	if freshness.Title == "" && feedResult.Feed != nil {
+		freshness.Title = feedResult.Feed.Title
+	}
+	if freshness.Newest != nil {
+		freshness.AgeSeconds = roundTo(max(now.Sub(*freshness.Newest).Seconds(), 0), 2)
+		freshness.Overdue = freshness.MedianIntervalSeconds > 0 &&
+			freshness.AgeSeconds > staleCadenceFactor*freshness.MedianIntervalSeconds
+	}
+	return freshness
+}
+
+// roundedDuration formats seconds as a duration rounded to the minute.
+func roundedDuration(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Minute).String()
+}
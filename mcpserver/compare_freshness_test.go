@@ -0,0 +1,106 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// dailyItems returns count items published a day apart, the newest at newest.
+func dailyItems(newest time.Time, count int) []*gofeed.Item {
+	items := make([]*gofeed.Item, count)
+	for i := range count {
+		items[i] = &gofeed.Item{Title: "Daily", PublishedParsed: new(newest.Add(-time.Duration(i) * 24 * time.Hour))}
+	}
+	return items
+}
+
+func TestCompareFreshness(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	feed := func(id string, items []*gofeed.Item) *model.FeedAndItemsResult {
+		return &model.FeedAndItemsResult{ID: id, Title: id, Items: items}
+	}
+	reference := feed("reference", dailyItems(now.Add(-2*time.Hour), 10))
+
+	tests := []struct {
+		name        string
+		target      *model.FeedAndItemsResult
+		reference   *model.FeedAndItemsResult
+		wantVerdict string
+	}{
+		{"stale daily feed", feed("target", dailyItems(now.Add(-20*24*time.Hour), 10)), reference, freshnessStale},
+		{"fresh daily feed", feed("target", dailyItems(now.Add(-26*time.Hour), 10)), reference, freshnessFresh},
+		{"weekly feed a few days behind", feed("target", []*gofeed.Item{
+			{PublishedParsed: new(now.Add(-5 * 24 * time.Hour))},
+			{PublishedParsed: new(now.Add(-12 * 24 * time.Hour))},
+			{PublishedParsed: new(now.Add(-19 * 24 * time.Hour))},
+		}), reference, freshnessFresh},
+		{"target without dates", feed("target", []*gofeed.Item{{Title: "Undated"}}), reference, freshnessStale},
+		{"reference without dates", feed("target", dailyItems(now, 3)), feed("reference", []*gofeed.Item{{Title: "Undated"}}), freshnessUnknown},
+		{"no cadence", feed("target", dailyItems(now.Add(-48*time.Hour), 1)), feed("reference", dailyItems(now, 1)), freshnessUnknown},
+		{"target failed to fetch", &model.FeedAndItemsResult{ID: "target", FetchError: "dial tcp: lookup example.com: no such host"}, reference, freshnessUnknown},
+		{"reference failed to fetch", feed("target", dailyItems(now, 3)), &model.FeedAndItemsResult{ID: "reference", FetchError: "http error: 503"}, freshnessUnknown},
+		{"single-item target uses the reference cadence", feed("target", dailyItems(now.Add(-10*24*time.Hour), 1)), reference, freshnessStale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compareFreshness(tt.target, tt.reference, now)
+			if result.Verdict != tt.wantVerdict || result.Stale != (tt.wantVerdict == freshnessStale) {
+				t.Errorf("verdict = %q (stale %v), want %q: %s", result.Verdict, result.Stale, tt.wantVerdict, result.Reason)
+			}
+			if result.Reason == "" {
+				t.Error("reason is empty")
+			}
+		})
+	}
+
+	failed := compareFreshness(tests[6].target, reference, now)
+	if !strings.Contains(failed.Reason, "no such host") || failed.Target.FetchError == "" {
+		t.Errorf("failed target = %+v, want the fetch error in reason and target", failed)
+	}
+
+	stale := compareFreshness(tests[0].target, reference, now)
+	if want := (20*24*time.Hour - 2*time.Hour).Seconds(); stale.LagSeconds != want {
+		t.Errorf("lag = %v, want %v", stale.LagSeconds, want)
+	}
+	if !stale.Target.Overdue || stale.Reference.Overdue {
+		t.Errorf("overdue: target %v, reference %v; want true, false", stale.Target.Overdue, stale.Reference.Overdue)
+	}
+	if stale.Target.MedianIntervalSeconds != (24*time.Hour).Seconds() || stale.Target.AgeSeconds != (20*24*time.Hour).Seconds() {
+		t.Errorf("target = %+v", stale.Target)
+	}
+}
+
+func TestCompareFreshnessTool(t *testing.T) {
+	now := time.Now()
+	session := newTestClientSession(t, &Config{
+		Transport:      model.StdioTransport,
+		AllFeedsGetter: &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+			"active":  {ID: "active", Title: "Active", Items: dailyItems(now.Add(-time.Hour), 7)},
+			"dormant": {ID: "dormant", Title: "Dormant", Items: dailyItems(now.Add(-60*24*time.Hour), 7)},
+		}},
+	})
+
+	var result FreshnessComparison
+	text := callToolText(t, session, toolCompareFreshness, map[string]any{keyFeedID: "dormant", "referenceFeedId": "active"})
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		t.Fatalf("unmarshal %q: %v", text, err)
+	}
+	if !result.Stale || result.Verdict != freshnessStale || result.Target.Title != "Dormant" || result.Reference.FeedID != "active" {
+		t.Errorf("result = %+v, want dormant stale against active", result)
+	}
+
+	text = callToolText(t, session, toolCompareFreshness, map[string]any{keyFeedID: "active", "referenceFeedId": "dormant"})
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		t.Fatalf("unmarshal %q: %v", text, err)
+	}
+	if result.Stale || result.Verdict != freshnessFresh || result.LagSeconds >= 0 {
+		t.Errorf("result = %+v, want active fresh and ahead of dormant", result)
+	}
+}
@@ -27,6 +27,8 @@ const (
 	keyURLLower    = "url"
 	keyItemIndex   = "itemIndex"
 	keyTimeframe   = "timeframe"
+	keyLocale      = "locale"
+	keyTags        = "tags"
 )
 
 // JSON-schema type values.
@@ -50,6 +52,44 @@ const (
 	toolFetchLink               = "fetch_link"
 	toolAllSyndicationFeeds     = "all_syndication_feeds"
 	toolGetSyndicationFeedItems = "get_syndication_feed_items"
+	toolListFeedIndex           = "list_feed_index"
+	toolGetFeedTree             = "get_feed_tree"
+	toolListMovedFeeds          = "list_moved_feeds"
+	toolListDuplicateFeeds      = "list_duplicate_feeds"
+	toolListFeedsByActivity     = "list_feeds_by_activity"
+	toolGetPodcastEpisodes      = "get_podcast_episodes"
+	toolEstimateFeedFrequency   = "estimate_feed_frequency"
+	toolGetFeedCategories       = "get_feed_categories"
+	toolFetchFeedFullContent    = "fetch_feed_full_content"
+	toolMarkRead                = "mark_read"
+	toolMarkUnread              = "mark_unread"
+	toolMarkAllRead             = "mark_all_read"
+	toolResetCircuitBreaker     = "reset_circuit_breaker"
+	toolPingFeeds               = "ping_feeds"
+	toolPurgeFeedCache          = "purge_feed_cache"
+	toolGetServerMetrics        = "get_server_metrics"
+	toolGetConfig               = "get_config"
+	toolMergeFeeds              = "merge_feeds"
+	toolExportFeedData          = "export_feed_data"
+	toolFeedOverlap             = "feed_overlap"
+	toolFindItem                = "find_item"
+	toolFindItemsLinkingTo      = "find_items_linking_to"
+	toolGetItemsOnDate          = "get_items_on_date"
+	toolCompareFreshness        = "compare_freshness"
+	toolKeywordCooccurrence     = "keyword_cooccurrence"
+	toolExportFeedHistory       = "export_feed_history"
+	toolAddFeed                 = "add_feed"
+	toolRemoveFeed              = "remove_feed"
+	toolListManagedFeeds        = "list_managed_feeds"
+	toolRefreshFeed             = "refresh_feed"
+	toolUpdateFeed              = "update_feed"
+	toolImportOPML              = "import_opml"
+)
+
+// all_syndication_feeds orderByHealth values.
+const (
+	healthOrderUnhealthyFirst = "unhealthy_first"
+	healthOrderUnhealthyLast  = "unhealthy_last"
 )
 
 // Sentiment, sort, and format enum/value strings shared across resources,
@@ -64,6 +104,10 @@ const (
 	sortByPopularity = "popularity"
 	valueSource      = "source"
 
+	orderNewest = "newest"
+	orderOldest = "oldest"
+	orderFeed   = "feed"
+
 	formatJSON     = "json"
 	formatXML      = "xml"
 	formatHTML     = "html"
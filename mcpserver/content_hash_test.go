@@ -0,0 +1,41 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestContentHash_ToolAndMetaResource(t *testing.T) {
+	items := makeTestItems(5)
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", items)
+	want := model.ContentHash(items)
+
+	// The hash covers the whole feed, whatever page or filter is requested.
+	for _, args := range []map[string]any{{}, {"limit": 2, "offset": 3}, {"hasMedia": true}} {
+		args[keyID] = "feed-1"
+		if got := feedItemsMetadata(t, session, args).ContentHash; got != want {
+			t.Errorf("get_syndication_feed_items(%v) content_hash = %q, want %q", args, got, want)
+		}
+	}
+
+	uri := strings.Replace(FeedMetaURI, "{feedId}", "feed-1", 1)
+	result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.Fatalf("ReadResource(%s): %v", uri, err)
+	}
+	var meta struct {
+		ContentHash string `json:"content_hash"`
+	}
+	if err := json.Unmarshal([]byte(result.Contents[0].Text), &meta); err != nil {
+		t.Fatalf("unmarshal meta: %v", err)
+	}
+	if meta.ContentHash != want {
+		t.Errorf("%s content_hash = %q, want %q", uri, meta.ContentHash, want)
+	}
+}
@@ -0,0 +1,216 @@
+package mcpserver
+
+import (
+	"maps"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// DefaultThumbnailSize is the thumbnail width, in pixels, itemOutput's
+// thumbnail is chosen for when no other size is configured.
+const DefaultThumbnailSize = 300
+
+// itemOutput is the JSON shape of an item returned by get_syndication_feed_items:
+// the gofeed item plus its stable ID, language, the images found in its HTML
+// content, its preferred thumbnail, its verified enclosures, and its podcast chapters. Many feeds embed images inline rather than as
+// enclosures, so the images complement extractImageLinks.
+type itemOutput struct {
+	*gofeed.Item
+	*rawDates
+	StableID  string   `json:"stable_id,omitempty"`
+	Language  string   `json:"language,omitempty"`
+	LeadImage string   `json:"lead_image,omitempty"`
+	Images    []string `json:"images,omitempty"`
+	// Thumbnail is the Media RSS thumbnail nearest the server's thumbnail
+	// size, as model.SelectThumbnail picks it.
+	Thumbnail *model.Thumbnail `json:"thumbnail,omitempty"`
+	Media     []mediaOutput    `json:"media,omitempty"`
+	Chapters  []model.Chapter  `json:"chapters,omitempty"`
+	// ChaptersURL links to the chapters file of a podcast:chapters element
+	// that doesn't embed its chapters.
+	ChaptersURL string `json:"chapters_url,omitempty"`
+	// Stub marks an item with less content than the server's substantive
+	// minimum length, such as a title-only item.
+	Stub bool `json:"stub,omitempty"`
+}
+
+// mediaOutput is an enclosure as the feed declared it alongside what a HEAD
+// request found, present when the server verifies enclosures
+// (--verify-enclosures). Unreachable is set, with VerifyError, when the
+// request failed.
+type mediaOutput struct {
+	URL          string `json:"url"`
+	Type         string `json:"type,omitempty"`
+	Length       string `json:"length,omitempty"`
+	VerifiedSize int64  `json:"verified_size,omitempty"`
+	VerifiedType string `json:"verified_type,omitempty"`
+	Unreachable  bool   `json:"unreachable,omitempty"`
+	VerifyError  string `json:"verify_error,omitempty"`
+}
+
+// newMediaOutput pairs each verified enclosure of the item with its check, or
+// returns nil when the item's enclosures weren't verified.
+func newMediaOutput(item *gofeed.Item) []mediaOutput {
+	checks := model.ItemEnclosureChecks(item)
+	if checks == nil {
+		return nil
+	}
+	var media []mediaOutput
+	for _, enclosure := range item.Enclosures {
+		if enclosure == nil {
+			continue
+		}
+		check, ok := checks[enclosure.URL]
+		if !ok {
+			continue
+		}
+		media = append(media, mediaOutput{
+			URL:          enclosure.URL,
+			Type:         enclosure.Type,
+			Length:       enclosure.Length,
+			VerifiedSize: check.VerifiedSize,
+			VerifiedType: check.VerifiedType,
+			Unreachable:  !check.Reachable,
+			VerifyError:  check.Error,
+		})
+	}
+	return media
+}
+
+// rawDates pairs the publish date as the feed wrote it with its parsed form,
+// added to itemOutput when includeRawDates is set. PublishedParsed is null
+// when the raw date didn't parse.
+type rawDates struct {
+	PublishedRaw    string  `json:"published_raw"`
+	PublishedParsed *string `json:"published_parsed"`
+}
+
+// newRawDates returns the item's raw and parsed (RFC3339) publish dates.
+func newRawDates(item *gofeed.Item) *rawDates {
+	dates := &rawDates{PublishedRaw: item.Published}
+	if item.PublishedParsed != nil {
+		dates.PublishedParsed = new(item.PublishedParsed.Format(time.RFC3339))
+	}
+	return dates
+}
+
+// newItemOutput wraps a processed item with the stable ID, language, images,
+// thumbnail, verified enclosures, and chapters of the original (untruncated)
+// item. The thumbnail is the one nearest thumbnailSize pixels wide.
+func newItemOutput(original, processed *gofeed.Item, thumbnailSize int) *itemOutput {
+	out := &itemOutput{Item: processed}
+	if original == nil {
+		return out
+	}
+	out.StableID = model.ItemStableID(original)
+	out.Language = model.ItemLanguage(original)
+	out.Media = newMediaOutput(original)
+	out.Chapters = model.ItemChapters(original)
+	if out.Chapters == nil {
+		out.ChaptersURL = model.ItemChaptersURL(original)
+	}
+	processed.Custom = withoutOutputKeys(processed.Custom)
+	out.Images = extractContentImages(original)
+	if len(out.Images) > 0 {
+		out.LeadImage = out.Images[0]
+	}
+	if thumbnail, ok := model.SelectThumbnail(model.ItemThumbnails(original), thumbnailSize); ok {
+		out.Thumbnail = &thumbnail
+	}
+	return out
+}
+
+// withoutOutputKeys returns custom without the keys itemOutput already
+// reports as top-level fields (stable_id, language, media), so they aren't repeated. It
+// copies the map rather than editing the cached item's.
+func withoutOutputKeys(custom map[string]string) map[string]string {
+	_, hasID := custom[model.StableIDKey]
+	_, hasLanguage := custom[model.ItemLanguageKey]
+	_, hasChecks := custom[model.EnclosureChecksKey]
+	if !hasID && !hasLanguage && !hasChecks {
+		return custom
+	}
+	custom = maps.Clone(custom)
+	delete(custom, model.StableIDKey)
+	delete(custom, model.ItemLanguageKey)
+	delete(custom, model.EnclosureChecksKey)
+	if len(custom) == 0 {
+		return nil
+	}
+	return custom
+}
+
+// extractContentImages returns the src of every <img> in the item's content and
+// description, in document order and without duplicates. Relative URLs are
+// resolved against the item link; anything that doesn't resolve to an http(s)
+// URL (data: URIs, javascript:, unresolvable relatives) is skipped.
+func extractContentImages(item *gofeed.Item) []string {
+	base, _ := url.Parse(item.Link)
+
+	var images []string
+	seen := make(map[string]bool)
+	for _, body := range []string{item.Content, item.Description} {
+		if !strings.Contains(strings.ToLower(body), "<img") {
+			continue
+		}
+		for _, src := range findTagAttrs(body, "img", "src") {
+			resolved := resolveHTTPURL(base, src)
+			if resolved == "" || seen[resolved] {
+				continue
+			}
+			seen[resolved] = true
+			images = append(images, resolved)
+		}
+	}
+	return images
+}
+
+// findTagAttrs returns the raw, non-empty attr attribute of each tag element
+// in an HTML fragment, such as the src of each <img>.
+func findTagAttrs(fragment, tag, attr string) []string {
+	var values []string
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return values
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != tag {
+				continue
+			}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tokenizer.TagAttr()
+				if string(key) == attr {
+					if value := strings.TrimSpace(string(val)); value != "" {
+						values = append(values, value)
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// resolveHTTPURL resolves src against base and returns it if the result is
+// an absolute http(s) URL, or "" otherwise.
+func resolveHTTPURL(base *url.URL, src string) string {
+	ref, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		ref = base.ResolveReference(ref)
+	}
+	if (ref.Scheme != "http" && ref.Scheme != "https") || ref.Host == "" {
+		return ""
+	}
+	return ref.String()
+}
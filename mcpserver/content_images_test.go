@@ -0,0 +1,280 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestExtractContentImages(t *testing.T) {
+	item := &gofeed.Item{
+		Link: "https://example.com/posts/hello",
+		Content: `<p>Intro</p>
+			<img src="/images/lead.jpg" alt="lead">
+			<figure><img class="wide" src="diagram.png"/></figure>
+			<img src="https://cdn.example.org/photo.webp">
+			<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">
+			<img alt="no source">`,
+		Description: `<img src="https://cdn.example.org/photo.webp"><img src="thumb.gif">`,
+	}
+
+	got := extractContentImages(item)
+	want := []string{
+		"https://example.com/images/lead.jpg",
+		"https://example.com/posts/diagram.png",
+		"https://cdn.example.org/photo.webp",
+		"https://example.com/posts/thumb.gif",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("extractContentImages() = %v, want %v", got, want)
+	}
+
+	// Without an item link, relative sources can't be resolved and are skipped.
+	item.Link = ""
+	got = extractContentImages(item)
+	if !slices.Equal(got, []string{"https://cdn.example.org/photo.webp"}) {
+		t.Errorf("extractContentImages() without link = %v", got)
+	}
+}
+
+func TestBuildItemContent_ContentImages(t *testing.T) {
+	s := &Server{}
+	item := &gofeed.Item{
+		Title:   "Post",
+		Link:    "https://example.com/posts/hello",
+		Content: `<img src="a.jpg"><p>text</p><img src="https://example.org/b.png">`,
+	}
+
+	// Images are extracted from the full content even when content is omitted.
+	blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{})
+	text, ok := blocks[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", blocks[0])
+	}
+	var out struct {
+		Title     string   `json:"title"`
+		Content   string   `json:"content"`
+		StableID  string   `json:"stable_id"`
+		LeadImage string   `json:"lead_image"`
+		Images    []string `json:"images"`
+	}
+	if err := json.Unmarshal([]byte(text.Text), &out); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	if out.Title != "Post" || out.Content != "" {
+		t.Errorf("unexpected item fields: title=%q content=%q", out.Title, out.Content)
+	}
+	if out.StableID != "link:example.com/posts/hello" {
+		t.Errorf("stable_id = %q", out.StableID)
+	}
+	if out.LeadImage != "https://example.com/posts/a.jpg" {
+		t.Errorf("lead_image = %q", out.LeadImage)
+	}
+	if !slices.Equal(out.Images, []string{"https://example.com/posts/a.jpg", "https://example.org/b.png"}) {
+		t.Errorf("images = %v", out.Images)
+	}
+
+	// Items without inline images omit both fields.
+	blocks = s.buildItemContent(context.Background(), &gofeed.Item{Title: "Plain"}, 0, ParsedFeedParams{IncludeContent: true})
+	text, _ = blocks[0].(*mcp.TextContent)
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(text.Text), &raw); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	if _, ok := raw["lead_image"]; ok {
+		t.Error("lead_image present for item without images")
+	}
+	if _, ok := raw["images"]; ok {
+		t.Error("images present for item without images")
+	}
+}
+
+func TestBuildItemContent_RawDates(t *testing.T) {
+	s := &Server{}
+	published := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	itemJSON := func(item *gofeed.Item, includeRawDates bool) map[string]any {
+		t.Helper()
+		blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{IncludeRawDates: includeRawDates})
+		var out map[string]any
+		if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("unmarshal item: %v", err)
+		}
+		return out
+	}
+
+	out := itemJSON(&gofeed.Item{Title: "Odd date", Published: "Tuesday-ish, early March"}, true)
+	if out["published_raw"] != "Tuesday-ish, early March" {
+		t.Errorf("published_raw = %v", out["published_raw"])
+	}
+	if parsed, ok := out["published_parsed"]; !ok || parsed != nil {
+		t.Errorf("published_parsed = %v (present: %v), want null", parsed, ok)
+	}
+
+	out = itemJSON(&gofeed.Item{Title: "Dated", Published: "Tue, 05 Mar 2024 09:30:00 GMT", PublishedParsed: &published}, true)
+	if out["published_raw"] != "Tue, 05 Mar 2024 09:30:00 GMT" || out["published_parsed"] != "2024-03-05T09:30:00Z" {
+		t.Errorf("published_raw = %v, published_parsed = %v", out["published_raw"], out["published_parsed"])
+	}
+
+	out = itemJSON(&gofeed.Item{Title: "Dated", Published: "Tue, 05 Mar 2024 09:30:00 GMT", PublishedParsed: &published}, false)
+	for _, key := range []string{"published_raw", "published_parsed"} {
+		if _, ok := out[key]; ok {
+			t.Errorf("%s present without includeRawDates", key)
+		}
+	}
+}
+
+func TestBuildItemContent_Thumbnail(t *testing.T) {
+	thumbnail := func(url, width string) ext.Extension {
+		return ext.Extension{Name: "thumbnail", Attrs: map[string]string{"url": url, "width": width}}
+	}
+	item := &gofeed.Item{
+		Title: "Gallery",
+		Extensions: ext.Extensions{"media": {
+			"thumbnail": {thumbnail("https://example.com/120.jpg", "120"), thumbnail("https://example.com/1024.jpg", "1024")},
+			"content":   {{Name: "content", Attrs: map[string]string{"url": "https://example.com/480.jpg", "medium": "image", "width": "480"}}},
+		}},
+	}
+	itemThumbnail := func(s *Server) *model.Thumbnail {
+		t.Helper()
+		blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{})
+		var out struct {
+			Thumbnail *model.Thumbnail `json:"thumbnail"`
+		}
+		if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("unmarshal item: %v", err)
+		}
+		return out.Thumbnail
+	}
+
+	tests := []struct {
+		size int
+		want string
+	}{
+		{size: DefaultThumbnailSize, want: "https://example.com/480.jpg"}, // smallest larger than 300
+		{size: 100, want: "https://example.com/120.jpg"},
+		{size: 600, want: "https://example.com/1024.jpg"},
+		{size: 2000, want: "https://example.com/1024.jpg"}, // largest smaller
+	}
+	for _, tt := range tests {
+		server, err := NewServer(&Config{Transport: model.StdioTransport, AllFeedsGetter: &mockAllFeedsGetter{}, FeedAndItemsGetter: &mockFeedAndItemsGetter{}, ThumbnailSize: tt.size})
+		if err != nil {
+			t.Fatalf("NewServer: %v", err)
+		}
+		if got := itemThumbnail(server); got == nil || got.URL != tt.want {
+			t.Errorf("thumbnail size %d: thumbnail = %+v, want %s", tt.size, got, tt.want)
+		}
+	}
+
+	item.Extensions = nil
+	if got := itemThumbnail(&Server{}); got != nil {
+		t.Errorf("thumbnail = %+v for an item without media", got)
+	}
+}
+
+func TestBuildItemContent_VerifiedMedia(t *testing.T) {
+	s := &Server{}
+	item := &gofeed.Item{
+		Title: "Episode",
+		Enclosures: []*gofeed.Enclosure{
+			{URL: "https://cdn.example.com/ep.mp3", Type: "audio/mpeg", Length: "100"},
+			{URL: "https://cdn.example.com/gone.mp3", Type: "audio/mpeg"},
+		},
+	}
+	model.SetEnclosureChecks(item, []model.EnclosureCheck{
+		{URL: "https://cdn.example.com/ep.mp3", Reachable: true, VerifiedSize: 123456, VerifiedType: "audio/mpeg"},
+		{URL: "https://cdn.example.com/gone.mp3", Error: "HTTP 404"},
+	})
+
+	blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{})
+	var out struct {
+		Media  []mediaOutput     `json:"media"`
+		Custom map[string]string `json:"custom"`
+	}
+	if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	want := []mediaOutput{
+		{URL: "https://cdn.example.com/ep.mp3", Type: "audio/mpeg", Length: "100", VerifiedSize: 123456, VerifiedType: "audio/mpeg"},
+		{URL: "https://cdn.example.com/gone.mp3", Type: "audio/mpeg", Unreachable: true, VerifyError: "HTTP 404"},
+	}
+	if !slices.Equal(out.Media, want) {
+		t.Errorf("media = %+v, want %+v", out.Media, want)
+	}
+	if _, ok := out.Custom[model.EnclosureChecksKey]; ok {
+		t.Error("raw enclosure checks repeated in custom")
+	}
+	if _, ok := item.Custom[model.EnclosureChecksKey]; !ok {
+		t.Error("output stripped the checks from the original item")
+	}
+}
+
+func TestBuildItemContent_StableIDNotRepeated(t *testing.T) {
+	s := &Server{}
+	item := &gofeed.Item{Title: "Post", Custom: map[string]string{model.StableIDKey: "guid:urn:1", "source": "wire"}}
+
+	blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{})
+	var out struct {
+		StableID string            `json:"stable_id"`
+		Custom   map[string]string `json:"custom"`
+	}
+	if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	if out.StableID != "guid:urn:1" {
+		t.Errorf("stable_id = %q, want guid:urn:1", out.StableID)
+	}
+	if _, ok := out.Custom[model.StableIDKey]; ok || out.Custom["source"] != "wire" {
+		t.Errorf("custom = %v, want only source", out.Custom)
+	}
+	if item.Custom[model.StableIDKey] != "guid:urn:1" {
+		t.Error("output removed the stable ID from the original item")
+	}
+
+	// With nothing else in custom, the field is omitted entirely.
+	blocks = s.buildItemContent(context.Background(), &gofeed.Item{Title: "Bare", Custom: map[string]string{model.StableIDKey: "guid:urn:2"}}, 0, ParsedFeedParams{})
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &raw); err != nil {
+		t.Fatalf("unmarshal item: %v", err)
+	}
+	if _, ok := raw["custom"]; ok {
+		t.Errorf("custom present with only the stable ID: %v", raw["custom"])
+	}
+}
+
+func TestBuildItemContent_Language(t *testing.T) {
+	s := &Server{}
+	decode := func(item *gofeed.Item) (string, map[string]string) {
+		t.Helper()
+		blocks := s.buildItemContent(context.Background(), item, 0, ParsedFeedParams{})
+		var out struct {
+			Language string            `json:"language"`
+			Custom   map[string]string `json:"custom"`
+		}
+		if err := json.Unmarshal([]byte(blocks[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("unmarshal item: %v", err)
+		}
+		return out.Language, out.Custom
+	}
+
+	// Assigned at fetch time: reported top-level, not repeated in custom.
+	lang, custom := decode(&gofeed.Item{Title: "Post", Custom: map[string]string{model.ItemLanguageKey: "fr"}})
+	if lang != "fr" {
+		t.Errorf("language = %q, want fr", lang)
+	}
+	if _, ok := custom[model.ItemLanguageKey]; ok {
+		t.Errorf("custom = %v, want no %s", custom, model.ItemLanguageKey)
+	}
+
+	// Items that didn't come through the store are detected on output.
+	if lang, _ := decode(&gofeed.Item{Title: "The plan", Description: "This is what the team will do with the money"}); lang != "en" {
+		t.Errorf("detected language = %q, want en", lang)
+	}
+}
@@ -0,0 +1,261 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html"
+)
+
+// Daily digest defaults.
+const (
+	defaultDigestItemsPerFeed = 10
+	digestExcerptLength       = 280
+)
+
+// digestFeed is one feed's section of the daily digest.
+type digestFeed struct {
+	Title string
+	Link  string
+	Items []digestItem
+}
+
+// digestItem is one linked item in the daily digest.
+type digestItem struct {
+	Title   string
+	Link    string
+	Date    string
+	Excerpt string
+}
+
+// digestData is the input to digestTemplate.
+type digestData struct {
+	Since     string
+	Generated string
+	Feeds     []digestFeed
+	ItemCount int
+}
+
+// digestTemplate renders the digest as a self-contained HTML email: inline
+// styles only, since most mail clients drop <style> blocks and external CSS.
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Daily Digest</title>
+</head>
+<body style="font-family: Arial, Helvetica, sans-serif; color: #222; max-width: 640px; margin: 0 auto; padding: 16px;">
+<h1 style="font-size: 22px;">Daily Digest</h1>
+<p style="color: #666; font-size: 13px;">{{.ItemCount}} items since {{.Since}} &middot; generated {{.Generated}}</p>
+{{- range .Feeds}}
+<h2 style="font-size: 18px; border-bottom: 1px solid #ddd; padding-bottom: 4px;">{{if .Link}}<a href="{{.Link}}" style="color: #222; text-decoration: none;">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h2>
+<ul style="list-style: none; padding: 0;">
+{{- range .Items}}
+<li style="margin-bottom: 14px;">
+{{if .Link}}<a href="{{.Link}}" style="font-size: 15px; font-weight: bold; color: #1a0dab;">{{.Title}}</a>{{else}}<strong style="font-size: 15px;">{{.Title}}</strong>{{end}}
+{{- if .Date}}<br><span style="color: #888; font-size: 12px;">{{.Date}}</span>{{end}}
+{{- if .Excerpt}}<br><span style="font-size: 14px;">{{.Excerpt}}</span>{{end}}
+</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>No new items in this period.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// handleGenerateDailyDigest builds an HTML email digest from the items each
+// feed published within the window, newest first. Unlike the analysis prompts,
+// it works from the actual items.
+func (s *Server) handleGenerateDailyDigest(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	since := getStringArg(req.Params.Arguments, "since", timeframe24h)
+	maxItems := getIntArg(req.Params.Arguments, "max_items_per_feed", defaultDigestItemsPerFeed)
+	if maxItems <= 0 {
+		return createErrorPromptResult(fmt.Sprintf("Invalid max_items_per_feed '%s': must be a positive integer", req.Params.Arguments["max_items_per_feed"])), nil
+	}
+
+	now := time.Now()
+	cutoff, err := parseDigestSince(since, now)
+	if err != nil {
+		return createErrorPromptResult(fmt.Sprintf("Invalid since '%s': %v", since, err)), nil
+	}
+
+	feedIDs, err := s.digestFeedIDs(ctx, getStringArg(req.Params.Arguments, "feed_ids", ""))
+	if err != nil {
+		return createErrorPromptResult(fmt.Sprintf("Failed to get feeds: %v", err)), nil
+	}
+
+	data := digestData{
+		Since:     cutoff.UTC().Format("2006-01-02 15:04 UTC"),
+		Generated: now.UTC().Format("2006-01-02 15:04 UTC"),
+	}
+	for _, id := range feedIDs {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, id)
+		if err != nil || feedResult.FetchError != "" {
+			continue // Skip failed feeds
+		}
+		section := digestFeed{Title: feedResult.Title, Link: feedResult.PublicURL}
+		if feedResult.Feed != nil {
+			if section.Title == "" {
+				section.Title = feedResult.Feed.Title
+			}
+			if feedResult.Feed.Link != "" {
+				section.Link = feedResult.Feed.Link
+			}
+		}
+		section.Items = digestItems(feedResult.Items, cutoff, maxItems)
+		if len(section.Items) == 0 {
+			continue
+		}
+		data.ItemCount += len(section.Items)
+		data.Feeds = append(data.Feeds, section)
+	}
+
+	var digest bytes.Buffer
+	if err := digestTemplate.Execute(&digest, data); err != nil {
+		return createErrorPromptResult(fmt.Sprintf("Failed to render digest: %v", err)), nil
+	}
+
+	promptContent := fmt.Sprintf(`Below is a ready-to-send HTML email digest of %d items from %d feeds published since %s. Send it as-is, or add a two or three sentence overview of the day's main themes at the top, keeping the existing HTML structure and links intact.
+
+%s`, data.ItemCount, len(data.Feeds), data.Since, digest.String())
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Daily HTML digest of %d items from %d feeds", data.ItemCount, len(data.Feeds)),
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: roleUser,
+				Content: &mcp.TextContent{
+					Text: promptContent,
+				},
+			},
+		},
+	}, nil
+}
+
+// digestFeedIDs returns the comma-separated feed IDs, or every feed's ID when
+// none are given.
+func (s *Server) digestFeedIDs(ctx context.Context, feedIDs string) ([]string, error) {
+	if feedIDs != "" {
+		var ids []string
+		for id := range strings.SplitSeq(feedIDs, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+	feeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(feeds))
+	for _, feed := range feeds {
+		ids = append(ids, feed.ID)
+	}
+	return ids, nil
+}
+
+// parseDigestSince returns the start of the digest window: since is either a
+// duration back from now ("24h", "7d", ...) or an RFC3339 timestamp.
+func parseDigestSince(since string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(since)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d <= 0 {
+		return time.Time{}, fmt.Errorf("must be a positive duration or an RFC3339 timestamp")
+	}
+	return now.Add(-d), nil
+}
+
+// digestItems returns up to maxItems of the items published (or, failing that,
+// updated) at or after cutoff, newest first. Undated items are left out, since
+// they can't be placed in the window.
+func digestItems(items []*gofeed.Item, cutoff time.Time, maxItems int) []digestItem {
+	type datedItem struct {
+		item *gofeed.Item
+		date time.Time
+	}
+	var dated []datedItem
+	for _, item := range items {
+		date := itemDate(item, dateFieldPublished)
+		if date == nil || date.Before(cutoff) {
+			continue
+		}
+		dated = append(dated, datedItem{item: item, date: *date})
+	}
+	// Stable, so same-date items keep feed order.
+	slices.SortStableFunc(dated, func(a, b datedItem) int { return b.date.Compare(a.date) })
+
+	out := make([]digestItem, 0, min(len(dated), maxItems))
+	for _, d := range dated[:min(len(dated), maxItems)] {
+		title := strings.TrimSpace(d.item.Title)
+		if title == "" {
+			title = "(untitled)"
+		}
+		excerpt := d.item.Description
+		if strings.TrimSpace(excerpt) == "" {
+			excerpt = d.item.Content
+		}
+		out = append(out, digestItem{
+			Title:   title,
+			Link:    d.item.Link,
+			Date:    d.date.UTC().Format("Jan 2, 2006 15:04 UTC"),
+			Excerpt: plainTextExcerpt(excerpt, digestExcerptLength),
+		})
+	}
+	return out
+}
+
+// plainTextExcerpt returns the text of an HTML fragment with whitespace
+// collapsed, cut at a word boundary to at most maxLen characters (plus an
+// ellipsis).
+func plainTextExcerpt(fragment string, maxLen int) string {
+	var text strings.Builder
+	skip := false // inside <script> or <style>
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			words := strings.Fields(text.String())
+			return truncateWords(strings.Join(words, " "), maxLen)
+		case html.TextToken:
+			if !skip {
+				text.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.EndTagToken:
+			if name, _ := tokenizer.TagName(); string(name) == "script" || string(name) == "style" {
+				skip = tokenType == html.StartTagToken
+			}
+		}
+		// Keep words in adjacent elements apart.
+		text.WriteByte(' ')
+	}
+}
+
+// truncateWords cuts s to at most maxLen characters, at the last word boundary
+// when there is one, and appends an ellipsis when anything was cut.
+func truncateWords(s string, maxLen int) string {
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	cut := string([]rune(s)[:maxLen])
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
@@ -0,0 +1,124 @@
+package mcpserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func digestTestServer() *Server {
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	return &Server{
+		allFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "tech"}, {ID: "world"}, {ID: "quiet"}}},
+		feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+			"tech": {ID: "tech", Title: "Tech Daily", Feed: &model.Feed{Link: "https://tech.example.com"}, Items: []*gofeed.Item{
+				{Title: "Go 1.30 released", Link: "https://tech.example.com/go", PublishedParsed: at(2 * time.Hour),
+					Description: "<p>The <b>new</b> release brings faster builds.</p><script>track()</script>"},
+				{Title: "Rust & <friends>", Link: "https://tech.example.com/rust", PublishedParsed: at(30 * time.Minute)},
+				{Title: "Last week's news", Link: "https://tech.example.com/old", PublishedParsed: at(72 * time.Hour)},
+				{Title: "Undated post", Link: "https://tech.example.com/undated"},
+			}},
+			"world": {ID: "world", Title: "World Report", Items: []*gofeed.Item{
+				{Title: "Summit opens", Link: "https://world.example.com/summit", UpdatedParsed: at(5 * time.Hour)},
+			}},
+			"quiet": {ID: "quiet", Title: "Quiet Blog", Items: []*gofeed.Item{
+				{Title: "Old musings", Link: "https://quiet.example.com/1", PublishedParsed: at(30 * 24 * time.Hour)},
+			}},
+		}},
+	}
+}
+
+func digestText(t *testing.T, s *Server, args map[string]string) string {
+	t.Helper()
+	result, err := s.handleGenerateDailyDigest(context.Background(), &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Arguments: args}})
+	if err != nil {
+		t.Fatalf("handleGenerateDailyDigest() failed: %v", err)
+	}
+	validatePromptResult(t, result)
+	text, ok := result.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", result.Messages[0].Content)
+	}
+	return text.Text
+}
+
+func TestGenerateDailyDigest(t *testing.T) {
+	s := digestTestServer()
+	digest := digestText(t, s, map[string]string{})
+
+	for _, want := range []string{
+		"<h2",
+		`<a href="https://tech.example.com" style="color: #222; text-decoration: none;">Tech Daily</a>`,
+		`<a href="https://tech.example.com/go"`,
+		">Go 1.30 released</a>",
+		"Rust &amp; &lt;friends&gt;",
+		"The new release brings faster builds.",
+		"World Report",
+		"Summit opens",
+		"3 items",
+	} {
+		if !strings.Contains(digest, want) {
+			t.Errorf("digest missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Last week&#39;s news", "Undated post", "Quiet Blog", "track()"} {
+		if strings.Contains(digest, unwanted) {
+			t.Errorf("digest contains %q, which is outside the window", unwanted)
+		}
+	}
+	// Newest first within a feed.
+	if strings.Index(digest, "Rust") > strings.Index(digest, "Go 1.30") {
+		t.Error("items are not ordered newest first")
+	}
+}
+
+func TestGenerateDailyDigest_Arguments(t *testing.T) {
+	s := digestTestServer()
+
+	digest := digestText(t, s, map[string]string{"feed_ids": "tech", "since": "7d", "max_items_per_feed": "2"})
+	if strings.Contains(digest, "World Report") {
+		t.Error("feed_ids did not restrict the digest to the tech feed")
+	}
+	if !strings.Contains(digest, "Go 1.30 released") || strings.Contains(digest, "Last week") {
+		t.Error("max_items_per_feed should keep the two newest items")
+	}
+
+	digest = digestText(t, s, map[string]string{"since": time.Now().Add(-time.Hour).Format(time.RFC3339)})
+	if !strings.Contains(digest, "Rust") || strings.Contains(digest, "Go 1.30") {
+		t.Error("an RFC3339 since should bound the window")
+	}
+
+	for _, args := range []map[string]string{{"since": "yesterday"}, {"max_items_per_feed": "0"}} {
+		if text := digestText(t, s, args); !strings.HasPrefix(text, "Error:") {
+			t.Errorf("args %v: expected an error prompt, got %q", args, text)
+		}
+	}
+}
+
+func TestPlainTextExcerpt(t *testing.T) {
+	tests := []struct {
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"<p>Hello <em>world</em></p><p>Again</p>", 100, "Hello world Again"},
+		{"plain   text\n with  spaces", 100, "plain text with spaces"},
+		{"<style>p{}</style>Body", 100, "Body"},
+		{"one two three four", 9, "one two…"},
+	}
+	for _, tt := range tests {
+		if got := plainTextExcerpt(tt.input, tt.maxLen); got != tt.want {
+			t.Errorf("plainTextExcerpt(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+		}
+	}
+}
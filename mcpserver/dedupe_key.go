@@ -0,0 +1,60 @@
+package mcpserver
+
+import (
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// dedupeKey values for merge_feeds and feed_overlap, choosing which item
+// fields decide that two items are the same. Leaving it unset uses
+// itemDedupKey: the link, else the title.
+const (
+	dedupeKeyTitleLink = "title_link" // title and link must both match
+	dedupeKeyLink      = "link"       // link only; items without one never match
+	dedupeKeyGUID      = "guid"       // GUID only; items without one never match
+	dedupeKeyTitle     = "title"      // title only
+)
+
+// dedupeKeyNames lists the dedupeKey values, for schemas and validation.
+var dedupeKeyNames = []string{dedupeKeyTitleLink, dedupeKeyLink, dedupeKeyGUID, dedupeKeyTitle}
+
+// itemDedupKeyFor identifies an item by the fields dedupeKey selects, or by
+// itemDedupKey when dedupeKey is empty. Links and titles are normalized as in
+// itemDedupKey; GUIDs are only trimmed. An empty key means the item lacks a
+// selected field and is never treated as a duplicate.
+func itemDedupKeyFor(item *gofeed.Item, dedupeKey string) string {
+	if item == nil {
+		return ""
+	}
+	switch dedupeKey {
+	case dedupeKeyLink:
+		if link := model.NormalizeItemLink(item.Link); link != "" {
+			return "link:" + link
+		}
+	case dedupeKeyTitle:
+		if title := normalizedItemTitle(item); title != "" {
+			return "title:" + title
+		}
+	case dedupeKeyGUID:
+		if guid := strings.TrimSpace(item.GUID); guid != "" {
+			return "guid:" + guid
+		}
+	case dedupeKeyTitleLink:
+		link, title := model.NormalizeItemLink(item.Link), normalizedItemTitle(item)
+		if link != "" && title != "" {
+			return "title_link:" + title + "\n" + link
+		}
+	default:
+		return itemDedupKey(item)
+	}
+	return ""
+}
+
+// normalizedItemTitle lowercases the item's title and collapses its
+// whitespace.
+func normalizedItemTitle(item *gofeed.Item) string {
+	return strings.Join(strings.Fields(strings.ToLower(item.Title)), " ")
+}
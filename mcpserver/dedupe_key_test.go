@@ -0,0 +1,112 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// dedupeKeyItems share some identifying fields but not others.
+func dedupeKeyItems() []*gofeed.Item {
+	return []*gofeed.Item{
+		{Title: "Launch", GUID: "g1", Link: "https://example.com/launch", Description: "A"},
+		{Title: "Launch", GUID: "g2", Link: "https://example.com/launch-2", Description: "B"},             // A's title
+		{Title: "Launch!", GUID: "g1", Link: "https://example.com/launch?utm_source=x", Description: "C"}, // A's link and GUID
+		{Title: "LAUNCH", GUID: "g3", Link: "https://example.com/launch/", Description: "D"},              // A's title and link
+		{Title: "Launch", GUID: "g1", Description: "E"},                                                   // no link
+		{Title: "Launch", Description: "F"},                                                               // no link or GUID
+	}
+}
+
+func descriptions(items []*gofeed.Item) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Description
+	}
+	return out
+}
+
+func TestDeduplicateItems_DedupeKey(t *testing.T) {
+	tests := []struct {
+		dedupeKey string
+		want      []string
+	}{
+		{"", []string{"A", "B", "E"}},                           // link, else title: E and F share a title
+		{dedupeKeyLink, []string{"A", "B", "E", "F"}},           // linkless items are kept
+		{dedupeKeyGUID, []string{"A", "B", "D", "F"}},           // C and E reuse A's GUID
+		{dedupeKeyTitle, []string{"A", "C"}},                    // only "Launch!" differs
+		{dedupeKeyTitleLink, []string{"A", "B", "C", "E", "F"}}, // only D matches A on both
+	}
+	for _, tt := range tests {
+		t.Run("dedupeKey="+tt.dedupeKey, func(t *testing.T) {
+			if got := descriptions(deduplicateItems(dedupeKeyItems(), tt.dedupeKey, 0)); !slices.Equal(got, tt.want) {
+				t.Errorf("kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeduplicateItems_Window(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		date := time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC)
+		return &date
+	}
+	// A weekly column shares its title across issues; one issue is
+	// syndicated twice a few hours apart.
+	items := []*gofeed.Item{
+		{Title: "Weekly Roundup", Description: "week 1", PublishedParsed: at(1, 9)},
+		{Title: "Weekly Roundup", Description: "week 1 repost", PublishedParsed: at(1, 15)},
+		{Title: "Weekly Roundup", Description: "week 2", PublishedParsed: at(8, 9)},
+		{Title: "Weekly Roundup", Description: "undated"},
+		{Title: "Weekly Roundup", Description: "week 3", PublishedParsed: at(15, 9)},
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   []string
+	}{
+		{"no window", 0, []string{"week 1"}},
+		{"24 hours", 24 * time.Hour, []string{"week 1", "week 2", "week 3"}},
+		{"6 hours is inclusive", 6 * time.Hour, []string{"week 1", "week 2", "week 3"}},
+		{"5 hours", 5 * time.Hour, []string{"week 1", "week 1 repost", "week 2", "week 3"}},
+		// Week 2 is dropped as a repeat of week 1, and week 3 is compared
+		// with the kept items only, so it is two weeks from its match.
+		{"a week", 7 * 24 * time.Hour, []string{"week 1", "week 3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptions(deduplicateItems(items, dedupeKeyTitle, tt.window)); !slices.Equal(got, tt.want) {
+				t.Errorf("kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedOverlap_DedupeKey(t *testing.T) {
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"a": {ID: "a", Items: []*gofeed.Item{
+			{Title: "Launch", GUID: "urn:1", Link: "https://example.com/launch"},
+		}},
+		"b": {ID: "b", Items: []*gofeed.Item{
+			// Same link, another title and GUID.
+			{Title: "We launched", GUID: "urn:2", Link: "https://example.com/launch"},
+		}},
+	}}
+	s := &Server{feedAndItemsGetter: getter}
+
+	for dedupeKey, wantShared := range map[string]int{"": 1, dedupeKeyLink: 1, dedupeKeyTitle: 0, dedupeKeyGUID: 0, dedupeKeyTitleLink: 0} {
+		result, err := s.feedOverlap(context.Background(), FeedOverlapParams{FeedIDs: []string{"a", "b"}, DedupeKey: dedupeKey})
+		if err != nil {
+			t.Fatalf("feedOverlap(%q): %v", dedupeKey, err)
+		}
+		if len(result.SharedItems) != wantShared {
+			t.Errorf("dedupeKey %q: %d shared items, want %d", dedupeKey, len(result.SharedItems), wantShared)
+		}
+	}
+}
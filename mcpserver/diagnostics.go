@@ -0,0 +1,155 @@
+package mcpserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// DiagnosticsProvider reports the store's recent fetch errors, circuit breaker
+// states, retry metrics, per-feed fetch timings, the rate-limit quotas hosts
+// have reported, and likely duplicate feeds.
+type DiagnosticsProvider interface {
+	GetDiagnostics(ctx context.Context) (*Diagnostics, error)
+}
+
+// Diagnostics is the body of the feeds://diagnostics resource.
+type Diagnostics struct {
+	RecentErrors    []RecentError          `json:"recent_errors"` // newest first
+	CircuitBreakers []CircuitBreakerStatus `json:"circuit_breakers"`
+	RetryMetrics    RetryMetricsSnapshot   `json:"retry_metrics"`
+	RateLimitQuotas []RateLimitQuotaStatus `json:"rate_limit_quotas"` // sorted by host
+	FeedTimings     []FeedTiming           `json:"feed_timings"`      // feeds fetched so far, by feed ID
+	// DuplicateFeeds are the likely mirror feeds found by the latest
+	// duplicate feed check; see DuplicateFeedsProvider.
+	DuplicateFeeds []DuplicateFeedGroup `json:"duplicate_feeds"`
+}
+
+// RecentError is a feed fetch failure as retained for diagnostics. ID is the
+// FeedError correlation ID, so it can be matched against logs and tool errors.
+type RecentError struct {
+	ID         string          `json:"id"`
+	Timestamp  time.Time       `json:"timestamp"`
+	FeedID     string          `json:"feed_id,omitempty"`
+	URL        string          `json:"url,omitempty"`
+	ErrorType  model.ErrorType `json:"error_type"`
+	Message    string          `json:"message"`
+	Operation  string          `json:"operation,omitempty"`
+	Component  string          `json:"component,omitempty"`
+	HTTPStatus int             `json:"http_status,omitempty"`
+	Cause      string          `json:"cause,omitempty"`
+}
+
+// CircuitBreakerStatus is one feed's circuit breaker state and counters.
+type CircuitBreakerStatus struct {
+	FeedID              string `json:"feed_id"`
+	URL                 string `json:"url"`
+	State               string `json:"state"` // closed, half-open, or open
+	Requests            uint32 `json:"requests"`
+	TotalFailures       uint32 `json:"total_failures"`
+	ConsecutiveFailures uint32 `json:"consecutive_failures"`
+}
+
+// FeedTiming is how long one feed's network fetches have taken. Fetches
+// counts every attempt to load the feed, each including its retries and any
+// fallback URLs; Failures counts those that ended in an error.
+type FeedTiming struct {
+	FeedID            string    `json:"feed_id"`
+	URL               string    `json:"url"`
+	Fetches           int64     `json:"fetches"`
+	Failures          int64     `json:"failures"`
+	LastDurationMs    int64     `json:"last_duration_ms"`
+	AverageDurationMs int64     `json:"average_duration_ms"`
+	LastFetchedAt     time.Time `json:"last_fetched_at"`
+}
+
+// RateLimitQuotaStatus is the request quota a host last reported through
+// rate-limit response headers, and how often fetches to it were slowed to stay
+// within it. Limit is omitted when the host didn't report one. ResetAssumed is
+// set when a low quota came without a reset time and a default window was
+// assumed.
+type RateLimitQuotaStatus struct {
+	Host              string    `json:"host"`
+	Limit             *int      `json:"limit,omitempty"`
+	Remaining         int       `json:"remaining"`
+	Reset             time.Time `json:"reset,omitzero"`
+	ResetAssumed      bool      `json:"reset_assumed,omitempty"`
+	ObservedAt        time.Time `json:"observed_at"`
+	ThrottledRequests int64     `json:"throttled_requests"`
+	TotalDelayMs      int64     `json:"total_delay_ms"`
+}
+
+// RetryMetricsSnapshot mirrors the store's retry metrics.
+type RetryMetricsSnapshot struct {
+	TotalAttempts    int64   `json:"total_attempts"`
+	TotalRetries     int64   `json:"total_retries"`
+	SuccessfulFeeds  int64   `json:"successful_feeds"`
+	FailedFeeds      int64   `json:"failed_feeds"`
+	RetrySuccessRate float64 `json:"retry_success_rate"`
+	// ElapsedBudgetExceeded counts failed feeds whose retries stopped early
+	// because the retry elapsed-time budget ran out.
+	ElapsedBudgetExceeded int64 `json:"elapsed_budget_exceeded"`
+}
+
+// diagnosticsProvider returns the configured DiagnosticsProvider, if any.
+func (rm *ResourceManager) diagnosticsProvider() (DiagnosticsProvider, bool) {
+	provider := rm.capabilities.diagnosticsProvider
+	return provider, provider != nil
+}
+
+// readDiagnostics reads the diagnostics resource. It is cached only briefly
+// (DiagnosticsTTL), since operators read it while a problem is unfolding.
+func (rm *ResourceManager) readDiagnostics(ctx context.Context) (*mcp.ReadResourceResult, error) {
+	provider, ok := rm.diagnosticsProvider()
+	if !ok {
+		return nil, model.CreateResourceUnavailableError(DiagnosticsURI, "the feed store does not provide diagnostics").
+			WithOperation("read_diagnostics")
+	}
+
+	cacheKey := rm.generateCacheKey(DiagnosticsURI)
+	if cachedContent, err := rm.resourceCache.Get(ctx, cacheKey); err == nil && cachedContent != "" {
+		rm.recordCacheHit()
+		return diagnosticsResult(cachedContent), nil
+	}
+	rm.recordCacheMiss()
+
+	diagnostics, err := provider.GetDiagnostics(ctx)
+	if err != nil {
+		return nil, model.CreateResourceUnavailableError(DiagnosticsURI, err.Error()).
+			WithOperation("read_diagnostics")
+	}
+
+	content := map[string]any{
+		"recent_errors":     diagnostics.RecentErrors,
+		"circuit_breakers":  diagnostics.CircuitBreakers,
+		"retry_metrics":     diagnostics.RetryMetrics,
+		"rate_limit_quotas": diagnostics.RateLimitQuotas,
+		"feed_timings":      diagnostics.FeedTimings,
+		keyUpdatedAt:        time.Now().UTC(),
+	}
+	contentJSON, err := marshalJSONContent(content, DiagnosticsURI)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := rm.getTTLForResourceType(DiagnosticsURI)
+	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
+
+	return diagnosticsResult(contentJSON), nil
+}
+
+func diagnosticsResult(contentJSON string) *mcp.ReadResourceResult {
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      DiagnosticsURI,
+				MIMEType: JSONMIMEType,
+				Text:     contentJSON,
+			},
+		},
+	}
+}
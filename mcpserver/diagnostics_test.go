@@ -0,0 +1,77 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockDiagnosticsGetter adds DiagnosticsProvider to the resource feed getter.
+type mockDiagnosticsGetter struct {
+	mockResourceFeedAndItemsGetter
+	diagnostics *Diagnostics
+	calls       int
+}
+
+func (m *mockDiagnosticsGetter) GetDiagnostics(ctx context.Context) (*Diagnostics, error) {
+	m.calls++
+	return m.diagnostics, nil
+}
+
+func TestReadDiagnostics(t *testing.T) {
+	getter := &mockDiagnosticsGetter{diagnostics: &Diagnostics{
+		RecentErrors:    []RecentError{{ID: "err-1", ErrorType: model.ErrorTypeHTTPServerError, Message: "HTTP 500"}},
+		CircuitBreakers: []CircuitBreakerStatus{{FeedID: "feed-1", State: "open", ConsecutiveFailures: 5}},
+	}}
+	rm := NewResourceManager(&mockResourceAllFeedsGetter{}, getter)
+	rm.capabilities.diagnosticsProvider = getter
+	ctx := context.Background()
+
+	resources, err := rm.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if !slices.ContainsFunc(resources, func(r *mcp.Resource) bool { return r.URI == DiagnosticsURI }) {
+		t.Error("diagnostics resource not listed")
+	}
+
+	for i := range 2 {
+		if i > 0 {
+			// Ristretto cache is async, give it time to process the Set operation
+			time.Sleep(10 * time.Millisecond)
+		}
+		result, err := rm.ReadResource(ctx, DiagnosticsURI)
+		if err != nil {
+			t.Fatalf("ReadResource failed: %v", err)
+		}
+		text := result.Contents[0].Text
+		if !strings.Contains(text, `"id":"err-1"`) || !strings.Contains(text, `"state":"open"`) {
+			t.Errorf("unexpected diagnostics content: %s", text)
+		}
+	}
+	if getter.calls != 1 {
+		t.Errorf("expected the second read to be served from cache, got %d provider calls", getter.calls)
+	}
+}
+
+func TestReadDiagnostics_Unsupported(t *testing.T) {
+	rm := createTestResourceManager()
+	ctx := context.Background()
+
+	resources, err := rm.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if slices.ContainsFunc(resources, func(r *mcp.Resource) bool { return r.URI == DiagnosticsURI }) {
+		t.Error("diagnostics resource listed without a provider")
+	}
+	if _, err := rm.ReadResource(ctx, DiagnosticsURI); err == nil {
+		t.Error("expected an error reading diagnostics without a provider")
+	}
+}
@@ -0,0 +1,62 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DuplicateFeedsProvider reports feeds that are likely mirrors of each other.
+type DuplicateFeedsProvider interface {
+	// DuplicateFeeds returns the result of the store's latest duplicate feed
+	// check.
+	DuplicateFeeds(ctx context.Context) (*DuplicateFeedsReport, error)
+}
+
+// DuplicateFeedsReport is the result of a duplicate feed check. CheckedAt is
+// zero until the first check has run.
+type DuplicateFeedsReport struct {
+	CheckedAt     time.Time            `json:"checked_at,omitzero"`
+	CheckInterval string               `json:"check_interval"`
+	Groups        []DuplicateFeedGroup `json:"groups"`
+}
+
+// DuplicateFeedGroup is a set of feeds whose latest fetches returned the same
+// items, likely one feed served under several URLs. Fingerprint identifies
+// the item set; ItemCount is its size.
+type DuplicateFeedGroup struct {
+	Fingerprint string          `json:"fingerprint"`
+	ItemCount   int             `json:"item_count"`
+	Feeds       []DuplicateFeed `json:"feeds"`
+}
+
+// DuplicateFeed is one feed of a DuplicateFeedGroup.
+type DuplicateFeed struct {
+	FeedID string `json:"feed_id"`
+	URL    string `json:"url"`
+}
+
+// addDuplicateFeedsTool adds the list_duplicate_feeds tool
+func (s *Server) addDuplicateFeedsTool(srv *mcp.Server, provider DuplicateFeedsProvider) {
+	duplicateFeedsTool := &mcp.Tool{
+		Name:        toolListDuplicateFeeds,
+		Description: "List groups of feeds that are likely mirrors: feeds whose latest fetches returned the identical set of items under different URLs, as found by the server's periodic duplicate check. Use to consolidate feeds and save fetches; feeds not yet fetched aren't compared.",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, duplicateFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		report, err := provider.DuplicateFeeds(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(report)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
@@ -0,0 +1,57 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockDuplicateFeedsProvider returns a fixed duplicate feed report.
+type mockDuplicateFeedsProvider struct {
+	mockFeedAndItemsGetter
+	report *DuplicateFeedsReport
+}
+
+func (m *mockDuplicateFeedsProvider) DuplicateFeeds(ctx context.Context) (*DuplicateFeedsReport, error) {
+	return m.report, nil
+}
+
+func TestListDuplicateFeedsTool(t *testing.T) {
+	checkedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	provider := &mockDuplicateFeedsProvider{report: &DuplicateFeedsReport{
+		CheckedAt:     checkedAt,
+		CheckInterval: "1h0m0s",
+		Groups: []DuplicateFeedGroup{{
+			Fingerprint: "abc123",
+			ItemCount:   2,
+			Feeds: []DuplicateFeed{
+				{FeedID: "original", URL: "https://example.com/feed"},
+				{FeedID: "mirror", URL: "https://mirror.example.net/feed"},
+			},
+		}},
+	}}
+	session := newTestClientSession(t, &Config{
+		Transport:              model.StdioTransport,
+		AllFeedsGetter:         &mockAllFeedsGetter{},
+		FeedAndItemsGetter:     provider,
+		DuplicateFeedsProvider: provider,
+	})
+	ctx := context.Background()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolListDuplicateFeeds, Arguments: map[string]any{}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	var report DuplicateFeedsReport
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !report.CheckedAt.Equal(checkedAt) || len(report.Groups) != 1 || len(report.Groups[0].Feeds) != 2 || report.Groups[0].Feeds[1].FeedID != "mirror" {
+		t.Errorf("report = %+v", report)
+	}
+}
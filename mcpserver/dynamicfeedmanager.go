@@ -22,8 +22,9 @@ type DynamicFeedManager interface {
 	// RefreshFeed forces a refresh of a specific feed
 	RefreshFeed(ctx context.Context, feedID string) (*RefreshFeedInfo, error)
 
-	// UpdateFeedMetadata updates feed metadata (title, category, description)
-	UpdateFeedMetadata(ctx context.Context, feedID string, metadata FeedMetadata) error
+	// UpdateFeedMetadata updates feed metadata (title, category, description,
+	// alias) and returns the updated feed information
+	UpdateFeedMetadata(ctx context.Context, feedID string, metadata FeedMetadata) (*ManagedFeedInfo, error)
 
 	// PauseFeed pauses fetching for a specific feed
 	PauseFeed(ctx context.Context, feedID string) error
@@ -34,10 +35,11 @@ type DynamicFeedManager interface {
 
 // FeedConfig holds configuration for a new feed
 type FeedConfig struct {
-	URL         string `json:"url" description:"RSS/Atom/JSON feed URL"`
-	Title       string `json:"title,omitempty" description:"Optional human-readable title"`
-	Category    string `json:"category,omitempty" description:"Optional category for organization"`
-	Description string `json:"description,omitempty" description:"Optional description"`
+	URL         string   `json:"url" description:"RSS/Atom/JSON feed URL"`
+	Title       string   `json:"title,omitempty" description:"Optional human-readable title"`
+	Category    string   `json:"category,omitempty" description:"Optional category for organization"`
+	Description string   `json:"description,omitempty" description:"Optional description"`
+	Tags        []string `json:"tags,omitempty" description:"Optional tags such as priority:high"`
 }
 
 // FeedMetadata holds updatable metadata for a feed
@@ -45,6 +47,9 @@ type FeedMetadata struct {
 	Title       string `json:"title,omitempty" description:"Feed title"`
 	Category    string `json:"category,omitempty" description:"Feed category"`
 	Description string `json:"description,omitempty" description:"Feed description"`
+	Alias       string `json:"alias,omitempty" description:"Short URI-safe name for the feed"`
+	// Tags replaces the feed's tags when non-nil; an empty list clears them.
+	Tags []string `json:"tags,omitempty" description:"Feed tags"`
 }
 
 // ManagedFeedInfo contains comprehensive information about a managed feed
@@ -54,12 +59,15 @@ type ManagedFeedInfo struct {
 	Title       string    `json:"title" description:"Feed title"`
 	Category    string    `json:"category,omitempty" description:"Feed category"`
 	Description string    `json:"description,omitempty" description:"Feed description"`
+	Alias       string    `json:"alias,omitempty" description:"Short URI-safe name for the feed"`
 	Status      string    `json:"status" description:"'active', 'error', 'paused'"`
 	LastFetched time.Time `json:"lastFetched" description:"Last successful fetch time"`
 	LastError   string    `json:"lastError,omitempty" description:"Most recent error message"`
 	ItemCount   int       `json:"itemCount" description:"Current number of cached items"`
 	AddedAt     time.Time `json:"addedAt" description:"When feed was added"`
 	Source      string    `json:"source" description:"'runtime', 'startup', 'opml'"`
+	Subscribers int       `json:"subscribers" description:"Sessions subscribed to the feed's resources"`
+	Tags        []string  `json:"tags,omitempty" description:"Feed tags, normalized"`
 }
 
 // RemovedFeedInfo contains information about a removed feed
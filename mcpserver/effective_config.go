@@ -0,0 +1,214 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// RedactedValue replaces secrets, such as per-feed header values, in the
+// configuration get_config reports.
+const RedactedValue = "[REDACTED]"
+
+// ConfigProvider reports the store's effective configuration, the store
+// section of get_config.
+type ConfigProvider interface {
+	// EffectiveConfig returns the configuration in force, with defaults
+	// applied and secrets replaced by RedactedValue.
+	EffectiveConfig(ctx context.Context) (*StoreSettings, error)
+}
+
+// EffectiveConfig is the get_config result. Store is omitted when the store
+// doesn't implement ConfigProvider.
+type EffectiveConfig struct {
+	Server ServerSettings `json:"server"`
+	Store  *StoreSettings `json:"store,omitempty"`
+}
+
+// ServerSettings is the MCP server's side of the effective configuration.
+// Durations are Go duration strings, e.g. "10m0s".
+type ServerSettings struct {
+	Transport            string                `json:"transport"`
+	HTTPPort             string                `json:"http_port,omitempty"`
+	HTTPStateless        bool                  `json:"http_stateless,omitempty"`
+	HTTPSessionTimeout   string                `json:"http_session_timeout,omitempty"`
+	HTTPCompression      bool                  `json:"http_compression,omitempty"`
+	RuntimeFeeds         bool                  `json:"runtime_feeds"`
+	ResourceCache        ResourceCacheSettings `json:"resource_cache"`
+	ToolResultCacheTTL   string                `json:"tool_result_cache_ttl"` // "0s" when disabled
+	SubstantiveMinLength int                   `json:"substantive_min_length"`
+	ThumbnailSize        int                   `json:"thumbnail_size"`
+	FileExportDir        string                `json:"file_export_dir,omitempty"`
+	ReadStateFile        string                `json:"read_state_file,omitempty"`
+}
+
+// ResourceCacheSettings is the resource cache's effective configuration.
+type ResourceCacheSettings struct {
+	DefaultTTL           string `json:"default_ttl"`
+	FeedListTTL          string `json:"feed_list_ttl"`
+	FeedItemsTTL         string `json:"feed_items_ttl"`
+	FeedMetadataTTL      string `json:"feed_metadata_ttl"`
+	DiagnosticsTTL       string `json:"diagnostics_ttl"`
+	MaxCost              int64  `json:"max_cost"`
+	MaxConcurrentFetches int    `json:"max_concurrent_fetches"`
+	RefreshInterval      string `json:"refresh_interval"`
+}
+
+// StoreSettings is the feed store's effective configuration, as reported by a
+// ConfigProvider. Durations are Go duration strings; "0s" means the setting
+// is off.
+type StoreSettings struct {
+	FeedCount        int                    `json:"feed_count"`
+	MaxFeeds         int                    `json:"max_feeds"` // 0: no cap
+	CacheExpireAfter string                 `json:"cache_expire_after"`
+	HTTP             StoreHTTPSettings      `json:"http"`
+	RateLimit        RateLimitSettings      `json:"rate_limit"`
+	Retry            RetrySettings          `json:"retry"`
+	CircuitBreaker   CircuitBreakerSettings `json:"circuit_breaker"`
+	Parsing          ParsingSettings        `json:"parsing"`
+	RefreshCron      string                 `json:"refresh_cron,omitempty"`
+	// DuplicateFeedCheckInterval is how often feeds are compared for
+	// identical content.
+	DuplicateFeedCheckInterval string `json:"duplicate_feed_check_interval"`
+	FeedStoreFile              string `json:"feed_store_file,omitempty"`
+	MaxConcurrentFetches       int    `json:"max_concurrent_fetches"` // 0: no bound
+	// FeedPriorities lists each feed URL's fetch priority, with any password
+	// in the URL masked. Feeds not listed have priority zero.
+	FeedPriorities map[string]int `json:"feed_priorities,omitempty"`
+	// PerFeedHeaders lists the extra headers sent to each feed URL, with
+	// their values redacted and any password in the URL masked.
+	PerFeedHeaders map[string]map[string]string `json:"per_feed_headers,omitempty"`
+}
+
+// StoreHTTPSettings holds the store's fetch and connection pool settings.
+type StoreHTTPSettings struct {
+	Timeout              string   `json:"timeout"`
+	OverallFetchTimeout  string   `json:"overall_fetch_timeout"`
+	ParseTimeout         string   `json:"parse_timeout"`
+	MaxIdleConns         int      `json:"max_idle_conns"`
+	MaxConnsPerHost      int      `json:"max_conns_per_host"`
+	MaxIdleConnsPerHost  int      `json:"max_idle_conns_per_host"`
+	IdleConnTimeout      string   `json:"idle_conn_timeout"`
+	MinTLSVersion        string   `json:"min_tls_version"`
+	AllowPrivateIPs      bool     `json:"allow_private_ips"`
+	UpgradeInsecureFeeds bool     `json:"upgrade_insecure_feeds"`
+	AcceptedContentTypes []string `json:"accepted_content_types,omitempty"`
+	UserAgents           []string `json:"user_agents,omitempty"`
+	UserAgentRotation    string   `json:"user_agent_rotation,omitempty"`
+}
+
+// RateLimitSettings holds the store's per-host rate limit.
+type RateLimitSettings struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	BurstCapacity     int     `json:"burst_capacity"`
+	IdleTimeout       string  `json:"idle_timeout"` // negative: limiters are never evicted
+}
+
+// RetrySettings holds the store's retry settings.
+type RetrySettings struct {
+	MaxAttempts    int    `json:"max_attempts"`
+	BaseDelay      string `json:"base_delay"`
+	MaxDelay       string `json:"max_delay"`
+	MaxElapsedTime string `json:"max_elapsed_time"`
+	Jitter         bool   `json:"jitter"`
+	ParseErrors    bool   `json:"parse_errors"`
+}
+
+// CircuitBreakerSettings holds the store's circuit breaker settings.
+type CircuitBreakerSettings struct {
+	Enabled          bool   `json:"enabled"`
+	MaxRequests      uint32 `json:"max_requests"`
+	Interval         string `json:"interval"`
+	Timeout          string `json:"timeout"`
+	FailureThreshold uint32 `json:"failure_threshold"`
+}
+
+// ParsingSettings holds the store's item processing settings.
+type ParsingSettings struct {
+	MissingDateStrategy    string   `json:"missing_date_strategy"`
+	HideFutureItems        bool     `json:"hide_future_items"`
+	FutureItemTolerance    string   `json:"future_item_tolerance"`
+	StrictParsing          bool     `json:"strict_parsing"`
+	LenientXML             bool     `json:"lenient_xml"`
+	StableIDChain          []string `json:"stable_id_chain"`
+	ResolveRelativeURLs    bool     `json:"resolve_relative_urls"`
+	DeduplicateWithinFeed  bool     `json:"deduplicate_within_feed"`
+	NormalizeCategories    bool     `json:"normalize_categories"`
+	SearchIndex            bool     `json:"search_index"`
+	VerifyEnclosures       bool     `json:"verify_enclosures"`
+	VerifyBodyCompleteness bool     `json:"verify_body_completeness"`
+}
+
+// addConfigTool adds the get_config tool
+func (s *Server) addConfigTool(srv *mcp.Server) {
+	configTool := &mcp.Tool{
+		Name:        toolGetConfig,
+		Description: "Get the server's effective configuration for debugging: transport, cache TTLs, and, when the store reports them, feed count, timeouts, rate limits, retry and circuit breaker settings, with defaults applied. Secrets such as per-feed header values are redacted.",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, configTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		config, err := s.effectiveConfig(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// effectiveConfig gathers the get_config result.
+func (s *Server) effectiveConfig(ctx context.Context) (*EffectiveConfig, error) {
+	config := &EffectiveConfig{Server: ServerSettings{
+		Transport:            s.transport.String(),
+		RuntimeFeeds:         s.dynamicFeedManager != nil,
+		ToolResultCacheTTL:   "0s",
+		SubstantiveMinLength: s.substantiveMinLen,
+		ThumbnailSize:        s.thumbnailSize,
+		FileExportDir:        s.fileExportDir,
+	}}
+	if s.readStates != nil {
+		config.Server.ReadStateFile = s.readStates.path
+	}
+	if s.transport != model.StdioTransport {
+		config.Server.HTTPPort = s.httpPort
+		config.Server.HTTPStateless = s.httpStateless
+		config.Server.HTTPSessionTimeout = s.httpSessionTimeout.String()
+		config.Server.HTTPCompression = s.httpCompression
+	}
+	if s.toolResultCache != nil {
+		config.Server.ToolResultCacheTTL = s.toolResultCache.ttl.String()
+	}
+	if s.resourceManager != nil {
+		cacheConfig := s.resourceManager.cacheConfig
+		config.Server.ResourceCache = ResourceCacheSettings{
+			DefaultTTL:           cacheConfig.DefaultTTL.String(),
+			FeedListTTL:          cacheConfig.FeedListTTL.String(),
+			FeedItemsTTL:         cacheConfig.FeedItemsTTL.String(),
+			FeedMetadataTTL:      cacheConfig.FeedMetadataTTL.String(),
+			DiagnosticsTTL:       cacheConfig.DiagnosticsTTL.String(),
+			MaxCost:              cacheConfig.MaxCost,
+			MaxConcurrentFetches: cacheConfig.MaxConcurrentFetches,
+			RefreshInterval:      cacheConfig.RefreshInterval.String(),
+		}
+	}
+
+	provider := s.capabilities.configProvider
+	if provider == nil {
+		return config, nil
+	}
+	storeConfig, err := provider.EffectiveConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config.Store = storeConfig
+	return config, nil
+}
@@ -0,0 +1,74 @@
+package mcpserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// configFeedAndItemsGetter adds ConfigProvider to mockFeedAndItemsGetter.
+type configFeedAndItemsGetter struct {
+	mockFeedAndItemsGetter
+	settings *StoreSettings
+}
+
+func (g *configFeedAndItemsGetter) EffectiveConfig(context.Context) (*StoreSettings, error) {
+	return g.settings, nil
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	newServer := func(getter FeedAndItemsGetter, config *Config) *Server {
+		t.Helper()
+		config.Transport = model.StdioTransport
+		config.AllFeedsGetter = &mockAllFeedsGetter{}
+		config.FeedAndItemsGetter = getter
+		srv, err := NewServer(config)
+		if err != nil {
+			t.Fatalf("NewServer: %v", err)
+		}
+		return srv
+	}
+
+	t.Run("server defaults without store settings", func(t *testing.T) {
+		srv := newServer(&mockFeedAndItemsGetter{}, &Config{})
+		config, err := srv.effectiveConfig(context.Background())
+		if err != nil {
+			t.Fatalf("effectiveConfig: %v", err)
+		}
+		if config.Store != nil {
+			t.Errorf("store = %+v, want omitted when the store doesn't implement ConfigProvider", config.Store)
+		}
+		server := config.Server
+		if server.Transport != "stdio" || server.HTTPPort != "" || server.RuntimeFeeds {
+			t.Errorf("transport = %q, http_port = %q, runtime_feeds = %v, want stdio without HTTP settings or runtime feeds", server.Transport, server.HTTPPort, server.RuntimeFeeds)
+		}
+		if server.ToolResultCacheTTL != "0s" || server.SubstantiveMinLength != DefaultSubstantiveMinLength {
+			t.Errorf("tool_result_cache_ttl = %q, substantive_min_length = %d, want 0s and %d", server.ToolResultCacheTTL, server.SubstantiveMinLength, DefaultSubstantiveMinLength)
+		}
+		if server.ResourceCache.DefaultTTL == "" || server.ResourceCache.MaxCost <= 0 || server.ResourceCache.MaxConcurrentFetches <= 0 {
+			t.Errorf("resource_cache = %+v, want defaults filled in", server.ResourceCache)
+		}
+	})
+
+	t.Run("configured values and store settings", func(t *testing.T) {
+		settings := &StoreSettings{FeedCount: 3}
+		getter := &configFeedAndItemsGetter{settings: settings}
+		srv := newServer(getter, &Config{
+			ConfigProvider:       getter,
+			ToolResultCacheTTL:   2 * time.Minute,
+			SubstantiveMinLength: 40,
+		})
+		config, err := srv.effectiveConfig(context.Background())
+		if err != nil {
+			t.Fatalf("effectiveConfig: %v", err)
+		}
+		if config.Store != settings {
+			t.Errorf("store = %+v, want the store's settings", config.Store)
+		}
+		if config.Server.ToolResultCacheTTL != "2m0s" || config.Server.SubstantiveMinLength != 40 {
+			t.Errorf("tool_result_cache_ttl = %q, substantive_min_length = %d, want 2m0s and 40", config.Server.ToolResultCacheTTL, config.Server.SubstantiveMinLength)
+		}
+	})
+}
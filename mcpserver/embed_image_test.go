@@ -61,7 +61,7 @@ func TestEmbedImages(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 
 		// Should have: [0] TextContent (feed metadata), [1] TextContent (item), [2] ImageContent
 		if len(content) != 3 {
@@ -151,11 +151,11 @@ func TestEmbedImages(t *testing.T) {
 		ctx := context.Background()
 
 		// First call - should fetch from server
-		content1 := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content1 := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 		firstRequestCount := requestCount
 
 		// Second call - should hit cache
-		content2 := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content2 := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 		secondRequestCount := requestCount
 
 		// Verify first call fetched from server
@@ -227,7 +227,7 @@ func TestEmbedImages(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 
 		// Should have: [0] TextContent (feed), [1] TextContent (item), [2] ResourceLink (fallback)
 		if len(content) != 3 {
@@ -288,7 +288,7 @@ func TestEmbedImages(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 
 		// Should fall back to ResourceLink when image is too large
 		if len(content) != 3 {
@@ -353,7 +353,7 @@ func TestEmbedImages(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 
 		// Should have: [0] TextContent (feed), [1] TextContent (item), [2-11] ImageContent (max 10)
 		expectedCount := 2 + MaxImagesPerItem
@@ -439,7 +439,7 @@ func TestEmbedImages(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_ = server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, true)
+		_ = server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true, EmbedImages: true})
 
 		// Circuit breaker should open after 3 consecutive failures
 		// So we expect 3 requests, not 4
@@ -491,7 +491,7 @@ func TestEmbedImages(t *testing.T) {
 
 		ctx := context.Background()
 		// includeImages=false, embedImages=true should result in no images
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, false, true)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{EmbedImages: true})
 
 		// Should only have feed metadata and item text (no images)
 		if len(content) != 2 {
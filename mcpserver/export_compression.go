@@ -0,0 +1,57 @@
+package mcpserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// export_feed_data compress values.
+const (
+	compressNone = "none"
+	compressGzip = "gzip"
+)
+
+// compressedExport is what export_feed_data returns with compress=gzip: the
+// export in the requested format, gzip-compressed and base64-encoded so it
+// fits in a text result. Encoding tells the client how to get it back.
+type compressedExport struct {
+	Format         string `json:"format"`
+	Encoding       string `json:"encoding"`
+	Size           int    `json:"size"`
+	CompressedSize int    `json:"compressed_size"`
+	Data           string `json:"data"`
+}
+
+// compressExport gzips an export and wraps it in a compressedExport.
+func compressExport(format, exported string) (string, error) {
+	compressed, err := gzipExport(exported)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(compressedExport{
+		Format:         format,
+		Encoding:       "gzip+base64",
+		Size:           len(exported),
+		CompressedSize: len(compressed),
+		Data:           base64.StdEncoding.EncodeToString(compressed),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// gzipExport gzip-compresses an export.
+func gzipExport(exported string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(exported)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package mcpserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// callExport calls export_feed_data with the given arguments and returns the
+// text result.
+func callExport(t *testing.T, session *mcp.ClientSession, args map[string]any) string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolExportFeedData, Arguments: args})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool(%v): %v, %+v", args, err, result)
+	}
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+// decompressExport decodes a compressed export_feed_data result.
+func decompressExport(t *testing.T, text string) (compressedExport, string) {
+	t.Helper()
+	var out compressedExport
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatalf("unmarshal compressed export: %v", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(out.Data)
+	if err != nil {
+		t.Fatalf("decode base64: %v", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	return out, string(data)
+}
+
+func TestExportFeedData_GzipDecompressesToPlainExport(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(200))
+
+	plain := callExport(t, session, map[string]any{keyFormat: formatCSV})
+	out, decompressed := decompressExport(t, callExport(t, session, map[string]any{keyFormat: formatCSV, "compress": compressGzip}))
+	if decompressed != plain {
+		t.Errorf("decompressed export differs from the plain export")
+	}
+	if out.Format != formatCSV || out.Encoding != "gzip+base64" {
+		t.Errorf("format, encoding = %q, %q", out.Format, out.Encoding)
+	}
+	if out.Size != len(plain) {
+		t.Errorf("size = %d, want %d", out.Size, len(plain))
+	}
+	if out.CompressedSize*4 > out.Size {
+		t.Errorf("compressed %d bytes to %d, expected a large export to shrink well", out.Size, out.CompressedSize)
+	}
+
+	// "none" is the same as leaving compress out.
+	if none := callExport(t, session, map[string]any{keyFormat: formatCSV, "compress": compressNone}); none != plain {
+		t.Error("compress=none changed the export")
+	}
+}
+
+func TestExportFeedData_GzipJSON(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(20))
+
+	// JSON exports carry their export time, so compare everything else.
+	decode := func(text string) map[string]any {
+		t.Helper()
+		var export map[string]any
+		if err := json.Unmarshal([]byte(text), &export); err != nil {
+			t.Fatalf("unmarshal export: %v", err)
+		}
+		delete(export, "exported_at")
+		return export
+	}
+	plain := decode(callExport(t, session, map[string]any{keyFormat: formatJSON}))
+	_, decompressed := decompressExport(t, callExport(t, session, map[string]any{keyFormat: formatJSON, "compress": compressGzip}))
+	got, _ := json.Marshal(decode(decompressed))
+	want, _ := json.Marshal(plain)
+	if !bytes.Equal(got, want) {
+		t.Errorf("decompressed JSON export differs from the plain export")
+	}
+}
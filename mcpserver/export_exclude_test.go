@@ -0,0 +1,72 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// recordingFeedGetter records the feed IDs it is asked for.
+type recordingFeedGetter struct {
+	mockFeedAndItemsGetter
+	requested []string
+}
+
+func (m *recordingFeedGetter) GetFeedAndItems(ctx context.Context, id string) (*model.FeedAndItemsResult, error) {
+	m.requested = append(m.requested, id)
+	return m.mockFeedAndItemsGetter.GetFeedAndItems(ctx, id)
+}
+
+func TestExportFeedData_ExcludeFeedIDs(t *testing.T) {
+	ids := []string{"quiet", "noisy", "broken"}
+	allFeeds := &mockAllFeedsGetter{}
+	feedMap := make(map[string]*model.FeedAndItemsResult, len(ids))
+	for _, id := range ids {
+		allFeeds.feeds = append(allFeeds.feeds, &model.FeedResult{ID: id})
+		feedMap[id] = &model.FeedAndItemsResult{ID: id, Title: id, Items: []*gofeed.Item{{Title: id + " item"}}}
+	}
+
+	tests := []struct {
+		name string
+		args ExportFeedDataParams
+		want []string
+	}{
+		{"all feeds", ExportFeedDataParams{ExcludeFeedIDs: []string{"noisy", "broken"}}, []string{"quiet"}},
+		{"listed feeds", ExportFeedDataParams{FeedIDs: []string{"quiet", "noisy"}, ExcludeFeedIDs: []string{"noisy"}}, []string{"quiet"}},
+		{"unknown exclusion", ExportFeedDataParams{ExcludeFeedIDs: []string{"missing"}}, ids},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := &recordingFeedGetter{mockFeedAndItemsGetter: mockFeedAndItemsGetter{feedMap: feedMap}}
+			s := &Server{allFeedsGetter: allFeeds, feedAndItemsGetter: getter}
+			tt.args.Format = formatJSON
+
+			exported, err := s.exportFeedData(context.Background(), &tt.args)
+			if err != nil {
+				t.Fatalf("exportFeedData: %v", err)
+			}
+			var export struct {
+				FeedResults []*FeedAndItemsResult `json:"feed_results"`
+			}
+			if err := json.Unmarshal([]byte(exported), &export); err != nil {
+				t.Fatalf("unmarshal export: %v", err)
+			}
+			var got []string
+			for _, feedResult := range export.FeedResults {
+				got = append(got, feedResult.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("exported feeds %v, want %v", got, tt.want)
+			}
+			// Excluded feeds aren't fetched at all.
+			if !slices.Equal(getter.requested, tt.want) {
+				t.Errorf("fetched feeds %v, want %v", getter.requested, tt.want)
+			}
+		})
+	}
+}
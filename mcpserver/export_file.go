@@ -0,0 +1,76 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// fileExport is what export_feed_data returns when it writes the export to
+// outputPath instead of returning it inline.
+type fileExport struct {
+	Path       string `json:"path"`
+	Format     string `json:"format"`
+	Bytes      int    `json:"bytes"`
+	Compressed bool   `json:"compressed,omitempty"`
+}
+
+// exportFeedDataToFile writes the export to args.OutputPath inside the
+// server's export directory and describes the file written. With
+// compress=gzip the file holds the raw gzip data rather than base64.
+func (s *Server) exportFeedDataToFile(ctx context.Context, args *ExportFeedDataParams) (string, error) {
+	if s.fileExportDir == "" {
+		return "", model.CreateParameterError(toolExportFeedData, "outputPath", "file export is disabled on this server",
+			"Omit outputPath to get the export inline, or start the server with --allow-file-export and --file-export-dir")
+	}
+	exported, err := s.renderExport(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	data := []byte(exported)
+	if args.Compress == compressGzip {
+		if data, err = gzipExport(exported); err != nil {
+			return "", err
+		}
+	}
+	path, err := writeExportFile(s.fileExportDir, args.OutputPath, data)
+	if err != nil {
+		return "", model.NewFeedErrorWithCause(model.ErrorTypeSystem, "failed to write export file", err).
+			WithOperation(toolExportFeedData).
+			WithComponent("file_export")
+	}
+	out, err := json.Marshal(fileExport{
+		Path:       path,
+		Format:     args.Format,
+		Bytes:      len(data),
+		Compressed: args.Compress == compressGzip,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// writeExportFile writes data to name, a local path inside dir, creating
+// parent directories as needed and replacing any existing file. Writing
+// through an os.Root keeps the file inside dir even if a path component is a
+// symlink pointing out of it.
+func writeExportFile(dir, name string, data []byte) (string, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = root.Close() }()
+	if parent := filepath.Dir(name); parent != "." {
+		if err := root.MkdirAll(parent, 0o750); err != nil {
+			return "", err
+		}
+	}
+	if err := root.WriteFile(name, data, 0o640); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
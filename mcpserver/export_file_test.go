@@ -0,0 +1,119 @@
+package mcpserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// fileExportSession connects a client to a server that writes export files
+// into dir; an empty dir leaves file export disabled.
+func fileExportSession(t *testing.T, dir string) *mcp.ClientSession {
+	t.Helper()
+	feed := &model.Feed{Title: "Export Feed", Link: "https://example.com", FeedType: "rss"}
+	return newTestClientSession(t, &Config{
+		Transport: model.StdioTransport,
+		AllFeedsGetter: &mockResourceAllFeedsGetter{feeds: []*model.FeedResult{
+			{ID: "feed-1", Title: "Export Feed", PublicURL: "https://example.com/feed.xml", Feed: feed},
+		}},
+		FeedAndItemsGetter: &mockResourceFeedAndItemsGetter{feeds: map[string]*model.FeedAndItemsResult{
+			"feed-1": {ID: "feed-1", Title: "Export Feed", PublicURL: "https://example.com/feed.xml", Feed: feed, Items: makeTestItems(5)},
+		}},
+		FileExportDir: dir,
+	})
+}
+
+func TestExportFeedData_OutputPathWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	session := fileExportSession(t, dir)
+
+	inline := callExport(t, session, map[string]any{keyFormat: formatCSV})
+	var out fileExport
+	text := callExport(t, session, map[string]any{keyFormat: formatCSV, "outputPath": "exports/feeds.csv"})
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", text, err)
+	}
+	want := filepath.Join(dir, "exports", "feeds.csv")
+	if out.Path != want || out.Format != formatCSV || out.Bytes != len(inline) || out.Compressed {
+		t.Errorf("result = %+v, want path %q and %d bytes", out, want, len(inline))
+	}
+	data, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("read export file: %v", err)
+	}
+	if string(data) != inline {
+		t.Errorf("file content differs from the inline export")
+	}
+}
+
+func TestExportFeedData_OutputPathGzip(t *testing.T) {
+	dir := t.TempDir()
+	session := fileExportSession(t, dir)
+
+	inline := callExport(t, session, map[string]any{keyFormat: formatCSV})
+	var out fileExport
+	text := callExport(t, session, map[string]any{keyFormat: formatCSV, "compress": compressGzip, "outputPath": "feeds.csv.gz"})
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", text, err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "feeds.csv.gz"))
+	if err != nil {
+		t.Fatalf("read export file: %v", err)
+	}
+	if !out.Compressed || out.Bytes != len(raw) {
+		t.Errorf("result = %+v, want compressed with %d bytes", out, len(raw))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if string(data) != inline {
+		t.Errorf("decompressed file differs from the inline export")
+	}
+}
+
+func TestExportFeedData_OutputPathRejected(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(dir, "escape")); err != nil {
+		t.Skipf("symlink: %v", err)
+	}
+	session := fileExportSession(t, dir)
+
+	for _, path := range []string{"../feeds.json", "a/../../feeds.json", filepath.Join(outside, "feeds.json"), "escape/feeds.json"} {
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      toolExportFeedData,
+			Arguments: map[string]any{keyFormat: formatJSON, "outputPath": path},
+		})
+		if err == nil && !result.IsError {
+			t.Errorf("outputPath %q: expected an error", path)
+		}
+	}
+	if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+		t.Errorf("files written outside the export directory: %v", entries)
+	}
+}
+
+func TestExportFeedData_OutputPathDisabled(t *testing.T) {
+	session := fileExportSession(t, "")
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolExportFeedData,
+		Arguments: map[string]any{keyFormat: formatJSON, "outputPath": "feeds.json"},
+	})
+	if err == nil && !result.IsError {
+		t.Fatal("expected an error with file export disabled")
+	}
+}
@@ -0,0 +1,69 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// TestExportFeedData_PreservesGUIDs exports items as RSS and Atom and parses
+// the result again: original GUIDs must survive, with the link, then a hash,
+// used only for items without one.
+func TestExportFeedData_PreservesGUIDs(t *testing.T) {
+	published := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	items := []*gofeed.Item{
+		{Title: "Tagged GUID", Link: "https://example.com/a", GUID: "tag:example.com,2024:a"},
+		{Title: "Permalink GUID", Link: "https://example.com/b", GUID: "https://example.com/b"},
+		{Title: "Link only", Link: "https://example.com/c"},
+		{Title: "Neither", PublishedParsed: &published},
+	}
+	hash := exportItemID(items[3])
+	if !strings.HasPrefix(hash, "urn:feed-mcp:item:") {
+		t.Fatalf("hash fallback = %q, want a urn:feed-mcp:item: ID", hash)
+	}
+	want := []string{"tag:example.com,2024:a", "https://example.com/b", "https://example.com/c", hash}
+
+	s := &Server{
+		allFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "a"}}},
+		feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+			"a": {ID: "a", Title: "A", Items: items},
+		}},
+	}
+	for _, format := range []string{formatRSS, formatAtom} {
+		t.Run(format, func(t *testing.T) {
+			exported, err := s.exportFeedData(context.Background(), &ExportFeedDataParams{Format: format})
+			if err != nil {
+				t.Fatalf("exportFeedData: %v", err)
+			}
+			feed, err := gofeed.NewParser().ParseString(exported)
+			if err != nil {
+				t.Fatalf("parse export: %v\n%s", err, exported)
+			}
+			var got []string
+			for _, item := range feed.Items {
+				got = append(got, item.GUID)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("GUIDs = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRSSGUIDElement_PermaLink(t *testing.T) {
+	if got := rssGUIDElement(&gofeed.Item{Link: "https://example.com/a"}); got != "<guid>https://example.com/a</guid>\n" {
+		t.Errorf("link fallback = %q, want a permalink guid", got)
+	}
+	if got := rssGUIDElement(&gofeed.Item{Link: "https://example.com/a", GUID: "42"}); got != `<guid isPermaLink="false">42</guid>`+"\n" {
+		t.Errorf("original GUID = %q, want isPermaLink=\"false\"", got)
+	}
+	if got := rssGUIDElement(&gofeed.Item{}); got != "" {
+		t.Errorf("no identifier = %q, want no guid element", got)
+	}
+}
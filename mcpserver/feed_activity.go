@@ -0,0 +1,114 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedActivity is one entry returned by the list_feeds_by_activity tool.
+// NewestItemAt is the publish date of the feed's most recent item; it is
+// omitted, and NoDatedItems or HasError set, for feeds that sort last.
+type FeedActivity struct {
+	ID              string    `json:"id"`
+	Title           string    `json:"title,omitempty"`
+	NewestItemAt    time.Time `json:"newest_item_at,omitzero"`
+	NewestItemTitle string    `json:"newest_item_title,omitempty"`
+	Items           int       `json:"items"`
+	NoDatedItems    bool      `json:"no_dated_items,omitempty"`
+	HasError        bool      `json:"has_error"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// addFeedsByActivityTool adds the list_feeds_by_activity tool
+func (s *Server) addFeedsByActivityTool(srv *mcp.Server) {
+	feedsByActivityTool := &mcp.Tool{
+		Name:        toolListFeedsByActivity,
+		Description: "List feeds by most recent activity: newest item's publish date first, with that date and title. Feeds with no dated items or a fetch error sort last and are flagged",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, feedsByActivityTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		result, err := s.cachedToolResult(ctx, toolListFeedsByActivity, nil, func() (any, error) {
+			return s.feedsByActivity(ctx)
+		})
+		return result, nil, err
+	})
+}
+
+// feedsByActivity finds each feed's newest dated item (from the feed cache)
+// and orders the feeds by it, newest first. Feeds without a dated item, then
+// feeds that failed to fetch, follow in title order.
+func (s *Server) feedsByActivity(ctx context.Context) ([]FeedActivity, error) {
+	feedResults, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]FeedActivity, 0, len(feedResults))
+	for _, feedResult := range feedResults {
+		entry := FeedActivity{ID: feedResult.ID, Title: feedResult.Title}
+		if entry.Title == "" && feedResult.Feed != nil {
+			entry.Title = feedResult.Feed.Title
+		}
+		if feedResult.FetchError != "" || feedResult.CircuitBreakerOpen {
+			entry.HasError = true
+			entry.Error = feedResult.FetchError
+			activity = append(activity, entry)
+			continue
+		}
+
+		items, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedResult.ID)
+		switch {
+		case err != nil:
+			entry.HasError, entry.Error = true, err.Error()
+		case items.FetchError != "":
+			entry.HasError, entry.Error = true, items.FetchError
+		default:
+			entry.Items = len(items.Items)
+			for _, item := range items.Items {
+				if item == nil || item.PublishedParsed == nil {
+					continue
+				}
+				if published := *item.PublishedParsed; published.After(entry.NewestItemAt) {
+					entry.NewestItemAt = published.UTC()
+					entry.NewestItemTitle = item.Title
+				}
+			}
+			entry.NoDatedItems = entry.NewestItemAt.IsZero()
+		}
+		activity = append(activity, entry)
+	}
+
+	slices.SortStableFunc(activity, compareFeedActivity)
+	return activity, nil
+}
+
+// compareFeedActivity orders feeds with a dated item newest first, then feeds
+// with no dated items, then feeds with errors; ties sort by title, then ID.
+func compareFeedActivity(a, b FeedActivity) int {
+	group := func(f FeedActivity) int {
+		switch {
+		case f.HasError:
+			return 2
+		case f.NoDatedItems:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if c := cmp.Compare(group(a), group(b)); c != 0 {
+		return c
+	}
+	if c := b.NewestItemAt.Compare(a.NewestItemAt); c != 0 {
+		return c
+	}
+	if c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
@@ -0,0 +1,60 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestFeedsByActivity(t *testing.T) {
+	day := func(d int) *time.Time {
+		return new(time.Date(2024, time.March, d, 12, 0, 0, 0, time.UTC))
+	}
+	s := &Server{
+		allFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{
+			{ID: "old", Title: "Old"},
+			{ID: "broken", Title: "Broken", FetchError: "connection refused"},
+			{ID: "undated", Title: "Undated"},
+			{ID: "fresh", Title: "Fresh"},
+			{ID: "middle", Title: "Middle"},
+		}},
+		feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+			"old":     {ID: "old", Items: []*gofeed.Item{{Title: "Old 1", PublishedParsed: day(1)}}},
+			"undated": {ID: "undated", Items: []*gofeed.Item{{Title: "No date"}}},
+			"fresh": {ID: "fresh", Items: []*gofeed.Item{
+				{Title: "Fresh older", PublishedParsed: day(10)},
+				{Title: "Fresh newest", PublishedParsed: day(20)},
+				{Title: "Fresh undated"},
+			}},
+			"middle": {ID: "middle", Items: []*gofeed.Item{{Title: "Middle 1", PublishedParsed: day(15)}}},
+		}},
+	}
+
+	activity, err := s.feedsByActivity(context.Background())
+	if err != nil {
+		t.Fatalf("feedsByActivity: %v", err)
+	}
+	ids := make([]string, len(activity))
+	for i, entry := range activity {
+		ids[i] = entry.ID
+	}
+	if want := []string{"fresh", "middle", "old", "undated", "broken"}; !slices.Equal(ids, want) {
+		t.Fatalf("order = %v, want %v", ids, want)
+	}
+
+	fresh := activity[0]
+	if !fresh.NewestItemAt.Equal(*day(20)) || fresh.NewestItemTitle != "Fresh newest" || fresh.Items != 3 {
+		t.Errorf("fresh = %+v, want newest item Fresh newest on March 20 of 3", fresh)
+	}
+	if undated := activity[3]; !undated.NoDatedItems || undated.HasError || !undated.NewestItemAt.IsZero() {
+		t.Errorf("undated = %+v, want no_dated_items", undated)
+	}
+	if broken := activity[4]; !broken.HasError || broken.Error != "connection refused" {
+		t.Errorf("broken = %+v, want has_error with the fetch error", broken)
+	}
+}
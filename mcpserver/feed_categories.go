@@ -0,0 +1,125 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetFeedCategoriesParams contains parameters for the get_feed_categories
+// tool.
+type GetFeedCategoriesParams struct {
+	FeedID string `json:"feedId"`
+}
+
+// FeedCategoriesResult is the JSON body returned by get_feed_categories.
+type FeedCategoriesResult struct {
+	FeedID             string              `json:"feed_id"`
+	Title              string              `json:"title"`
+	TotalItems         int                 `json:"total_items"`
+	UncategorizedItems int                 `json:"uncategorized_items"`
+	Categories         []FeedCategoryCount `json:"categories"`
+}
+
+// FeedCategoryCount is one distinct category of a feed and the number of its
+// items that carry it. FeedLevel marks categories the feed declares for
+// itself; those may have no items.
+type FeedCategoryCount struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	FeedLevel bool   `json:"feed_level,omitempty"`
+}
+
+// addFeedCategoriesTool adds the get_feed_categories tool
+func (s *Server) addFeedCategoriesTool(srv *mcp.Server) {
+	feedCategoriesTool := &mcp.Tool{
+		Name:        toolGetFeedCategories,
+		Description: "List the distinct categories of one feed, from its items and the feed itself, with how many items carry each, most used first. Categories are as normalized by --normalize-categories when it is enabled.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyFeedID},
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, feedCategoriesTool, func(ctx context.Context, req *mcp.CallToolRequest, args GetFeedCategoriesParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolGetFeedCategories, args, func() (any, error) {
+			feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.FeedID)
+			if err != nil {
+				return nil, err
+			}
+			result := &FeedCategoriesResult{
+				FeedID: feedResult.ID,
+				Title:  feedResult.Title,
+			}
+			var feedCategories []string
+			if feedResult.Feed != nil {
+				feedCategories = feedResult.Feed.Categories
+				if result.Title == "" {
+					result.Title = feedResult.Feed.Title
+				}
+			}
+			countFeedCategories(result, feedResult.Items, feedCategories)
+			return result, nil
+		})
+		return result, nil, err
+	})
+}
+
+// countFeedCategories fills in the item totals and the distinct categories,
+// sorted by count and then name. An item listing a category twice counts
+// once. Categories are compared as given, after trimming: normalization, when
+// enabled, has already been applied at fetch time.
+func countFeedCategories(result *FeedCategoriesResult, items []*gofeed.Item, feedCategories []string) {
+	byName := make(map[string]*FeedCategoryCount)
+	category := func(name string) *FeedCategoryCount {
+		entry := byName[name]
+		if entry == nil {
+			entry = &FeedCategoryCount{Name: name}
+			byName[name] = entry
+		}
+		return entry
+	}
+
+	for _, name := range feedCategories {
+		if name = strings.TrimSpace(name); name != "" {
+			category(name).FeedLevel = true
+		}
+	}
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		result.TotalItems++
+		seen := make(map[string]bool, len(item.Categories))
+		for _, name := range item.Categories {
+			if name = strings.TrimSpace(name); name != "" && !seen[name] {
+				seen[name] = true
+				category(name).Count++
+			}
+		}
+		if len(seen) == 0 {
+			result.UncategorizedItems++
+		}
+	}
+
+	result.Categories = make([]FeedCategoryCount, 0, len(byName))
+	for _, entry := range byName {
+		result.Categories = append(result.Categories, *entry)
+	}
+	slices.SortFunc(result.Categories, func(a, b FeedCategoryCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+}
@@ -0,0 +1,64 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCountFeedCategories(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "a", Categories: []string{"go", "release"}},
+		{Title: "b", Categories: []string{"go", " go "}}, // counted once
+		{Title: "c", Categories: []string{"rust"}},
+		{Title: "d", Categories: []string{"release", "go"}},
+		{Title: "e"},
+		{Title: "f", Categories: []string{" "}},
+		nil,
+	}
+	result := &FeedCategoriesResult{}
+	countFeedCategories(result, items, []string{"programming", "go"})
+
+	want := []FeedCategoryCount{
+		{Name: "go", Count: 3, FeedLevel: true},
+		{Name: "release", Count: 2},
+		{Name: "rust", Count: 1},
+		{Name: "programming", Count: 0, FeedLevel: true},
+	}
+	if !slices.Equal(result.Categories, want) {
+		t.Errorf("categories = %+v, want %+v", result.Categories, want)
+	}
+	if result.TotalItems != 6 || result.UncategorizedItems != 2 {
+		t.Errorf("total/uncategorized = %d/%d, want 6/2", result.TotalItems, result.UncategorizedItems)
+	}
+}
+
+func TestGetFeedCategoriesTool(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "one", Categories: []string{"tech", "ai"}},
+		{Title: "two", Categories: []string{"tech"}},
+		{Title: "three", Categories: []string{"science"}},
+		{Title: "four", Categories: []string{"tech", "science"}},
+	}
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", items)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolGetFeedCategories,
+		Arguments: map[string]any{keyFeedID: "feed-1"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	var got FeedCategoriesResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := []FeedCategoryCount{{Name: "tech", Count: 3}, {Name: "science", Count: 2}, {Name: "ai", Count: 1}}
+	if got.FeedID != "feed-1" || got.TotalItems != 4 || !slices.Equal(got.Categories, want) {
+		t.Errorf("result = %+v, want 4 items with %+v", got, want)
+	}
+}
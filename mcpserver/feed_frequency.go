@@ -0,0 +1,151 @@
+package mcpserver
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Bounds for estimate_feed_frequency.
+const (
+	// minFrequencySamples is the fewest dated items for a confident estimate.
+	minFrequencySamples = 5
+	// The suggested poll interval is half the median interval, clamped to
+	// these bounds: no feed needs polling more often than every 15 minutes,
+	// and even a dormant one should be checked daily.
+	minSuggestedPollInterval = 15 * time.Minute
+	maxSuggestedPollInterval = 24 * time.Hour
+)
+
+// EstimateFeedFrequencyParams contains parameters for the
+// estimate_feed_frequency tool.
+type EstimateFeedFrequencyParams struct {
+	FeedID string `json:"feedId"`
+}
+
+// FeedFrequencyResult is the JSON body returned by estimate_feed_frequency.
+// Interval fields are zero when there are fewer than two dated items.
+// FetchError is set when the feed couldn't be fetched, in which case there
+// is no estimate.
+type FeedFrequencyResult struct {
+	FeedID                       string     `json:"feed_id"`
+	Title                        string     `json:"title"`
+	FetchError                   string     `json:"fetch_error,omitempty"`
+	DatedItems                   int        `json:"dated_items"`
+	Oldest                       *time.Time `json:"oldest,omitempty"`
+	Newest                       *time.Time `json:"newest,omitempty"`
+	MedianIntervalSeconds        float64    `json:"median_interval_seconds"`
+	MeanIntervalSeconds          float64    `json:"mean_interval_seconds"`
+	ItemsPerDay                  float64    `json:"items_per_day"`
+	SuggestedPollInterval        string     `json:"suggested_poll_interval,omitempty"`
+	SuggestedPollIntervalSeconds float64    `json:"suggested_poll_interval_seconds,omitempty"`
+	LowConfidence                bool       `json:"low_confidence"`
+}
+
+// addEstimateFeedFrequencyTool adds the estimate_feed_frequency tool
+func (s *Server) addEstimateFeedFrequencyTool(srv *mcp.Server) {
+	estimateFeedFrequencyTool := &mcp.Tool{
+		Name:        toolEstimateFeedFrequency,
+		Description: "Estimate how often a feed publishes from the gaps between its items' publish dates: median and mean interval, items per day, and a suggested poll interval. low_confidence is set when the feed has too few dated items.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{"feedId"},
+			Properties: map[string]*jsonschema.Schema{
+				"feedId": {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, estimateFeedFrequencyTool, func(ctx context.Context, req *mcp.CallToolRequest, args EstimateFeedFrequencyParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolEstimateFeedFrequency, args, func() (any, error) {
+			feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.FeedID)
+			if err != nil {
+				return nil, err
+			}
+			result := estimateFeedFrequency(feedResult.Items)
+			result.FeedID = feedResult.ID
+			result.Title = feedResult.Title
+			result.FetchError = feedResult.FetchError
+			if result.Title == "" && feedResult.Feed != nil {
+				result.Title = feedResult.Feed.Title
+			}
+			return result, nil
+		})
+		return result, nil, err
+	})
+}
+
+// fetchFailed reports that the estimate is for a feed that failed to fetch,
+// so the tool result cache doesn't keep it (see fetchFailure).
+func (r *FeedFrequencyResult) fetchFailed() bool {
+	return r.FetchError != ""
+}
+
+// estimateFeedFrequency analyzes the intervals between consecutive publish
+// dates. Undated items are ignored. The median is reported alongside the mean
+// because a single long pause (a holiday, a dormant spell) skews the mean.
+func estimateFeedFrequency(items []*gofeed.Item) *FeedFrequencyResult {
+	var dates []time.Time
+	for _, item := range items {
+		if item.PublishedParsed != nil {
+			dates = append(dates, *item.PublishedParsed)
+		}
+	}
+	result := &FeedFrequencyResult{
+		DatedItems:    len(dates),
+		LowConfidence: len(dates) < minFrequencySamples,
+	}
+	if len(dates) == 0 {
+		return result
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	oldest, newest := dates[0], dates[len(dates)-1]
+	result.Oldest, result.Newest = &oldest, &newest
+	if len(dates) < 2 {
+		return result
+	}
+
+	intervals := make([]float64, 0, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		intervals = append(intervals, dates[i].Sub(dates[i-1]).Seconds())
+	}
+	slices.Sort(intervals)
+	span := newest.Sub(oldest).Seconds()
+
+	result.MeanIntervalSeconds = roundTo(span/float64(len(intervals)), 2)
+	result.MedianIntervalSeconds = roundTo(median(intervals), 2)
+	if span > 0 {
+		result.ItemsPerDay = roundTo(float64(len(intervals))/(span/(24*60*60)), 2)
+	}
+
+	poll := time.Duration(result.MedianIntervalSeconds / 2 * float64(time.Second))
+	poll = min(max(poll, minSuggestedPollInterval), maxSuggestedPollInterval).Round(time.Minute)
+	result.SuggestedPollInterval = poll.String()
+	result.SuggestedPollIntervalSeconds = poll.Seconds()
+	return result
+}
+
+// median returns the median of sorted values.
+func median(sorted []float64) float64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// roundTo rounds v to the given number of decimal places.
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
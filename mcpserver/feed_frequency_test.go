@@ -0,0 +1,84 @@
+package mcpserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// itemsEvery returns n items published interval apart, newest first, plus
+// one undated item.
+func itemsEvery(n int, interval time.Duration) []*gofeed.Item {
+	newest := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := make([]*gofeed.Item, 0, n+1)
+	for i := range n {
+		published := newest.Add(-time.Duration(i) * interval)
+		items = append(items, &gofeed.Item{Title: "post", PublishedParsed: &published})
+	}
+	return append(items, &gofeed.Item{Title: "undated"})
+}
+
+func TestEstimateFeedFrequency(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []*gofeed.Item
+		median       float64
+		itemsPerDay  float64
+		poll         string
+		lowConfident bool
+	}{
+		{name: "every 6 hours", items: itemsEvery(10, 6*time.Hour), median: 6 * 3600, itemsPerDay: 4, poll: "3h0m0s"},
+		{name: "daily", items: itemsEvery(7, 24*time.Hour), median: 24 * 3600, itemsPerDay: 1, poll: "12h0m0s"},
+		{name: "every 10 minutes is clamped", items: itemsEvery(12, 10*time.Minute), median: 600, itemsPerDay: 144, poll: "15m0s"},
+		{name: "weekly is clamped", items: itemsEvery(5, 7*24*time.Hour), median: 7 * 24 * 3600, itemsPerDay: 0.14, poll: "24h0m0s"},
+		{name: "too few items", items: itemsEvery(3, time.Hour), median: 3600, itemsPerDay: 24, poll: "30m0s", lowConfident: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimateFeedFrequency(tt.items)
+			if got.MedianIntervalSeconds != tt.median || got.MeanIntervalSeconds != tt.median {
+				t.Errorf("median/mean = %v/%v, want %v", got.MedianIntervalSeconds, got.MeanIntervalSeconds, tt.median)
+			}
+			if got.ItemsPerDay != tt.itemsPerDay {
+				t.Errorf("items_per_day = %v, want %v", got.ItemsPerDay, tt.itemsPerDay)
+			}
+			if got.SuggestedPollInterval != tt.poll {
+				t.Errorf("suggested_poll_interval = %q, want %q", got.SuggestedPollInterval, tt.poll)
+			}
+			if got.LowConfidence != tt.lowConfident {
+				t.Errorf("low_confidence = %v, want %v", got.LowConfidence, tt.lowConfident)
+			}
+			if got.DatedItems != len(tt.items)-1 {
+				t.Errorf("dated_items = %d, want %d", got.DatedItems, len(tt.items)-1)
+			}
+		})
+	}
+}
+
+func TestEstimateFeedFrequency_Irregular(t *testing.T) {
+	// Four daily posts, then a 30-day pause: the median stays at a day while
+	// the mean is pulled up by the gap.
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var items []*gofeed.Item
+	for _, day := range []int{0, 1, 2, 3, 33} {
+		published := base.AddDate(0, 0, day)
+		items = append(items, &gofeed.Item{PublishedParsed: &published})
+	}
+	got := estimateFeedFrequency(items)
+	if got.MedianIntervalSeconds != 24*3600 || got.MeanIntervalSeconds != 33*24*3600/4.0 {
+		t.Errorf("median/mean = %v/%v", got.MedianIntervalSeconds, got.MeanIntervalSeconds)
+	}
+	if got.Oldest == nil || !got.Oldest.Equal(base) || got.LowConfidence {
+		t.Errorf("oldest = %v, low_confidence = %v", got.Oldest, got.LowConfidence)
+	}
+}
+
+func TestEstimateFeedFrequency_NoIntervals(t *testing.T) {
+	for _, items := range [][]*gofeed.Item{nil, itemsEvery(1, time.Hour)} {
+		got := estimateFeedFrequency(items)
+		if !got.LowConfidence || got.SuggestedPollInterval != "" || got.MedianIntervalSeconds != 0 {
+			t.Errorf("estimate for %d dated items = %+v", got.DatedItems, got)
+		}
+	}
+}
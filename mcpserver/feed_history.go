@@ -0,0 +1,87 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedHistoryProvider reports the snapshots a feed's fetches have produced.
+type FeedHistoryProvider interface {
+	// FeedHistory returns the retained snapshots of the feed with the given
+	// ID, oldest first.
+	FeedHistory(ctx context.Context, feedID string) ([]FeedSnapshot, error)
+}
+
+// FeedSnapshot is the state of a feed after one successful network fetch.
+// Added and Removed compare the items' stable IDs with the previous snapshot;
+// the first snapshot counts every item as added. ItemDelta is the change in
+// ItemCount.
+type FeedSnapshot struct {
+	FetchedAt   time.Time `json:"fetched_at"`
+	ItemCount   int       `json:"item_count"`
+	ItemDelta   int       `json:"item_delta"`
+	Added       int       `json:"added"`
+	Removed     int       `json:"removed"`
+	ContentHash string    `json:"content_hash"`
+}
+
+// ExportFeedHistoryParams contains parameters for the export_feed_history
+// tool.
+type ExportFeedHistoryParams struct {
+	FeedID string `json:"feedId"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// FeedHistoryResult is the export_feed_history response.
+type FeedHistoryResult struct {
+	FeedID    string         `json:"feed_id"`
+	Snapshots []FeedSnapshot `json:"snapshots"` // oldest first
+}
+
+// addExportFeedHistoryTool adds the export_feed_history tool
+func (s *Server) addExportFeedHistoryTool(srv *mcp.Server, provider FeedHistoryProvider) {
+	historyTool := &mcp.Tool{
+		Name:        toolExportFeedHistory,
+		Description: "Return the snapshots a feed's fetches have produced since the server started, oldest first: when each fetch happened, its item count and change from the previous fetch, how many items were added and removed, and a content hash. Use it to see how often a feed changes and whether it is growing.",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+				"limit": {
+					Type:        typeInteger,
+					Description: "Return only the most recent snapshots, up to this many (default: all retained)",
+				},
+			},
+			Required: []string{keyFeedID},
+		},
+	}
+	mcp.AddTool(srv, historyTool, func(ctx context.Context, req *mcp.CallToolRequest, args ExportFeedHistoryParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		snapshots, err := provider.FeedHistory(ctx, args.FeedID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if args.Limit > 0 && len(snapshots) > args.Limit {
+			snapshots = snapshots[len(snapshots)-args.Limit:]
+		}
+		if snapshots == nil {
+			snapshots = []FeedSnapshot{}
+		}
+		data, err := json.Marshal(FeedHistoryResult{FeedID: args.FeedID, Snapshots: snapshots})
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
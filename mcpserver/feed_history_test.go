@@ -0,0 +1,64 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockFeedHistoryProvider is a feed getter with three snapshots of feed "a".
+type mockFeedHistoryProvider struct {
+	mockFeedAndItemsGetter
+}
+
+func (m *mockFeedHistoryProvider) FeedHistory(ctx context.Context, feedID string) ([]FeedSnapshot, error) {
+	if feedID != "a" {
+		return nil, model.NewFeedError(model.ErrorTypeValidation, "feed with ID "+feedID+" not found")
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []FeedSnapshot{
+		{FetchedAt: start, ItemCount: 2, ItemDelta: 2, Added: 2},
+		{FetchedAt: start.Add(time.Hour), ItemCount: 4, ItemDelta: 2, Added: 2},
+		{FetchedAt: start.Add(2 * time.Hour), ItemCount: 3, ItemDelta: -1, Added: 1, Removed: 2},
+	}, nil
+}
+
+func TestExportFeedHistoryTool(t *testing.T) {
+	session := newTestClientSession(t, &Config{
+		Transport:           model.StdioTransport,
+		AllFeedsGetter:      &mockAllFeedsGetter{},
+		FeedAndItemsGetter:  &mockFeedHistoryProvider{},
+		FeedHistoryProvider: &mockFeedHistoryProvider{},
+	})
+	ctx := context.Background()
+
+	history := func(args map[string]any) FeedHistoryResult {
+		t.Helper()
+		var result FeedHistoryResult
+		if err := json.Unmarshal([]byte(callToolText(t, session, toolExportFeedHistory, args)), &result); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return result
+	}
+
+	all := history(map[string]any{keyFeedID: "a"})
+	if all.FeedID != "a" || len(all.Snapshots) != 3 || all.Snapshots[0].ItemCount != 2 || all.Snapshots[2].Removed != 2 {
+		t.Errorf("history = %+v, want the three snapshots oldest first", all)
+	}
+	recent := history(map[string]any{keyFeedID: "a", "limit": 2})
+	if len(recent.Snapshots) != 2 || recent.Snapshots[0].ItemCount != 4 || recent.Snapshots[1].ItemCount != 3 {
+		t.Errorf("limit=2 history = %+v, want the two latest snapshots", recent)
+	}
+
+	for _, args := range []map[string]any{{}, {keyFeedID: "missing"}} {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolExportFeedHistory, Arguments: args})
+		if err != nil || !result.IsError {
+			t.Errorf("CallTool(%v) = %+v, %v; want a tool error", args, result, err)
+		}
+	}
+}
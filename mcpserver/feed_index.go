@@ -0,0 +1,79 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedIndexEntry is one entry returned by the list_feed_index tool: just enough
+// to identify a feed, without its metadata body or items.
+type FeedIndexEntry struct {
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	Category string `json:"category,omitempty"`
+	HasError bool   `json:"has_error"`
+}
+
+// addFeedIndexTool adds the list_feed_index tool
+func (s *Server) addFeedIndexTool(srv *mcp.Server) {
+	feedIndexTool := &mcp.Tool{
+		Name:        toolListFeedIndex,
+		Description: "List feed IDs, titles, and categories only (no feed bodies or items); use to build a lightweight index",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, feedIndexTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		index, err := s.feedIndex(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// feedIndex builds the compact feed index. A feed's category is the one set
+// through runtime feed management when available, otherwise the feed's first
+// declared category.
+func (s *Server) feedIndex(ctx context.Context) ([]FeedIndexEntry, error) {
+	feedResults, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managedCategories := make(map[string]string)
+	if s.dynamicFeedManager != nil {
+		if managed, err := s.dynamicFeedManager.ListManagedFeeds(ctx); err == nil {
+			for i := range managed {
+				managedCategories[managed[i].FeedID] = managed[i].Category
+			}
+		}
+	}
+
+	index := make([]FeedIndexEntry, 0, len(feedResults))
+	for _, feedResult := range feedResults {
+		entry := FeedIndexEntry{
+			ID:       feedResult.ID,
+			Title:    feedResult.Title,
+			Category: managedCategories[feedResult.ID],
+			HasError: feedResult.FetchError != "" || feedResult.CircuitBreakerOpen,
+		}
+		if feed := feedResult.Feed; feed != nil {
+			if entry.Title == "" {
+				entry.Title = feed.Title
+			}
+			if entry.Category == "" && len(feed.Categories) > 0 {
+				entry.Category = feed.Categories[0]
+			}
+		}
+		index = append(index, entry)
+	}
+	return index, nil
+}
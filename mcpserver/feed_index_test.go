@@ -0,0 +1,101 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestFeedIndex(t *testing.T) {
+	s := &Server{allFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{
+		{ID: "a", Title: "Alpha", Feed: &model.Feed{Title: "Alpha Feed", Categories: []string{"tech", "go"}}},
+		{ID: "b", Feed: &model.Feed{Title: "Beta Feed"}},
+		{ID: "c", PublicURL: "https://c.example.com/feed", FetchError: "connection refused"},
+		{ID: "d", Title: "Delta", CircuitBreakerOpen: true},
+	}}}
+
+	index, err := s.feedIndex(context.Background())
+	if err != nil {
+		t.Fatalf("feedIndex: %v", err)
+	}
+	want := []FeedIndexEntry{
+		{ID: "a", Title: "Alpha", Category: "tech"},
+		{ID: "b", Title: "Beta Feed"},
+		{ID: "c", HasError: true},
+		{ID: "d", Title: "Delta", HasError: true},
+	}
+	if !slices.Equal(index, want) {
+		t.Errorf("feedIndex() = %+v, want %+v", index, want)
+	}
+}
+
+// TestListFeedIndexTool verifies the tool returns only the compact index shape,
+// with no feed body or item data.
+func TestListFeedIndexTool(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(3))
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolListFeedIndex})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("expected 1 content block, got %d", len(result.Content))
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", result.Content[0])
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(text.Text), &entries); err != nil {
+		t.Fatalf("unmarshal index: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["id"] != "feed-1" || entry["title"] != "Template Test Feed" || entry["has_error"] != false {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+	for key := range entry {
+		if !slices.Contains([]string{"id", "title", "category", "has_error"}, key) {
+			t.Errorf("index entry has unexpected field %q", key)
+		}
+	}
+}
+
+func TestOrderFeedsByHealth(t *testing.T) {
+	feeds := []*model.FeedResult{
+		{ID: "a-ok"},
+		{ID: "b-error", FetchError: "connection refused"},
+		{ID: "c-open", CircuitBreakerOpen: true, FetchError: "circuit breaker is open"},
+		{ID: "d-ok"},
+		{ID: "e-error", FetchError: "http error: 500"},
+	}
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{"", []string{"a-ok", "b-error", "c-open", "d-ok", "e-error"}},
+		{healthOrderUnhealthyFirst, []string{"c-open", "b-error", "e-error", "a-ok", "d-ok"}},
+		{healthOrderUnhealthyLast, []string{"a-ok", "d-ok", "b-error", "e-error", "c-open"}},
+	}
+	for _, tt := range tests {
+		got := orderFeedsByHealth(feeds, tt.order)
+		ids := make([]string, len(got))
+		for i, feed := range got {
+			ids[i] = feed.ID
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("orderByHealth=%q: %v, want %v", tt.order, ids, tt.want)
+		}
+	}
+	if feeds[0].ID != "a-ok" || feeds[2].ID != "c-open" {
+		t.Error("orderFeedsByHealth must not reorder the caller's slice")
+	}
+}
@@ -0,0 +1,170 @@
+package mcpserver
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// FeedOverlapParams contains parameters for the feed_overlap tool.
+type FeedOverlapParams struct {
+	FeedIDs   []string `json:"feedIds,omitempty"`
+	Tags      []string `json:"tags,omitempty"`      // Only feeds carrying every tag
+	DedupeKey string   `json:"dedupeKey,omitempty"` // title_link, link, guid, or title (default: link, else title)
+}
+
+// SharedItem is an item found in more than one of the compared feeds.
+type SharedItem struct {
+	Title   string   `json:"title"`
+	Link    string   `json:"link,omitempty"`
+	FeedIDs []string `json:"feed_ids"`
+}
+
+// FeedOverlapStats reports how much of one feed is duplicated elsewhere.
+type FeedOverlapStats struct {
+	FeedID         string  `json:"feed_id"`
+	Title          string  `json:"title"`
+	Items          int     `json:"items"`
+	SharedItems    int     `json:"shared_items"`
+	OverlapPercent float64 `json:"overlap_percent"`
+}
+
+// FeedOverlapResult is the JSON body returned by the feed_overlap tool.
+// OverlapPercent is the share of distinct items that appear in two or more
+// of the feeds.
+type FeedOverlapResult struct {
+	TotalItems     int                `json:"total_items"`
+	OverlapPercent float64            `json:"overlap_percent"`
+	Feeds          []FeedOverlapStats `json:"feeds"`
+	SharedItems    []SharedItem       `json:"shared_items"`
+}
+
+// addFeedOverlapTool adds the feed_overlap tool
+func (s *Server) addFeedOverlapTool(srv *mcp.Server) {
+	feedOverlapTool := &mcp.Tool{
+		Name:        toolFeedOverlap,
+		Description: "Find items shared between two or more feeds (matched by normalized link, or title when there is no link), with the feeds each appears in and overlap percentages; use to spot redundant subscriptions",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedIDs: {
+					Type:        "array",
+					Description: "Array of two or more feed IDs to compare (required unless tags is given)",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				keyTags: {
+					Type:        "array",
+					Description: "Compare only feeds carrying all of these tags; with feedIds, narrows that list",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				"dedupeKey": {
+					Type:        typeString,
+					Description: "Fields that make items in different feeds the same item (default: link, or title when an item has no link). title_link: title and link both match. link: link only. guid: GUID only. title: title only. Items missing the selected fields are left out of the counts.",
+					Enum:        []any{dedupeKeyTitleLink, dedupeKeyLink, dedupeKeyGUID, dedupeKeyTitle},
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, feedOverlapTool, func(ctx context.Context, req *mcp.CallToolRequest, args FeedOverlapParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolFeedOverlap, args, func() (any, error) {
+			return s.feedOverlap(ctx, args)
+		})
+		return result, nil, err
+	})
+}
+
+// feedOverlap compares the feeds' items by the key args.DedupeKey selects (see
+// itemDedupKeyFor). Items without a key can't be matched and are left out of
+// the counts.
+func (s *Server) feedOverlap(ctx context.Context, args FeedOverlapParams) (*FeedOverlapResult, error) {
+	selected, err := s.selectFeedsByTags(ctx, toolFeedOverlap, args.FeedIDs, args.Tags)
+	if err != nil {
+		return nil, err
+	}
+	var feedIDs []string
+	for _, id := range selected {
+		if !slices.Contains(feedIDs, id) {
+			feedIDs = append(feedIDs, id)
+		}
+	}
+	if len(feedIDs) < 2 {
+		if len(args.Tags) > 0 {
+			return nil, model.CreateParameterError(toolFeedOverlap, keyTags, "the tags match fewer than two feeds",
+				"Pass tags shared by two or more feeds, or list feedIds")
+		}
+		return nil, args.validate()
+	}
+
+	var order []string                      // keys in first-seen order
+	entries := make(map[string]*SharedItem) // every keyed item, shared or not
+	feedKeys := make([][]string, len(feedIDs))
+	result := &FeedOverlapResult{Feeds: make([]FeedOverlapStats, len(feedIDs))}
+
+	for i, feedID := range feedIDs {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+		if err != nil {
+			return nil, err
+		}
+		result.Feeds[i] = FeedOverlapStats{FeedID: feedID, Title: feedResult.Title}
+		if result.Feeds[i].Title == "" && feedResult.Feed != nil {
+			result.Feeds[i].Title = feedResult.Feed.Title
+		}
+
+		seen := make(map[string]bool)
+		for _, item := range feedResult.Items {
+			key := itemDedupKeyFor(item, args.DedupeKey)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			feedKeys[i] = append(feedKeys[i], key)
+			entry, ok := entries[key]
+			if !ok {
+				entry = &SharedItem{Title: item.Title, Link: item.Link}
+				entries[key] = entry
+				order = append(order, key)
+			}
+			entry.FeedIDs = append(entry.FeedIDs, feedID)
+		}
+	}
+
+	result.SharedItems = []SharedItem{}
+	for _, key := range order {
+		if entry := entries[key]; len(entry.FeedIDs) > 1 {
+			result.SharedItems = append(result.SharedItems, *entry)
+		}
+	}
+	for i, keys := range feedKeys {
+		stats := &result.Feeds[i]
+		stats.Items = len(keys)
+		for _, key := range keys {
+			if len(entries[key].FeedIDs) > 1 {
+				stats.SharedItems++
+			}
+		}
+		stats.OverlapPercent = percentOf(stats.SharedItems, stats.Items)
+	}
+	result.TotalItems = len(order)
+	result.OverlapPercent = percentOf(len(result.SharedItems), result.TotalItems)
+	return result, nil
+}
+
+// percentOf returns part as a percentage of total, rounded to two decimals.
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
@@ -0,0 +1,77 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestFeedOverlap(t *testing.T) {
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"wire": {ID: "wire", Feed: &model.Feed{Title: "Wire"}, Items: []*gofeed.Item{
+			{Title: "Rates rise", GUID: "wire-1", Link: "https://news.example.com/rates"},
+			{Title: "Storm warning", GUID: "wire-2", Link: "https://news.example.com/storm"},
+			{Title: "Local election", Link: "https://news.example.com/election"},
+			{Title: "Untitled brief"},
+		}},
+		"mirror": {ID: "mirror", Feed: &model.Feed{Title: "Mirror"}, Items: []*gofeed.Item{
+			// Same articles under the mirror's own GUIDs, linked with tracking
+			// parameters and different casing.
+			{Title: "Rates Rise!", GUID: "mirror-7", Link: "http://www.News.example.com/rates/?utm_source=rss"},
+			{Title: "Storm warning", GUID: "mirror-8", Link: "https://news.example.com/storm#comments"},
+			{Title: "untitled   BRIEF"},
+			{Title: "Sports roundup", Link: "https://mirror.example.com/sports"},
+		}},
+		"other": {ID: "other", Feed: &model.Feed{Title: "Other"}, Items: []*gofeed.Item{
+			{Title: "Gardening tips", Link: "https://garden.example.com/tips"},
+		}},
+	}}
+	// As the store does at fetch time, so GUID-based stable IDs are present.
+	for _, feed := range getter.feedMap {
+		model.AssignStableIDs(feed.Items, model.DefaultStableIDChain)
+	}
+	s := &Server{feedAndItemsGetter: getter}
+
+	result, err := s.feedOverlap(context.Background(), FeedOverlapParams{FeedIDs: []string{"wire", "mirror", "other"}})
+	if err != nil {
+		t.Fatalf("feedOverlap: %v", err)
+	}
+
+	var sharedTitles []string
+	for _, item := range result.SharedItems {
+		sharedTitles = append(sharedTitles, item.Title)
+		if !slices.Equal(item.FeedIDs, []string{"wire", "mirror"}) {
+			t.Errorf("%q appears in %v, want [wire mirror]", item.Title, item.FeedIDs)
+		}
+	}
+	if want := []string{"Rates rise", "Storm warning", "Untitled brief"}; !slices.Equal(sharedTitles, want) {
+		t.Errorf("shared items = %v, want %v", sharedTitles, want)
+	}
+
+	// 6 distinct items across the feeds, 3 of them shared.
+	if result.TotalItems != 6 || result.OverlapPercent != 50 {
+		t.Errorf("total_items = %d, overlap_percent = %v; want 6, 50", result.TotalItems, result.OverlapPercent)
+	}
+	want := []FeedOverlapStats{
+		{FeedID: "wire", Title: "Wire", Items: 4, SharedItems: 3, OverlapPercent: 75},
+		{FeedID: "mirror", Title: "Mirror", Items: 4, SharedItems: 3, OverlapPercent: 75},
+		{FeedID: "other", Title: "Other", Items: 1, SharedItems: 0, OverlapPercent: 0},
+	}
+	if !slices.Equal(result.Feeds, want) {
+		t.Errorf("feeds = %+v, want %+v", result.Feeds, want)
+	}
+}
+
+func TestFeedOverlap_RequiresTwoFeeds(t *testing.T) {
+	s := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{}}
+	_, err := s.feedOverlap(context.Background(), FeedOverlapParams{FeedIDs: []string{"a", "a"}})
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package mcpserver
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// FeedTagsProvider reports the tags attached to each feed, by feed ID.
+type FeedTagsProvider interface {
+	// FeedTags maps feed IDs to their normalized tags. Feeds without tags
+	// may be left out.
+	FeedTags(ctx context.Context) (map[string][]string, error)
+}
+
+// selectFeedsByTags narrows feedIDs to the feeds carrying every one of tags.
+// With no feedIDs, every tagged feed is a candidate. Without tags, feedIDs is
+// returned unchanged. It is an error for tags to match no feed, so that a
+// misspelled tag doesn't silently widen or empty a bulk operation.
+func (s *Server) selectFeedsByTags(ctx context.Context, tool string, feedIDs, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return feedIDs, nil
+	}
+	provider := s.capabilities.feedTagsProvider
+	if provider == nil {
+		return nil, model.CreateParameterError(tool, keyTags, "this server does not track feed tags",
+			"Pass feedIds instead of tags")
+	}
+	feedTags, err := provider.FeedTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := feedIDs
+	if len(candidates) == 0 {
+		candidates = slices.Sorted(maps.Keys(feedTags))
+	}
+	var selected []string
+	for _, id := range candidates {
+		if model.HasFeedTags(feedTags[id], tags) {
+			selected = append(selected, id)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, model.CreateParameterError(tool, keyTags,
+			"no feeds carry all of the tags "+strings.Join(model.NormalizeFeedTags(tags), ", "),
+			"Check the tags reported by list_managed_feeds, or pass fewer tags")
+	}
+	return selected, nil
+}
+
+// checkTags validates a tags argument.
+func checkTags(tool string, tags []string) error {
+	if err := model.ValidateFeedTags(tags); err != nil {
+		return model.CreateParameterError(tool, keyTags, err.Error(),
+			"Use short tags without commas, such as priority:high")
+	}
+	return nil
+}
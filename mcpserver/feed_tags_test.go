@@ -0,0 +1,173 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// taggedFeedGetter adds FeedTagsProvider to the feed getter mock.
+type taggedFeedGetter struct {
+	recordingFeedGetter
+	tags map[string][]string
+}
+
+func (m *taggedFeedGetter) FeedTags(ctx context.Context) (map[string][]string, error) {
+	return m.tags, nil
+}
+
+// newTaggedServer serves three feeds whose items share links, tagged as
+// infra+high, infra, and news.
+func newTaggedServer() (*Server, *taggedFeedGetter) {
+	ids := []string{"alerts", "infra", "news"}
+	allFeeds := &mockAllFeedsGetter{}
+	feedMap := make(map[string]*model.FeedAndItemsResult, len(ids))
+	for _, id := range ids {
+		allFeeds.feeds = append(allFeeds.feeds, &model.FeedResult{ID: id})
+		feedMap[id] = &model.FeedAndItemsResult{
+			ID:    id,
+			Title: id,
+			Feed:  &model.Feed{Title: id},
+			Items: []*gofeed.Item{{Title: "shared", Link: "https://example.com/shared"}, {Title: id + " item", Link: "https://example.com/" + id}},
+		}
+	}
+	getter := &taggedFeedGetter{
+		recordingFeedGetter: recordingFeedGetter{mockFeedAndItemsGetter: mockFeedAndItemsGetter{feedMap: feedMap}},
+		tags: map[string][]string{
+			"alerts": {"priority:high", "team:infra"},
+			"infra":  {"team:infra"},
+			"news":   {"news"},
+		},
+	}
+	return &Server{allFeedsGetter: allFeeds, feedAndItemsGetter: getter, capabilities: storeCapabilities{feedTagsProvider: getter}}, getter
+}
+
+func TestMergeFeeds_Tags(t *testing.T) {
+	tests := []struct {
+		name string
+		args MergeFeedsParams
+		want []string
+	}{
+		{"tags only", MergeFeedsParams{Tags: []string{"Team:Infra"}}, []string{"alerts", "infra"}},
+		{"every tag must match", MergeFeedsParams{Tags: []string{"team:infra", "priority:high"}}, []string{"alerts"}},
+		{"tags narrow feedIds", MergeFeedsParams{FeedIDs: []string{"infra", "news"}, Tags: []string{"team:infra"}}, []string{"infra"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, getter := newTaggedServer()
+			merged, err := s.mergeFeeds(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("mergeFeeds: %v", err)
+			}
+			if !slices.Equal(getter.requested, tt.want) {
+				t.Errorf("fetched feeds %v, want %v", getter.requested, tt.want)
+			}
+			if !slices.Equal(merged.SourceFeeds, tt.want) {
+				t.Errorf("source feeds %v, want %v", merged.SourceFeeds, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportFeedData_Tags(t *testing.T) {
+	s, getter := newTaggedServer()
+	exported, err := s.exportFeedData(context.Background(), &ExportFeedDataParams{Format: formatJSON, Tags: []string{"team:infra"}, ExcludeFeedIDs: []string{"alerts"}})
+	if err != nil {
+		t.Fatalf("exportFeedData: %v", err)
+	}
+	var export struct {
+		FeedResults []*FeedAndItemsResult `json:"feed_results"`
+	}
+	if err := json.Unmarshal([]byte(exported), &export); err != nil {
+		t.Fatalf("unmarshal export: %v", err)
+	}
+	if len(export.FeedResults) != 1 || export.FeedResults[0].ID != "infra" {
+		t.Errorf("exported %+v, want only infra", export.FeedResults)
+	}
+	if !slices.Equal(getter.requested, []string{"infra"}) {
+		t.Errorf("fetched feeds %v, want [infra]", getter.requested)
+	}
+}
+
+func TestFeedOverlap_Tags(t *testing.T) {
+	s, _ := newTaggedServer()
+	result, err := s.feedOverlap(context.Background(), FeedOverlapParams{Tags: []string{"team:infra"}})
+	if err != nil {
+		t.Fatalf("feedOverlap: %v", err)
+	}
+	if len(result.Feeds) != 2 || len(result.SharedItems) != 1 {
+		t.Errorf("feeds = %+v, shared = %+v; want alerts and infra sharing one item", result.Feeds, result.SharedItems)
+	}
+
+	// One matching feed can't overlap with anything.
+	_, err = s.feedOverlap(context.Background(), FeedOverlapParams{Tags: []string{"news"}})
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.Field != keyTags {
+		t.Errorf("expected a tags error, got %v", err)
+	}
+}
+
+func TestSelectFeedsByTags_Errors(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTaggedServer()
+	_, err := s.selectFeedsByTags(ctx, toolMergeFeeds, nil, []string{"missing"})
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.Field != keyTags || feedErr.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("unmatched tag: expected a tags validation error, got %v", err)
+	}
+
+	// Without tag support the filter can't be honored.
+	untagged := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{}}
+	if _, err := untagged.selectFeedsByTags(ctx, toolMergeFeeds, nil, []string{"news"}); err == nil {
+		t.Error("expected an error when the store doesn't track tags")
+	}
+	if ids, err := untagged.selectFeedsByTags(ctx, toolMergeFeeds, []string{"a"}, nil); err != nil || !slices.Equal(ids, []string{"a"}) {
+		t.Errorf("no tags: got %v, %v; want feedIds unchanged", ids, err)
+	}
+}
+
+// tagResourceGetter adds FeedTagsProvider to the resource mock.
+type tagResourceGetter struct {
+	mockResourceFeedAndItemsGetter
+	tags map[string][]string
+}
+
+func (m *tagResourceGetter) FeedTags(ctx context.Context) (map[string][]string, error) {
+	return m.tags, nil
+}
+
+func TestReadFeedMetadataResource_Tags(t *testing.T) {
+	ctx := context.Background()
+	feeds := map[string]*model.FeedAndItemsResult{
+		"tagged":   {ID: "tagged", Title: "Tagged", Feed: &model.Feed{Title: "Tagged"}},
+		"untagged": {ID: "untagged", Title: "Untagged", Feed: &model.Feed{Title: "Untagged"}},
+	}
+	getter := &tagResourceGetter{
+		mockResourceFeedAndItemsGetter: mockResourceFeedAndItemsGetter{feeds: feeds},
+		tags:                           map[string][]string{"tagged": {"priority:high", "team:infra"}},
+	}
+	rm := NewResourceManager(&mockResourceAllFeedsGetter{}, getter)
+	rm.capabilities.feedTagsProvider = getter
+
+	for feedID, want := range map[string][]any{"tagged": {"priority:high", "team:infra"}, "untagged": nil} {
+		uri := expandURITemplate(FeedMetaURI, map[string]string{"feedId": feedID})
+		result, err := rm.ReadResource(ctx, uri)
+		if err != nil {
+			t.Fatalf("ReadResource(%s): %v", uri, err)
+		}
+		var meta map[string]any
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), &meta); err != nil {
+			t.Fatalf("unmarshal metadata: %v", err)
+		}
+		got, _ := meta[keyTags].([]any)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: tags = %v, want %v", feedID, got, want)
+		}
+	}
+}
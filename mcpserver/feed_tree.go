@@ -0,0 +1,102 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// categoryPathSeparator separates the levels of a nested category path such
+// as "Tech/Programming".
+const categoryPathSeparator = "/"
+
+// FeedTreeResult is the JSON body returned by the get_feed_tree tool: the
+// top-level categories, and the feeds that have no category.
+type FeedTreeResult struct {
+	Categories    []*FeedTreeNode  `json:"categories"`
+	Uncategorized []FeedIndexEntry `json:"uncategorized"`
+}
+
+// FeedTreeNode is one category of the feed tree. Feeds lists the feeds filed
+// directly under it and Children its subcategories; FeedCount counts the
+// feeds of the whole subtree.
+type FeedTreeNode struct {
+	Name      string           `json:"name"`
+	Path      string           `json:"path"`
+	FeedCount int              `json:"feed_count"`
+	Feeds     []FeedIndexEntry `json:"feeds,omitempty"`
+	Children  []*FeedTreeNode  `json:"children,omitempty"`
+}
+
+// addFeedTreeTool adds the get_feed_tree tool
+func (s *Server) addFeedTreeTool(srv *mcp.Server) {
+	feedTreeTool := &mcp.Tool{
+		Name:        toolGetFeedTree,
+		Description: "List feeds as a tree of categories in one call, for hierarchical views. Category paths such as \"Tech/Programming\" nest: each node carries its name, full path, total feed count, the feeds filed directly under it (as in list_feed_index), and its subcategories. Feeds without a category are listed separately.",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, feedTreeTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		index, err := s.feedIndex(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(buildFeedTree(index))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// buildFeedTree files each index entry under its category path. Path segments
+// are trimmed and empty ones dropped, so "Tech//Programming/" and
+// "Tech/Programming" are the same node. Categories are sorted by name; feeds
+// keep their index order.
+func buildFeedTree(index []FeedIndexEntry) *FeedTreeResult {
+	result := &FeedTreeResult{Categories: []*FeedTreeNode{}, Uncategorized: []FeedIndexEntry{}}
+	for _, entry := range index {
+		var segments []string
+		for segment := range strings.SplitSeq(entry.Category, categoryPathSeparator) {
+			if segment = strings.TrimSpace(segment); segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+		if len(segments) == 0 {
+			result.Uncategorized = append(result.Uncategorized, entry)
+			continue
+		}
+
+		nodes := &result.Categories
+		var node *FeedTreeNode
+		for i, segment := range segments {
+			j := slices.IndexFunc(*nodes, func(n *FeedTreeNode) bool { return n.Name == segment })
+			if j < 0 {
+				*nodes = append(*nodes, &FeedTreeNode{
+					Name: segment,
+					Path: strings.Join(segments[:i+1], categoryPathSeparator),
+				})
+				j = len(*nodes) - 1
+			}
+			node = (*nodes)[j]
+			node.FeedCount++
+			nodes = &node.Children
+		}
+		node.Feeds = append(node.Feeds, entry)
+	}
+	sortFeedTree(result.Categories)
+	return result
+}
+
+// sortFeedTree sorts each level of the tree by category name.
+func sortFeedTree(nodes []*FeedTreeNode) {
+	slices.SortFunc(nodes, func(a, b *FeedTreeNode) int { return strings.Compare(a.Name, b.Name) })
+	for _, node := range nodes {
+		sortFeedTree(node.Children)
+	}
+}
@@ -0,0 +1,63 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestBuildFeedTree(t *testing.T) {
+	index := []FeedIndexEntry{
+		{ID: "go", Title: "Go Blog", Category: "Tech/Programming"},
+		{ID: "hn", Title: "Hacker News", Category: "Tech"},
+		{ID: "rust", Title: "Rust Blog", Category: " Tech / Programming /"},
+		{ID: "bbc", Title: "BBC", Category: "News"},
+		{ID: "misc", Title: "Misc"},
+		{ID: "gpu", Title: "GPUs", Category: "Tech/Hardware"},
+	}
+
+	got := buildFeedTree(index)
+	want := &FeedTreeResult{
+		Categories: []*FeedTreeNode{
+			{Name: "News", Path: "News", FeedCount: 1, Feeds: []FeedIndexEntry{index[3]}},
+			{Name: "Tech", Path: "Tech", FeedCount: 4, Feeds: []FeedIndexEntry{index[1]}, Children: []*FeedTreeNode{
+				{Name: "Hardware", Path: "Tech/Hardware", FeedCount: 1, Feeds: []FeedIndexEntry{index[5]}},
+				{Name: "Programming", Path: "Tech/Programming", FeedCount: 2, Feeds: []FeedIndexEntry{index[0], index[2]}},
+			}},
+		},
+		Uncategorized: []FeedIndexEntry{index[4]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("buildFeedTree() =\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+}
+
+// TestGetFeedTreeTool verifies the tool's JSON shape end to end; a feed
+// without a category lands in uncategorized.
+func TestGetFeedTreeTool(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(1))
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetFeedTree})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	var tree map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &tree); err != nil {
+		t.Fatalf("unmarshal tree: %v", err)
+	}
+	if got := string(tree["categories"]); got != "[]" {
+		t.Errorf("categories = %s, want []", got)
+	}
+	var uncategorized []FeedIndexEntry
+	if err := json.Unmarshal(tree["uncategorized"], &uncategorized); err != nil {
+		t.Fatalf("unmarshal uncategorized: %v", err)
+	}
+	if want := []FeedIndexEntry{{ID: "feed-1", Title: "Template Test Feed"}}; !reflect.DeepEqual(uncategorized, want) {
+		t.Errorf("uncategorized = %+v, want %+v", uncategorized, want)
+	}
+}
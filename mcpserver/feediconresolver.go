@@ -0,0 +1,11 @@
+package mcpserver
+
+import (
+	"context"
+)
+
+// FeedIconResolver resolves the icon URL for a feed, reported as icon_url by
+// feeds://feed/{feedId}/meta. An empty URL means the feed has no icon.
+type FeedIconResolver interface {
+	ResolveFeedIcon(ctx context.Context, id string) (string, error)
+}
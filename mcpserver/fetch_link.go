@@ -0,0 +1,198 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gocolly/colly"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/richardwooding/ssrfguard"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// fetch_link defaults.
+const (
+	// DefaultFetchLinkTimeout bounds each fetch_link request.
+	DefaultFetchLinkTimeout = 30 * time.Second
+	// DefaultFetchLinkMaxAttempts is how many times fetch_link tries a page
+	// that fails transiently: network errors, 429, and 5xx responses.
+	DefaultFetchLinkMaxAttempts = 3
+	// Backoff between fetch_link attempts, as for feed fetches (see
+	// model.RetryDelay) but shorter, since a client is waiting on the call.
+	fetchLinkRetryBaseDelay = 500 * time.Millisecond
+	fetchLinkRetryMaxDelay  = 5 * time.Second
+)
+
+// fetchLinkConfig configures the collector behind fetch_link.
+type fetchLinkConfig struct {
+	timeout         time.Duration
+	maxAttempts     int
+	allowPrivateIPs bool
+	// transport refuses to dial private addresses unless allowPrivateIPs is
+	// set, so a redirect can't reach them either.
+	transport http.RoundTripper
+}
+
+// newFetchLinkConfig applies defaults to the fetch_link settings in config.
+func newFetchLinkConfig(config *Config) fetchLinkConfig {
+	guard := ssrfguard.New(ssrfguard.WithAllowPrivate(config.AllowPrivateIPs))
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   guard.Control,
+	}).DialContext
+
+	fetchConfig := fetchLinkConfig{
+		timeout:         config.FetchLinkTimeout,
+		maxAttempts:     config.FetchLinkMaxAttempts,
+		allowPrivateIPs: config.AllowPrivateIPs,
+		transport:       transport,
+	}
+	if fetchConfig.timeout <= 0 {
+		fetchConfig.timeout = DefaultFetchLinkTimeout
+	}
+	if fetchConfig.maxAttempts <= 0 {
+		fetchConfig.maxAttempts = DefaultFetchLinkMaxAttempts
+	}
+	return fetchConfig
+}
+
+// addFetchLinkTool adds the fetch_link tool
+func (s *Server) addFetchLinkTool(srv *mcp.Server) {
+	fetchLinkTool := &mcp.Tool{
+		Name:        toolFetchLink,
+		Description: fetchLinkDescription,
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyURL},
+			Properties: map[string]*jsonschema.Schema{
+				keyURL: {
+					Type:        typeString,
+					Description: linkURLDescription,
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, fetchLinkTool, func(ctx context.Context, req *mcp.CallToolRequest, args FetchLinkParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		data, err := s.fetchLinkBody(ctx, args.URL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// fetchLinkBody fetches a page for fetch_link. The URL must pass the same
+// checks as feed URLs; transient failures are retried with exponential
+// backoff up to the configured number of attempts.
+func (s *Server) fetchLinkBody(ctx context.Context, rawURL string) ([]byte, error) {
+	if err := model.ValidateFeedURLContext(ctx, rawURL, s.fetchLinkConfig.allowPrivateIPs); err != nil {
+		return nil, err
+	}
+	maxAttempts := max(s.fetchLinkConfig.maxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		body, status, err := s.visitLink(ctx, rawURL)
+		if err == nil {
+			return body, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		err = linkFetchError(rawURL, status, err)
+		if !isTransientLinkError(status, err) {
+			return nil, err
+		}
+		if attempt >= maxAttempts {
+			return nil, model.CreateRetryError(err, rawURL, attempt, maxAttempts)
+		}
+		select {
+		case <-time.After(model.RetryDelay(attempt, fetchLinkRetryBaseDelay, fetchLinkRetryMaxDelay, true)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// visitLink makes one request for rawURL, returning the body and the response
+// status (zero when no response arrived). The request is bound to ctx, limited
+// by the fetch_link timeout, so cancelling the tool call aborts it.
+func (s *Server) visitLink(ctx context.Context, rawURL string) (body []byte, status int, err error) {
+	if s.fetchLinkConfig.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.fetchLinkConfig.timeout)
+		defer cancel()
+	}
+	transport := s.fetchLinkConfig.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	c := colly.NewCollector()
+	if s.fetchLinkConfig.timeout > 0 {
+		c.SetRequestTimeout(s.fetchLinkConfig.timeout)
+	}
+	c.WithTransport(contextTransport{ctx: ctx, base: transport})
+	c.OnResponse(func(response *colly.Response) {
+		body, status = response.Body, response.StatusCode
+	})
+	c.OnError(func(response *colly.Response, _ error) {
+		status = response.StatusCode
+	})
+	err = c.Visit(rawURL)
+	return body, status, err
+}
+
+// contextTransport sends every request with ctx as its context. colly v1 takes
+// no context, so this is how a fetch_link request learns of cancellation; the
+// transport is built per visit and ctx carries the request timeout.
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// linkFetchError wraps a failed fetch_link request in a FeedError typed by
+// the response status.
+func linkFetchError(rawURL string, status int, err error) error {
+	errorType := model.ErrorTypeNetwork
+	switch {
+	case errors.Is(err, ssrfguard.ErrBlockedAddress):
+		errorType = model.ErrorTypePrivateIP
+	case status >= http.StatusInternalServerError:
+		errorType = model.ErrorTypeHTTPServerError
+	case status >= http.StatusBadRequest:
+		errorType = model.ErrorTypeHTTPClientError
+	}
+	return model.NewFeedErrorWithCause(errorType, "failed to fetch link", err).
+		WithURL(rawURL).
+		WithOperation("fetch_link").
+		WithComponent("mcp_server")
+}
+
+// isTransientLinkError reports whether a failed fetch_link request is worth
+// retrying: no response at all (other than a blocked address), rate limiting,
+// or a server error.
+func isTransientLinkError(status int, err error) bool {
+	if errors.Is(err, ssrfguard.ErrBlockedAddress) {
+		return false
+	}
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
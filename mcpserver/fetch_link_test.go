@@ -0,0 +1,132 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func newFetchLinkTestServer(t *testing.T, timeout time.Duration, maxAttempts int, allowPrivateIPs bool) *Server {
+	t.Helper()
+	s, err := NewServer(&Config{
+		Transport:            model.StdioTransport,
+		AllFeedsGetter:       &mockAllFeedsGetter{},
+		FeedAndItemsGetter:   &mockFeedAndItemsGetter{},
+		FetchLinkTimeout:     timeout,
+		FetchLinkMaxAttempts: maxAttempts,
+		AllowPrivateIPs:      allowPrivateIPs,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return s
+}
+
+func TestFetchLink_Timeout(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer page.Close()
+
+	s := newFetchLinkTestServer(t, 100*time.Millisecond, 1, true)
+	start := time.Now()
+	_, err := s.fetchLinkBody(context.Background(), page.URL)
+	if err == nil {
+		t.Fatal("expected a timeout error from a slow page")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fetch took %v, want it cut off near the 100ms timeout", elapsed)
+	}
+}
+
+func TestFetchLink_RetriesTransientFailure(t *testing.T) {
+	var requests atomic.Int32
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("<html>recovered</html>"))
+	}))
+	defer page.Close()
+
+	s := newFetchLinkTestServer(t, time.Second, 3, true)
+	body, err := s.fetchLinkBody(context.Background(), page.URL)
+	if err != nil || string(body) != "<html>recovered</html>" {
+		t.Fatalf("fetchLinkBody = %q, %v", body, err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestFetchLink_ClientErrorNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer page.Close()
+
+	s := newFetchLinkTestServer(t, time.Second, 3, true)
+	_, err := s.fetchLinkBody(context.Background(), page.URL)
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeHTTPClientError {
+		t.Fatalf("err = %v, want an http_client_error FeedError", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestFetchLink_BlocksPrivateAddresses(t *testing.T) {
+	var requests atomic.Int32
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer page.Close()
+
+	s := newFetchLinkTestServer(t, time.Second, 3, false)
+	if _, err := s.fetchLinkBody(context.Background(), page.URL); err == nil {
+		t.Fatal("expected a loopback URL to be rejected")
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+func TestFetchLink_ContextCancelled(t *testing.T) {
+	var requests atomic.Int32
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer page.Close()
+
+	s := newFetchLinkTestServer(t, 10*time.Second, 3, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := s.fetchLinkBody(ctx, page.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the context's deadline error", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fetch took %v, want it cut off when the context ended", elapsed)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1: no retry after the context ended", got)
+	}
+}
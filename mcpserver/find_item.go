@@ -0,0 +1,123 @@
+package mcpserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// FindItemParams contains parameters for the find_item tool. Exactly one of
+// GUID and Link is set.
+type FindItemParams struct {
+	GUID string `json:"guid,omitempty"`
+	Link string `json:"link,omitempty"`
+}
+
+// FoundItem is an item matched by find_item, with the feed it was found in.
+type FoundItem struct {
+	FeedID    string `json:"feed_id"`
+	FeedTitle string `json:"feed_title"`
+	Title     string `json:"title"`
+	Link      string `json:"link,omitempty"`
+	GUID      string `json:"guid,omitempty"`
+	Published string `json:"published,omitempty"`
+	Updated   string `json:"updated,omitempty"`
+}
+
+// FindItemResult is the JSON body returned by the find_item tool. FeedIDs
+// lists each feed with a match once, in feed order.
+type FindItemResult struct {
+	Matches []FoundItem `json:"matches"`
+	FeedIDs []string    `json:"feed_ids"`
+}
+
+// addFindItemTool adds the find_item tool
+func (s *Server) addFindItemTool(srv *mcp.Server) {
+	findItemTool := &mcp.Tool{
+		Name:        toolFindItem,
+		Description: "Find every item with a given GUID or link across all feeds, with the feed each copy was found in; use to trace where a syndicated item originated",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				"guid": {
+					Type:        typeString,
+					Description: "Exact item GUID to look for (surrounding whitespace is ignored)",
+				},
+				"link": {
+					Type:        typeString,
+					Description: "Item link to look for. Links are compared normalized, ignoring the scheme, a leading www., a trailing slash, and utm_* parameters.",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, findItemTool, func(ctx context.Context, req *mcp.CallToolRequest, args FindItemParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolFindItem, args, func() (any, error) {
+			return s.findItem(ctx, args)
+		})
+		return result, nil, err
+	})
+}
+
+// findItem scans every feed for items matching args. Feeds that fail to load
+// are skipped, as in export_feed_data.
+func (s *Server) findItem(ctx context.Context, args FindItemParams) (*FindItemResult, error) {
+	feeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := itemMatcher(args)
+	result := &FindItemResult{Matches: []FoundItem{}, FeedIDs: []string{}}
+	for _, feed := range feeds {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feed.ID)
+		if err != nil {
+			continue
+		}
+		feedTitle := feedResult.Title
+		if feedTitle == "" && feedResult.Feed != nil {
+			feedTitle = feedResult.Feed.Title
+		}
+		found := false
+		for _, item := range feedResult.Items {
+			if item == nil || !matches(item) {
+				continue
+			}
+			found = true
+			result.Matches = append(result.Matches, FoundItem{
+				FeedID:    feed.ID,
+				FeedTitle: feedTitle,
+				Title:     item.Title,
+				Link:      item.Link,
+				GUID:      item.GUID,
+				Published: item.Published,
+				Updated:   item.Updated,
+			})
+		}
+		if found {
+			result.FeedIDs = append(result.FeedIDs, feed.ID)
+		}
+	}
+	return result, nil
+}
+
+// itemMatcher returns a predicate for the item args identifies: by exact GUID,
+// or by normalized link (see model.NormalizeItemLink).
+func itemMatcher(args FindItemParams) func(*gofeed.Item) bool {
+	if guid := strings.TrimSpace(args.GUID); guid != "" {
+		return func(item *gofeed.Item) bool {
+			return strings.TrimSpace(item.GUID) == guid
+		}
+	}
+	link := model.NormalizeItemLink(args.Link)
+	return func(item *gofeed.Item) bool {
+		return item.Link != "" && model.NormalizeItemLink(item.Link) == link
+	}
+}
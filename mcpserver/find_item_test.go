@@ -0,0 +1,75 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestFindItem(t *testing.T) {
+	allFeeds := &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "origin"}, {ID: "other"}, {ID: "mirror"}, {ID: "broken"}}}
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"origin": {ID: "origin", Title: "Origin", Items: []*gofeed.Item{
+			{Title: "Story", Link: "https://example.com/story", GUID: "story-1", Published: "Mon, 01 Jan 2024 10:00:00 GMT"},
+			{Title: "Unrelated", Link: "https://example.com/other", GUID: "other-1"},
+		}},
+		"other": {ID: "other", Title: "Other", Items: []*gofeed.Item{{Title: "Elsewhere", Link: "https://other.example/x", GUID: "x"}}},
+		"mirror": {ID: "mirror", Feed: &model.Feed{Title: "Mirror"}, Items: []*gofeed.Item{
+			{Title: "Story (reposted)", Link: "http://www.example.com/story/?utm_source=rss", GUID: " story-1 "},
+		}},
+	}}
+	s := &Server{allFeedsGetter: allFeeds, feedAndItemsGetter: getter}
+
+	tests := []struct {
+		name string
+		args FindItemParams
+		want []string
+	}{
+		{"by guid", FindItemParams{GUID: "story-1"}, []string{"origin", "mirror"}},
+		{"by link", FindItemParams{Link: "https://example.com/story"}, []string{"origin", "mirror"}},
+		{"no match", FindItemParams{GUID: "missing"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.findItem(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("findItem: %v", err)
+			}
+			if !slices.Equal(result.FeedIDs, tt.want) {
+				t.Errorf("feed_ids = %v, want %v", result.FeedIDs, tt.want)
+			}
+			if len(result.Matches) != len(tt.want) {
+				t.Fatalf("got %d matches, want %d", len(result.Matches), len(tt.want))
+			}
+			for i, match := range result.Matches {
+				if match.FeedID != tt.want[i] {
+					t.Errorf("match %d from feed %q, want %q", i, match.FeedID, tt.want[i])
+				}
+			}
+		})
+	}
+
+	result, _ := s.findItem(context.Background(), FindItemParams{GUID: "story-1"})
+	if got := result.Matches[1]; got.FeedTitle != "Mirror" || got.Title != "Story (reposted)" {
+		t.Errorf("mirror match = %+v, want the feed title from the parsed feed", got)
+	}
+	if got := result.Matches[0]; got.FeedTitle != "Origin" || got.Published == "" {
+		t.Errorf("origin match = %+v", got)
+	}
+}
+
+func TestFindItemTool_RequiresGUIDOrLink(t *testing.T) {
+	session, getter := toolCacheSession(t, 0)
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolFindItem, Arguments: map[string]any{}})
+	if err != nil || !result.IsError {
+		t.Fatalf("CallTool without guid or link = %+v, %v; want a tool error", result, err)
+	}
+	if got := getter.fetches.Load(); got != 0 {
+		t.Errorf("an invalid call fetched %d feeds, want 0", got)
+	}
+}
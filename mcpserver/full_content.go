@@ -0,0 +1,202 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	ristretto "github.com/dgraph-io/ristretto/v2"
+	gocache "github.com/eko/gocache/lib/v4/cache"
+	"github.com/eko/gocache/lib/v4/store"
+	ristrettostore "github.com/eko/gocache/store/ristretto/v4"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/time/rate"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// fetch_feed_full_content limits.
+const (
+	// DefaultFullContentItems is how many items are fetched when maxItems is
+	// not specified.
+	DefaultFullContentItems = 10
+	// MaxFullContentItems caps maxItems.
+	MaxFullContentItems = 25
+	// ArticleCacheTTL is how long extracted article text is cached per link.
+	ArticleCacheTTL = 1 * time.Hour
+	// articleCacheMaxBytes bounds the cached article text.
+	articleCacheMaxBytes = 32 << 20
+	// fullContentConcurrency bounds the article fetches one call runs at once,
+	// and fullContentRate the fetches it starts per second.
+	fullContentConcurrency = 4
+	fullContentRate        = 4
+)
+
+// FetchFeedFullContentParams contains parameters for the
+// fetch_feed_full_content tool.
+type FetchFeedFullContentParams struct {
+	FeedID   string `json:"feedId"`
+	Confirm  bool   `json:"confirm"`
+	MaxItems int    `json:"maxItems,omitempty"`
+}
+
+// FullContentItem is an item with the text extracted from its linked article.
+// Error is set instead of FullText when the article couldn't be fetched.
+type FullContentItem struct {
+	Title     string     `json:"title"`
+	Link      string     `json:"link,omitempty"`
+	StableID  string     `json:"stable_id,omitempty"`
+	Published *time.Time `json:"published,omitempty"`
+	FullText  string     `json:"full_text,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// FullContentResult is the JSON body returned by fetch_feed_full_content.
+type FullContentResult struct {
+	FeedID     string            `json:"feed_id"`
+	Title      string            `json:"title"`
+	TotalItems int               `json:"total_items"`
+	Items      []FullContentItem `json:"items"`
+}
+
+// initializeArticleCache creates the cache of extracted article text.
+func (s *Server) initializeArticleCache() error {
+	ristrettoCache, err := ristretto.NewCache[string, string](&ristretto.Config[string, string]{
+		NumCounters: 10000,
+		MaxCost:     articleCacheMaxBytes,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create article cache: %w", err)
+	}
+	s.articleCache = gocache.New[string](ristrettostore.NewRistretto(ristrettoCache))
+	return nil
+}
+
+// addFetchFeedFullContentTool adds the fetch_feed_full_content tool
+func (s *Server) addFetchFeedFullContentTool(srv *mcp.Server) {
+	fetchFeedFullContentTool := &mcp.Tool{
+		Name:        toolFetchFeedFullContent,
+		Description: fmt.Sprintf("Fetch the full article text of a feed's items by downloading each item's link and extracting its readable text. Heavy: one page fetch per item, so confirm=true is required and at most maxItems (default %d, max %d) items are fetched, in feed order. Articles are cached per link; items whose page fails carry an error instead of full_text.", DefaultFullContentItems, MaxFullContentItems),
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{"feedId", "confirm"},
+			Properties: map[string]*jsonschema.Schema{
+				"feedId": {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+				"confirm": {
+					Type:        typeBoolean,
+					Description: "Must be true: acknowledges that every item's linked page will be fetched",
+				},
+				"maxItems": {
+					Type:        typeInteger,
+					Description: fmt.Sprintf("Maximum items to fetch (default: %d, max: %d)", DefaultFullContentItems, MaxFullContentItems),
+					Minimum:     &[]float64{1}[0],
+					Maximum:     &[]float64{float64(MaxFullContentItems)}[0],
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, fetchFeedFullContentTool, func(ctx context.Context, req *mcp.CallToolRequest, args FetchFeedFullContentParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.fetchFeedFullContent(ctx, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// fetchFeedFullContent fetches and extracts the articles of the feed's first
+// maxItems items, fullContentConcurrency at a time and no faster than
+// fullContentRate per second.
+func (s *Server) fetchFeedFullContent(ctx context.Context, args FetchFeedFullContentParams) (*FullContentResult, error) {
+	if !args.Confirm {
+		return nil, args.validate()
+	}
+	feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.FeedID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxItems := DefaultFullContentItems
+	if args.MaxItems > 0 {
+		maxItems = min(args.MaxItems, MaxFullContentItems)
+	}
+	items := feedResult.Items[:min(maxItems, len(feedResult.Items))]
+
+	result := &FullContentResult{
+		FeedID:     feedResult.ID,
+		Title:      feedResult.Title,
+		TotalItems: len(feedResult.Items),
+		Items:      make([]FullContentItem, len(items)),
+	}
+	if result.Title == "" && feedResult.Feed != nil {
+		result.Title = feedResult.Feed.Title
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(fullContentRate), 1)
+	slots := make(chan struct{}, fullContentConcurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		result.Items[i] = FullContentItem{
+			Title:     item.Title,
+			Link:      item.Link,
+			StableID:  model.ItemStableID(item),
+			Published: item.PublishedParsed,
+		}
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			text, err := s.articleText(ctx, limiter, item)
+			if err != nil {
+				result.Items[i].Error = err.Error()
+				return
+			}
+			result.Items[i].FullText = text
+		})
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// articleText returns the extracted text of the item's linked article, from
+// the cache when it has been fetched recently.
+func (s *Server) articleText(ctx context.Context, limiter *rate.Limiter, item *gofeed.Item) (string, error) {
+	if item.Link == "" {
+		return "", model.NewFeedError(model.ErrorTypeValidation, "item has no link").
+			WithOperation("fetch_feed_full_content").
+			WithComponent("mcp_server")
+	}
+	if s.articleCache != nil {
+		if text, err := s.articleCache.Get(ctx, item.Link); err == nil {
+			return text, nil
+		}
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	page, err := s.fetchLinkBody(ctx, item.Link)
+	if err != nil {
+		return "", err
+	}
+	text := extractArticleText(page)
+	if s.articleCache != nil {
+		_ = s.articleCache.Set(ctx, item.Link, text, store.WithExpiration(ArticleCacheTTL), store.WithCost(int64(len(text))))
+	}
+	return text, nil
+}
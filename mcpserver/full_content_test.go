@@ -0,0 +1,123 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestExtractArticleText(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want string
+	}{
+		{
+			name: "article element",
+			page: `<html><head><script>var x = 1;</script></head><body><nav><p>Home</p></nav>` +
+				`<article><h1>Title</h1><p>First   paragraph<br>continues.</p><aside><p>Related</p></aside><ul><li>Point</li></ul></article>` +
+				`<footer><p>Copyright</p></footer></body></html>`,
+			want: "Title\n\nFirst paragraph continues.\n\nPoint",
+		},
+		{
+			name: "densest paragraph container",
+			page: `<body><div class="sidebar"><p>Short</p></div>` +
+				`<div class="content"><p>The story begins here.</p><p>And then it ends.</p></div></body>`,
+			want: "The story begins here.\n\nAnd then it ends.",
+		},
+		{
+			name: "no block elements",
+			page: `<body><div>Just   some <b>text</b></div><script>ignored()</script></body>`,
+			want: "Just some text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractArticleText([]byte(tt.page)); got != tt.want {
+				t.Errorf("extractArticleText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchFeedFullContent(t *testing.T) {
+	var requests atomic.Int32
+	pages := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/one":
+			_, _ = w.Write([]byte(`<article><p>Article one.</p></article>`))
+		case "/two":
+			_, _ = w.Write([]byte(`<main><p>Article two.</p></main>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer pages.Close()
+
+	items := []*gofeed.Item{
+		{Title: "One", Link: pages.URL + "/one"},
+		{Title: "Two", Link: pages.URL + "/two"},
+		{Title: "Gone", Link: pages.URL + "/gone"},
+		{Title: "Unlinked"},
+		{Title: "Beyond maxItems", Link: pages.URL + "/one?page=2"},
+	}
+	s, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{"feed": {ID: "feed", Title: "Feed", Items: items}}},
+		AllowPrivateIPs:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	args := FetchFeedFullContentParams{FeedID: "feed", Confirm: true, MaxItems: 4}
+	result, err := s.fetchFeedFullContent(context.Background(), args)
+	if err != nil {
+		t.Fatalf("fetchFeedFullContent: %v", err)
+	}
+	if result.TotalItems != 5 || len(result.Items) != 4 {
+		t.Fatalf("total_items = %d, items = %d; want 5 and 4", result.TotalItems, len(result.Items))
+	}
+	if got := result.Items[0].FullText; got != "Article one." {
+		t.Errorf("items[0].full_text = %q", got)
+	}
+	if got := result.Items[1].FullText; got != "Article two." {
+		t.Errorf("items[1].full_text = %q", got)
+	}
+	for _, i := range []int{2, 3} {
+		if result.Items[i].Error == "" || result.Items[i].FullText != "" {
+			t.Errorf("items[%d] = %+v, want an error and no text", i, result.Items[i])
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+
+	// Extracted articles are cached by link; only the failed page is refetched.
+	time.Sleep(10 * time.Millisecond)
+	if _, err := s.fetchFeedFullContent(context.Background(), args); err != nil {
+		t.Fatalf("fetchFeedFullContent: %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("requests after refetch = %d, want 4", got)
+	}
+}
+
+func TestFetchFeedFullContent_RequiresConfirm(t *testing.T) {
+	s := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{}}
+	_, err := s.fetchFeedFullContent(context.Background(), FetchFeedFullContentParams{FeedID: "feed"})
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("err = %v, want a validation error", err)
+	}
+}
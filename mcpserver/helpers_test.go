@@ -0,0 +1,49 @@
+package mcpserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestClientSession builds a server from config and returns a client
+// session connected to it over in-memory transports.
+func newTestClientSession(t *testing.T, config *Config) *mcp.ClientSession {
+	t.Helper()
+	srv, err := NewServer(config)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return connectTestClient(t, srv)
+}
+
+// connectTestClient connects a client to srv over in-memory transports, for
+// tests that also inspect the server. Both sessions close when the test ends.
+func connectTestClient(t *testing.T, srv *Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := srv.buildMCPServer().Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0.0.1"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// callToolText calls a tool that must succeed and returns its first text
+// content block.
+func callToolText(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool(%s, %v) = %+v, %v", name, args, result, err)
+	}
+	return result.Content[0].(*mcp.TextContent).Text
+}
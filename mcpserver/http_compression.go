@@ -0,0 +1,123 @@
+package mcpserver
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// gzipHandler compresses responses with gzip for clients whose Accept-Encoding
+// allows it, and passes other requests through unchanged. Streamed (SSE)
+// responses stay streamed: each Flush from the wrapped handler flushes the
+// compressor, so every event reaches the client as soon as it's written.
+// Responses without a body are left unencoded.
+func gzipHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip, either
+// by name or through "*", with a non-zero quality.
+func acceptsGzip(header string) bool {
+	for coding := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		if quality, err := strconv.ParseFloat(q, 64); err == nil && quality > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body written through it. The status and
+// headers are held back until the first Write or Flush, so a response that
+// turns out to have no body, or that the handler encoded itself, goes out
+// uncompressed.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	gz          *gzip.Writer // nil until the body starts, or when not compressing
+}
+
+// WriteHeader records the status; it's sent when the body starts.
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader || w.status != 0 {
+		return
+	}
+	w.status = status
+}
+
+// start commits the status and headers, switching to gzip when the response
+// can carry a body that isn't already encoded.
+func (w *gzipResponseWriter) start() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	h := w.Header()
+	if status != http.StatusNoContent && status != http.StatusNotModified && h.Get("Content-Encoding") == "" {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	w.start()
+	if w.gz == nil {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.gz.Write(p)
+}
+
+// Flush sends everything written so far to the client.
+func (w *gzipResponseWriter) Flush() {
+	w.start()
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close finishes the gzip stream, or sends a held-back status for a response
+// that never wrote a body.
+func (w *gzipResponseWriter) close() {
+	if !w.wroteHeader {
+		if w.status != 0 {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		return
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
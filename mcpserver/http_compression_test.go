@@ -0,0 +1,150 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// encodingRecorder records, for every response with a body, whether it
+// arrived gzip-encoded. http.Transport decodes gzip itself when it asked for
+// it, reporting that through Response.Uncompressed.
+type encodingRecorder struct {
+	base http.RoundTripper
+
+	mu         sync.Mutex
+	compressed []bool
+}
+
+func (r *encodingRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil || resp.StatusCode == http.StatusAccepted || resp.ContentLength == 0 {
+		return resp, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.compressed = append(r.compressed, resp.Uncompressed || resp.Header.Get("Content-Encoding") == "gzip")
+	return resp, err
+}
+
+// callToolOverHTTP serves the test feed over the Streamable HTTP transport and
+// calls get_syndication_feed_items through a client with the given transport,
+// returning whether each response body was gzip-encoded.
+func callToolOverHTTP(t *testing.T, compression bool, clientTransport *http.Transport) []bool {
+	t.Helper()
+
+	items := makeTestItems(20)
+	srv, err := NewServer(&Config{
+		Transport:          model.StreamableHTTPTransport,
+		AllFeedsGetter:     &mockResourceAllFeedsGetter{feeds: []*model.FeedResult{{ID: "feed-1", Title: "Feed"}}},
+		FeedAndItemsGetter: &mockResourceFeedAndItemsGetter{feeds: map[string]*model.FeedAndItemsResult{"feed-1": {ID: "feed-1", Title: "Feed", Items: items}}},
+		HTTPCompression:    &compression,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	httpServer := httptest.NewServer(srv.httpHandler(srv.buildMCPServer()))
+	t.Cleanup(httpServer.Close)
+
+	recorder := &encodingRecorder{base: clientTransport}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0.0.1"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:             httpServer.URL,
+		HTTPClient:           &http.Client{Transport: recorder},
+		DisableStandaloneSSE: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolGetSyndicationFeedItems,
+		Arguments: map[string]any{keyID: "feed-1", "limit": len(items), "includeContent": true},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	if got := len(result.Content); got != len(items)+1 {
+		t.Fatalf("got %d content blocks, want %d", got, len(items)+1)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if len(recorder.compressed) == 0 {
+		t.Fatal("no responses recorded")
+	}
+	return recorder.compressed
+}
+
+func TestHTTPTransport_GzipWhenAccepted(t *testing.T) {
+	// http.Transport sends Accept-Encoding: gzip unless compression is disabled.
+	for i, compressed := range callToolOverHTTP(t, true, http.DefaultTransport.(*http.Transport).Clone()) {
+		if !compressed {
+			t.Errorf("response %d was not gzip-encoded", i)
+		}
+	}
+}
+
+func TestHTTPTransport_PlainWhenNotAccepted(t *testing.T) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = true
+	for i, compressed := range callToolOverHTTP(t, true, transport) {
+		if compressed {
+			t.Errorf("response %d was gzip-encoded without Accept-Encoding", i)
+		}
+	}
+}
+
+func TestHTTPTransport_CompressionDisabled(t *testing.T) {
+	for i, compressed := range callToolOverHTTP(t, false, http.DefaultTransport.(*http.Transport).Clone()) {
+		if compressed {
+			t.Errorf("response %d was gzip-encoded with compression disabled", i)
+		}
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.8", true},
+		{"GZIP", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"identity", false},
+		{"br, deflate", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsGzip(tt.header); got != tt.want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestGzipHandler_NoBodyStaysPlain(t *testing.T) {
+	handler := gzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none", got)
+	}
+}
@@ -318,7 +318,7 @@ func TestBuildFeedContentWithImages(t *testing.T) {
 
 		// Call buildFeedContent with includeImages=true, embedImages=false
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, true, false)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{IncludeImages: true})
 
 		// Verify structure:
 		// [0] TextContent (feed metadata)
@@ -416,7 +416,7 @@ func TestBuildFeedContentWithImages(t *testing.T) {
 
 		// Call buildFeedContent with includeImages=false, embedImages=false
 		ctx := context.Background()
-		content := server.buildFeedContent(ctx, feed, items, paginationInfo, false, 0, false, false)
+		content := server.buildFeedContent(ctx, feed, items, paginationInfo, ParsedFeedParams{})
 
 		// Should only have feed metadata + item content (no images)
 		expectedContentCount := 2
@@ -0,0 +1,154 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// import_opml per-feed outcomes.
+const (
+	importStatusAdded   = "added"
+	importStatusSkipped = "skipped"
+	importStatusFailed  = "failed"
+)
+
+// ImportOPMLParams contains parameters for the import_opml tool.
+type ImportOPMLParams struct {
+	OPML string `json:"opml"`
+}
+
+// ImportOPMLResult is returned by the import_opml tool. Added counts the
+// feeds added; Merged counts those of them filed into a category that already
+// existed; Skipped counts feeds already present; Failed counts feeds that
+// couldn't be added.
+type ImportOPMLResult struct {
+	Added   int            `json:"added"`
+	Merged  int            `json:"merged"`
+	Skipped int            `json:"skipped"`
+	Failed  int            `json:"failed"`
+	Feeds   []ImportedFeed `json:"feeds"`
+}
+
+// ImportedFeed is the outcome for one feed listed in the imported OPML.
+type ImportedFeed struct {
+	URL      string `json:"url"`
+	Title    string `json:"title,omitempty"`
+	Category string `json:"category,omitempty"`
+	Status   string `json:"status"`
+	FeedID   string `json:"feedId,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// addImportOPMLTool adds the import_opml tool to the server
+func (s *Server) addImportOPMLTool(srv *mcp.Server) {
+	importOPMLTool := &mcp.Tool{
+		Name:        toolImportOPML,
+		Description: "Import the feeds listed in an OPML document into the running server. Feeds already present (compared by normalized URL) are skipped, and OPML folders matching an existing category (case-insensitive) are merged into it instead of creating a parallel category. Returns added/merged/skipped/failed counts and the outcome for each feed. Each new feed is fetched once as it is added, so large imports take a while.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{"opml"},
+			Properties: map[string]*jsonschema.Schema{
+				"opml": {
+					Type:        typeString,
+					Description: "OPML document (XML) listing the feeds to import; folder outlines become categories",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, importOPMLTool, func(ctx context.Context, req *mcp.CallToolRequest, args ImportOPMLParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.importOPML(ctx, []byte(args.OPML))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// importOPML adds the feeds listed in opmlContent that aren't managed yet.
+// Categories are matched case-insensitively, so a feed filed under an OPML
+// folder "news" joins an existing "News" category, and folders differing only
+// in case within the import also end up in one category. A feed that fails to
+// add is reported and doesn't stop the import.
+func (s *Server) importOPML(ctx context.Context, opmlContent []byte) (*ImportOPMLResult, error) {
+	feeds, err := model.ExtractFeedsFromOPML(opmlContent)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := s.dynamicFeedManager.ListManagedFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]string, len(managed)+len(feeds))
+	categories := make(map[string]string)
+	existingCategories := make(map[string]bool)
+	for _, feed := range managed {
+		known[model.FeedURLKey(feed.URL)] = feed.FeedID
+		if key := categoryKey(feed.Category); key != "" {
+			categories[key] = feed.Category
+			existingCategories[key] = true
+		}
+	}
+
+	result := &ImportOPMLResult{Feeds: make([]ImportedFeed, 0, len(feeds))}
+	for _, feed := range feeds {
+		entry := ImportedFeed{URL: feed.URL, Title: feed.Title}
+		urlKey := model.FeedURLKey(feed.URL)
+		if feedID, ok := known[urlKey]; ok {
+			entry.Status = importStatusSkipped
+			entry.FeedID = feedID
+			result.Skipped++
+			result.Feeds = append(result.Feeds, entry)
+			continue
+		}
+
+		key := categoryKey(feed.Category)
+		entry.Category = strings.TrimSpace(feed.Category)
+		if existing, ok := categories[key]; ok {
+			entry.Category = existing
+		}
+		info, err := s.dynamicFeedManager.AddFeed(ctx, FeedConfig{URL: feed.URL, Title: feed.Title, Category: entry.Category})
+		if err != nil {
+			entry.Status = importStatusFailed
+			entry.Error = err.Error()
+			result.Failed++
+			result.Feeds = append(result.Feeds, entry)
+			continue
+		}
+
+		entry.Status = importStatusAdded
+		entry.FeedID = info.FeedID
+		known[urlKey] = info.FeedID
+		result.Added++
+		if key != "" {
+			if existingCategories[key] {
+				result.Merged++
+			}
+			categories[key] = entry.Category
+		}
+		result.Feeds = append(result.Feeds, entry)
+	}
+	return result, nil
+}
+
+// categoryKey is the form categories are compared in.
+func categoryKey(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
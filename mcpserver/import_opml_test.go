@@ -0,0 +1,95 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestImportOPML_MergesIntoExistingFeeds(t *testing.T) {
+	manager := &mockDynamicFeedManager{
+		feeds: map[string]*ManagedFeedInfo{
+			"feed-1": {FeedID: "feed-1", URL: "https://example.com/feed", Category: "News"},
+			"feed-2": {FeedID: "feed-2", URL: "https://blog.example.org/rss", Category: "Tech"},
+		},
+		addErrs: map[string]error{"https://broken.example.com/feed": errors.New("feed unreachable")},
+	}
+	srv, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		DynamicFeedManager: manager,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	opml := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+	<body>
+		<outline text="news">
+			<outline text="Example" xmlUrl="HTTPS://Example.com/feed/" />
+			<outline text="Daily News" xmlUrl="https://news.example.net/atom" />
+		</outline>
+		<outline text="Podcasts">
+			<outline text="Pod One" xmlUrl="https://pod.example.com/feed" />
+		</outline>
+		<outline text="podcasts">
+			<outline text="Pod Two" xmlUrl="https://pod2.example.com/feed" />
+			<outline text="Broken" xmlUrl="https://broken.example.com/feed" />
+		</outline>
+		<outline text="Daily News again" xmlUrl="https://news.example.net/atom#latest" />
+	</body>
+</opml>`
+	result, err := srv.importOPML(context.Background(), []byte(opml))
+	if err != nil {
+		t.Fatalf("importOPML: %v", err)
+	}
+
+	if result.Added != 3 || result.Merged != 1 || result.Skipped != 2 || result.Failed != 1 {
+		t.Errorf("counts added=%d merged=%d skipped=%d failed=%d, want 3, 1, 2, 1",
+			result.Added, result.Merged, result.Skipped, result.Failed)
+	}
+
+	want := []ImportedFeed{
+		{URL: "HTTPS://Example.com/feed/", Title: "Example", Status: importStatusSkipped, FeedID: "feed-1"},
+		{URL: "https://news.example.net/atom", Title: "Daily News", Category: "News", Status: importStatusAdded, FeedID: model.GenerateFeedID("https://news.example.net/atom")},
+		{URL: "https://pod.example.com/feed", Title: "Pod One", Category: "Podcasts", Status: importStatusAdded, FeedID: model.GenerateFeedID("https://pod.example.com/feed")},
+		{URL: "https://pod2.example.com/feed", Title: "Pod Two", Category: "Podcasts", Status: importStatusAdded, FeedID: model.GenerateFeedID("https://pod2.example.com/feed")},
+		{URL: "https://broken.example.com/feed", Title: "Broken", Category: "Podcasts", Status: importStatusFailed, Error: "feed unreachable"},
+		{URL: "https://news.example.net/atom#latest", Title: "Daily News again", Status: importStatusSkipped, FeedID: model.GenerateFeedID("https://news.example.net/atom")},
+	}
+	if !slices.Equal(result.Feeds, want) {
+		t.Errorf("feeds:\n got %+v\nwant %+v", result.Feeds, want)
+	}
+
+	// No parallel categories differing only in case were created
+	var categories []string
+	for _, feed := range manager.feeds {
+		if !slices.Contains(categories, feed.Category) {
+			categories = append(categories, feed.Category)
+		}
+	}
+	slices.Sort(categories)
+	if wantCategories := []string{"News", "Podcasts", "Tech"}; !slices.Equal(categories, wantCategories) {
+		t.Errorf("categories = %v, want %v", categories, wantCategories)
+	}
+}
+
+func TestImportOPML_InvalidOPML(t *testing.T) {
+	srv, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		DynamicFeedManager: &mockDynamicFeedManager{},
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	if _, err := srv.importOPML(context.Background(), []byte("<opml><body></body></opml>")); err == nil {
+		t.Error("Expected an error for OPML without feeds")
+	}
+}
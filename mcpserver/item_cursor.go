@@ -0,0 +1,89 @@
+package mcpserver
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// itemCursorVersion prefixes every item cursor so the format can change.
+const itemCursorVersion byte = 1
+
+// itemCursor is a position in a feed's items in cursor order (see
+// compareCursorOrder): that of the last item a get_syndication_feed_items
+// page returned. Unlike an offset it doesn't shift when new items arrive, so
+// the next page starts right after that item however the feed has changed.
+// It travels to and from the client as an opaque token: a version byte, a
+// byte that is 1 when the item is dated, the date in Unix nanoseconds as a
+// big-endian int64, and the item's stable ID, in unpadded URL-safe base64.
+type itemCursor struct {
+	date *time.Time
+	id   string
+}
+
+// newItemCursor returns the cursor token for the position of item, dated by
+// the date for field.
+func newItemCursor(item *gofeed.Item, field string) string {
+	data := make([]byte, 10, 10+len(model.ItemStableID(item)))
+	data[0] = itemCursorVersion
+	if date := itemDate(item, field); date != nil {
+		data[1] = 1
+		binary.BigEndian.PutUint64(data[2:], uint64(date.UnixNano()))
+	}
+	data = append(data, model.ItemStableID(item)...)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeItemCursor parses a cursor returned by an earlier call. An empty
+// token is a nil cursor, which starts from the newest item.
+func decodeItemCursor(token string) (*itemCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) < 10 || data[0] != itemCursorVersion || data[1] > 1 {
+		return nil, model.CreateParameterError(toolGetSyndicationFeedItems, "cursor", "invalid cursor",
+			"Pass the next_cursor returned by the previous call unchanged, or use cursorPaging=true without a cursor to start from the newest item")
+	}
+	cursor := &itemCursor{id: string(data[10:])}
+	if data[1] == 1 {
+		cursor.date = new(time.Unix(0, int64(binary.BigEndian.Uint64(data[2:]))))
+	}
+	return cursor, nil
+}
+
+// compareCursorOrder orders items newest first by the date for field, with
+// undated items last, like order=newest, but breaks ties by stable ID rather
+// than feed order, so every item has a fixed place that a cursor can name.
+func compareCursorOrder(a, b *gofeed.Item, field string) int {
+	return cmp.Or(compareItemDates(a, b, field), strings.Compare(model.ItemStableID(a), model.ItemStableID(b)))
+}
+
+// itemsAfterCursor returns the items in cursor order, starting after the
+// cursor's position; a nil cursor starts from the newest item. The caller's
+// slice is not reordered.
+func itemsAfterCursor(items []*gofeed.Item, cursor *itemCursor, field string) []*gofeed.Item {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b *gofeed.Item) int {
+		return compareCursorOrder(a, b, field)
+	})
+	if cursor == nil {
+		return sorted
+	}
+	start, _ := slices.BinarySearchFunc(sorted, cursor, func(item *gofeed.Item, cursor *itemCursor) int {
+		return cmp.Or(compareDates(itemDate(item, field), cursor.date), strings.Compare(model.ItemStableID(item), cursor.id))
+	})
+	// Skip the cursor's own item, if it is still there.
+	if start < len(sorted) && model.ItemStableID(sorted[start]) == cursor.id &&
+		compareDates(itemDate(sorted[start], field), cursor.date) == 0 {
+		start++
+	}
+	return sorted[start:]
+}
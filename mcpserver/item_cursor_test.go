@@ -0,0 +1,101 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// TestGetFeedItems_CursorPaging pages through a feed that gains new items and
+// loses one mid-scroll: offset paging then repeats items, while cursor paging
+// returns every original item exactly once, newest first.
+func TestGetFeedItems_CursorPaging(t *testing.T) {
+	day := func(d int) *time.Time { return new(time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)) }
+	item := func(guid string, d int) *gofeed.Item {
+		return &gofeed.Item{Title: guid, GUID: guid, PublishedParsed: day(d)}
+	}
+	// b and c share a date, so cursor order breaks the tie by stable ID.
+	feed := &model.FeedAndItemsResult{ID: "feed-1", Items: []*gofeed.Item{
+		item("a", 1), item("c", 2), item("b", 2), item("d", 3), item("e", 4), {Title: "undated", GUID: "undated"},
+	}}
+	session := newTestClientSession(t, &Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{"feed-1": feed}},
+	})
+	ctx := context.Background()
+
+	page := func(args map[string]any) (titles []string, nextCursor string, nextOffset *int) {
+		t.Helper()
+		args[keyID] = "feed-1"
+		args["limit"] = 2
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolGetSyndicationFeedItems, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("CallTool(%v): %v, %+v", args, err, result)
+		}
+		var meta struct {
+			NextCursor string `json:"next_cursor"`
+			NextOffset *int   `json:"next_offset"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &meta); err != nil {
+			t.Fatalf("unmarshal metadata: %v", err)
+		}
+		for _, block := range result.Content[1:] {
+			var output map[string]any
+			if err := json.Unmarshal([]byte(block.(*mcp.TextContent).Text), &output); err != nil {
+				t.Fatalf("unmarshal item: %v", err)
+			}
+			titles = append(titles, fmt.Sprint(output["title"]))
+		}
+		return titles, meta.NextCursor, meta.NextOffset
+	}
+
+	got, cursor, nextOffset := page(map[string]any{"cursorPaging": true})
+	if want := []string{"e", "d"}; !slices.Equal(got, want) || cursor == "" || nextOffset != nil {
+		t.Fatalf("first page = %v, cursor %q, next_offset %v; want %v with only a cursor", got, cursor, nextOffset, want)
+	}
+	_, _, offset := page(map[string]any{"order": orderNewest})
+
+	// Two newer items arrive, and d, the cursor's own item, is removed.
+	feed.Items = append([]*gofeed.Item{item("g", 6), item("f", 5)}, slices.DeleteFunc(slices.Clone(feed.Items), func(i *gofeed.Item) bool { return i.GUID == "d" })...)
+
+	if got, _, _ := page(map[string]any{"order": orderNewest, "offset": *offset}); !slices.Equal(got, []string{"e", "c"}) {
+		t.Errorf("offset page after the update = %v; want the shifted page [e c] that repeats e", got)
+	}
+	for cursor != "" {
+		var titles []string
+		titles, cursor, _ = page(map[string]any{"cursor": cursor})
+		got = append(got, titles...)
+	}
+	if want := []string{"e", "d", "b", "c", "a", "undated"}; !slices.Equal(got, want) {
+		t.Errorf("cursor pages = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeItemCursor(t *testing.T) {
+	dated := &gofeed.Item{GUID: "urn:post:1", PublishedParsed: new(time.Date(2024, 3, 1, 12, 30, 0, 5, time.UTC))}
+	undated := &gofeed.Item{GUID: "urn:post:2"}
+	for _, item := range []*gofeed.Item{dated, undated} {
+		cursor, err := decodeItemCursor(newItemCursor(item, dateFieldPublished))
+		if err != nil {
+			t.Fatalf("decodeItemCursor: %v", err)
+		}
+		if cursor.id != model.ItemStableID(item) || compareDates(cursor.date, item.PublishedParsed) != 0 || (cursor.date == nil) != (item.PublishedParsed == nil) {
+			t.Errorf("cursor for %s = %+v, want its stable ID and date", item.GUID, cursor)
+		}
+	}
+	if cursor, err := decodeItemCursor(""); cursor != nil || err != nil {
+		t.Errorf("decodeItemCursor(\"\") = %v, %v; want a nil cursor", cursor, err)
+	}
+	if _, err := decodeItemCursor("AQ"); err == nil {
+		t.Error("decodeItemCursor accepted a truncated cursor")
+	}
+}
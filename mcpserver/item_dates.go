@@ -0,0 +1,53 @@
+package mcpserver
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Item date fields that ordering and date filters can key on. Atom separates
+// an entry's first publication from its last update, and some feeds only fill
+// in <updated>.
+const (
+	dateFieldPublished = "published"
+	dateFieldUpdated   = "updated"
+)
+
+// itemDate returns the item's date for field: its updated date for
+// dateFieldUpdated, else its published date. Either falls back to the other
+// when the item lacks it, so update-only Atom entries still have a date. Nil
+// means the item has neither.
+func itemDate(item *gofeed.Item, field string) *time.Time {
+	if item == nil {
+		return nil
+	}
+	first, second := item.PublishedParsed, item.UpdatedParsed
+	if field == dateFieldUpdated {
+		first, second = second, first
+	}
+	if first != nil {
+		return first
+	}
+	return second
+}
+
+// compareItemDates orders a and b newest first by the date for field, with
+// undated items last.
+func compareItemDates(a, b *gofeed.Item, field string) int {
+	return compareDates(itemDate(a, field), itemDate(b, field))
+}
+
+// compareDates orders two item dates newest first, with nil (undated) last.
+func compareDates(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return b.Compare(*a)
+	}
+}
@@ -0,0 +1,85 @@
+package mcpserver
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// updateHeavyAtom has entries that only carry <updated>, mixed with entries
+// published long before their last update.
+const updateHeavyAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Wiki changes</title>
+  <entry><title>march-only</title><id>a</id><updated>2024-03-01T00:00:00Z</updated></entry>
+  <entry><title>old-but-edited</title><id>b</id><published>2023-01-01T00:00:00Z</published><updated>2024-04-01T00:00:00Z</updated></entry>
+  <entry><title>january-only</title><id>c</id><updated>2024-01-01T00:00:00Z</updated></entry>
+  <entry><title>february</title><id>d</id><published>2024-02-01T00:00:00Z</published></entry>
+  <entry><title>undated</title><id>e</id></entry>
+</feed>`
+
+func parseUpdateHeavyAtom(t *testing.T) []*gofeed.Item {
+	t.Helper()
+	feed, err := gofeed.NewParser().ParseString(updateHeavyAtom)
+	if err != nil {
+		t.Fatalf("parse atom: %v", err)
+	}
+	return feed.Items
+}
+
+func itemTitles(items []*gofeed.Item) []string {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+	return titles
+}
+
+func TestOrderItems_UpdatedOnlyAtom(t *testing.T) {
+	items := parseUpdateHeavyAtom(t)
+	tests := []struct {
+		order, field string
+		want         []string
+	}{
+		{orderNewest, dateFieldPublished, []string{"march-only", "february", "january-only", "old-but-edited", "undated"}},
+		{orderOldest, dateFieldPublished, []string{"undated", "old-but-edited", "january-only", "february", "march-only"}},
+		{orderNewest, dateFieldUpdated, []string{"old-but-edited", "march-only", "february", "january-only", "undated"}},
+	}
+	for _, tt := range tests {
+		if got := itemTitles(orderItems(items, tt.order, tt.field)); !slices.Equal(got, tt.want) {
+			t.Errorf("orderItems(%s, %s) = %v, want %v", tt.order, tt.field, got, tt.want)
+		}
+	}
+
+	sorted := slices.Clone(items)
+	sortItemsByDateField(sorted, dateFieldUpdated)
+	if got, want := itemTitles(sorted), tests[2].want; !slices.Equal(got, want) {
+		t.Errorf("sortItemsByDateField(updated) = %v, want %v", got, want)
+	}
+}
+
+func TestApplyFilters_DateField(t *testing.T) {
+	items := parseUpdateHeavyAtom(t)
+	since := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	// Update-only entries are filtered by their updated date; undated ones
+	// pass, as before.
+	published := itemTitles(ApplyFilters(items, &FilterParams{Since: &since}))
+	if want := []string{"march-only", "undated"}; !slices.Equal(published, want) {
+		t.Errorf("since by published = %v, want %v", published, want)
+	}
+
+	params, err := ParseURIParameters("feeds://feed/x/items?since=2024-02-15T00:00:00Z&date_field=updated")
+	if err != nil {
+		t.Fatalf("ParseURIParameters: %v", err)
+	}
+	updated := itemTitles(ApplyFilters(items, params))
+	if want := []string{"march-only", "old-but-edited", "undated"}; !slices.Equal(updated, want) {
+		t.Errorf("since by updated = %v, want %v", updated, want)
+	}
+	if got := CreateFilterSummary(len(items), len(updated), params).AppliedFilters["date_field"]; got != dateFieldUpdated {
+		t.Errorf("applied date_field = %v, want %q", got, dateFieldUpdated)
+	}
+}
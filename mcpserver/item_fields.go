@@ -0,0 +1,54 @@
+package mcpserver
+
+import (
+	"github.com/mmcdole/gofeed"
+)
+
+// itemFieldNames lists the item fields a tool's fields option can select.
+// Each is named after the gofeed.Item JSON key it copies, except source,
+// which the tool fills in itself.
+var itemFieldNames = []string{
+	keyTitle, "link", "published", "updated", keyDescription, "content",
+	"author", "guid", "categories", "enclosures", "image", valueSource,
+}
+
+// projectItem returns the selected fields of item, keyed as in the item's
+// JSON. Empty fields are left out, as gofeed.Item's omitempty tags do. source
+// is the value for the source field.
+func projectItem(item *gofeed.Item, fields []string, source string) map[string]any {
+	projected := make(map[string]any, len(fields))
+	set := func(field string, value any, present bool) {
+		if present {
+			projected[field] = value
+		}
+	}
+	for _, field := range fields {
+		switch field {
+		case keyTitle:
+			set(field, item.Title, item.Title != "")
+		case "link":
+			set(field, item.Link, item.Link != "")
+		case "published":
+			set(field, item.Published, item.Published != "")
+		case "updated":
+			set(field, item.Updated, item.Updated != "")
+		case keyDescription:
+			set(field, item.Description, item.Description != "")
+		case "content":
+			set(field, item.Content, item.Content != "")
+		case "author":
+			set(field, item.Author, item.Author != nil)
+		case "guid":
+			set(field, item.GUID, item.GUID != "")
+		case "categories":
+			set(field, item.Categories, len(item.Categories) > 0)
+		case "enclosures":
+			set(field, item.Enclosures, len(item.Enclosures) > 0)
+		case "image":
+			set(field, item.Image, item.Image != nil)
+		case valueSource:
+			set(field, source, source != "")
+		}
+	}
+	return projected
+}
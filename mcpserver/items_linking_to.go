@@ -0,0 +1,174 @@
+package mcpserver
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FindItemsLinkingToParams contains parameters for the find_items_linking_to
+// tool.
+type FindItemsLinkingToParams struct {
+	Domain string `json:"domain"`
+}
+
+// LinkingItem is an item found by find_items_linking_to, with the feed it was
+// found in. Links are the item's links to the domain: its own link and the
+// links in its content, in that order and without duplicates.
+type LinkingItem struct {
+	FeedID    string   `json:"feed_id"`
+	FeedTitle string   `json:"feed_title"`
+	Title     string   `json:"title"`
+	Link      string   `json:"link,omitempty"`
+	GUID      string   `json:"guid,omitempty"`
+	Published string   `json:"published,omitempty"`
+	Links     []string `json:"links"`
+}
+
+// ItemsLinkingToResult is the JSON body returned by the find_items_linking_to
+// tool. Domain is the normalized domain matched; FeedIDs lists each feed with
+// a match once, in feed order.
+type ItemsLinkingToResult struct {
+	Domain  string        `json:"domain"`
+	Matches []LinkingItem `json:"matches"`
+	FeedIDs []string      `json:"feed_ids"`
+}
+
+// addFindItemsLinkingToTool adds the find_items_linking_to tool
+func (s *Server) addFindItemsLinkingToTool(srv *mcp.Server) {
+	findItemsLinkingToTool := &mcp.Tool{
+		Name:        toolFindItemsLinkingTo,
+		Description: "Find items across all feeds that link to a domain, through the item's own link or an <a href> in its content or description, with the feed each was found in and the matching links; use to track coverage of a site or source. Subdomains match too.",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				"domain": {
+					Type:        typeString,
+					Description: "Domain to look for, e.g. example.com (a URL is accepted too). Case, a leading www., and the scheme are ignored, and subdomains such as blog.example.com match.",
+				},
+			},
+			Required: []string{"domain"},
+		},
+	}
+	mcp.AddTool(srv, findItemsLinkingToTool, func(ctx context.Context, req *mcp.CallToolRequest, args FindItemsLinkingToParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolFindItemsLinkingTo, args, func() (any, error) {
+			return s.findItemsLinkingTo(ctx, args)
+		})
+		return result, nil, err
+	})
+}
+
+// findItemsLinkingTo scans every feed for items linking to args.Domain. Feeds
+// that fail to load are skipped, as in find_item.
+func (s *Server) findItemsLinkingTo(ctx context.Context, args FindItemsLinkingToParams) (*ItemsLinkingToResult, error) {
+	feeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := normalizeDomain(args.Domain)
+	result := &ItemsLinkingToResult{Domain: domain, Matches: []LinkingItem{}, FeedIDs: []string{}}
+	for _, feed := range feeds {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feed.ID)
+		if err != nil {
+			continue
+		}
+		feedTitle := feedResult.Title
+		if feedTitle == "" && feedResult.Feed != nil {
+			feedTitle = feedResult.Feed.Title
+		}
+		found := false
+		for _, item := range feedResult.Items {
+			if item == nil {
+				continue
+			}
+			links := itemLinksToDomain(item, domain)
+			if len(links) == 0 {
+				continue
+			}
+			found = true
+			result.Matches = append(result.Matches, LinkingItem{
+				FeedID:    feed.ID,
+				FeedTitle: feedTitle,
+				Title:     item.Title,
+				Link:      item.Link,
+				GUID:      item.GUID,
+				Published: item.Published,
+				Links:     links,
+			})
+		}
+		if found {
+			result.FeedIDs = append(result.FeedIDs, feed.ID)
+		}
+	}
+	return result, nil
+}
+
+// itemLinksToDomain returns the item's links to domain: its own link, then
+// the <a href> links in its content and description, resolved against the
+// item link. Only absolute http(s) links count.
+func itemLinksToDomain(item *gofeed.Item, domain string) []string {
+	base, _ := url.Parse(item.Link)
+	var links []string
+	seen := make(map[string]bool)
+	add := func(raw string) {
+		resolved := resolveHTTPURL(base, raw)
+		if resolved == "" || seen[resolved] || !linksToDomain(resolved, domain) {
+			return
+		}
+		seen[resolved] = true
+		links = append(links, resolved)
+	}
+
+	if link := strings.TrimSpace(item.Link); link != "" {
+		add(link)
+	}
+	for _, body := range []string{item.Content, item.Description} {
+		if !strings.Contains(strings.ToLower(body), "<a") {
+			continue
+		}
+		for _, href := range findTagAttrs(body, "a", "href") {
+			add(href)
+		}
+	}
+	return links
+}
+
+// linksToDomain reports whether the absolute URL link is on domain or one of
+// its subdomains.
+func linksToDomain(link, domain string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := normalizeHost(u.Hostname())
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// normalizeDomain reduces a domain, or a URL, to the lowercase host name
+// find_items_linking_to compares: no scheme, port, path, or leading www.
+// It returns "" when there is no host.
+func normalizeDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	if !strings.Contains(domain, "://") {
+		domain = "https://" + domain
+	}
+	u, err := url.Parse(domain)
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(u.Hostname())
+}
+
+// normalizeHost lowercases host and drops a leading www. and a trailing dot.
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return strings.TrimPrefix(host, "www.")
+}
@@ -0,0 +1,108 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestFindItemsLinkingTo(t *testing.T) {
+	allFeeds := &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "news"}, {ID: "blog"}, {ID: "other"}, {ID: "broken"}}}
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"news": {ID: "news", Title: "News", Items: []*gofeed.Item{
+			{Title: "Direct", Link: "https://www.Example.com/story"},
+			{Title: "Cites it", Link: "https://news.test/a", Content: `<p>Per <a href="https://docs.example.com/report">the report</a> and <a href="https://elsewhere.test/">others</a>.</p>`},
+			{Title: "Lookalike", Link: "https://notexample.com/story", Content: `<a href="https://example.com.evil.test/">trap</a>`},
+		}},
+		"blog": {ID: "blog", Feed: &model.Feed{Title: "Blog"}, Items: []*gofeed.Item{
+			{Title: "Both", Link: "https://example.com/post", Description: `See <a href="/post">here</a>, <a href="http://example.com/other">there</a> and <a href="mailto:me@example.com">mail</a>`},
+		}},
+		"other": {ID: "other", Title: "Other", Items: []*gofeed.Item{
+			{Title: "Unrelated", Link: "https://other.test/x", Content: `<img src="https://example.com/pic.png">`},
+		}},
+	}}
+	s := &Server{allFeedsGetter: allFeeds, feedAndItemsGetter: getter}
+
+	result, err := s.findItemsLinkingTo(context.Background(), FindItemsLinkingToParams{Domain: "https://WWW.example.com/"})
+	if err != nil {
+		t.Fatalf("findItemsLinkingTo: %v", err)
+	}
+	if result.Domain != "example.com" {
+		t.Errorf("domain = %q, want example.com", result.Domain)
+	}
+	if !slices.Equal(result.FeedIDs, []string{"news", "blog"}) {
+		t.Errorf("feed_ids = %v, want [news blog]", result.FeedIDs)
+	}
+
+	want := []struct {
+		title, feedTitle string
+		links            []string
+	}{
+		{"Direct", "News", []string{"https://www.Example.com/story"}},
+		{"Cites it", "News", []string{"https://docs.example.com/report"}},
+		{"Both", "Blog", []string{"https://example.com/post", "http://example.com/other"}},
+	}
+	if len(result.Matches) != len(want) {
+		t.Fatalf("got %d matches (%+v), want %d", len(result.Matches), result.Matches, len(want))
+	}
+	for i, w := range want {
+		got := result.Matches[i]
+		if got.Title != w.title || got.FeedTitle != w.feedTitle || !slices.Equal(got.Links, w.links) {
+			t.Errorf("match %d = %+v, want %s from %s linking %v", i, got, w.title, w.feedTitle, w.links)
+		}
+	}
+
+	result, err = s.findItemsLinkingTo(context.Background(), FindItemsLinkingToParams{Domain: "nowhere.test"})
+	if err != nil || len(result.Matches) != 0 || len(result.FeedIDs) != 0 {
+		t.Errorf("no matches: %+v, %v", result, err)
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := map[string]string{
+		"example.com":                   "example.com",
+		" Example.COM. ":                "example.com",
+		"www.example.com":               "example.com",
+		"https://www.example.com:8443/": "example.com",
+		"blog.example.com/path":         "blog.example.com",
+		"https://":                      "",
+	}
+	for input, want := range tests {
+		if got := normalizeDomain(input); got != want {
+			t.Errorf("normalizeDomain(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFindItemsLinkingToTool(t *testing.T) {
+	session := buildTestServerSession(t, "feed", "https://example.com/feed", []*gofeed.Item{
+		{Title: "Linked", Link: "https://target.test/a"},
+		{Title: "Not linked", Link: "https://other.test/b"},
+	})
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolFindItemsLinkingTo,
+		Arguments: map[string]any{"domain": "target.test"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	var got ItemsLinkingToResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(got.Matches) != 1 || got.Matches[0].Title != "Linked" || got.Matches[0].FeedID != "feed" {
+		t.Errorf("matches = %+v, want only the linked item", got.Matches)
+	}
+
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolFindItemsLinkingTo, Arguments: map[string]any{"domain": ""}})
+	if err != nil || !result.IsError {
+		t.Fatalf("CallTool without a domain = %+v, %v; want a tool error", result, err)
+	}
+}
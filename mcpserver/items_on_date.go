@@ -0,0 +1,165 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// GetItemsOnDateParams contains parameters for the get_items_on_date tool.
+// Exactly one of FeedID and All is set.
+type GetItemsOnDateParams struct {
+	FeedID   string `json:"feedId,omitempty"`
+	All      bool   `json:"all,omitempty"`
+	Date     string `json:"date"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// DatedItem is an item published on the day get_items_on_date was asked
+// about. Published is its publish date in the requested time zone.
+type DatedItem struct {
+	FeedID    string `json:"feed_id"`
+	FeedTitle string `json:"feed_title"`
+	Title     string `json:"title"`
+	Link      string `json:"link,omitempty"`
+	GUID      string `json:"guid,omitempty"`
+	Published string `json:"published"`
+}
+
+// ItemsOnDateResult is the JSON body returned by the get_items_on_date tool.
+// Items are in publish order, oldest first.
+type ItemsOnDateResult struct {
+	Date     string      `json:"date"`
+	Timezone string      `json:"timezone"`
+	Items    []DatedItem `json:"items"`
+}
+
+// addItemsOnDateTool adds the get_items_on_date tool
+func (s *Server) addItemsOnDateTool(srv *mcp.Server) {
+	itemsOnDateTool := &mcp.Tool{
+		Name:        toolGetItemsOnDate,
+		Description: "List the items a feed (feedId) or every feed (all=true) published on one calendar day, oldest first, e.g. for a journal or archive of what was published on 2024-03-15. Items without a publish date are left out.",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+				"all": {
+					Type:        typeBoolean,
+					Description: "Search every feed instead of one",
+				},
+				"date": {
+					Type:        typeString,
+					Description: "Calendar day as YYYY-MM-DD",
+				},
+				"timezone": {
+					Type:        typeString,
+					Description: "IANA time zone the day is in, e.g. America/New_York (default: UTC)",
+				},
+			},
+			Required: []string{"date"},
+		},
+	}
+	mcp.AddTool(srv, itemsOnDateTool, func(ctx context.Context, req *mcp.CallToolRequest, args GetItemsOnDateParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.cachedToolResult(ctx, toolGetItemsOnDate, args, func() (any, error) {
+			return s.itemsOnDate(ctx, args)
+		})
+		return result, nil, err
+	})
+}
+
+// itemsOnDate collects the items published on args' day. With all=true,
+// feeds that fail to load are skipped, as in find_item; a single feed that
+// fails is an error.
+func (s *Server) itemsOnDate(ctx context.Context, args GetItemsOnDateParams) (*ItemsOnDateResult, error) {
+	start, end, err := args.day()
+	if err != nil {
+		return nil, err
+	}
+	feedIDs := []string{args.FeedID}
+	if args.All {
+		feeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
+		if err != nil {
+			return nil, err
+		}
+		feedIDs = feedIDs[:0]
+		for _, feed := range feeds {
+			feedIDs = append(feedIDs, feed.ID)
+		}
+	}
+
+	type datedItem struct {
+		DatedItem
+		at time.Time
+	}
+	var found []datedItem
+	for _, feedID := range feedIDs {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+		if err != nil {
+			if args.All {
+				continue
+			}
+			return nil, err
+		}
+		feedTitle := feedResult.Title
+		if feedTitle == "" && feedResult.Feed != nil {
+			feedTitle = feedResult.Feed.Title
+		}
+		for _, item := range feedResult.Items {
+			published := itemDate(item, dateFieldPublished)
+			if published == nil || published.Before(start) || !published.Before(end) {
+				continue
+			}
+			found = append(found, datedItem{
+				DatedItem: DatedItem{
+					FeedID:    feedID,
+					FeedTitle: feedTitle,
+					Title:     item.Title,
+					Link:      item.Link,
+					GUID:      item.GUID,
+					Published: published.In(start.Location()).Format(time.RFC3339),
+				},
+				at: *published,
+			})
+		}
+	}
+	slices.SortStableFunc(found, func(a, b datedItem) int { return a.at.Compare(b.at) })
+
+	result := &ItemsOnDateResult{Date: args.Date, Timezone: start.Location().String(), Items: make([]DatedItem, 0, len(found))}
+	for _, item := range found {
+		result.Items = append(result.Items, item.DatedItem)
+	}
+	return result, nil
+}
+
+// day returns the start of p's date in its time zone and the start of the
+// next day, so a day made longer or shorter by a DST change is covered
+// exactly.
+func (p GetItemsOnDateParams) day() (start, end time.Time, err error) {
+	const tool = toolGetItemsOnDate
+	timezone := cmp.Or(strings.TrimSpace(p.Timezone), "UTC")
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, model.CreateParameterError(tool, "timezone", fmt.Sprintf("unknown timezone %q", p.Timezone),
+			"Use an IANA time zone name such as UTC or Europe/Berlin")
+	}
+	date, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(p.Date), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, model.CreateParameterError(tool, "date", fmt.Sprintf("invalid date %q", p.Date),
+			"Use a calendar day as YYYY-MM-DD, such as 2024-03-15")
+	}
+	return date, date.AddDate(0, 0, 1), nil
+}
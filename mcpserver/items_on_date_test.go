@@ -0,0 +1,103 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestItemsOnDate(t *testing.T) {
+	at := func(value string) *time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &parsed
+	}
+	// March 15, 2024 in New York (UTC-4) runs from 04:00 UTC on the 15th to
+	// 04:00 UTC on the 16th.
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"news": {ID: "news", Title: "News", Items: []*gofeed.Item{
+			{Title: "Late on the 14th", PublishedParsed: at("2024-03-15T03:59:59Z")},
+			{Title: "Evening of the 15th", PublishedParsed: at("2024-03-16T01:30:00Z")},
+			{Title: "Midnight starting the 15th", PublishedParsed: at("2024-03-15T04:00:00Z")},
+			{Title: "Midnight starting the 16th", PublishedParsed: at("2024-03-16T04:00:00Z")},
+			{Title: "Undated"},
+			{Title: "Only updated", UpdatedParsed: at("2024-03-15T12:00:00Z")},
+		}},
+		"blog": {ID: "blog", Feed: &model.Feed{Title: "Blog"}, Items: []*gofeed.Item{
+			{Title: "Blog post", PublishedParsed: at("2024-03-15T10:00:00-04:00")},
+		}},
+	}}
+	s := &Server{
+		allFeedsGetter:     &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "news"}, {ID: "blog"}, {ID: "broken"}}},
+		feedAndItemsGetter: getter,
+	}
+
+	titles := func(result *ItemsOnDateResult) []string {
+		var got []string
+		for _, item := range result.Items {
+			got = append(got, item.Title)
+		}
+		return got
+	}
+
+	result, err := s.itemsOnDate(context.Background(), GetItemsOnDateParams{FeedID: "news", Date: "2024-03-15", Timezone: "America/New_York"})
+	if err != nil {
+		t.Fatalf("itemsOnDate: %v", err)
+	}
+	want := []string{"Midnight starting the 15th", "Only updated", "Evening of the 15th"}
+	if got := titles(result); !slices.Equal(got, want) {
+		t.Errorf("New York items = %v, want %v", got, want)
+	}
+	if result.Timezone != "America/New_York" || result.Items[0].Published != "2024-03-15T00:00:00-04:00" {
+		t.Errorf("result = %+v, want dates in New York time", result)
+	}
+
+	// The same instants fall on different days in UTC.
+	result, err = s.itemsOnDate(context.Background(), GetItemsOnDateParams{FeedID: "news", Date: "2024-03-15"})
+	if err != nil {
+		t.Fatalf("itemsOnDate: %v", err)
+	}
+	want = []string{"Late on the 14th", "Midnight starting the 15th", "Only updated"}
+	if got := titles(result); !slices.Equal(got, want) || result.Timezone != "UTC" {
+		t.Errorf("UTC items = %v in %s, want %v in UTC", got, result.Timezone, want)
+	}
+
+	// all=true merges every feed in publish order and skips failing feeds.
+	result, err = s.itemsOnDate(context.Background(), GetItemsOnDateParams{All: true, Date: "2024-03-15", Timezone: "America/New_York"})
+	if err != nil {
+		t.Fatalf("itemsOnDate(all): %v", err)
+	}
+	want = []string{"Midnight starting the 15th", "Only updated", "Blog post", "Evening of the 15th"}
+	if got := titles(result); !slices.Equal(got, want) {
+		t.Errorf("all items = %v, want %v", got, want)
+	}
+	if blog := result.Items[2]; blog.FeedID != "blog" || blog.FeedTitle != "Blog" {
+		t.Errorf("blog item = %+v, want its feed ID and title", blog)
+	}
+}
+
+func TestItemsOnDateTool(t *testing.T) {
+	session, _ := toolCacheSession(t, 0)
+	var result ItemsOnDateResult
+	text := callToolText(t, session, toolGetItemsOnDate, map[string]any{"all": true, "date": "2024-03-15"})
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.Date != "2024-03-15" || result.Items == nil {
+		t.Errorf("result = %s, want an empty item list for the date", text)
+	}
+
+	bad, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetItemsOnDate, Arguments: map[string]any{keyFeedID: "a", "date": "2024-02-30"}})
+	if err != nil || !bad.IsError {
+		t.Errorf("CallTool with an invalid date = %+v, %v; want a tool error", bad, err)
+	}
+}
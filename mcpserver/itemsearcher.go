@@ -0,0 +1,15 @@
+package mcpserver
+
+import (
+	"context"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ItemSearcher answers item search queries from an index, so the search filter
+// on feed resources needn't scan every item. ok is false when the index can't
+// serve the query (e.g. it is disabled or the query is too short), and the
+// caller falls back to scanning.
+type ItemSearcher interface {
+	SearchFeedItems(ctx context.Context, id, query string) (items []*gofeed.Item, ok bool, err error)
+}
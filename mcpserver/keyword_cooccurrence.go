@@ -0,0 +1,209 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxCooccurrenceKeywords caps the keywords keyword_cooccurrence compares,
+// which keeps the matrix readable.
+const maxCooccurrenceKeywords = 20
+
+// KeywordCooccurrenceParams contains parameters for the keyword_cooccurrence
+// tool.
+type KeywordCooccurrenceParams struct {
+	Keywords  []string `json:"keywords"`
+	Timeframe string   `json:"timeframe,omitempty"` // Default: 7d
+}
+
+// KeywordPair is two keywords and the number of items mentioning both.
+type KeywordPair struct {
+	Keywords [2]string `json:"keywords"`
+	Count    int       `json:"count"`
+}
+
+// KeywordCooccurrenceResult is the JSON body returned by the
+// keyword_cooccurrence tool. Matrix[i][j] counts the items mentioning both
+// Keywords[i] and Keywords[j]; the diagonal counts the items mentioning each
+// keyword at all. Pairs lists the pairs seen together at least once, most
+// frequent first.
+type KeywordCooccurrenceResult struct {
+	Keywords     []string      `json:"keywords"`
+	Timeframe    string        `json:"timeframe"`
+	Since        string        `json:"since"`
+	ItemsScanned int           `json:"items_scanned"`
+	Matrix       [][]int       `json:"matrix"`
+	Pairs        []KeywordPair `json:"pairs"`
+}
+
+// addKeywordCooccurrenceTool adds the keyword_cooccurrence tool
+func (s *Server) addKeywordCooccurrenceTool(srv *mcp.Server) {
+	keywordCooccurrenceTool := &mcp.Tool{
+		Name:        toolKeywordCooccurrence,
+		Description: "Count how often pairs of keywords appear in the same item across all feeds within a timeframe, as a co-occurrence matrix plus the pairs seen together, most frequent first; use to find related topics. Keywords match case-insensitively as whole words or phrases, plurals included, in an item's title, description, or content. Items without a date are left out.",
+		InputSchema: &jsonschema.Schema{
+			Type: typeObject,
+			Properties: map[string]*jsonschema.Schema{
+				"keywords": {
+					Type:        "array",
+					Description: "Two or more keywords or phrases to compare (up to 20)",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				keyTimeframe: {
+					Type:        typeString,
+					Description: "How far back to look, e.g. 24h, 7d, 30d, or a Go duration such as 36h (default: 7d)",
+				},
+			},
+			Required: []string{"keywords"},
+		},
+	}
+	mcp.AddTool(srv, keywordCooccurrenceTool, func(ctx context.Context, req *mcp.CallToolRequest, args KeywordCooccurrenceParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		// Not cached: the window moves with the current time.
+		result, err := s.keywordCooccurrence(ctx, args, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// keywordCooccurrence counts keyword co-occurrence in the items of every feed
+// dated within args.Timeframe of now. Feeds that fail to load are skipped, as
+// in find_item.
+func (s *Server) keywordCooccurrence(ctx context.Context, args KeywordCooccurrenceParams, now time.Time) (*KeywordCooccurrenceResult, error) {
+	timeframe := cmp.Or(strings.TrimSpace(args.Timeframe), timeframe7d)
+	window, err := parseDuration(timeframe)
+	if err != nil {
+		return nil, err
+	}
+	feeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := cooccurrenceKeywords(args.Keywords)
+	since := now.Add(-window)
+	result := &KeywordCooccurrenceResult{
+		Keywords:  keywords,
+		Timeframe: timeframe,
+		Since:     since.Format(time.RFC3339),
+		Matrix:    make([][]int, len(keywords)),
+	}
+	for i := range result.Matrix {
+		result.Matrix[i] = make([]int, len(keywords))
+	}
+
+	for _, feed := range feeds {
+		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feed.ID)
+		if err != nil {
+			continue
+		}
+		for _, item := range feedResult.Items {
+			date := itemDate(item, dateFieldPublished)
+			if date == nil || date.Before(since) {
+				continue
+			}
+			result.ItemsScanned++
+			countCooccurrence(result.Matrix, keywords, item)
+		}
+	}
+
+	result.Pairs = []KeywordPair{}
+	for i := range keywords {
+		for j := i + 1; j < len(keywords); j++ {
+			if count := result.Matrix[i][j]; count > 0 {
+				result.Pairs = append(result.Pairs, KeywordPair{Keywords: [2]string{keywords[i], keywords[j]}, Count: count})
+			}
+		}
+	}
+	// Stable, so equal counts keep keyword order.
+	slices.SortStableFunc(result.Pairs, func(a, b KeywordPair) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return result, nil
+}
+
+// countCooccurrence adds the item's mentions to matrix: one to the diagonal
+// entry of each keyword its title, description, or content mentions and one
+// to each pair of them.
+func countCooccurrence(matrix [][]int, keywords []string, item *gofeed.Item) {
+	text := strings.ToLower(item.Title + "\n" + item.Description + "\n" + item.Content)
+	var mentioned []int
+	for i, keyword := range keywords {
+		if containsWord(text, keyword) {
+			mentioned = append(mentioned, i)
+		}
+	}
+	for _, i := range mentioned {
+		for _, j := range mentioned {
+			matrix[i][j]++
+		}
+	}
+}
+
+// containsWord reports whether word occurs in text as a whole word or
+// phrase, optionally pluralized with "s" or "es", and not inside a longer
+// word: "chip" matches "chips" but "ai" doesn't match "said". Both are
+// lowercase.
+func containsWord(text, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		if !isWordRune(before) {
+			rest := text[end:]
+			for _, suffix := range []string{"", "s", "es"} {
+				if after, ok := strings.CutPrefix(rest, suffix); ok {
+					if r, _ := utf8.DecodeRuneInString(after); !isWordRune(r) {
+						return true
+					}
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+// isWordRune reports whether r is part of a word. utf8.RuneError, returned
+// at either end of the text, is not.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// cooccurrenceKeywords lowercases and trims keywords, dropping repeats, so
+// the matrix has one row per distinct keyword in the order given.
+func cooccurrenceKeywords(keywords []string) []string {
+	var normalized []string
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && !slices.Contains(normalized, keyword) {
+			normalized = append(normalized, keyword)
+		}
+	}
+	return normalized
+}
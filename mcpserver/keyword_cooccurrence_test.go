@@ -0,0 +1,130 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestKeywordCooccurrence(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time { return new(now.Add(-d)) }
+	allFeeds := &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "tech"}, {ID: "policy"}, {ID: "broken"}}}
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"tech": {ID: "tech", Items: []*gofeed.Item{
+			{Title: "AI chips get faster", Description: "New GPUs", PublishedParsed: ago(time.Hour)},
+			{Title: "Chip exports", Content: "<p>Rules on <b>AI</b> chips and GPUs</p>", PublishedParsed: ago(2 * time.Hour)},
+			{Title: "GPU prices fall", UpdatedParsed: ago(3 * time.Hour)},
+			{Title: "Old AI chips story", PublishedParsed: ago(30 * 24 * time.Hour)}, // outside the timeframe
+			{Title: "Undated AI chips story"}, // undated
+		}},
+		"policy": {ID: "policy", Items: []*gofeed.Item{
+			{Title: "AI regulation passes", Description: "Lawmakers on ai safety", PublishedParsed: ago(24 * time.Hour)},
+			{Title: "Weather", Description: "Forecasters said rain is likely", PublishedParsed: ago(time.Hour)}, // "said" isn't "ai"
+		}},
+	}}
+	s := &Server{allFeedsGetter: allFeeds, feedAndItemsGetter: getter}
+
+	result, err := s.keywordCooccurrence(context.Background(), KeywordCooccurrenceParams{Keywords: []string{" AI ", "chip", "GPU", "ai", "regulation"}}, now)
+	if err != nil {
+		t.Fatalf("keywordCooccurrence: %v", err)
+	}
+
+	if want := []string{"ai", "chip", "gpu", "regulation"}; !slices.Equal(result.Keywords, want) {
+		t.Errorf("keywords = %v, want %v", result.Keywords, want)
+	}
+	if result.Timeframe != timeframe7d || result.Since != "2024-06-03T12:00:00Z" {
+		t.Errorf("timeframe = %q, since = %q, want 7d from 2024-06-03T12:00:00Z", result.Timeframe, result.Since)
+	}
+	if result.ItemsScanned != 5 {
+		t.Errorf("items_scanned = %d, want 5", result.ItemsScanned)
+	}
+	wantMatrix := [][]int{
+		//  ai chip gpu regulation
+		{3, 2, 2, 1}, // ai
+		{2, 2, 2, 0}, // chip
+		{2, 2, 3, 0}, // gpu
+		{1, 0, 0, 1}, // regulation
+	}
+	if !reflect.DeepEqual(result.Matrix, wantMatrix) {
+		t.Errorf("matrix = %v, want %v", result.Matrix, wantMatrix)
+	}
+	wantPairs := []KeywordPair{
+		{Keywords: [2]string{"ai", "chip"}, Count: 2},
+		{Keywords: [2]string{"ai", "gpu"}, Count: 2},
+		{Keywords: [2]string{"chip", "gpu"}, Count: 2},
+		{Keywords: [2]string{"ai", "regulation"}, Count: 1},
+	}
+	if !reflect.DeepEqual(result.Pairs, wantPairs) {
+		t.Errorf("pairs = %+v, want %+v", result.Pairs, wantPairs)
+	}
+
+	// A wider timeframe takes in the older item.
+	result, err = s.keywordCooccurrence(context.Background(), KeywordCooccurrenceParams{Keywords: []string{"ai", "chip"}, Timeframe: "90d"}, now)
+	if err != nil {
+		t.Fatalf("keywordCooccurrence: %v", err)
+	}
+	if result.ItemsScanned != 6 || result.Matrix[0][1] != 3 {
+		t.Errorf("90d: items_scanned = %d, ai+chip = %d, want 6 and 3", result.ItemsScanned, result.Matrix[0][1])
+	}
+}
+
+func TestContainsWord(t *testing.T) {
+	tests := []struct {
+		text, word string
+		want       bool
+	}{
+		{"ai chips", "ai", true},
+		{"she said so", "ai", false},
+		{"new gpus.", "gpu", true},
+		{"two taxes", "tax", true},
+		{"taxing times", "tax", false},
+		{"(machine learning)", "machine learning", true},
+		{"machine learnings", "machine learning", true},
+		{"paid, then ai", "ai", true}, // a later whole-word match
+		{"", "ai", false},
+	}
+	for _, tt := range tests {
+		if got := containsWord(tt.text, tt.word); got != tt.want {
+			t.Errorf("containsWord(%q, %q) = %v, want %v", tt.text, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestKeywordCooccurrenceTool(t *testing.T) {
+	session := buildTestServerSession(t, "feed", "https://example.com/feed", []*gofeed.Item{
+		{Title: "Rust and Go compared", PublishedParsed: new(time.Now().Add(-time.Hour))},
+		{Title: "Go generics", PublishedParsed: new(time.Now().Add(-2 * time.Hour))},
+	})
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolKeywordCooccurrence,
+		Arguments: map[string]any{"keywords": []string{"go", "rust"}, "timeframe": "24h"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	var got KeywordCooccurrenceResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if !reflect.DeepEqual(got.Matrix, [][]int{{2, 1}, {1, 1}}) {
+		t.Errorf("matrix = %v, want [[2 1] [1 1]]", got.Matrix)
+	}
+
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolKeywordCooccurrence,
+		Arguments: map[string]any{"keywords": []string{"go"}},
+	})
+	if err != nil || !result.IsError {
+		t.Fatalf("CallTool with one keyword = %+v, %v; want a tool error", result, err)
+	}
+}
@@ -0,0 +1,56 @@
+package mcpserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mergeExportFormats are the export formats merge_feeds can return a merge
+// in. json is left out: the merge's own JSON result already is one.
+var mergeExportFormats = []string{formatRSS, formatAtom, formatOPML, formatCSV}
+
+// exportMergedFeed formats a merged feed with the export_feed_data formatters.
+// RSS and Atom documents carry the merge's title and description and its
+// items in merge order, as does CSV, one row per item with its source feed.
+// OPML lists the merged feeds.
+func exportMergedFeed(merged *MergedFeedResult, format string) (string, error) {
+	switch format {
+	case formatRSS:
+		return exportAsRSS(merged.itemRuns(), merged.Title, merged.Description)
+	case formatAtom:
+		return exportAsAtom(merged.itemRuns(), merged.Title, merged.Description)
+	case formatOPML:
+		return exportAsOPML(merged.feeds, merged.Title)
+	case formatCSV:
+		return exportAsCSV(merged.itemRuns())
+	default:
+		return "", model.CreateParameterError(toolMergeFeeds, keyFormat, fmt.Sprintf("unsupported export format: %s", format),
+			"Use one of: "+strings.Join(mergeExportFormats, ", "))
+	}
+}
+
+// itemRuns splits the merged items into runs of consecutive items from the
+// same feed, each a copy of that feed's result holding just the run. The
+// formatters walk feeds and then their items, so this keeps the merge order
+// while still telling them where each item came from.
+func (m *MergedFeedResult) itemRuns() []*FeedAndItemsResult {
+	var runs []*FeedAndItemsResult
+	var last *FeedAndItemsResult
+	for _, item := range m.Items {
+		source := m.sources[item]
+		if source == nil {
+			source = &FeedAndItemsResult{}
+		}
+		if len(runs) == 0 || source != last {
+			run := *source
+			run.Items = nil
+			runs = append(runs, &run)
+			last = source
+		}
+		run := runs[len(runs)-1]
+		run.Items = append(run.Items, item)
+	}
+	return runs
+}
@@ -0,0 +1,94 @@
+package mcpserver
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestExportMergedFeed(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"alpha": {ID: "alpha", Title: "Alpha", PublicURL: "https://alpha.example.com/feed", Feed: &model.Feed{Title: "Alpha"}, Items: sourceItems("alpha", 2, now)},
+		"beta":  {ID: "beta", Title: "Beta", PublicURL: "https://beta.example.com/feed", Feed: &model.Feed{Title: "Beta"}, Items: sourceItems("beta", 2, now.Add(-30*time.Minute))},
+	}}
+	s := &Server{feedAndItemsGetter: getter}
+	merged, err := s.mergeFeeds(context.Background(), MergeFeedsParams{FeedIDs: []string{"alpha", "beta"}, Title: "Alpha & Beta"})
+	if err != nil {
+		t.Fatalf("mergeFeeds: %v", err)
+	}
+
+	exported, err := exportMergedFeed(merged, formatRSS)
+	if err != nil {
+		t.Fatalf("exportMergedFeed(rss): %v", err)
+	}
+	feed, err := gofeed.NewParser().ParseString(exported)
+	if err != nil {
+		t.Fatalf("merged RSS doesn't parse: %v\n%s", err, exported)
+	}
+	if feed.FeedType != "rss" || feed.Title != "Alpha & Beta" {
+		t.Errorf("feed type %q, title %q; want rss titled Alpha & Beta", feed.FeedType, feed.Title)
+	}
+	var titles []string
+	for _, item := range feed.Items {
+		titles = append(titles, item.Title)
+	}
+	if want := []string{"alpha-0", "beta-0", "alpha-1", "beta-1"}; !slices.Equal(titles, want) {
+		t.Errorf("RSS items = %v, want the merge order %v", titles, want)
+	}
+
+	exported, err = exportMergedFeed(merged, formatAtom)
+	if err != nil {
+		t.Fatalf("exportMergedFeed(atom): %v", err)
+	}
+	if feed, err := gofeed.NewParser().ParseString(exported); err != nil || feed.FeedType != "atom" || len(feed.Items) != 4 {
+		t.Errorf("merged Atom: %v, %+v", err, feed)
+	}
+
+	exported, err = exportMergedFeed(merged, formatOPML)
+	if err != nil {
+		t.Fatalf("exportMergedFeed(opml): %v", err)
+	}
+	urls, err := model.ExtractFeedURLsFromOPML([]byte(exported))
+	if err != nil {
+		t.Fatalf("merged OPML doesn't parse: %v", err)
+	}
+	if !slices.Equal(urls, []string{"https://alpha.example.com/feed", "https://beta.example.com/feed"}) {
+		t.Errorf("OPML feeds = %v, want both merged feeds", urls)
+	}
+
+	exported, err = exportMergedFeed(merged, formatCSV)
+	if err != nil {
+		t.Fatalf("exportMergedFeed(csv): %v", err)
+	}
+	rows := strings.Split(strings.TrimSpace(exported), "\n")
+	if len(rows) != 5 || !strings.HasPrefix(rows[1], "Alpha,") || !strings.HasPrefix(rows[2], "Beta,") {
+		t.Errorf("CSV rows = %q, want a header and one row per item with its source", rows)
+	}
+}
+
+func TestMergeFeedsTool_Format(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(3))
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolMergeFeeds,
+		Arguments: map[string]any{keyFeedIDs: []string{"feed-1"}, keyFormat: formatRSS, "maxItems": 2},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	feed, err := gofeed.NewParser().ParseString(result.Content[0].(*mcp.TextContent).Text)
+	if err != nil {
+		t.Fatalf("merge_feeds format=rss didn't return RSS: %v", err)
+	}
+	if len(feed.Items) != 2 || feed.Title != "Merged Feed (1 sources)" {
+		t.Errorf("got %d items titled %q, want 2 items and the merge title", len(feed.Items), feed.Title)
+	}
+}
@@ -0,0 +1,275 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// sourceItems returns n items titled "<prefix>-<i>", item 0 being the newest.
+func sourceItems(prefix string, n int, newest time.Time) []*gofeed.Item {
+	items := make([]*gofeed.Item, 0, n)
+	for i := range n {
+		published := newest.Add(-time.Duration(i) * time.Hour)
+		items = append(items, &gofeed.Item{
+			Title:           fmt.Sprintf("%s-%d", prefix, i),
+			Link:            fmt.Sprintf("https://%s.example.com/%d", prefix, i),
+			PublishedParsed: &published,
+		})
+	}
+	return items
+}
+
+func TestMergeFeeds_MaxPerSource(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// The prolific feed publishes 20 items, all newer than the quiet feeds'.
+	prolific := sourceItems("prolific", 20, now)
+	getter := &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"prolific": {ID: "prolific", Feed: &model.Feed{Title: "Prolific"}, Items: prolific},
+		"quiet":    {ID: "quiet", Feed: &model.Feed{Title: "Quiet"}, Items: sourceItems("quiet", 3, now.Add(-48*time.Hour))},
+		"rare":     {ID: "rare", Feed: &model.Feed{Title: "Rare"}, Items: sourceItems("rare", 1, now.Add(-72*time.Hour))},
+	}}
+	s := &Server{feedAndItemsGetter: getter}
+	feedIDs := []string{"prolific", "quiet", "rare"}
+
+	countBySource := func(result *MergedFeedResult) map[string]int {
+		counts := make(map[string]int)
+		for _, item := range result.Items {
+			for _, prefix := range feedIDs {
+				if strings.HasPrefix(item.Title, prefix+"-") {
+					counts[prefix]++
+				}
+			}
+		}
+		return counts
+	}
+
+	// Without a per-source cap, the prolific feed fills the whole page.
+	result, err := s.mergeFeeds(context.Background(), MergeFeedsParams{FeedIDs: feedIDs, MaxItems: 6})
+	if err != nil {
+		t.Fatalf("mergeFeeds: %v", err)
+	}
+	if got := countBySource(result); got["prolific"] != 6 {
+		t.Fatalf("uncapped merge: counts = %v, want 6 prolific", got)
+	}
+
+	// With maxPerSource, every source is represented.
+	result, err = s.mergeFeeds(context.Background(), MergeFeedsParams{FeedIDs: feedIDs, MaxItems: 6, MaxPerSource: 2})
+	if err != nil {
+		t.Fatalf("mergeFeeds: %v", err)
+	}
+	got := countBySource(result)
+	if got["prolific"] != 2 || got["quiet"] != 2 || got["rare"] != 1 {
+		t.Errorf("capped merge: counts = %v, want prolific=2 quiet=2 rare=1", got)
+	}
+	if result.TotalItems != 5 {
+		t.Errorf("TotalItems = %d, want 5", result.TotalItems)
+	}
+	// Each source contributes its newest items, and the result stays date-sorted.
+	if result.Items[0].Title != "prolific-0" || result.Items[1].Title != "prolific-1" || result.Items[2].Title != "quiet-0" {
+		t.Errorf("unexpected order: %s, %s, %s", result.Items[0].Title, result.Items[1].Title, result.Items[2].Title)
+	}
+
+	// The global cap still applies after the per-source cap.
+	result, err = s.mergeFeeds(context.Background(), MergeFeedsParams{FeedIDs: feedIDs, MaxItems: 3, MaxPerSource: 2})
+	if err != nil {
+		t.Fatalf("mergeFeeds: %v", err)
+	}
+	if result.TotalItems != 3 {
+		t.Errorf("TotalItems = %d, want 3", result.TotalItems)
+	}
+
+	// The source's cached item order is left untouched.
+	if prolific[0].Title != "prolific-0" || prolific[19].Title != "prolific-19" {
+		t.Error("mergeFeeds reordered the source feed's items")
+	}
+}
+
+func TestSortItems_EqualKeysDeterministic(t *testing.T) {
+	published := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	earlier := published.Add(-time.Hour)
+	newItems := func() []*gofeed.Item {
+		return []*gofeed.Item{
+			{Title: "Charlie", Link: "https://c.example.com/1", PublishedParsed: &published, Custom: map[string]string{valueSource: "Feed B"}},
+			{Title: "Alpha", Link: "https://a.example.com/2", PublishedParsed: &published, Custom: map[string]string{valueSource: "Feed A"}},
+			{Title: "Bravo", Link: "https://b.example.com/1", PublishedParsed: &earlier, Custom: map[string]string{valueSource: "Feed A"}},
+			{Title: "Alpha", Link: "https://a.example.com/1", PublishedParsed: &published, Custom: map[string]string{valueSource: "Feed B"}},
+			{Title: "Bravo", Link: "https://b.example.com/2", PublishedParsed: &published, Custom: map[string]string{valueSource: "Feed A"}},
+		}
+	}
+	links := func(items []*gofeed.Item) []string {
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Link)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name string
+		sort func([]*gofeed.Item)
+		want []string
+	}{
+		{"date", sortItemsByDate, []string{"https://a.example.com/1", "https://a.example.com/2", "https://b.example.com/2", "https://c.example.com/1", "https://b.example.com/1"}},
+		{"title", sortItemsByTitle, []string{"https://a.example.com/1", "https://a.example.com/2", "https://b.example.com/2", "https://b.example.com/1", "https://c.example.com/1"}},
+		{"source", sortItemsBySource, []string{"https://a.example.com/2", "https://b.example.com/2", "https://b.example.com/1", "https://a.example.com/1", "https://c.example.com/1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The same items gathered in any order sort identically
+			for i := range 20 {
+				items := newItems()
+				if i > 0 {
+					shift := i % len(items)
+					items = append(items[shift:], items[:shift]...)
+					if i%2 == 1 {
+						slices.Reverse(items)
+					}
+				}
+				tt.sort(items)
+				if got := links(items); !slices.Equal(got, tt.want) {
+					t.Fatalf("sort %d = %v, want %v", i, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeduplicateItems_AcrossFeeds(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "Rates rise", GUID: "urn:feed-a:1", Link: "https://news.example.com/rates"},
+		// The same article syndicated by another feed, under its own GUID.
+		{Title: "Rates Rise!", GUID: "urn:feed-b:99", Link: "http://www.news.example.com/rates/?utm_source=rss"},
+		{Title: "Digest", GUID: "urn:feed-a:2"},
+		{Title: "digest", GUID: "urn:feed-b:3"},
+		{Title: "Other", GUID: "urn:feed-a:1", Link: "https://news.example.com/other"},
+		{Description: "no identity"},
+		{Description: "no identity"},
+	}
+	var titles []string
+	for _, item := range deduplicateItems(items, "", 0) {
+		titles = append(titles, item.Title)
+	}
+	if got, want := strings.Join(titles, ","), "Rates rise,Digest,Other,,"; got != want {
+		t.Errorf("deduplicated titles = %q, want %q", got, want)
+	}
+}
+
+func TestMergeFeeds_SeenCursor(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	feeds := map[string]*model.FeedAndItemsResult{
+		"a": {ID: "a", Feed: &model.Feed{Title: "A"}, Items: sourceItems("a", 3, now)},
+		"b": {ID: "b", Feed: &model.Feed{Title: "B"}, Items: sourceItems("b", 2, now.Add(-30*time.Minute))},
+	}
+	s := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: feeds}}
+	ctx := context.Background()
+	titles := func(result *MergedFeedResult) []string {
+		out := make([]string, len(result.Items))
+		for i, item := range result.Items {
+			out[i] = item.Title
+		}
+		return out
+	}
+
+	first, err := s.mergeFeeds(ctx, MergeFeedsParams{FeedIDs: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if len(first.Items) != 5 || first.Cursor == "" {
+		t.Fatalf("first poll = %v with cursor %q, want 5 items and a cursor", titles(first), first.Cursor)
+	}
+
+	// Both feeds publish something new before the second poll.
+	feeds["a"].Items = append(sourceItems("a-new", 1, now.Add(time.Hour)), feeds["a"].Items...)
+	feeds["b"].Items = append(sourceItems("b-new", 1, now.Add(2*time.Hour)), feeds["b"].Items...)
+
+	second, err := s.mergeFeeds(ctx, MergeFeedsParams{FeedIDs: []string{"a", "b"}, Cursor: first.Cursor})
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if got := titles(second); strings.Join(got, ",") != "b-new-0,a-new-0" {
+		t.Errorf("second poll = %v, want only the two new items", got)
+	}
+
+	// The new cursor covers both polls.
+	third, err := s.mergeFeeds(ctx, MergeFeedsParams{FeedIDs: []string{"a", "b"}, Cursor: second.Cursor})
+	if err != nil {
+		t.Fatalf("third poll: %v", err)
+	}
+	if len(third.Items) != 0 {
+		t.Errorf("third poll = %v, want nothing new", titles(third))
+	}
+
+	// seenSince drops items published at or before it.
+	since, err := s.mergeFeeds(ctx, MergeFeedsParams{FeedIDs: []string{"a", "b"}, SeenSince: now.Format(time.RFC3339)})
+	if err != nil {
+		t.Fatalf("seenSince poll: %v", err)
+	}
+	if got := titles(since); strings.Join(got, ",") != "b-new-0,a-new-0" {
+		t.Errorf("seenSince poll = %v, want only items after it", got)
+	}
+
+	for _, args := range []MergeFeedsParams{
+		{FeedIDs: []string{"a"}, Cursor: "not a cursor"},
+		{FeedIDs: []string{"a"}, SeenSince: "yesterday"},
+	} {
+		_, err := s.mergeFeeds(ctx, args)
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeValidation {
+			t.Errorf("mergeFeeds(%+v) error = %v, want a validation error", args, err)
+		}
+	}
+}
+
+func TestMergeFeedsTool_Fields(t *testing.T) {
+	items := sourceItems("news", 2, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	for _, item := range items {
+		item.Published = item.PublishedParsed.Format(time.RFC1123Z)
+		item.Description = strings.Repeat("long description ", 50)
+		item.GUID = item.Link
+	}
+	session := buildTestServerSession(t, "news", "https://example.com/feed", items)
+
+	text := callToolText(t, session, toolMergeFeeds, map[string]any{
+		keyFeedIDs: []string{"news"},
+		"fields":   []string{keyTitle, "link", "published", valueSource},
+	})
+	var merged struct {
+		TotalItems int              `json:"total_items"`
+		Items      []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(text), &merged); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if merged.TotalItems != 2 || len(merged.Items) != 2 {
+		t.Fatalf("got %d items (total_items %d), want 2", len(merged.Items), merged.TotalItems)
+	}
+	for _, item := range merged.Items {
+		keys := slices.Sorted(maps.Keys(item))
+		if want := []string{"link", "published", valueSource, keyTitle}; !slices.Equal(keys, want) {
+			t.Errorf("item fields = %v, want %v", keys, want)
+		}
+		if item[valueSource] != "Template Test Feed" {
+			t.Errorf("source = %v, want the source feed's title", item[valueSource])
+		}
+	}
+	if merged.Items[0][keyTitle] != "news-0" || merged.Items[0]["link"] != items[0].Link {
+		t.Errorf("first item = %v, want news-0", merged.Items[0])
+	}
+
+	// Without fields, items are returned whole.
+	full := callToolText(t, session, toolMergeFeeds, map[string]any{keyFeedIDs: []string{"news"}})
+	if len(full) <= len(text) || !strings.Contains(full, "long description") {
+		t.Errorf("merge without fields (%d bytes) isn't larger than the projection (%d bytes)", len(full), len(text))
+	}
+}
@@ -0,0 +1,69 @@
+package mcpserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// TestMissingDateStrategy_SortAndFilter verifies how each missing-date strategy
+// (applied by the store at fetch time) affects date sorting and filtering of an
+// undated item alongside dated ones.
+func TestMissingDateStrategy_SortAndFilter(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	fetchedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	newItems := func() []*gofeed.Item {
+		return []*gofeed.Item{
+			{Title: "older", PublishedParsed: &older},
+			{Title: "undated", UpdatedParsed: &updated},
+			{Title: "newer", PublishedParsed: &newer},
+		}
+	}
+
+	tests := []struct {
+		strategy     model.MissingDateStrategy
+		wantSorted   []string
+		wantFiltered []string // since..until
+	}{
+		// Sorting and filtering fall back to the updated date on their own.
+		{model.MissingDateInclude, []string{"newer", "undated", "older"}, []string{"undated", "newer"}},
+		// Undated items are gone entirely.
+		{model.MissingDateExclude, []string{"newer", "older"}, []string{"newer"}},
+		// The updated date places the item between the dated ones.
+		{model.MissingDateUseUpdated, []string{"newer", "undated", "older"}, []string{"undated", "newer"}},
+		// The fetch time makes the item newest, and past the until bound.
+		{model.MissingDateUseNow, []string{"undated", "newer", "older"}, []string{"newer"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			items := model.ApplyMissingDateStrategy(newItems(), tt.strategy, fetchedAt)
+
+			sorted := append([]*gofeed.Item(nil), items...)
+			sortItemsByDate(sorted)
+			assertTitles(t, "sorted", sorted, tt.wantSorted)
+
+			assertTitles(t, "filterItemsByDateRange", filterItemsByDateRange(items, since, until), tt.wantFiltered)
+			assertTitles(t, "ApplyFilters", ApplyFilters(items, &FilterParams{Since: &since, Until: &until}), tt.wantFiltered)
+		})
+	}
+}
+
+func assertTitles(t *testing.T, label string, items []*gofeed.Item, want []string) {
+	t.Helper()
+	if len(items) != len(want) {
+		t.Fatalf("%s: got %d items, want %v", label, len(items), want)
+	}
+	for i, item := range items {
+		if item.Title != want[i] {
+			t.Errorf("%s[%d] = %q, want %q", label, i, item.Title, want[i])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// MovedFeed is one entry returned by the list_moved_feeds tool: a feed whose
+// configured URL now permanently redirects elsewhere.
+type MovedFeed struct {
+	FeedID        string `json:"feed_id"`
+	Title         string `json:"title,omitempty"`
+	ConfiguredURL string `json:"configured_url"`
+	RedirectedTo  string `json:"redirected_to"`
+}
+
+// addMovedFeedsTool adds the list_moved_feeds tool
+func (s *Server) addMovedFeedsTool(srv *mcp.Server) {
+	movedFeedsTool := &mcp.Tool{
+		Name:        toolListMovedFeeds,
+		Description: "List feeds whose configured URL permanently redirects (301 or 308) to a new location, with that location; use to find stale feed URLs to update",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, movedFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		moved, err := s.movedFeeds(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(moved)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// movedFeeds lists the feeds whose last fetch was permanently redirected away
+// from the configured URL. Feeds whose fetch failed, or that haven't been
+// fetched yet, aren't listed.
+func (s *Server) movedFeeds(ctx context.Context) ([]MovedFeed, error) {
+	feedResults, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	moved := []MovedFeed{}
+	for _, feedResult := range feedResults {
+		redirectedTo := model.FeedRedirectedTo(feedResult.Feed)
+		if redirectedTo == "" || redirectedTo == feedResult.PublicURL {
+			continue
+		}
+		moved = append(moved, MovedFeed{
+			FeedID:        feedResult.ID,
+			Title:         cmp.Or(feedResult.Title, feedResult.Feed.Title),
+			ConfiguredURL: feedResult.PublicURL,
+			RedirectedTo:  redirectedTo,
+		})
+	}
+	return moved, nil
+}
@@ -0,0 +1,58 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestMovedFeeds(t *testing.T) {
+	s := &Server{allFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{
+		{ID: "moved", PublicURL: "http://old.example/feed", Feed: &model.Feed{
+			Title:  "Moved Feed",
+			Custom: map[string]string{model.RedirectedToKey: "https://new.example/feed"},
+		}},
+		{ID: "stayed", PublicURL: "https://example.com/feed", Feed: &model.Feed{Title: "Stayed"}},
+		{ID: "failed", PublicURL: "https://down.example/feed", FetchError: "connection refused"},
+	}}}
+
+	moved, err := s.movedFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("movedFeeds: %v", err)
+	}
+	want := []MovedFeed{{FeedID: "moved", Title: "Moved Feed", ConfiguredURL: "http://old.example/feed", RedirectedTo: "https://new.example/feed"}}
+	if !slices.Equal(moved, want) {
+		t.Errorf("movedFeeds() = %+v, want %+v", moved, want)
+	}
+}
+
+func TestReadFeedMetadataResource_Redirects(t *testing.T) {
+	ctx := context.Background()
+	feeds := map[string]*model.FeedAndItemsResult{
+		"moved": {ID: "moved", Title: "Moved", Feed: &model.Feed{
+			Title:  "Moved",
+			Custom: map[string]string{model.RedirectedToKey: "https://new.example/feed"},
+		}},
+		"stayed": {ID: "stayed", Title: "Stayed", Feed: &model.Feed{Title: "Stayed"}},
+	}
+	rm := NewResourceManager(&mockResourceAllFeedsGetter{}, &mockResourceFeedAndItemsGetter{feeds: feeds})
+
+	for feedID, want := range map[string]string{"moved": "https://new.example/feed", "stayed": ""} {
+		uri := expandURITemplate(FeedMetaURI, map[string]string{"feedId": feedID})
+		result, err := rm.ReadResource(ctx, uri)
+		if err != nil {
+			t.Fatalf("ReadResource(%s): %v", uri, err)
+		}
+		var meta map[string]any
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), &meta); err != nil {
+			t.Fatalf("unmarshal metadata: %v", err)
+		}
+		redirectedTo, _ := meta["redirected_to"].(string)
+		if redirectedTo != want || meta["moved_permanently"] != (want != "") {
+			t.Errorf("%s: redirected_to = %q, moved_permanently = %v; want %q", feedID, redirectedTo, meta["moved_permanently"], want)
+		}
+	}
+}
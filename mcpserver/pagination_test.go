@@ -1,9 +1,21 @@
 package mcpserver
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
 )
 
 // applyPaginationParams applies the same pagination logic as server.go
@@ -72,25 +84,25 @@ func TestProcessItemForOutput(t *testing.T) {
 	}
 
 	t.Run("include full content (unlimited)", func(t *testing.T) {
-		result := processItemForOutput(testItem, true, 0)
+		result := processItemForOutput(testItem, true, 0, false)
 		verifyContentIncluded(t, result, testItem)
 		verifyMetadataPreserved(t, result, testItem)
 	})
 
 	t.Run("exclude content (default behavior)", func(t *testing.T) {
-		result := processItemForOutput(testItem, false, 0)
+		result := processItemForOutput(testItem, false, 0, false)
 		verifyContentExcluded(t, result)
 		verifyMetadataPreserved(t, result, testItem)
 	})
 
 	t.Run("truncate content at 500 chars (default when included)", func(t *testing.T) {
-		result := processItemForOutput(testItem, true, DefaultContentLength)
+		result := processItemForOutput(testItem, true, DefaultContentLength, false)
 		verifyContentTruncated(t, result, DefaultContentLength)
 		verifyMetadataPreserved(t, result, testItem)
 	})
 
 	t.Run("truncate content at 20 chars (custom)", func(t *testing.T) {
-		result := processItemForOutput(testItem, true, 20)
+		result := processItemForOutput(testItem, true, 20, false)
 		verifyContentTruncated(t, result, 20)
 		verifyMetadataPreserved(t, result, testItem)
 	})
@@ -127,3 +139,327 @@ func verifyMetadataPreserved(t *testing.T, result, original *gofeed.Item) {
 		t.Error("Link should never be modified")
 	}
 }
+
+// oversizedFeed builds a feed result whose items each carry ~2KB of content.
+func oversizedFeed(n int) (*model.FeedAndItemsResult, []*gofeed.Item) {
+	items := make([]*gofeed.Item, n)
+	for i := range items {
+		items[i] = &gofeed.Item{
+			Title:   fmt.Sprintf("Item %d", i),
+			Link:    fmt.Sprintf("https://example.com/item%d", i),
+			Content: strings.Repeat("x", 2000),
+		}
+	}
+	return &model.FeedAndItemsResult{
+		ID:        "big-feed",
+		PublicURL: "https://example.com/feed",
+		Title:     "Big Feed",
+		Items:     items,
+	}, items
+}
+
+// decodeFeedMetadata unmarshals the leading metadata block of a feed response.
+func decodeFeedMetadata(t *testing.T, content []mcp.Content) map[string]any {
+	t.Helper()
+	text, ok := content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("content[0] is %T, want *mcp.TextContent", content[0])
+	}
+	var meta map[string]any
+	if err := json.Unmarshal([]byte(text.Text), &meta); err != nil {
+		t.Fatalf("unmarshal metadata: %v", err)
+	}
+	return meta
+}
+
+func TestBuildFeedContent_MaxResponseBytes(t *testing.T) {
+	server := &Server{}
+	ctx := context.Background()
+	feed, items := oversizedFeed(10)
+	info := PaginationInfo{TotalItems: 10, ReturnedItems: 10, Offset: 0, Limit: 10}
+
+	t.Run("stops before exceeding the limit and reports next offset", func(t *testing.T) {
+		const limit = 7000
+		content := server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true, MaxResponseBytes: limit})
+
+		if size := marshaledContentSize(content); size > limit {
+			t.Errorf("response size %d exceeds limit %d", size, limit)
+		}
+		returned := len(content) - 1
+		if returned == 0 || returned >= len(items) {
+			t.Fatalf("expected a partial page, got %d items", returned)
+		}
+
+		meta := decodeFeedMetadata(t, content)
+		if meta["truncated_by_size"] != true {
+			t.Errorf("truncated_by_size = %v, want true", meta["truncated_by_size"])
+		}
+		if meta["has_more"] != true {
+			t.Errorf("has_more = %v, want true", meta["has_more"])
+		}
+		if got := meta["next_offset"]; got != float64(returned) {
+			t.Errorf("next_offset = %v, want %d", got, returned)
+		}
+		if got := meta["returned_items"]; got != float64(returned) {
+			t.Errorf("returned_items = %v, want %d", got, returned)
+		}
+	})
+
+	t.Run("next offset accounts for the starting offset", func(t *testing.T) {
+		offsetInfo := info
+		offsetInfo.Offset = 20
+		content := server.buildFeedContent(ctx, feed, items, offsetInfo, ParsedFeedParams{IncludeContent: true, MaxResponseBytes: 7000})
+		meta := decodeFeedMetadata(t, content)
+		if got, want := meta["next_offset"], float64(20+len(content)-1); got != want {
+			t.Errorf("next_offset = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("always returns at least one item", func(t *testing.T) {
+		content := server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true, MaxResponseBytes: 100})
+		if len(content) != 2 {
+			t.Fatalf("expected metadata plus one item, got %d blocks", len(content))
+		}
+		meta := decodeFeedMetadata(t, content)
+		if meta["next_offset"] != float64(1) {
+			t.Errorf("next_offset = %v, want 1", meta["next_offset"])
+		}
+	})
+
+	t.Run("unlimited when zero", func(t *testing.T) {
+		content := server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true})
+		if len(content) != len(items)+1 {
+			t.Fatalf("expected all %d items, got %d", len(items), len(content)-1)
+		}
+		meta := decodeFeedMetadata(t, content)
+		if _, ok := meta["truncated_by_size"]; ok {
+			t.Error("truncated_by_size should be omitted when not truncated")
+		}
+		if _, ok := meta["next_offset"]; ok {
+			t.Error("next_offset should be omitted when there are no more items")
+		}
+	})
+}
+
+func TestOrderItems(t *testing.T) {
+	day := func(d int) *time.Time {
+		ts := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	items := []*gofeed.Item{
+		{Title: "undated-a"},
+		{Title: "jan2", PublishedParsed: day(2)},
+		{Title: "jan3", PublishedParsed: day(3)},
+		{Title: "undated-b"},
+		{Title: "jan1", PublishedParsed: day(1)},
+		{Title: "jan2-dup", PublishedParsed: day(2)},
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{orderFeed, []string{"undated-a", "jan2", "jan3", "undated-b", "jan1", "jan2-dup"}},
+		{"", []string{"undated-a", "jan2", "jan3", "undated-b", "jan1", "jan2-dup"}},
+		{orderNewest, []string{"jan3", "jan2", "jan2-dup", "jan1", "undated-a", "undated-b"}},
+		{orderOldest, []string{"undated-a", "undated-b", "jan1", "jan2", "jan2-dup", "jan3"}},
+	}
+	for _, tt := range tests {
+		t.Run("order="+tt.order, func(t *testing.T) {
+			got := orderItems(items, tt.order, dateFieldPublished)
+			titles := make([]string, len(got))
+			for i, item := range got {
+				titles[i] = item.Title
+			}
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("got %v, want %v", titles, tt.want)
+			}
+		})
+	}
+
+	if items[0].Title != "undated-a" || items[2].Title != "jan3" {
+		t.Error("orderItems must not reorder the caller's slice")
+	}
+}
+
+func TestParsePaginationParams_Order(t *testing.T) {
+	server := &Server{}
+	for order, want := range map[string]string{"": orderFeed, orderFeed: orderFeed, orderNewest: orderNewest, orderOldest: orderOldest, "bogus": orderFeed} {
+		if got := server.parsePaginationParams(GetSyndicationFeedParams{Order: order}).Order; got != want {
+			t.Errorf("order %q parsed as %q, want %q", order, got, want)
+		}
+	}
+}
+
+func TestGetFeedItemsTool_HasMedia(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "photo", Content: `<p>Look</p><IMG src="https://example.com/a.jpg">`},
+		{Title: "text", Content: "<p>Words only</p>"},
+		{Title: "episode", Enclosures: []*gofeed.Enclosure{{URL: "https://example.com/ep.mp3", Type: "audio/mpeg"}}},
+		{Title: "attachment", Enclosures: []*gofeed.Enclosure{{URL: "https://example.com/doc.pdf", Type: "application/pdf"}}},
+	}
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", items)
+
+	titles := func(hasMedia *bool) ([]string, float64) {
+		t.Helper()
+		args := map[string]any{keyID: "feed-1"}
+		if hasMedia != nil {
+			args["hasMedia"] = *hasMedia
+		}
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetSyndicationFeedItems, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("CallTool: %v, %+v", err, result)
+		}
+		var meta map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &meta); err != nil {
+			t.Fatalf("unmarshal metadata: %v", err)
+		}
+		var got []string
+		for _, block := range result.Content[1:] {
+			var item map[string]any
+			if err := json.Unmarshal([]byte(block.(*mcp.TextContent).Text), &item); err != nil {
+				t.Fatalf("unmarshal item: %v", err)
+			}
+			got = append(got, item["title"].(string))
+		}
+		return got, meta["total_items"].(float64)
+	}
+
+	tests := []struct {
+		hasMedia *bool
+		want     []string
+	}{
+		{nil, []string{"photo", "text", "episode", "attachment"}},
+		{new(true), []string{"photo", "episode"}},
+		{new(false), []string{"text", "attachment"}},
+	}
+	for _, tt := range tests {
+		got, total := titles(tt.hasMedia)
+		if !slices.Equal(got, tt.want) || int(total) != len(tt.want) {
+			t.Errorf("hasMedia=%v: items %v (total_items %v), want %v", tt.hasMedia, got, total, tt.want)
+		}
+	}
+}
+
+func TestGetFeedItemsTool_Authors(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "by jane", Author: &gofeed.Person{Name: "Jane Smith"}},
+		{Title: "by bob", Author: &gofeed.Person{Name: "Bob Wilson"}},
+		{Title: "co-written", Authors: []*gofeed.Person{{Name: "Alice Johnson"}, {Name: "Carol White"}}},
+		{Title: "anonymous"},
+	}
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", items)
+
+	tests := []struct {
+		authors []any
+		want    []string
+	}{
+		{nil, []string{"by jane", "by bob", "co-written", "anonymous"}},
+		{[]any{"jane smith"}, []string{"by jane"}},
+		{[]any{"Jane Smith", "carol white"}, []string{"by jane", "co-written"}},
+		{[]any{"Nobody"}, nil},
+	}
+	for _, tt := range tests {
+		args := map[string]any{keyID: "feed-1"}
+		if tt.authors != nil {
+			args["authors"] = tt.authors
+		}
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetSyndicationFeedItems, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("CallTool: %v, %+v", err, result)
+		}
+		var got []string
+		for _, block := range result.Content[1:] {
+			var item map[string]any
+			if err := json.Unmarshal([]byte(block.(*mcp.TextContent).Text), &item); err != nil {
+				t.Fatalf("unmarshal item: %v", err)
+			}
+			got = append(got, item["title"].(string))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("authors=%v: items %v, want %v", tt.authors, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFeedContent_MaxResponseBytesEmbedImages(t *testing.T) {
+	var mu sync.Mutex
+	fetched := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(make([]byte, 2000))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	newFeed := func() (*model.FeedAndItemsResult, []*gofeed.Item) {
+		feed, items := oversizedFeed(3)
+		for i, item := range items {
+			item.Image = &gofeed.Image{URL: fmt.Sprintf("%s/img%d.png", ts.URL, i)}
+		}
+		return feed, items
+	}
+	newServer := func() *Server {
+		server := &Server{}
+		if err := server.initializeImageCache(); err != nil {
+			t.Fatalf("Failed to initialize image cache: %v", err)
+		}
+		mu.Lock()
+		clear(fetched)
+		mu.Unlock()
+		return server
+	}
+	info := PaginationInfo{TotalItems: 3, ReturnedItems: 3, Offset: 0, Limit: 3}
+
+	t.Run("items past the limit fetch no images", func(t *testing.T) {
+		server := newServer()
+		feed, items := newFeed()
+		// Room for the first item with its image as a link, not the second.
+		limit := marshaledContentSize(server.buildFeedContent(ctx, feed, items[:1], info, ParsedFeedParams{IncludeContent: true, IncludeImages: true})) + 200
+		content := server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true, IncludeImages: true, EmbedImages: true, MaxResponseBytes: limit})
+
+		if size := marshaledContentSize(content); size > limit {
+			t.Errorf("response size %d exceeds limit %d", size, limit)
+		}
+		if len(content) != 3 {
+			t.Fatalf("expected metadata, one item and its image link, got %d blocks", len(content))
+		}
+		if _, ok := content[2].(*mcp.ResourceLink); !ok {
+			t.Errorf("image that doesn't fit is %T, want *mcp.ResourceLink", content[2])
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if fetched["/img1.png"] != 0 || fetched["/img2.png"] != 0 {
+			t.Errorf("images of items past the limit were fetched: %v", fetched)
+		}
+	})
+
+	t.Run("embeds the images that fit", func(t *testing.T) {
+		server := newServer()
+		feed, items := newFeed()
+		linksOnly := marshaledContentSize(server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true, IncludeImages: true}))
+		// Room for every item plus one embedded image (2000 bytes, about 3.6KB
+		// once encoded), but not two.
+		limit := linksOnly + 5000
+		content := server.buildFeedContent(ctx, feed, items, info, ParsedFeedParams{IncludeContent: true, IncludeImages: true, EmbedImages: true, MaxResponseBytes: limit})
+
+		if size := marshaledContentSize(content); size > limit {
+			t.Errorf("response size %d exceeds limit %d", size, limit)
+		}
+		if len(content) != 7 {
+			t.Fatalf("expected metadata plus three items with an image each, got %d blocks", len(content))
+		}
+		var embedded int
+		for _, block := range content {
+			if _, ok := block.(*mcp.ImageContent); ok {
+				embedded++
+			}
+		}
+		if embedded != 1 {
+			t.Errorf("embedded %d images, want 1", embedded)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedPinger checks that each feed's server answers, without fetching or
+// parsing the feed.
+type FeedPinger interface {
+	// PingFeeds sends one lightweight request to each feed's URL and
+	// reports the outcome, in feed ID order.
+	PingFeeds(ctx context.Context) ([]FeedPing, error)
+}
+
+// FeedPing is the outcome of pinging one feed. Reachable means the server
+// answered, whatever the status; Error explains a feed that didn't. LatencyMs
+// is the time from acquiring a connection (including any dial and TLS
+// handshake) to the first response byte, so time spent waiting for the
+// host's rate limit isn't counted. The TLS fields are set for https feeds.
+type FeedPing struct {
+	FeedID         string     `json:"feed_id"`
+	URL            string     `json:"url"`
+	Reachable      bool       `json:"reachable"`
+	Method         string     `json:"method,omitempty"` // HEAD, or GET when the server rejects HEAD
+	Status         int        `json:"status,omitempty"`
+	LatencyMs      int64      `json:"latency_ms"`
+	TLSVersion     string     `json:"tls_version,omitempty"`
+	TLSCipherSuite string     `json:"tls_cipher_suite,omitempty"`
+	CertExpires    *time.Time `json:"cert_expires,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// PingFeedsResult is the ping_feeds response.
+type PingFeedsResult struct {
+	Feeds       []FeedPing `json:"feeds"`
+	Reachable   int        `json:"reachable"`
+	Unreachable int        `json:"unreachable"`
+}
+
+// addPingFeedsTool adds the ping_feeds tool
+func (s *Server) addPingFeedsTool(srv *mcp.Server, pinger FeedPinger) {
+	pingTool := &mcp.Tool{
+		Name:        toolPingFeeds,
+		Description: "Check connectivity to every feed's server with one lightweight HEAD request each (GET when HEAD is rejected), without fetching or parsing feeds. Returns per-feed reachability, HTTP status, latency, and TLS version, cipher suite, and certificate expiry; use it first when diagnosing feed failures.",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, pingTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		pings, err := pinger.PingFeeds(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		result := PingFeedsResult{Feeds: pings}
+		if result.Feeds == nil {
+			result.Feeds = []FeedPing{}
+		}
+		for _, ping := range result.Feeds {
+			if ping.Reachable {
+				result.Reachable++
+			} else {
+				result.Unreachable++
+			}
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
@@ -0,0 +1,45 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockFeedPinger is a feed getter with one reachable and one unreachable
+// feed.
+type mockFeedPinger struct {
+	mockFeedAndItemsGetter
+}
+
+func (m *mockFeedPinger) PingFeeds(ctx context.Context) ([]FeedPing, error) {
+	return []FeedPing{
+		{FeedID: "up", URL: "https://example.com/feed", Reachable: true, Method: "HEAD", Status: 200, LatencyMs: 42, TLSVersion: "TLS 1.3"},
+		{FeedID: "down", URL: "https://down.example/feed", LatencyMs: 3, Error: "connection refused"},
+	}, nil
+}
+
+func TestPingFeedsTool(t *testing.T) {
+	session := newTestClientSession(t, &Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedPinger{},
+		FeedPinger:         &mockFeedPinger{},
+	})
+
+	var result PingFeedsResult
+	if err := json.Unmarshal([]byte(callToolText(t, session, toolPingFeeds, map[string]any{})), &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.Reachable != 1 || result.Unreachable != 1 || len(result.Feeds) != 2 {
+		t.Fatalf("result = %+v, want one reachable and one unreachable feed", result)
+	}
+	if up := result.Feeds[0]; up.FeedID != "up" || up.Status != 200 || up.LatencyMs != 42 || up.TLSVersion != "TLS 1.3" {
+		t.Errorf("reachable feed = %+v", up)
+	}
+	if down := result.Feeds[1]; down.Reachable || down.Error != "connection refused" {
+		t.Errorf("unreachable feed = %+v", down)
+	}
+}
@@ -0,0 +1,116 @@
+package mcpserver
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// plaintextSkipTags are elements whose text is never shown to a reader.
+var plaintextSkipTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Head:     true,
+}
+
+// plaintextBlockTags are elements that start and end a paragraph.
+var plaintextBlockTags = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.Div:        true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Li:         true,
+	atom.Ul:         true,
+	atom.Ol:         true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Dd:         true,
+	atom.Pre:        true,
+	atom.Blockquote: true,
+	atom.Figure:     true,
+	atom.Figcaption: true,
+	atom.Table:      true,
+	atom.Tr:         true,
+	atom.Section:    true,
+	atom.Article:    true,
+	atom.Header:     true,
+	atom.Footer:     true,
+	atom.Hr:         true,
+}
+
+// htmlToPlaintext converts item HTML to plain text for the plaintext output
+// option: tags are dropped, entities decoded, and whitespace collapsed. Block
+// elements become paragraphs separated by blank lines, <br> a line break, and
+// list items are prefixed with "- ". Text without markup is only unescaped
+// and trimmed, so plain-text descriptions keep their own line breaks.
+func htmlToPlaintext(s string) string {
+	if !strings.Contains(s, "<") {
+		return strings.TrimSpace(html.UnescapeString(s))
+	}
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return strings.TrimSpace(html.UnescapeString(s))
+	}
+
+	var paragraphs []string
+	var current strings.Builder
+	bullet := false // the next paragraph starts a list item
+	flush := func() {
+		var lines []string
+		for line := range strings.SplitSeq(current.String(), "\n") {
+			if line = collapseSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		current.Reset()
+		if len(lines) == 0 {
+			return
+		}
+		if bullet {
+			lines[0] = "- " + lines[0]
+			bullet = false
+		}
+		paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			current.WriteString(n.Data)
+			return
+		}
+		if n.Type == html.ElementNode {
+			switch {
+			case plaintextSkipTags[n.DataAtom]:
+				return
+			case n.DataAtom == atom.Br:
+				current.WriteByte('\n')
+				return
+			case plaintextBlockTags[n.DataAtom]:
+				flush()
+				if n.DataAtom == atom.Li {
+					bullet = true
+				}
+				defer func() {
+					flush()
+					if n.DataAtom == atom.Li {
+						bullet = false // an empty item
+					}
+				}()
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	flush()
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,82 @@
+package mcpserver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestHTMLToPlaintext(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "paragraphs and inline tags",
+			in:   "<p>Go 1.26 is <strong>out</strong>.</p>\n<p>Read the <a href=\"/notes\">release   notes</a>.</p>",
+			want: "Go 1.26 is out.\n\nRead the release notes.",
+		},
+		{
+			name: "entities",
+			in:   "<p>Fish &amp; chips &mdash; &quot;cheap&quot; at &pound;5 &lt;3</p>",
+			want: "Fish & chips — \"cheap\" at £5 <3",
+		},
+		{
+			name: "headings, lists, and line breaks",
+			in:   "<h2>Changes</h2><ul><li>Faster <em>builds</em></li><li><p>New</p><p>APIs</p></li><li></li></ul><p>Line one<br>Line two</p>",
+			want: "Changes\n\n- Faster builds\n\n- New\n\nAPIs\n\nLine one\nLine two",
+		},
+		{
+			name: "scripts and styles dropped",
+			in:   "<style>p{color:red}</style><p>Visible</p><script>alert(1)</script>",
+			want: "Visible",
+		},
+		{
+			name: "text outside blocks",
+			in:   "Intro text <div>Block</div> trailing",
+			want: "Intro text\n\nBlock\n\ntrailing",
+		},
+		{
+			name: "plain text keeps its line breaks",
+			in:   "  First line\nSecond &amp; last  ",
+			want: "First line\nSecond & last",
+		},
+		{name: "empty", in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToPlaintext(tt.in); got != tt.want {
+				t.Errorf("htmlToPlaintext(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessItemForOutput_Plaintext(t *testing.T) {
+	item := &gofeed.Item{
+		Title:       "Release",
+		Description: "<p>Short &amp; sweet</p>",
+		Content:     "<div><p>" + strings.Repeat("<b>word</b> ", 20) + "</p></div>",
+	}
+
+	got := processItemForOutput(item, true, 0, true)
+	if got.Description != "Short & sweet" {
+		t.Errorf("description = %q", got.Description)
+	}
+	if strings.Contains(got.Content, "<") {
+		t.Errorf("content still has markup: %q", got.Content)
+	}
+
+	// Truncation applies to the converted text, not the HTML.
+	got = processItemForOutput(item, true, 14, true)
+	if want := "word word word" + TruncationMarker; got.Content != want {
+		t.Errorf("truncated content = %q, want %q", got.Content, want)
+	}
+
+	// Without content there is nothing to convert; the original is untouched.
+	if got = processItemForOutput(item, false, 0, true); got.Content != "" || item.Content == "" {
+		t.Errorf("content = %q without includeContent, original %q", got.Content, item.Content)
+	}
+}
@@ -0,0 +1,231 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// GetPodcastEpisodesParams contains parameters for the get_podcast_episodes tool.
+type GetPodcastEpisodesParams struct {
+	FeedID string `json:"feedId"`
+	Limit  *int   `json:"limit,omitempty"`  // Maximum episodes to return (default: DefaultItemLimit, max: MaxItemLimit)
+	Offset *int   `json:"offset,omitempty"` // Number of episodes to skip (default: 0)
+}
+
+// PodcastEpisode is a feed item in the shape podcast clients expect: the
+// audio enclosure and iTunes episode fields flattened alongside the basics.
+// Podcast fields are always present, empty for items that don't carry them,
+// except the chapters, which are left out when the item has none.
+type PodcastEpisode struct {
+	Title           string          `json:"title"`
+	Link            string          `json:"link,omitempty"`
+	GUID            string          `json:"guid,omitempty"`
+	Published       *time.Time      `json:"published,omitempty"`
+	AudioURL        string          `json:"audio_url"`
+	AudioType       string          `json:"audio_type"`
+	AudioLength     int64           `json:"audio_length"` // bytes, 0 when unknown
+	Duration        string          `json:"duration"`     // itunes:duration as published
+	DurationSeconds int             `json:"duration_seconds"`
+	Episode         *int            `json:"episode"`
+	Season          *int            `json:"season"`
+	EpisodeType     string          `json:"episode_type"`
+	Explicit        *bool           `json:"explicit"`
+	Chapters        []model.Chapter `json:"chapters,omitempty"`
+	ChaptersURL     string          `json:"chapters_url,omitempty"`
+}
+
+// PodcastEpisodesResult is the JSON body returned by get_podcast_episodes.
+// FetchError is set when the feed couldn't be fetched, which tells that apart
+// from a feed with no episodes.
+type PodcastEpisodesResult struct {
+	FeedID        string           `json:"feed_id"`
+	Title         string           `json:"title"`
+	FetchError    string           `json:"fetch_error,omitempty"`
+	Author        string           `json:"author,omitempty"`
+	TotalEpisodes int              `json:"total_episodes"`
+	Offset        int              `json:"offset"`
+	HasMore       bool             `json:"has_more"`
+	Episodes      []PodcastEpisode `json:"episodes"`
+}
+
+// addPodcastEpisodesTool adds the get_podcast_episodes tool
+func (s *Server) addPodcastEpisodesTool(srv *mcp.Server) {
+	podcastEpisodesTool := &mcp.Tool{
+		Name:        toolGetPodcastEpisodes,
+		Description: "Get a podcast feed's episodes with audio enclosure (URL, type, length) and iTunes fields (duration, episode, season, episode type, explicit). Items of non-podcast feeds are returned with empty podcast fields.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{"feedId"},
+			Properties: map[string]*jsonschema.Schema{
+				"feedId": {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+				"limit": {
+					Type:        typeInteger,
+					Description: fmt.Sprintf("Maximum episodes to return (default: %d, max: %d)", DefaultItemLimit, MaxItemLimit),
+					Minimum:     &[]float64{0}[0],
+					Maximum:     &[]float64{float64(MaxItemLimit)}[0],
+				},
+				"offset": {
+					Type:        typeInteger,
+					Description: "Number of episodes to skip for pagination (default: 0)",
+					Minimum:     &[]float64{0}[0],
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, podcastEpisodesTool, func(ctx context.Context, req *mcp.CallToolRequest, args GetPodcastEpisodesParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.podcastEpisodes(ctx, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// podcastEpisodes fetches a feed and converts a page of its items to episodes.
+func (s *Server) podcastEpisodes(ctx context.Context, args GetPodcastEpisodesParams) (*PodcastEpisodesResult, error) {
+	feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.FeedID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := s.parsePaginationParams(GetSyndicationFeedParams{Limit: args.Limit, Offset: args.Offset})
+	items, info := s.applyPagination(feedResult.Items, params.Limit, params.Offset)
+
+	result := &PodcastEpisodesResult{
+		FeedID:        feedResult.ID,
+		Title:         feedResult.Title,
+		FetchError:    feedResult.FetchError,
+		TotalEpisodes: info.TotalItems,
+		Offset:        info.Offset,
+		HasMore:       info.HasMore,
+		Episodes:      make([]PodcastEpisode, 0, len(items)),
+	}
+	if feed := feedResult.Feed; feed != nil {
+		if result.Title == "" {
+			result.Title = feed.Title
+		}
+		if feed.ITunesExt != nil {
+			result.Author = feed.ITunesExt.Author
+		}
+	}
+	for _, item := range items {
+		result.Episodes = append(result.Episodes, newPodcastEpisode(item))
+	}
+	return result, nil
+}
+
+// newPodcastEpisode extracts episode metadata from an item's audio enclosure,
+// iTunes extension, and chapters.
+func newPodcastEpisode(item *gofeed.Item) PodcastEpisode {
+	episode := PodcastEpisode{
+		Title:     item.Title,
+		Link:      item.Link,
+		GUID:      item.GUID,
+		Published: item.PublishedParsed,
+		Chapters:  model.ItemChapters(item),
+	}
+	if episode.Chapters == nil {
+		episode.ChaptersURL = model.ItemChaptersURL(item)
+	}
+
+	if enclosure := audioEnclosure(item.Enclosures); enclosure != nil {
+		episode.AudioURL = enclosure.URL
+		episode.AudioType = enclosure.Type
+		episode.AudioLength, _ = strconv.ParseInt(strings.TrimSpace(enclosure.Length), 10, 64)
+	}
+
+	if ext := item.ITunesExt; ext != nil {
+		episode.Duration = ext.Duration
+		episode.DurationSeconds = parseITunesDuration(ext.Duration)
+		episode.Episode = parseOptionalInt(ext.Episode)
+		episode.Season = parseOptionalInt(ext.Season)
+		episode.EpisodeType = ext.EpisodeType
+		episode.Explicit = parseITunesExplicit(ext.Explicit)
+	}
+	return episode
+}
+
+// audioEnclosure returns the item's first audio enclosure, or its first
+// enclosure of any type when none is labeled audio.
+func audioEnclosure(enclosures []*gofeed.Enclosure) *gofeed.Enclosure {
+	for _, enclosure := range enclosures {
+		if enclosure != nil && strings.HasPrefix(strings.ToLower(enclosure.Type), "audio/") {
+			return enclosure
+		}
+	}
+	for _, enclosure := range enclosures {
+		if enclosure != nil {
+			return enclosure
+		}
+	}
+	return nil
+}
+
+// parseITunesDuration converts an itunes:duration value — plain seconds,
+// MM:SS, or HH:MM:SS — to seconds. Unparseable values yield 0.
+func parseITunesDuration(duration string) int {
+	duration = strings.TrimSpace(duration)
+	if duration == "" {
+		return 0
+	}
+	parts := strings.Split(duration, ":")
+	if len(parts) > 3 {
+		return 0
+	}
+	seconds := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
+
+// parseOptionalInt parses a positive integer, returning nil when the value is
+// empty or not a number.
+func parseOptionalInt(value string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
+
+// parseITunesExplicit interprets itunes:explicit, which feeds publish as
+// true/false, yes/no, or explicit/clean. Unrecognized values yield nil.
+func parseITunesExplicit(value string) *bool {
+	var explicit bool
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "explicit":
+		explicit = true
+	case "false", "no", "clean":
+		explicit = false
+	default:
+		return nil
+	}
+	return &explicit
+}
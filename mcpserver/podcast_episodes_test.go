@@ -0,0 +1,130 @@
+package mcpserver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+const podcastRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:psc="http://podlove.org/simple-chapters" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+<channel>
+  <title>Go Time</title>
+  <itunes:author>Changelog Media</itunes:author>
+  <item>
+    <title>Generics in practice</title>
+    <guid>ep-42</guid>
+    <pubDate>Mon, 03 Jun 2024 10:00:00 GMT</pubDate>
+    <enclosure url="https://cdn.example.com/art.jpg" type="image/jpeg" length="1000"/>
+    <enclosure url="https://cdn.example.com/ep42.mp3" type="audio/mpeg" length="52428800"/>
+    <itunes:duration>1:02:03</itunes:duration>
+    <itunes:episode>42</itunes:episode>
+    <itunes:season>3</itunes:season>
+    <itunes:episodeType>full</itunes:episodeType>
+    <itunes:explicit>no</itunes:explicit>
+    <psc:chapters version="1.2">
+      <psc:chapter start="00:00:00" title="Intro"/>
+      <psc:chapter start="00:12:30.5" title="Type parameters" href="https://go.dev/doc/tutorial/generics"/>
+    </psc:chapters>
+  </item>
+  <item>
+    <title>Trailer</title>
+    <enclosure url="https://cdn.example.com/trailer.m4a" type="audio/x-m4a" length="12345"/>
+    <itunes:duration>95</itunes:duration>
+    <itunes:episodeType>trailer</itunes:episodeType>
+    <itunes:explicit>yes</itunes:explicit>
+    <podcast:chapters url="https://cdn.example.com/trailer-chapters.json" type="application/json+chapters"/>
+  </item>
+</channel>
+</rss>`
+
+func TestPodcastEpisodes(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(podcastRSS)
+	if err != nil {
+		t.Fatalf("parse podcast feed: %v", err)
+	}
+	blog, err := gofeed.NewParser().ParseString(`<rss version="2.0"><channel><title>Blog</title><item><title>Post</title><link>https://blog.example.com/post</link></item></channel></rss>`)
+	if err != nil {
+		t.Fatalf("parse blog feed: %v", err)
+	}
+	s := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"podcast": {ID: "podcast", Feed: model.FromGoFeed(feed), Items: feed.Items},
+		"blog":    {ID: "blog", Feed: model.FromGoFeed(blog), Items: blog.Items},
+		"down":    {ID: "down", FetchError: "connection refused"},
+	}}}
+
+	result, err := s.podcastEpisodes(context.Background(), GetPodcastEpisodesParams{FeedID: "podcast"})
+	if err != nil {
+		t.Fatalf("podcastEpisodes: %v", err)
+	}
+	if result.Title != "Go Time" || result.Author != "Changelog Media" || result.TotalEpisodes != 2 {
+		t.Errorf("unexpected podcast header: %+v", result)
+	}
+
+	ep := result.Episodes[0]
+	if ep.AudioURL != "https://cdn.example.com/ep42.mp3" || ep.AudioType != "audio/mpeg" || ep.AudioLength != 52428800 {
+		t.Errorf("audio enclosure = %q %q %d, want the mp3 enclosure", ep.AudioURL, ep.AudioType, ep.AudioLength)
+	}
+	if ep.Duration != "1:02:03" || ep.DurationSeconds != 3723 {
+		t.Errorf("duration = %q (%ds), want 1:02:03 (3723s)", ep.Duration, ep.DurationSeconds)
+	}
+	if ep.Episode == nil || *ep.Episode != 42 || ep.Season == nil || *ep.Season != 3 {
+		t.Errorf("episode/season = %v/%v, want 42/3", ep.Episode, ep.Season)
+	}
+	if ep.EpisodeType != "full" || ep.Explicit == nil || *ep.Explicit {
+		t.Errorf("episodeType/explicit = %q/%v, want full/false", ep.EpisodeType, ep.Explicit)
+	}
+	if ep.GUID != "ep-42" || ep.Published == nil {
+		t.Errorf("guid/published = %q/%v", ep.GUID, ep.Published)
+	}
+
+	wantChapters := []model.Chapter{{StartTime: 0, Title: "Intro"}, {StartTime: 750.5, Title: "Type parameters", URL: "https://go.dev/doc/tutorial/generics"}}
+	if !reflect.DeepEqual(ep.Chapters, wantChapters) || ep.ChaptersURL != "" {
+		t.Errorf("chapters = %+v (url %q), want %+v", ep.Chapters, ep.ChaptersURL, wantChapters)
+	}
+
+	trailer := result.Episodes[1]
+	if trailer.Chapters != nil || trailer.ChaptersURL != "https://cdn.example.com/trailer-chapters.json" {
+		t.Errorf("trailer chapters = %+v (url %q), want only the linked chapters file", trailer.Chapters, trailer.ChaptersURL)
+	}
+	if trailer.DurationSeconds != 95 || trailer.Episode != nil || trailer.Explicit == nil || !*trailer.Explicit {
+		t.Errorf("unexpected trailer: %+v", trailer)
+	}
+
+	// Non-podcast feeds still list their items, with empty podcast fields.
+	result, err = s.podcastEpisodes(context.Background(), GetPodcastEpisodesParams{FeedID: "blog"})
+	if err != nil {
+		t.Fatalf("podcastEpisodes: %v", err)
+	}
+	if len(result.Episodes) != 1 {
+		t.Fatalf("expected 1 episode, got %d", len(result.Episodes))
+	}
+	want := PodcastEpisode{Title: "Post", Link: "https://blog.example.com/post"}
+	if got := result.Episodes[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("blog item = %+v, want %+v", got, want)
+	}
+	if result.FetchError != "" {
+		t.Errorf("blog fetch_error = %q, want none", result.FetchError)
+	}
+
+	// A feed that failed to fetch reports why, rather than looking empty.
+	result, err = s.podcastEpisodes(context.Background(), GetPodcastEpisodesParams{FeedID: "down"})
+	if err != nil {
+		t.Fatalf("podcastEpisodes: %v", err)
+	}
+	if result.FetchError != "connection refused" || len(result.Episodes) != 0 {
+		t.Errorf("failed feed = %+v, want its fetch error and no episodes", result)
+	}
+}
+
+func TestParseITunesDuration(t *testing.T) {
+	for input, want := range map[string]int{"": 0, "95": 95, "12:34": 754, "01:02:03": 3723, "abc": 0, "1:2:3:4": 0, " 60 ": 60} {
+		if got := parseITunesDuration(input); got != want {
+			t.Errorf("parseITunesDuration(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package mcpserver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// defaultPromptLocale is the locale report prompts use when none is given.
+const defaultPromptLocale = "en"
+
+// promptMessage identifies a static label in the report prompts.
+type promptMessage string
+
+const (
+	msgGenerated promptMessage = "generated"
+
+	msgSummaryTitle         promptMessage = "summary.title"
+	msgSummaryType          promptMessage = "summary.type"
+	msgSummaryFeedsIncluded promptMessage = "summary.feeds_included"
+	msgSummaryFooter        promptMessage = "summary.footer"
+	msgSummaryDescription   promptMessage = "summary.description"
+
+	msgReportTitle         promptMessage = "report.title"
+	msgReportType          promptMessage = "report.type"
+	msgReportPeriod        promptMessage = "report.period"
+	msgReportFeedsAnalyzed promptMessage = "report.feeds_analyzed"
+	msgReportFooter        promptMessage = "report.footer"
+	msgReportDescription   promptMessage = "report.description"
+
+	msgOverviewHeading          promptMessage = "overview.heading"
+	msgTotalFeeds               promptMessage = "overview.total_feeds"
+	msgActiveFeeds              promptMessage = "overview.active_feeds"
+	msgFeedsWithErrors          promptMessage = "overview.feeds_with_errors"
+	msgStatus                   promptMessage = "overview.status"
+	msgKeyHighlights            promptMessage = "overview.key_highlights"
+	msgContentFlowIs            promptMessage = "overview.content_flow_is"
+	msgErrorRate                promptMessage = "overview.error_rate"
+	msgRecommendedAction        promptMessage = "overview.recommended_action"
+	msgFeedsWithIssues          promptMessage = "detailed.feeds_with_issues"
+	msgNoActiveFeeds            promptMessage = "detailed.no_active_feeds"
+	msgAllFeedsNormal           promptMessage = "detailed.all_feeds_normal"
+	msgExecutiveSummary         promptMessage = "report.executive_summary"
+	msgSystemStatus             promptMessage = "report.system_status"
+	msgFeedHealth               promptMessage = "report.feed_health"
+	msgUptimeAcross             promptMessage = "report.uptime_across"
+	msgContentFlow              promptMessage = "report.content_flow"
+	msgOperationalStatus        promptMessage = "report.operational_status"
+	msgPerformanceMetrics       promptMessage = "report.performance_metrics"
+	msgContentAnalysis          promptMessage = "report.content_analysis"
+	msgTechnicalHealth          promptMessage = "report.technical_health"
+	msgStrategicRecommendations promptMessage = "report.strategic_recommendations"
+)
+
+// promptCatalog maps each static label to its phrasing in one language.
+// Entries containing verbs are fmt templates; feed titles, counts, and other
+// data are passed through untranslated.
+type promptCatalog map[promptMessage]string
+
+// promptCatalogs holds the report prompt phrasing for each supported locale.
+// Every catalog must define every key in the English one.
+var promptCatalogs = map[string]promptCatalog{
+	"en": {
+		msgGenerated: "Generated",
+
+		msgSummaryTitle:         "Feed Summary Report",
+		msgSummaryType:          "Summary Type",
+		msgSummaryFeedsIncluded: "Feeds Included",
+		msgSummaryFooter:        "This summary provides an overview of your syndicated feed content. Use it to quickly understand what's happening across your information sources.",
+		msgSummaryDescription:   "Feed content summary (%s)",
+
+		msgReportTitle:         "Feed Performance Report",
+		msgReportType:          "Report Type",
+		msgReportPeriod:        "Time Period",
+		msgReportFeedsAnalyzed: "Feeds Analyzed",
+		msgReportFooter:        "This report provides detailed insights into your feed ecosystem performance, helping optimize content consumption and source management.",
+		msgReportDescription:   "Feed %s report",
+
+		msgOverviewHeading:          "Quick Overview",
+		msgTotalFeeds:               "Total Feeds",
+		msgActiveFeeds:              "Active Feeds",
+		msgFeedsWithErrors:          "Feeds with Errors",
+		msgStatus:                   "Status",
+		msgKeyHighlights:            "Key Highlights",
+		msgContentFlowIs:            "Content flow is %s",
+		msgErrorRate:                "Error rate",
+		msgRecommendedAction:        "Recommended action",
+		msgFeedsWithIssues:          "Feeds with Issues",
+		msgNoActiveFeeds:            "No active feeds found",
+		msgAllFeedsNormal:           "All feeds are functioning normally",
+		msgExecutiveSummary:         "Executive Summary",
+		msgSystemStatus:             "System Status",
+		msgFeedHealth:               "Feed Health",
+		msgUptimeAcross:             "%.1f%% uptime across %d sources",
+		msgContentFlow:              "Content Flow",
+		msgOperationalStatus:        "Operational Status",
+		msgPerformanceMetrics:       "Performance Metrics",
+		msgContentAnalysis:          "Content Analysis",
+		msgTechnicalHealth:          "Technical Health",
+		msgStrategicRecommendations: "Strategic Recommendations",
+	},
+	"es": {
+		msgGenerated: "Generado",
+
+		msgSummaryTitle:         "Informe de resumen de feeds",
+		msgSummaryType:          "Tipo de resumen",
+		msgSummaryFeedsIncluded: "Feeds incluidos",
+		msgSummaryFooter:        "Este resumen ofrece una visión general del contenido de tus feeds sindicados. Úsalo para entender rápidamente qué ocurre en tus fuentes de información.",
+		msgSummaryDescription:   "Resumen del contenido de los feeds (%s)",
+
+		msgReportTitle:         "Informe de rendimiento de feeds",
+		msgReportType:          "Tipo de informe",
+		msgReportPeriod:        "Periodo",
+		msgReportFeedsAnalyzed: "Feeds analizados",
+		msgReportFooter:        "Este informe ofrece información detallada sobre el rendimiento de tu ecosistema de feeds para optimizar el consumo de contenido y la gestión de fuentes.",
+		msgReportDescription:   "Informe de feeds (%s)",
+
+		msgOverviewHeading:          "Resumen rápido",
+		msgTotalFeeds:               "Total de feeds",
+		msgActiveFeeds:              "Feeds activos",
+		msgFeedsWithErrors:          "Feeds con errores",
+		msgStatus:                   "Estado",
+		msgKeyHighlights:            "Aspectos clave",
+		msgContentFlowIs:            "Flujo de contenido: %s",
+		msgErrorRate:                "Tasa de errores",
+		msgRecommendedAction:        "Acción recomendada",
+		msgFeedsWithIssues:          "Feeds con problemas",
+		msgNoActiveFeeds:            "No se encontraron feeds activos",
+		msgAllFeedsNormal:           "Todos los feeds funcionan con normalidad",
+		msgExecutiveSummary:         "Resumen ejecutivo",
+		msgSystemStatus:             "Estado del sistema",
+		msgFeedHealth:               "Salud de los feeds",
+		msgUptimeAcross:             "%.1f%% de disponibilidad en %d fuentes",
+		msgContentFlow:              "Flujo de contenido",
+		msgOperationalStatus:        "Estado operativo",
+		msgPerformanceMetrics:       "Métricas de rendimiento",
+		msgContentAnalysis:          "Análisis de contenido",
+		msgTechnicalHealth:          "Salud técnica",
+		msgStrategicRecommendations: "Recomendaciones estratégicas",
+	},
+}
+
+// text returns the phrasing for key, formatting it with args when given.
+// Keys missing from a catalog fall back to English.
+func (c promptCatalog) text(key promptMessage, args ...any) string {
+	msg, ok := c[key]
+	if !ok {
+		msg = promptCatalogs[defaultPromptLocale][key]
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// promptCatalogFor resolves a locale argument such as "es" or "es-MX" to a
+// catalog by its language subtag. An empty locale selects English.
+func promptCatalogFor(locale string) (promptCatalog, error) {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" {
+		lang = defaultPromptLocale
+	}
+	catalog, ok := promptCatalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(supportedPromptLocales(), ", "))
+	}
+	return catalog, nil
+}
+
+// supportedPromptLocales lists the locales with a message catalog.
+func supportedPromptLocales() []string {
+	return slices.Sorted(maps.Keys(promptCatalogs))
+}
@@ -157,36 +157,41 @@ Use this analysis to understand content trends, optimize feed monitoring, and id
 func (s *Server) handleSummarizeFeeds(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	feedIDs := getStringArg(req.Params.Arguments, "feed_ids", "")
 	summaryType := getStringArg(req.Params.Arguments, "summary_type", "brief")
+	msgs, err := promptCatalogFor(getStringArg(req.Params.Arguments, keyLocale, ""))
+	if err != nil {
+		return createErrorPromptResult(err.Error()), nil
+	}
 
 	// Get feeds to summarize
-	var feedsToSummarize []*model.FeedResult
 	feedsToSummarize, err := s.getFeedsForPrompt(ctx, feedIDs)
 	if err != nil {
 		return createErrorPromptResult(err.Error()), nil
 	}
 
 	// Generate summary based on type
-	summary := generateFeedSummary(feedsToSummarize, summaryType)
+	summary := generateFeedSummary(feedsToSummarize, summaryType, msgs)
 
-	promptContent := fmt.Sprintf(`# Feed Summary Report
+	promptContent := fmt.Sprintf(`# %s
 
-**Summary Type:** %s
-**Generated:** %s
-**Feeds Included:** %d
+**%s:** %s
+**%s:** %s
+**%s:** %d
 
 %s
 
 ---
 
-*This summary provides an overview of your syndicated feed content. Use it to quickly understand what's happening across your information sources.*`,
-		titleCase(summaryType),
-		time.Now().Format("2006-01-02 15:04:05 UTC"),
-		len(feedsToSummarize),
+*%s*`,
+		msgs.text(msgSummaryTitle),
+		msgs.text(msgSummaryType), titleCase(summaryType),
+		msgs.text(msgGenerated), time.Now().Format("2006-01-02 15:04:05 UTC"),
+		msgs.text(msgSummaryFeedsIncluded), len(feedsToSummarize),
 		summary,
+		msgs.text(msgSummaryFooter),
 	)
 
 	return &mcp.GetPromptResult{
-		Description: fmt.Sprintf("Feed content summary (%s)", summaryType),
+		Description: msgs.text(msgSummaryDescription, summaryType),
 		Messages: []*mcp.PromptMessage{
 			{
 				Role: roleUser,
@@ -338,6 +343,10 @@ func (s *Server) handleCompareSources(ctx context.Context, req *mcp.GetPromptReq
 func (s *Server) handleGenerateFeedReport(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	reportType := getStringArg(req.Params.Arguments, "report_type", "comprehensive")
 	timeframe := getStringArg(req.Params.Arguments, keyTimeframe, timeframe7d)
+	msgs, err := promptCatalogFor(getStringArg(req.Params.Arguments, keyLocale, ""))
+	if err != nil {
+		return createErrorPromptResult(err.Error()), nil
+	}
 
 	// Parse timeframe
 	duration, err := parseDuration(timeframe)
@@ -352,29 +361,31 @@ func (s *Server) handleGenerateFeedReport(ctx context.Context, req *mcp.GetPromp
 	}
 
 	// Generate report
-	report := generateFeedReport(feedResults, reportType, duration)
+	report := generateFeedReport(feedResults, reportType, duration, msgs)
 
-	promptContent := fmt.Sprintf(`# Feed Performance Report
+	promptContent := fmt.Sprintf(`# %s
 
-**Report Type:** %s
-**Time Period:** %s
-**Generated:** %s
-**Feeds Analyzed:** %d
+**%s:** %s
+**%s:** %s
+**%s:** %s
+**%s:** %d
 
 %s
 
 ---
 
-*This report provides detailed insights into your feed ecosystem performance, helping optimize content consumption and source management.*`,
-		titleCase(reportType),
-		timeframe,
-		time.Now().Format("2006-01-02 15:04:05 UTC"),
-		len(feedResults),
+*%s*`,
+		msgs.text(msgReportTitle),
+		msgs.text(msgReportType), titleCase(reportType),
+		msgs.text(msgReportPeriod), timeframe,
+		msgs.text(msgGenerated), time.Now().Format("2006-01-02 15:04:05 UTC"),
+		msgs.text(msgReportFeedsAnalyzed), len(feedResults),
 		report,
+		msgs.text(msgReportFooter),
 	)
 
 	return &mcp.GetPromptResult{
-		Description: fmt.Sprintf("Feed %s report", reportType),
+		Description: msgs.text(msgReportDescription, reportType),
 		Messages: []*mcp.PromptMessage{
 			{
 				Role: roleUser,
@@ -498,18 +509,18 @@ func formatTrendsSummary(trends *trendAnalysis) string {
 		trends.totalItems, trends.activeFeeds, trends.errorRate)
 }
 
-func generateFeedSummary(feeds []*model.FeedResult, summaryType string) string {
+func generateFeedSummary(feeds []*model.FeedResult, summaryType string, msgs promptCatalog) string {
 	switch summaryType {
 	case "detailed":
-		return generateDetailedSummary(feeds)
+		return generateDetailedSummary(feeds, msgs)
 	case "executive":
 		return generateExecutiveSummary(feeds)
 	default:
-		return generateBriefSummary(feeds)
+		return generateBriefSummary(feeds, msgs)
 	}
 }
 
-func generateBriefSummary(feeds []*model.FeedResult) string {
+func generateBriefSummary(feeds []*model.FeedResult, msgs promptCatalog) string {
 	activeFeeds := 0
 	errorFeeds := 0
 
@@ -521,29 +532,31 @@ func generateBriefSummary(feeds []*model.FeedResult) string {
 		}
 	}
 
-	return fmt.Sprintf(`## Quick Overview
+	return fmt.Sprintf(`## %s
 
-**Total Feeds**: %d
-**Active Feeds**: %d  
-**Feeds with Errors**: %d
+**%s**: %d
+**%s**: %d  
+**%s**: %d
 
-**Status**: %s
+**%s**: %s
 
-**Key Highlights**:
-- Content flow is %s
-- Error rate: %.1f%%
-- Recommended action: %s`,
-		len(feeds),
-		activeFeeds,
-		errorFeeds,
-		getOverallStatus(activeFeeds, errorFeeds),
-		getContentFlowStatus(activeFeeds, len(feeds)),
-		getErrorRate(activeFeeds, errorFeeds),
-		getRecommendedAction(activeFeeds, errorFeeds),
+**%s**:
+- %s
+- %s: %.1f%%
+- %s: %s`,
+		msgs.text(msgOverviewHeading),
+		msgs.text(msgTotalFeeds), len(feeds),
+		msgs.text(msgActiveFeeds), activeFeeds,
+		msgs.text(msgFeedsWithErrors), errorFeeds,
+		msgs.text(msgStatus), getOverallStatus(activeFeeds, errorFeeds),
+		msgs.text(msgKeyHighlights),
+		msgs.text(msgContentFlowIs, getContentFlowStatus(activeFeeds, len(feeds))),
+		msgs.text(msgErrorRate), getErrorRate(activeFeeds, errorFeeds),
+		msgs.text(msgRecommendedAction), getRecommendedAction(activeFeeds, errorFeeds),
 	)
 }
 
-func generateDetailedSummary(feeds []*model.FeedResult) string {
+func generateDetailedSummary(feeds []*model.FeedResult, msgs promptCatalog) string {
 	// Group feeds by status
 	var activeFeedsList []string
 	var errorFeedsList []string
@@ -556,18 +569,18 @@ func generateDetailedSummary(feeds []*model.FeedResult) string {
 		}
 	}
 
-	activeSection := "## Active Feeds\n\n"
+	activeSection := "## " + msgs.text(msgActiveFeeds) + "\n\n"
 	if len(activeFeedsList) > 0 {
 		activeSection += strings.Join(activeFeedsList, "\n")
 	} else {
-		activeSection += "*No active feeds found*"
+		activeSection += "*" + msgs.text(msgNoActiveFeeds) + "*"
 	}
 
-	errorSection := "\n\n## Feeds with Issues\n\n"
+	errorSection := "\n\n## " + msgs.text(msgFeedsWithIssues) + "\n\n"
 	if len(errorFeedsList) > 0 {
 		errorSection += strings.Join(errorFeedsList, "\n")
 	} else {
-		errorSection += "*All feeds are functioning normally*"
+		errorSection += "*" + msgs.text(msgAllFeedsNormal) + "*"
 	}
 
 	return activeSection + errorSection
@@ -837,7 +850,7 @@ func generateComparisonRecommendations(comparison *sourceComparison) string {
 4. **Regular Review**: Update source mix based on coverage patterns and relevance`
 }
 
-func generateFeedReport(feeds []*model.FeedResult, reportType string, duration time.Duration) string {
+func generateFeedReport(feeds []*model.FeedResult, reportType string, duration time.Duration, msgs promptCatalog) string {
 	switch reportType {
 	case "performance":
 		return generatePerformanceReport(feeds, duration)
@@ -846,7 +859,7 @@ func generateFeedReport(feeds []*model.FeedResult, reportType string, duration t
 	case "engagement":
 		return generateEngagementReport(feeds, duration)
 	default:
-		return generateComprehensiveReport(feeds, duration)
+		return generateComprehensiveReport(feeds, duration, msgs)
 	}
 }
 
@@ -952,36 +965,37 @@ func generateEngagementReport(feeds []*model.FeedResult, duration time.Duration)
 	)
 }
 
-func generateComprehensiveReport(feeds []*model.FeedResult, duration time.Duration) string {
+func generateComprehensiveReport(feeds []*model.FeedResult, duration time.Duration, msgs promptCatalog) string {
 	activeCount := getActiveCount(feeds)
 	errorCount := len(feeds) - activeCount
 	uptime := getUptimePercentage(activeCount, errorCount)
 
-	return fmt.Sprintf(`## Executive Summary
-- **System Status**: %s
-- **Feed Health**: %.1f%% uptime across %d sources
-- **Content Flow**: %s
-- **Operational Status**: %s
+	return fmt.Sprintf(`## %s
+- **%s**: %s
+- **%s**: %s
+- **%s**: %s
+- **%s**: %s
 
-## Performance Metrics
+## %s
 %s
 
-## Content Analysis
+## %s
 %s
 
-## Technical Health
+## %s
 %s
 
-## Strategic Recommendations
+## %s
 %s`,
-		getSystemStatus(uptime),
-		uptime, len(feeds),
-		getContentFlowStatus(activeCount, len(feeds)),
-		getOperationalStatus(activeCount, errorCount),
-		generatePerformanceMetrics(feeds),
-		generateContentMetrics(feeds),
-		generateTechnicalHealth(feeds),
-		getStrategicRecommendations(activeCount, errorCount),
+		msgs.text(msgExecutiveSummary),
+		msgs.text(msgSystemStatus), getSystemStatus(uptime),
+		msgs.text(msgFeedHealth), msgs.text(msgUptimeAcross, uptime, len(feeds)),
+		msgs.text(msgContentFlow), getContentFlowStatus(activeCount, len(feeds)),
+		msgs.text(msgOperationalStatus), getOperationalStatus(activeCount, errorCount),
+		msgs.text(msgPerformanceMetrics), generatePerformanceMetrics(feeds),
+		msgs.text(msgContentAnalysis), generateContentMetrics(feeds),
+		msgs.text(msgTechnicalHealth), generateTechnicalHealth(feeds),
+		msgs.text(msgStrategicRecommendations), getStrategicRecommendations(activeCount, errorCount),
 	)
 }
 
@@ -284,3 +284,96 @@ func TestPromptHelperFunctions(t *testing.T) {
 		}
 	})
 }
+
+// TestPromptLocale verifies that the locale argument switches the static
+// labels of the summary and report prompts while feed data stays as-is.
+func TestPromptLocale(t *testing.T) {
+	server := createTestServer(t)
+
+	promptText := func(t *testing.T, result *mcp.GetPromptResult) string {
+		t.Helper()
+		validatePromptResult(t, result)
+		return result.Messages[0].Content.(*mcp.TextContent).Text
+	}
+
+	t.Run("summarize_feeds in Spanish", func(t *testing.T) {
+		req := &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{
+				Arguments: map[string]string{"summary_type": "detailed", "locale": "es-ES"},
+			},
+		}
+		result, err := server.handleSummarizeFeeds(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handleSummarizeFeeds() failed: %v", err)
+		}
+		text := promptText(t, result)
+		for _, want := range []string{"# Informe de resumen de feeds", "**Tipo de resumen:** Detailed", "## Feeds activos", "- Test Feed 1"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("summary missing %q:\n%s", want, text)
+			}
+		}
+		if strings.Contains(text, "Feed Summary Report") {
+			t.Errorf("summary kept English title:\n%s", text)
+		}
+		if result.Description != "Resumen del contenido de los feeds (detailed)" {
+			t.Errorf("Description = %q", result.Description)
+		}
+	})
+
+	t.Run("generate_feed_report in Spanish", func(t *testing.T) {
+		req := &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{
+				Arguments: map[string]string{"timeframe": "7d", "locale": "es"},
+			},
+		}
+		result, err := server.handleGenerateFeedReport(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handleGenerateFeedReport() failed: %v", err)
+		}
+		text := promptText(t, result)
+		for _, want := range []string{"# Informe de rendimiento de feeds", "**Periodo:** 7d", "## Resumen ejecutivo", "100.0% de disponibilidad en 1 fuentes", "## Recomendaciones estratégicas"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("report missing %q:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("default locale is English", func(t *testing.T) {
+		req := &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{Arguments: map[string]string{}},
+		}
+		result, err := server.handleSummarizeFeeds(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handleSummarizeFeeds() failed: %v", err)
+		}
+		text := promptText(t, result)
+		for _, want := range []string{"# Feed Summary Report", "## Quick Overview", "**Total Feeds**: 1"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("summary missing %q:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		req := &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{Arguments: map[string]string{"locale": "xx"}},
+		}
+		result, err := server.handleGenerateFeedReport(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handleGenerateFeedReport() failed: %v", err)
+		}
+		validateErrorResult(t, result, `unsupported locale "xx" (supported: en, es)`)
+	})
+}
+
+// TestPromptCatalogsComplete guards against a catalog missing a label that
+// the English catalog defines.
+func TestPromptCatalogsComplete(t *testing.T) {
+	for locale, catalog := range promptCatalogs {
+		for key := range promptCatalogs[defaultPromptLocale] {
+			if catalog[key] == "" {
+				t.Errorf("locale %s has no message for %s", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedCachePurger evicts a feed from the store's caches without fetching it
+// again.
+type FeedCachePurger interface {
+	// PurgeFeedCache drops everything the store has cached for the feed with
+	// the given ID, so that the next read loads it from the network.
+	PurgeFeedCache(ctx context.Context, feedID string) (*FeedCachePurge, error)
+}
+
+// FeedCachePurge reports what purging one feed's caches evicted.
+type FeedCachePurge struct {
+	FeedID string `json:"feed_id"`
+	URL    string `json:"url"`
+	// WasCached reports whether the store held the parsed feed before the
+	// purge; false means the next read would have fetched it anyway.
+	WasCached bool `json:"was_cached"`
+	// ResourceCacheInvalidated is set by the purge_feed_cache tool once the
+	// feed's resources have been evicted from the resource cache.
+	ResourceCacheInvalidated bool `json:"resource_cache_invalidated"`
+}
+
+// PurgeFeedCacheParams contains parameters for the purge_feed_cache tool.
+type PurgeFeedCacheParams struct {
+	FeedID string `json:"feedId"`
+}
+
+// addPurgeFeedCacheTool adds the purge_feed_cache tool
+func (s *Server) addPurgeFeedCacheTool(srv *mcp.Server, purger FeedCachePurger) {
+	purgeTool := &mcp.Tool{
+		Name:        toolPurgeFeedCache,
+		Description: "Evict a feed from the store cache and the resource cache without re-fetching it, so the next read loads it cleanly from the network. Unlike refresh_feed, nothing is fetched now.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyFeedID},
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, purgeTool, func(ctx context.Context, req *mcp.CallToolRequest, args PurgeFeedCacheParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		purge, err := purger.PurgeFeedCache(ctx, args.FeedID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.resourceManager.InvalidateFeedCache(ctx, args.FeedID); err != nil {
+			return nil, nil, err
+		}
+		purge.ResourceCacheInvalidated = true
+		data, err := json.Marshal(purge)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
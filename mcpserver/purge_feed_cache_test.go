@@ -0,0 +1,89 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockFeedCachePurger records the feeds it was asked to purge.
+type mockFeedCachePurger struct {
+	mockFeedAndItemsGetter
+	purged []string
+}
+
+func (m *mockFeedCachePurger) PurgeFeedCache(ctx context.Context, feedID string) (*FeedCachePurge, error) {
+	m.purged = append(m.purged, feedID)
+	return &FeedCachePurge{FeedID: feedID, URL: "https://example.com/" + feedID, WasCached: true}, nil
+}
+
+func TestPurgeFeedCacheTool(t *testing.T) {
+	purger := &mockFeedCachePurger{mockFeedAndItemsGetter: mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"feed-1": {ID: "feed-1", Title: "Feed 1"},
+	}}}
+	srv, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "feed-1"}}},
+		FeedAndItemsGetter: purger,
+		FeedCachePurger:    purger,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	session := connectTestClient(t, srv)
+	ctx := context.Background()
+
+	// Warm the resource cache. ristretto applies writes asynchronously, so
+	// read until one is served from the cache.
+	rm := srv.resourceManager
+	uri := "feeds://feed/feed-1"
+	deadline := time.Now().Add(2 * time.Second)
+	for rm.GetCacheMetrics().Hits == 0 {
+		if _, err := rm.ReadResource(ctx, uri); err != nil {
+			t.Fatalf("ReadResource: %v", err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("resource was never cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	before := rm.GetCacheMetrics()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolPurgeFeedCache, Arguments: map[string]any{keyFeedID: "feed-1"}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	var purge FeedCachePurge
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &purge); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if purge.FeedID != "feed-1" || !purge.WasCached || !purge.ResourceCacheInvalidated {
+		t.Errorf("purge = %+v", purge)
+	}
+	if len(purger.purged) != 1 || purger.purged[0] != "feed-1" {
+		t.Errorf("store purged %q, want feed-1", purger.purged)
+	}
+
+	// The next read misses the resource cache.
+	if _, err := rm.ReadResource(ctx, uri); err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	after := rm.GetCacheMetrics()
+	if after.Hits != before.Hits || after.Misses != before.Misses+1 {
+		t.Errorf("after purge: hits=%d misses=%d, want %d and %d", after.Hits, after.Misses, before.Hits, before.Misses+1)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: toolPurgeFeedCache, Arguments: map[string]any{keyFeedID: ""}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Field: feedId") {
+		t.Errorf("empty feedId: got %+v, want a parameter error", result)
+	}
+}
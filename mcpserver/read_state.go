@@ -0,0 +1,411 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// readStateVersion is the current version of the read state file format.
+const readStateVersion = 1
+
+// defaultReadSession keys the read state of clients whose transport has no
+// session ID (stdio and stateless HTTP). It is the only session persisted:
+// HTTP session IDs aren't reused after a restart, so no client could get
+// another session's saved state back.
+const defaultReadSession = "default"
+
+// maxReadItemsPerSession caps the items a session remembers as read. Past it,
+// the items marked read longest ago are forgotten and count as unread again.
+const maxReadItemsPerSession = 10000
+
+// readSessionIdleTimeout is how long the read state of a session other than
+// defaultReadSession is kept after its last change. HTTP session IDs aren't
+// reused, so an idle session's state would otherwise be kept forever.
+const readSessionIdleTimeout = 7 * 24 * time.Hour
+
+// readStateFile is the on-disk form of the read state, written to
+// Config.ReadStateFile. Sessions holds only defaultReadSession.
+type readStateFile struct {
+	Version  int                          `json:"version"`
+	Sessions map[string]*readSessionState `json:"sessions"`
+}
+
+// readSessionState is the read state of one client session: the stable IDs
+// of the items it has read, with when each was marked read.
+type readSessionState struct {
+	UpdatedAt time.Time            `json:"updatedAt"`
+	Items     map[string]time.Time `json:"items"`
+}
+
+// readStates tracks which items each client session has read, keyed by
+// session and item stable ID. It lives in memory and, when path is set, the
+// default session's state is written to that file after every change to it.
+type readStates struct {
+	path     string
+	mu       sync.Mutex
+	sessions map[string]*readSessionState
+	// generation counts changes; persistMu serializes file writes, and
+	// savedGeneration keeps an older snapshot from overwriting a newer one.
+	generation      uint64
+	persistMu       sync.Mutex
+	savedGeneration uint64
+}
+
+// newReadStates creates the read state store, loading the default session's
+// state from path when it is set. A missing file is an empty state. A file
+// that can't be decoded is moved aside (to path.corrupt-<unix>) and an empty
+// state is used, as for the feed store file.
+func newReadStates(path string) (*readStates, error) {
+	rs := &readStates{path: path, sessions: make(map[string]*readSessionState)}
+	if path == "" {
+		return rs, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return rs, nil
+	}
+	if err != nil {
+		return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "failed to read read state file", err).
+			WithOperation("load_read_state").
+			WithComponent("read_state")
+	}
+	var state readStateFile
+	if err := json.Unmarshal(data, &state); err != nil || state.Version > readStateVersion {
+		if err == nil {
+			err = fmt.Errorf("unsupported version %d", state.Version)
+		}
+		backup, renameErr := model.MoveCorruptFile(path)
+		if renameErr != nil {
+			return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "read state file is unreadable and could not be moved aside", renameErr).
+				WithOperation("load_read_state").
+				WithComponent("read_state")
+		}
+		log.Printf("warning: read state file %s is invalid (%v); moved to %s and starting with no read items", path, err, backup)
+		return rs, nil
+	}
+	if session := state.Sessions[defaultReadSession]; session != nil && session.Items != nil {
+		rs.sessions[defaultReadSession] = session
+	}
+	return rs, nil
+}
+
+// readSessionKey returns the read state key for the session a request came
+// from.
+func readSessionKey(req *mcp.CallToolRequest) string {
+	if req != nil && req.Session != nil {
+		if id := req.Session.ID(); id != "" {
+			return id
+		}
+	}
+	return defaultReadSession
+}
+
+// markRead records the items with the given stable IDs as read by session and
+// returns how many weren't already.
+func (rs *readStates) markRead(session string, ids []string, now time.Time) (int, error) {
+	rs.mu.Lock()
+	state := rs.sessions[session]
+	if state == nil {
+		state = &readSessionState{Items: make(map[string]time.Time)}
+		rs.sessions[session] = state
+	}
+	marked := 0
+	for _, id := range ids {
+		if _, ok := state.Items[id]; !ok && id != "" {
+			state.Items[id] = now
+			marked++
+		}
+	}
+	trimReadItems(state.Items)
+	return marked, rs.changedLocked(session, marked, now)
+}
+
+// markUnread forgets that session read the items with the given stable IDs
+// and returns how many it had read.
+func (rs *readStates) markUnread(session string, ids []string, now time.Time) (int, error) {
+	rs.mu.Lock()
+	unmarked := 0
+	if state := rs.sessions[session]; state != nil {
+		for _, id := range ids {
+			if _, ok := state.Items[id]; ok {
+				delete(state.Items, id)
+				unmarked++
+			}
+		}
+	}
+	return unmarked, rs.changedLocked(session, unmarked, now)
+}
+
+// changedLocked finishes a change of count items to session's state: it
+// prunes idle sessions, releases mu, and saves the state if session is
+// defaultReadSession. Callers must hold mu.
+func (rs *readStates) changedLocked(session string, count int, now time.Time) error {
+	if count == 0 {
+		rs.mu.Unlock()
+		return nil
+	}
+	if state := rs.sessions[session]; state != nil {
+		state.UpdatedAt = now
+		if len(state.Items) == 0 {
+			delete(rs.sessions, session)
+		}
+	}
+	for id, state := range rs.sessions {
+		if id != defaultReadSession && now.Sub(state.UpdatedAt) > readSessionIdleTimeout {
+			delete(rs.sessions, id)
+		}
+	}
+	if rs.path == "" || session != defaultReadSession {
+		rs.mu.Unlock()
+		return nil
+	}
+	rs.generation++
+	generation := rs.generation
+	saved := make(map[string]*readSessionState)
+	if state := rs.sessions[defaultReadSession]; state != nil {
+		saved[defaultReadSession] = state
+	}
+	data, err := json.MarshalIndent(readStateFile{Version: readStateVersion, Sessions: saved}, "", "  ")
+	rs.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return rs.save(data, generation)
+}
+
+// save writes a snapshot of the state to the read state file, unless a newer
+// snapshot has already been written. It runs after mu is released so disk I/O
+// never blocks reads.
+func (rs *readStates) save(data []byte, generation uint64) error {
+	rs.persistMu.Lock()
+	defer rs.persistMu.Unlock()
+	if generation <= rs.savedGeneration {
+		return nil
+	}
+	if err := model.WriteFileAtomic(rs.path, data); err != nil {
+		return model.NewFeedErrorWithCause(model.ErrorTypeSystem, "read state changed but could not be saved", err).
+			WithOperation("save_read_state").
+			WithComponent("read_state").
+			WithSuggestion("Check that the directory of --read-state-file is writable; the change is kept in memory and saved with the next one")
+	}
+	rs.savedGeneration = generation
+	return nil
+}
+
+// trimReadItems forgets the items marked read longest ago once there are more
+// than maxReadItemsPerSession.
+func trimReadItems(items map[string]time.Time) {
+	excess := len(items) - maxReadItemsPerSession
+	if excess <= 0 {
+		return
+	}
+	ids := slices.SortedFunc(maps.Keys(items), func(a, b string) int {
+		return cmp.Or(items[a].Compare(items[b]), cmp.Compare(a, b))
+	})
+	for _, id := range ids[:excess] {
+		delete(items, id)
+	}
+}
+
+// unread returns the items session hasn't marked read.
+func (rs *readStates) unread(session string, items []*gofeed.Item) []*gofeed.Item {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	state := rs.sessions[session]
+	if state == nil || len(state.Items) == 0 {
+		return items
+	}
+	var unread []*gofeed.Item
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if _, read := state.Items[model.ItemStableID(item)]; !read {
+			unread = append(unread, item)
+		}
+	}
+	return unread
+}
+
+// MarkReadParams contains parameters for the mark_read tool.
+type MarkReadParams struct {
+	FeedID  string   `json:"feedId"`
+	ItemIDs []string `json:"itemIds"`
+}
+
+// MarkUnreadParams contains parameters for the mark_unread tool.
+type MarkUnreadParams MarkReadParams
+
+// MarkAllReadParams contains parameters for the mark_all_read tool.
+type MarkAllReadParams struct {
+	FeedID string `json:"feedId"`
+}
+
+// ReadStateResult is the JSON body returned by the read state tools. Changed
+// counts the items whose state changed, NotFound lists the requested IDs that
+// matched no item of the feed, and UnreadItems counts the feed's items the
+// session hasn't read afterwards.
+type ReadStateResult struct {
+	FeedID      string   `json:"feed_id"`
+	Changed     int      `json:"changed"`
+	NotFound    []string `json:"not_found,omitempty"`
+	UnreadItems int      `json:"unread_items"`
+}
+
+// markItemsSchema returns the input schema of mark_read and mark_unread.
+func markItemsSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:     typeObject,
+		Required: []string{keyFeedID, "itemIds"},
+		Properties: map[string]*jsonschema.Schema{
+			keyFeedID: {
+				Type:        typeString,
+				Description: "Feed ID from all_syndication_feeds tool",
+			},
+			"itemIds": {
+				Type:        "array",
+				Description: "Items to mark: each item's stable_id, GUID, or link, from get_syndication_feed_items",
+				MinItems:    new(1),
+				Items:       &jsonschema.Schema{Type: typeString},
+			},
+		},
+	}
+}
+
+// addMarkReadTool adds the mark_read tool
+func (s *Server) addMarkReadTool(srv *mcp.Server) {
+	markReadTool := &mcp.Tool{
+		Name:        toolMarkRead,
+		Description: "Mark items of a feed as read for this session, so get_syndication_feed_items with unreadOnly=true leaves them out. Read state is per client session.",
+		InputSchema: markItemsSchema(),
+	}
+	mcp.AddTool(srv, markReadTool, func(ctx context.Context, req *mcp.CallToolRequest, args MarkReadParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.markItems(ctx, readSessionKey(req), args.FeedID, args.ItemIDs, true)
+		return readStateToolResult(result, err)
+	})
+}
+
+// addMarkUnreadTool adds the mark_unread tool
+func (s *Server) addMarkUnreadTool(srv *mcp.Server) {
+	markUnreadTool := &mcp.Tool{
+		Name:        toolMarkUnread,
+		Description: "Mark items of a feed as unread again for this session.",
+		InputSchema: markItemsSchema(),
+	}
+	mcp.AddTool(srv, markUnreadTool, func(ctx context.Context, req *mcp.CallToolRequest, args MarkUnreadParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.markItems(ctx, readSessionKey(req), args.FeedID, args.ItemIDs, false)
+		return readStateToolResult(result, err)
+	})
+}
+
+// addMarkAllReadTool adds the mark_all_read tool
+func (s *Server) addMarkAllReadTool(srv *mcp.Server) {
+	markAllReadTool := &mcp.Tool{
+		Name:        toolMarkAllRead,
+		Description: "Mark every current item of a feed as read for this session. Items published later start out unread.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyFeedID},
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID from all_syndication_feeds tool",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, markAllReadTool, func(ctx context.Context, req *mcp.CallToolRequest, args MarkAllReadParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		result, err := s.markAllRead(ctx, readSessionKey(req), args.FeedID)
+		return readStateToolResult(result, err)
+	})
+}
+
+// readStateToolResult marshals a read state tool's result.
+func readStateToolResult(result *ReadStateResult, err error) (*mcp.CallToolResult, any, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, nil, nil
+}
+
+// markItems marks the feed's items with the given IDs (see findFeedItem) as
+// read or unread for session. An ID that matches no current item is taken as
+// a stable ID when marking unread, so items that have left the feed can still
+// be cleared; marking read reports it as not found.
+func (s *Server) markItems(ctx context.Context, session, feedID string, ids []string, read bool) (*ReadStateResult, error) {
+	feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReadStateResult{FeedID: feedID}
+	stableIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if item := findFeedItem(feedResult.Items, id); item != nil {
+			stableIDs = append(stableIDs, model.ItemStableID(item))
+		} else if read {
+			result.NotFound = append(result.NotFound, id)
+		} else {
+			stableIDs = append(stableIDs, id)
+		}
+	}
+	if read {
+		result.Changed, err = s.readStates.markRead(session, stableIDs, time.Now())
+	} else {
+		result.Changed, err = s.readStates.markUnread(session, stableIDs, time.Now())
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.UnreadItems = len(s.readStates.unread(session, feedResult.Items))
+	return result, nil
+}
+
+// markAllRead marks every current item of the feed as read for session.
+func (s *Server) markAllRead(ctx context.Context, session, feedID string) (*ReadStateResult, error) {
+	feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	stableIDs := make([]string, 0, len(feedResult.Items))
+	for _, item := range feedResult.Items {
+		if item != nil {
+			stableIDs = append(stableIDs, model.ItemStableID(item))
+		}
+	}
+	changed, err := s.readStates.markRead(session, stableIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &ReadStateResult{FeedID: feedID, Changed: changed, UnreadItems: len(s.readStates.unread(session, feedResult.Items))}, nil
+}
@@ -0,0 +1,181 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestReadStateTools(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "first", GUID: "urn:post:1"},
+		{Title: "second", GUID: "urn:post:2", Link: "https://example.com/second"},
+		{Title: "third", GUID: "urn:post:3"},
+	}
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", items)
+	ctx := context.Background()
+
+	call := func(name string, args map[string]any) *ReadStateResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("%s: %v, %+v", name, err, result)
+		}
+		var state ReadStateResult
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &state); err != nil {
+			t.Fatalf("unmarshal %s result: %v", name, err)
+		}
+		return &state
+	}
+	unreadTitles := func() []string {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      toolGetSyndicationFeedItems,
+			Arguments: map[string]any{keyID: "feed-1", "unreadOnly": true},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("CallTool: %v, %+v", err, result)
+		}
+		var got []string
+		for _, block := range result.Content[1:] {
+			var item map[string]any
+			if err := json.Unmarshal([]byte(block.(*mcp.TextContent).Text), &item); err != nil {
+				t.Fatalf("unmarshal item: %v", err)
+			}
+			got = append(got, item["title"].(string))
+		}
+		return got
+	}
+
+	// Items can be named by GUID or link; unknown IDs are reported.
+	state := call(toolMarkRead, map[string]any{keyFeedID: "feed-1", "itemIds": []string{"urn:post:1", "https://example.com/second", "urn:post:9"}})
+	if state.Changed != 2 || state.UnreadItems != 1 || !slices.Equal(state.NotFound, []string{"urn:post:9"}) {
+		t.Errorf("mark_read = %+v, want 2 changed, 1 unread, urn:post:9 not found", state)
+	}
+	if got := unreadTitles(); !slices.Equal(got, []string{"third"}) {
+		t.Errorf("unreadOnly items = %v, want [third]", got)
+	}
+
+	state = call(toolMarkUnread, map[string]any{keyFeedID: "feed-1", "itemIds": []string{"urn:post:2"}})
+	if state.Changed != 1 || state.UnreadItems != 2 {
+		t.Errorf("mark_unread = %+v, want 1 changed, 2 unread", state)
+	}
+	if got := unreadTitles(); !slices.Equal(got, []string{"second", "third"}) {
+		t.Errorf("unreadOnly items = %v, want [second third]", got)
+	}
+
+	state = call(toolMarkAllRead, map[string]any{keyFeedID: "feed-1"})
+	if state.Changed != 2 || state.UnreadItems != 0 {
+		t.Errorf("mark_all_read = %+v, want 2 changed, 0 unread", state)
+	}
+	if got := unreadTitles(); len(got) != 0 {
+		t.Errorf("unreadOnly items = %v, want none", got)
+	}
+}
+
+func TestReadStates_PerSession(t *testing.T) {
+	rs, err := newReadStates("")
+	if err != nil {
+		t.Fatalf("newReadStates: %v", err)
+	}
+	items := []*gofeed.Item{{Title: "a", GUID: "a"}, {Title: "b", GUID: "b"}}
+	if _, err := rs.markRead("session-1", []string{model.ItemStableID(items[0])}, time.Now()); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	if got := rs.unread("session-1", items); len(got) != 1 || got[0].GUID != "b" {
+		t.Errorf("session-1 unread = %v, want [b]", itemTitles(got))
+	}
+	if got := rs.unread("session-2", items); len(got) != 2 {
+		t.Errorf("session-2 unread = %v, want both items", itemTitles(got))
+	}
+}
+
+func TestReadStates_Persisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "read-state.json")
+	now := time.Now()
+	items := []*gofeed.Item{{Title: "a", GUID: "a"}, {Title: "b", GUID: "b"}}
+	a, b := model.ItemStableID(items[0]), model.ItemStableID(items[1])
+	rs, err := newReadStates(path)
+	if err != nil {
+		t.Fatalf("newReadStates: %v", err)
+	}
+	if _, err := rs.markRead(defaultReadSession, []string{a, b}, now); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	// HTTP sessions are kept in memory only.
+	if _, err := rs.markRead("session-1", []string{a}, now); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	if _, err := rs.markUnread(defaultReadSession, []string{b}, now); err != nil {
+		t.Fatalf("markUnread: %v", err)
+	}
+
+	reloaded, err := newReadStates(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := reloaded.unread(defaultReadSession, items); len(got) != 1 || got[0].GUID != "b" {
+		t.Errorf("reloaded unread = %v, want [b]", itemTitles(got))
+	}
+	if _, ok := reloaded.sessions["session-1"]; ok {
+		t.Error("an HTTP session was persisted")
+	}
+}
+
+func TestReadStates_CorruptFileRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "read-state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := newReadStates(path)
+	if err != nil {
+		t.Fatalf("newReadStates with a corrupt file: %v", err)
+	}
+	if len(rs.sessions) != 0 {
+		t.Errorf("sessions = %v, want none", rs.sessions)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("corrupt file still at %s (err %v)", path, err)
+	}
+	backups, _ := filepath.Glob(path + ".corrupt-*")
+	if len(backups) != 1 {
+		t.Fatalf("backups = %v, want one", backups)
+	}
+	if data, _ := os.ReadFile(backups[0]); string(data) != "{not json" {
+		t.Errorf("backup content = %q", data)
+	}
+}
+
+func TestReadStates_IdleSessionPruned(t *testing.T) {
+	rs, err := newReadStates("")
+	if err != nil {
+		t.Fatalf("newReadStates: %v", err)
+	}
+	now := time.Now()
+	if _, err := rs.markRead("stale", []string{"a"}, now.Add(-2*readSessionIdleTimeout)); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	if _, err := rs.markRead(defaultReadSession, []string{"a"}, now.Add(-2*readSessionIdleTimeout)); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	// An HTTP session idle past the timeout is dropped on the next change.
+	if _, err := rs.markRead("session-1", []string{"a"}, now); err != nil {
+		t.Fatalf("markRead: %v", err)
+	}
+	if _, ok := rs.sessions["stale"]; ok {
+		t.Error("the idle session was kept")
+	}
+	if _, ok := rs.sessions[defaultReadSession]; !ok {
+		t.Error("the default session was pruned")
+	}
+}
@@ -2,11 +2,13 @@ package mcpserver
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/eko/gocache/lib/v4/store"
 	"github.com/mmcdole/gofeed"
 
 	"github.com/richardwooding/feed-mcp/model"
@@ -216,6 +218,53 @@ func TestCacheMetrics(t *testing.T) {
 	}
 }
 
+func TestCacheMetrics_Evictions(t *testing.T) {
+	config := DefaultResourceCacheConfig()
+	config.MaxCost = 4096
+	rm := NewResourceManagerWithConfig(&mockAllFeedsGetter{}, &mockFeedAndItemsGetter{}, config)
+	ctx := context.Background()
+
+	// Each entry costs about 1KB, so forty of them overflow the 4KB budget
+	value := strings.Repeat("x", 1024)
+	for i := range 40 {
+		_ = rm.resourceCache.Set(ctx, fmt.Sprintf("feeds://feed/%d", i), value, store.WithCost(int64(len(value))))
+	}
+
+	// Ristretto applies writes asynchronously, so poll for the evictions
+	deadline := time.Now().Add(2 * time.Second)
+	for rm.GetCacheMetrics().Evictions == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if evictions := rm.GetCacheMetrics().Evictions; evictions == 0 {
+		t.Fatal("Expected filling the cache past MaxCost to record evictions")
+	}
+}
+
+func TestCacheMetrics_InvalidateCacheNotCountedAsEvictions(t *testing.T) {
+	rm := NewResourceManager(&mockAllFeedsGetter{}, &mockFeedAndItemsGetter{})
+	ctx := context.Background()
+
+	_ = rm.resourceCache.Set(ctx, "feeds://feed/a", "content")
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := rm.resourceCache.Get(ctx, "feeds://feed/a"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := rm.InvalidateCache(ctx); err != nil {
+		t.Fatalf("InvalidateCache failed: %v", err)
+	}
+	metrics := rm.GetCacheMetrics()
+	if metrics.Evictions != 0 {
+		t.Errorf("Expected no evictions after InvalidateCache, got %d", metrics.Evictions)
+	}
+	if metrics.InvalidationHits != 1 {
+		t.Errorf("Expected 1 invalidation, got %d", metrics.InvalidationHits)
+	}
+}
+
 func TestCacheInvalidationHooks(t *testing.T) {
 	rm := NewResourceManager(&mockAllFeedsGetter{}, &mockFeedAndItemsGetter{})
 
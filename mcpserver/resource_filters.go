@@ -16,13 +16,15 @@ import (
 // FilterParams represents parsed URI parameters for filtering
 type FilterParams struct {
 	// Existing filters
-	Since    *time.Time // Filter items since this date
-	Until    *time.Time // Filter items until this date
-	Limit    *int       // Maximum number of items to return
-	Offset   *int       // Number of items to skip (for pagination)
-	Category string     // Filter by category/tag
-	Author   string     // Filter by author
-	Search   string     // Search in title/description
+	Since     *time.Time // Filter items since this date
+	Until     *time.Time // Filter items until this date
+	DateField string     // Date since/until compare: published (default) or updated
+	Limit     *int       // Maximum number of items to return
+	Offset    *int       // Number of items to skip (for pagination)
+	Category  string     // Filter by category/tag
+	Author    string     // Filter by author
+	Authors   []string   // Filter by any of these authors
+	Search    string     // Search in title/description
 
 	// Enhanced filters (Phase 2)
 	Language   string // Filter by language (en, es, fr, etc.)
@@ -33,6 +35,12 @@ type FilterParams struct {
 	Duplicates *bool  // Include/exclude duplicate content
 	SortBy     string // date, relevance, popularity
 	Format     string // json, xml, html, markdown
+
+	// Substantive keeps only substantive items (true) or only stubs (false);
+	// SubstantiveMinLength is the content length below which an item is a
+	// stub (0: DefaultSubstantiveMinLength).
+	Substantive          *bool
+	SubstantiveMinLength int
 }
 
 // ParseURIParameters extracts and validates filter parameters from a resource URI
@@ -173,9 +181,15 @@ func parseBasicStringParams(query url.Values, params *FilterParams) {
 	if author := query.Get("author"); author != "" {
 		params.Author = author
 	}
+	if authors := query.Get("authors"); authors != "" {
+		params.Authors = splitAuthors(authors)
+	}
 	if search := query.Get("search"); search != "" {
 		params.Search = search
 	}
+	if dateField := query.Get("date_field"); dateField == dateFieldPublished || dateField == dateFieldUpdated {
+		params.DateField = dateField
+	}
 }
 
 // parseEnhancedStringParams parses Phase 2 enhanced string parameters
@@ -212,7 +226,7 @@ func isValidFormat(format string) bool {
 	return format == formatJSON || format == formatXML || format == formatHTML || format == formatMarkdown
 }
 
-// parseBooleanParameters handles has_media and duplicates parameter parsing
+// parseBooleanParameters handles has_media, substantive, and duplicates parameter parsing
 func parseBooleanParameters(query url.Values, params *FilterParams, resourceURI string) error {
 	// Parse 'has_media' parameter
 	if hasMediaStr := query.Get("has_media"); hasMediaStr != "" {
@@ -226,6 +240,18 @@ func parseBooleanParameters(query url.Values, params *FilterParams, resourceURI
 		params.HasMedia = &hasMedia
 	}
 
+	// Parse 'substantive' parameter
+	if substantiveStr := query.Get("substantive"); substantiveStr != "" {
+		substantive, err := strconv.ParseBool(substantiveStr)
+		if err != nil {
+			return model.NewFeedError(model.ErrorTypeValidation, "Invalid 'substantive' value: must be true or false").
+				WithURL(resourceURI).
+				WithOperation("parse_substantive_parameter").
+				WithComponent("resource_filters")
+		}
+		params.Substantive = &substantive
+	}
+
 	// Parse 'duplicates' parameter
 	if duplicatesStr := query.Get("duplicates"); duplicatesStr != "" {
 		duplicates, err := strconv.ParseBool(duplicatesStr)
@@ -312,15 +338,16 @@ func shouldIncludeItem(item *gofeed.Item, filters *FilterParams) bool {
 
 // passesDateFilters checks if item passes date-based filtering
 func passesDateFilters(item *gofeed.Item, filters *FilterParams) bool {
-	if item.PublishedParsed == nil {
+	date := itemDate(item, filters.DateField)
+	if date == nil {
 		return true
 	}
 
-	if filters.Since != nil && item.PublishedParsed.Before(*filters.Since) {
+	if filters.Since != nil && date.Before(*filters.Since) {
 		return false
 	}
 
-	if filters.Until != nil && item.PublishedParsed.After(*filters.Until) {
+	if filters.Until != nil && date.After(*filters.Until) {
 		return false
 	}
 
@@ -337,6 +364,10 @@ func passesBasicFilters(item *gofeed.Item, filters *FilterParams) bool {
 		return false
 	}
 
+	if len(filters.Authors) > 0 && !hasAnyAuthor(item, filters.Authors) {
+		return false
+	}
+
 	if filters.Search != "" && !matchesSearch(item, filters.Search) {
 		return false
 	}
@@ -365,7 +396,7 @@ func passesEnhancedFilters(item *gofeed.Item, filters *FilterParams) bool {
 	return true
 }
 
-// passesContentLengthFilter checks min/max content length filters
+// passesContentLengthFilter checks min/max content length and substantive filters
 func passesContentLengthFilter(item *gofeed.Item, filters *FilterParams) bool {
 	contentLength := getContentLength(item)
 
@@ -377,6 +408,10 @@ func passesContentLengthFilter(item *gofeed.Item, filters *FilterParams) bool {
 		return false
 	}
 
+	if filters.Substantive != nil && isStub(item, filters.SubstantiveMinLength) == *filters.Substantive {
+		return false
+	}
+
 	return true
 }
 
@@ -491,6 +526,27 @@ func buildAppliedFiltersMap(filters *FilterParams) map[string]any {
 	return appliedFilters
 }
 
+// hasAnyAuthor checks if an item has at least one of the specified authors
+func hasAnyAuthor(item *gofeed.Item, authors []string) bool {
+	for _, author := range authors {
+		if hasAuthor(item, author) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAuthors splits a comma-separated authors parameter, dropping blanks
+func splitAuthors(authors string) []string {
+	var names []string
+	for name := range strings.SplitSeq(authors, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // addBasicFiltersToMap adds basic filter parameters to the map
 func addBasicFiltersToMap(appliedFilters map[string]any, filters *FilterParams) {
 	if filters.Since != nil {
@@ -499,6 +555,9 @@ func addBasicFiltersToMap(appliedFilters map[string]any, filters *FilterParams)
 	if filters.Until != nil {
 		appliedFilters["until"] = filters.Until.Format(time.RFC3339)
 	}
+	if filters.DateField != "" {
+		appliedFilters["date_field"] = filters.DateField
+	}
 	if filters.Limit != nil {
 		appliedFilters["limit"] = *filters.Limit
 	}
@@ -511,6 +570,9 @@ func addBasicFiltersToMap(appliedFilters map[string]any, filters *FilterParams)
 	if filters.Author != "" {
 		appliedFilters["author"] = filters.Author
 	}
+	if len(filters.Authors) > 0 {
+		appliedFilters["authors"] = filters.Authors
+	}
 	if filters.Search != "" {
 		appliedFilters["search"] = filters.Search
 	}
@@ -527,6 +589,9 @@ func addEnhancedFiltersToMap(appliedFilters map[string]any, filters *FilterParam
 	if filters.MaxLength != nil {
 		appliedFilters["max_length"] = *filters.MaxLength
 	}
+	if filters.Substantive != nil {
+		appliedFilters["substantive"] = *filters.Substantive
+	}
 	if filters.HasMedia != nil {
 		appliedFilters["has_media"] = *filters.HasMedia
 	}
@@ -579,46 +644,22 @@ func hasLanguageInMetadata(item *gofeed.Item, language string) bool {
 		if lang, exists := item.Custom["lang"]; exists && strings.EqualFold(lang, language) {
 			return true
 		}
+		if lang, exists := item.Custom[model.ItemLanguageKey]; exists && strings.EqualFold(lang, language) {
+			return true
+		}
 	}
 
 	return false
 }
 
-// hasLanguageInContent uses simple heuristics to detect language in content
+// hasLanguageInContent uses common-word heuristics to detect language in
+// content; languages the detector doesn't know fall back to a substring match.
 func hasLanguageInContent(item *gofeed.Item, language string) bool {
 	content := strings.ToLower(item.Title + " " + item.Description + " " + item.Content)
-
-	switch language {
-	case "en", "english":
-		return detectEnglishWords(content)
-	case "es", "spanish":
-		return detectSpanishWords(content)
-	default:
-		return strings.Contains(content, language)
-	}
-}
-
-// detectEnglishWords checks for common English words
-func detectEnglishWords(content string) bool {
-	englishWords := []string{"the", "and", "that", "have", "for", "not", "with", "you", "this", "but"}
-	return countWordMatches(content, englishWords) >= 3
-}
-
-// detectSpanishWords checks for common Spanish words
-func detectSpanishWords(content string) bool {
-	spanishWords := []string{"que", "con", "para", "una", "por", "como", "del", "los", "las", "más"}
-	return countWordMatches(content, spanishWords) >= 3
-}
-
-// countWordMatches counts how many words from the list appear in content
-func countWordMatches(content string, words []string) int {
-	matches := 0
-	for _, word := range words {
-		if strings.Contains(content, " "+word+" ") {
-			matches++
-		}
+	if model.LanguageCode(language) != "" {
+		return model.LooksLikeLanguage(content, language)
 	}
-	return matches
+	return strings.Contains(content, language)
 }
 
 // getContentLength calculates the approximate content length of a feed item
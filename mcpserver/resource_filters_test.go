@@ -1,6 +1,7 @@
 package mcpserver
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -68,6 +69,14 @@ func TestParseURIParameters(t *testing.T) {
 				Author: "john",
 			},
 		},
+		{
+			name:        "Valid authors parameter",
+			uri:         "feeds://feed/test-feed/items?authors=jane%20smith,%20bob,,",
+			expectError: false,
+			expected: &FilterParams{
+				Authors: []string{"jane smith", "bob"},
+			},
+		},
 		{
 			name:        "Valid search parameter",
 			uri:         "feeds://feed/test-feed/items?search=golang",
@@ -272,6 +281,17 @@ func TestApplyFilters(t *testing.T) {
 				"Advanced JavaScript Techniques",
 			},
 		},
+		{
+			name: "Authors filter matches any author",
+			filters: &FilterParams{
+				Authors: []string{"jane smith", "Bob Wilson", "Nobody"},
+			},
+			expectedCount: 2,
+			expectedTitles: []string{
+				"Advanced JavaScript Techniques",
+				"Web Design Trends 2023",
+			},
+		},
 		{
 			name: "Search filter (title)",
 			filters: &FilterParams{
@@ -372,6 +392,23 @@ func TestHasAuthor(t *testing.T) {
 	}
 }
 
+func TestHasAnyAuthor(t *testing.T) {
+	item := &gofeed.Item{
+		Author:  &gofeed.Person{Name: "John Doe"},
+		Authors: []*gofeed.Person{{Name: "Jane Smith"}},
+	}
+
+	if !hasAnyAuthor(item, []string{"Alice Johnson", "jane smith"}) {
+		t.Error("Should match when any listed author wrote the item")
+	}
+	if hasAnyAuthor(item, []string{"Alice Johnson", "Bob Wilson"}) {
+		t.Error("Should not match when no listed author wrote the item")
+	}
+	if hasAnyAuthor(item, nil) {
+		t.Error("Should not match an empty author list")
+	}
+}
+
 func TestMatchesSearch(t *testing.T) {
 	item := &gofeed.Item{
 		Title:       "Go Programming Tutorial",
@@ -512,5 +549,5 @@ func compareFilterParams(a, b *FilterParams) bool {
 	}
 
 	// Compare strings
-	return a.Category == b.Category && a.Author == b.Author && a.Search == b.Search
+	return a.Category == b.Category && a.Author == b.Author && slices.Equal(a.Authors, b.Authors) && a.Search == b.Search
 }
@@ -0,0 +1,122 @@
+package mcpserver
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// refreshURIParam is the feed resource URI parameter that forces a fresh
+// fetch of the feed instead of serving cached content.
+const refreshURIParam = "refresh"
+
+// DefaultResourceRefreshInterval is the default minimum time between two
+// forced refreshes of one feed by resource reads.
+const DefaultResourceRefreshInterval = 30 * time.Second
+
+// refreshLimiter allows each feed one forced refresh per interval, so clients
+// reading with refresh=true in a loop can't hammer the feed's origin.
+type refreshLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	last     map[string]time.Time // feed ID -> time of its last forced refresh
+}
+
+// newRefreshLimiter returns a refreshLimiter allowing one refresh per feed
+// per interval.
+func newRefreshLimiter(interval time.Duration) *refreshLimiter {
+	return &refreshLimiter{interval: interval, last: make(map[string]time.Time)}
+}
+
+// allow reports whether feedID may be refreshed at now, and if so records the
+// refresh. A nil limiter allows every refresh.
+func (l *refreshLimiter) allow(feedID string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if last, ok := l.last[feedID]; ok && now.Sub(last) < l.interval {
+		return false
+	}
+	// Drop expired entries so feed IDs that are never read again don't pile up.
+	for id, last := range l.last {
+		if now.Sub(last) >= l.interval {
+			delete(l.last, id)
+		}
+	}
+	l.last[feedID] = now
+	return true
+}
+
+// applyRefresh handles the refresh parameter of a feed or feed items resource
+// read. It returns uri without the parameter, which the read then serves and
+// caches, so a refreshed read also replaces what later plain reads get.
+//
+// With refresh=true, the feed is purged from the store, when the store is a
+// FeedCachePurger, and its resources from the resource cache, so the read
+// fetches it again. Within the refresh interval of the feed's last forced
+// refresh the parameter is ignored and the read is served as usual.
+func (rm *ResourceManager) applyRefresh(ctx context.Context, uri, template string) (string, error) {
+	baseURI, refresh, err := splitRefreshParam(uri)
+	if err != nil || !refresh {
+		return baseURI, err
+	}
+	feedID, err := extractFeedIDFromURI(baseURI, template)
+	if err != nil {
+		return "", err
+	}
+	if !rm.refreshLimiter.allow(feedID, time.Now()) {
+		return baseURI, nil
+	}
+
+	if purger := rm.capabilities.feedCachePurger; purger != nil {
+		// An unknown feed fails the read itself with the usual not found error.
+		_, _ = purger.PurgeFeedCache(ctx, feedID)
+	}
+	_ = rm.InvalidateFeedCache(ctx, feedID) // in-memory; deletion errors are not critical
+	if parsed, err := url.Parse(baseURI); err == nil && parsed.RawQuery != "" {
+		// Filtered reads are cached under their own keys.
+		_ = rm.InvalidateResourceCache(ctx, baseURI)
+	}
+	return baseURI, nil
+}
+
+// splitRefreshParam removes the refresh parameter from uri, keeping the other
+// parameters as they were, and reports whether it asked for a refresh. URIs
+// without the parameter are returned unchanged.
+func splitRefreshParam(uri string) (string, bool, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.RawQuery == "" {
+		// Parse errors are reported by the read itself.
+		return uri, false, nil
+	}
+
+	var kept []string
+	found, refresh := false, false
+	for part := range strings.SplitSeq(parsed.RawQuery, "&") {
+		key, value, _ := strings.Cut(part, "=")
+		if name, err := url.QueryUnescape(key); err != nil || name != refreshURIParam {
+			kept = append(kept, part)
+			continue
+		}
+		found = true
+		value, _ = url.QueryUnescape(value)
+		if refresh, err = strconv.ParseBool(value); err != nil {
+			return "", false, model.NewFeedError(model.ErrorTypeValidation, "Invalid 'refresh' value: must be true or false").
+				WithURL(uri).
+				WithOperation("parse_refresh_parameter").
+				WithComponent("resource_filters")
+		}
+	}
+	if !found {
+		return uri, false, nil
+	}
+	parsed.RawQuery = strings.Join(kept, "&")
+	return parsed.String(), refresh, nil
+}
@@ -0,0 +1,158 @@
+package mcpserver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// cachingFeedStore stands in for the feed store: it counts the backend
+// fetches of each feed, fetching only feeds that aren't cached or were purged.
+type cachingFeedStore struct {
+	mockFeedAndItemsGetter
+	mu      sync.Mutex
+	cached  map[string]bool
+	fetches map[string]int
+}
+
+func newCachingFeedStore(feedIDs ...string) *cachingFeedStore {
+	feedMap := make(map[string]*model.FeedAndItemsResult)
+	for _, id := range feedIDs {
+		feedMap[id] = &model.FeedAndItemsResult{ID: id, Title: id}
+	}
+	return &cachingFeedStore{
+		mockFeedAndItemsGetter: mockFeedAndItemsGetter{feedMap: feedMap},
+		cached:                 make(map[string]bool),
+		fetches:                make(map[string]int),
+	}
+}
+
+func (c *cachingFeedStore) GetFeedAndItems(ctx context.Context, id string) (*model.FeedAndItemsResult, error) {
+	c.mu.Lock()
+	if !c.cached[id] {
+		c.cached[id] = true
+		c.fetches[id]++
+	}
+	c.mu.Unlock()
+	return c.mockFeedAndItemsGetter.GetFeedAndItems(ctx, id)
+}
+
+func (c *cachingFeedStore) PurgeFeedCache(ctx context.Context, feedID string) (*FeedCachePurge, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wasCached := c.cached[feedID]
+	delete(c.cached, feedID)
+	return &FeedCachePurge{FeedID: feedID, WasCached: wasCached}, nil
+}
+
+func (c *cachingFeedStore) fetchCount(id string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetches[id]
+}
+
+func TestReadResource_Refresh(t *testing.T) {
+	ctx := context.Background()
+	feedStore := newCachingFeedStore("feed-1", "feed-2")
+	rm := NewResourceManagerWithConfig(&mockAllFeedsGetter{}, feedStore, &ResourceCacheConfig{RefreshInterval: time.Hour})
+	rm.capabilities.feedCachePurger = feedStore
+
+	read := func(uri string) {
+		t.Helper()
+		result, err := rm.ReadResource(ctx, uri)
+		if err != nil {
+			t.Fatalf("ReadResource(%s): %v", uri, err)
+		}
+		if got := result.Contents[0].URI; got != "feeds://feed/feed-1" && got != "feeds://feed/feed-2/items?limit=5" {
+			t.Errorf("ReadResource(%s) served %s, want the URI without refresh", uri, got)
+		}
+	}
+
+	// Warm the resource cache. ristretto applies writes asynchronously, so
+	// read until one is served from the cache.
+	uri := "feeds://feed/feed-1"
+	deadline := time.Now().Add(2 * time.Second)
+	for rm.GetCacheMetrics().Hits == 0 {
+		read(uri)
+		if time.Now().After(deadline) {
+			t.Fatal("resource was never cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	read(uri)
+	if got := feedStore.fetchCount("feed-1"); got != 1 {
+		t.Fatalf("plain reads fetched the feed %d times, want 1", got)
+	}
+
+	read(uri + "?refresh=true")
+	if got := feedStore.fetchCount("feed-1"); got != 2 {
+		t.Errorf("refresh=true: feed fetched %d times, want 2", got)
+	}
+
+	// Within the refresh interval, refresh=true is served as a plain read.
+	read(uri + "?refresh=true")
+	if got := feedStore.fetchCount("feed-1"); got != 2 {
+		t.Errorf("rate-limited refresh: feed fetched %d times, want still 2", got)
+	}
+
+	// Feed items with other parameters, which keep their order.
+	itemsURI := "feeds://feed/feed-2/items?limit=5"
+	read(itemsURI)
+	read(itemsURI)
+	read("feeds://feed/feed-2/items?refresh=1&limit=5")
+	if got := feedStore.fetchCount("feed-2"); got != 2 {
+		t.Errorf("items refresh: feed fetched %d times, want 2", got)
+	}
+	read("feeds://feed/feed-2/items?limit=5&refresh=false")
+	if got := feedStore.fetchCount("feed-2"); got != 2 {
+		t.Errorf("refresh=false: feed fetched %d times, want still 2", got)
+	}
+
+	if _, err := rm.ReadResource(ctx, uri+"?refresh=soon"); err == nil {
+		t.Error("refresh=soon: expected a validation error")
+	}
+}
+
+func TestSplitRefreshParam(t *testing.T) {
+	tests := []struct {
+		uri, want string
+		refresh   bool
+	}{
+		{"feeds://feed/abc", "feeds://feed/abc", false},
+		{"feeds://feed/abc?refresh=true", "feeds://feed/abc", true},
+		{"feeds://feed/abc/items?since=2024-01-01T00:00:00Z&refresh=TRUE&limit=5", "feeds://feed/abc/items?since=2024-01-01T00:00:00Z&limit=5", true},
+		{"feeds://feed/abc/items?limit=5&refresh=0", "feeds://feed/abc/items?limit=5", false},
+		{"feeds://feed/abc/items?search=refresh%3Dtrue", "feeds://feed/abc/items?search=refresh%3Dtrue", false},
+	}
+	for _, tt := range tests {
+		got, refresh, err := splitRefreshParam(tt.uri)
+		if err != nil || got != tt.want || refresh != tt.refresh {
+			t.Errorf("splitRefreshParam(%q) = %q, %v, %v; want %q, %v", tt.uri, got, refresh, err, tt.want, tt.refresh)
+		}
+	}
+}
+
+func TestRefreshLimiter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRefreshLimiter(time.Minute)
+	if !limiter.allow("a", now) || !limiter.allow("b", now) {
+		t.Fatal("first refreshes should be allowed")
+	}
+	if limiter.allow("a", now.Add(30*time.Second)) {
+		t.Error("refresh within the interval should be refused")
+	}
+	if !limiter.allow("a", now.Add(time.Minute)) {
+		t.Error("refresh after the interval should be allowed")
+	}
+	if _, ok := limiter.last["b"]; ok {
+		t.Error("expired entry for b was not dropped")
+	}
+
+	var none *refreshLimiter
+	if !none.allow("a", now) || !none.allow("a", now) {
+		t.Error("a nil limiter should allow every refresh")
+	}
+}
@@ -40,33 +40,11 @@ func buildTestServerSession(t *testing.T, feedID, publicURL string, items []*gof
 		},
 	}}
 
-	srv, err := NewServer(&Config{
+	return newTestClientSession(t, &Config{
 		Transport:          model.StdioTransport,
 		AllFeedsGetter:     allFeeds,
 		FeedAndItemsGetter: feedGetter,
 	})
-	if err != nil {
-		t.Fatalf("NewServer: %v", err)
-	}
-
-	mcpServer := srv.buildMCPServer()
-
-	ctx := context.Background()
-	clientTransport, serverTransport := mcp.NewInMemoryTransports()
-	serverSession, err := mcpServer.Connect(ctx, serverTransport, nil)
-	if err != nil {
-		t.Fatalf("server connect: %v", err)
-	}
-	t.Cleanup(func() { _ = serverSession.Close() })
-
-	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0.0.1"}, nil)
-	clientSession, err := client.Connect(ctx, clientTransport, nil)
-	if err != nil {
-		t.Fatalf("client connect: %v", err)
-	}
-	t.Cleanup(func() { _ = clientSession.Close() })
-
-	return clientSession
 }
 
 func makeTestItems(n int) []*gofeed.Item {
@@ -11,12 +11,14 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/eko/gocache/lib/v4/cache"
 	"github.com/eko/gocache/lib/v4/store"
 	ristretto_store "github.com/eko/gocache/store/ristretto/v4"
+	"github.com/mmcdole/gofeed"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/richardwooding/feed-mcp/model"
@@ -29,6 +31,7 @@ const (
 	FeedItemsURI     = "feeds://feed/{feedId}/items"
 	FeedMetaURI      = "feeds://feed/{feedId}/meta"
 	ParameterDocsURI = "feeds://parameters"
+	DiagnosticsURI   = "feeds://diagnostics"
 )
 
 // MIME type constants
@@ -37,12 +40,13 @@ const (
 )
 
 // ParameterDocsSummary is the concise parameter documentation string used in resource descriptions
-const ParameterDocsSummary = "URI parameters: since/until (ISO 8601 date), limit (0-1000), offset (0+), category/author/search (text), language (en/es/fr/etc), min_length/max_length (chars), has_media (true/false), sentiment (positive/negative/neutral), duplicates (true/false), sort_by (date/relevance/popularity), format (json/xml/html/markdown)"
+const ParameterDocsSummary = "URI parameters: since/until (ISO 8601 date), date_field (published/updated), limit (0-1000), offset (0+), category/author/search (text), authors (comma-separated, any match), language (en/es/fr/etc), min_length/max_length (chars), substantive (true/false), has_media (true/false), sentiment (positive/negative/neutral), duplicates (true/false), sort_by (date/relevance/popularity), format (json/xml/html/markdown), refresh (true: fetch the feed again instead of serving the cache)"
 
 // ResourceManager handles MCP resource operations for feeds
 type ResourceManager struct {
 	store                AllFeedsGetter
 	feedAndItemsGetter   FeedAndItemsGetter
+	capabilities         storeCapabilities // Optional feed store capabilities; set by NewServer
 	sessions             map[string]*ResourceSession
 	resourceCache        *cache.Cache[string]  // Cache for serialized resource content
 	cacheConfig          *ResourceCacheConfig  // Cache configuration
@@ -50,6 +54,11 @@ type ResourceManager struct {
 	invalidationHooks    []func(uri string)    // Cache invalidation hooks for notifications
 	pendingNotifications map[string]time.Time  // URIs needing notification -> timestamp
 	mu                   sync.RWMutex
+	// fetchGate bounds the concurrent upstream fetches resource reads cause;
+	// see fetchContext.
+	fetchGate *model.FetchGate
+	// refreshLimiter rate limits reads with refresh=true; see applyRefresh.
+	refreshLimiter *refreshLimiter
 }
 
 // ResourceSession tracks subscription state for a client session
@@ -64,9 +73,22 @@ type ResourceSession struct {
 type ResourceCacheMetrics struct {
 	Hits             uint64
 	Misses           uint64
-	Evictions        uint64
+	Evictions        uint64 // Entries dropped to stay under MaxCost or on expiry
 	InvalidationHits uint64 // Cache invalidations triggered
 	mu               sync.RWMutex
+	clearing         atomic.Bool // Set while InvalidateCache empties the cache
+}
+
+// recordEviction increments the eviction counter. Ristretto calls it from its
+// own goroutines, once per entry it drops. Entries removed by InvalidateCache
+// are not evictions and aren't counted.
+func (m *ResourceCacheMetrics) recordEviction() {
+	if m.clearing.Load() {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Evictions++
 }
 
 // ResourceCacheConfig holds resource-specific cache configuration
@@ -75,9 +97,42 @@ type ResourceCacheConfig struct {
 	FeedListTTL     time.Duration // TTL for feed list resources
 	FeedItemsTTL    time.Duration // TTL for feed items resources
 	FeedMetadataTTL time.Duration // TTL for feed metadata resources
+	DiagnosticsTTL  time.Duration // TTL for the diagnostics resource
 	MaxCost         int64         // Maximum cache size in bytes
 	NumCounters     int64         // Number of keys to track frequency
 	BufferItems     int64         // Number of keys per Get buffer
+	// MaxConcurrentFetches bounds how many upstream fetches (feeds on cache
+	// misses, icon lookups) resource reads run at once, across all reads and
+	// across the feeds of one feeds://all read; further fetches wait for a slot.
+	MaxConcurrentFetches int
+	// SubstantiveMinLength is the content length below which the substantive
+	// URI filter treats an item as a stub. Zero means
+	// DefaultSubstantiveMinLength.
+	SubstantiveMinLength int
+	// RefreshInterval is the minimum time between two forced refreshes of one
+	// feed by reads with refresh=true; reads within it are served as usual.
+	RefreshInterval time.Duration
+}
+
+// DefaultMaxConcurrentResourceFetches is the default bound on concurrent feed
+// fetches by resource reads.
+const DefaultMaxConcurrentResourceFetches = 8
+
+// DefaultResourceCacheConfig returns the cache configuration used when none is
+// given.
+func DefaultResourceCacheConfig() *ResourceCacheConfig {
+	return &ResourceCacheConfig{
+		DefaultTTL:           10 * time.Minute, // Default 10 minutes TTL
+		FeedListTTL:          5 * time.Minute,  // Feed list changes less frequently
+		FeedItemsTTL:         10 * time.Minute, // Feed items change regularly
+		FeedMetadataTTL:      15 * time.Minute, // Metadata changes less frequently
+		DiagnosticsTTL:       5 * time.Second,  // Diagnostics should be near-live
+		MaxCost:              1 << 30,          // 1GB max size
+		NumCounters:          1000,             // Track frequency of 1000 keys
+		BufferItems:          64,               // Buffer 64 keys per Get
+		MaxConcurrentFetches: DefaultMaxConcurrentResourceFetches,
+		RefreshInterval:      DefaultResourceRefreshInterval,
+	}
 }
 
 // NewResourceManager creates a new ResourceManager with configurable cache settings
@@ -89,15 +144,7 @@ func NewResourceManager(feedStore AllFeedsGetter, feedAndItemsGetter FeedAndItem
 func NewResourceManagerWithConfig(feedStore AllFeedsGetter, feedAndItemsGetter FeedAndItemsGetter, config *ResourceCacheConfig) *ResourceManager {
 	// Set default cache configuration if not provided
 	if config == nil {
-		config = &ResourceCacheConfig{
-			DefaultTTL:      10 * time.Minute, // Default 10 minutes TTL
-			FeedListTTL:     5 * time.Minute,  // Feed list changes less frequently
-			FeedItemsTTL:    10 * time.Minute, // Feed items change regularly
-			FeedMetadataTTL: 15 * time.Minute, // Metadata changes less frequently
-			MaxCost:         1 << 30,          // 1GB max size
-			NumCounters:     1000,             // Track frequency of 1000 keys
-			BufferItems:     64,               // Buffer 64 keys per Get
-		}
+		config = DefaultResourceCacheConfig()
 	}
 
 	// Validate and set defaults for zero values
@@ -113,6 +160,9 @@ func NewResourceManagerWithConfig(feedStore AllFeedsGetter, feedAndItemsGetter F
 	if config.FeedMetadataTTL <= 0 {
 		config.FeedMetadataTTL = config.DefaultTTL
 	}
+	if config.DiagnosticsTTL <= 0 {
+		config.DiagnosticsTTL = 5 * time.Second
+	}
 	if config.MaxCost <= 0 {
 		config.MaxCost = 1 << 30 // 1GB default
 	}
@@ -122,12 +172,24 @@ func NewResourceManagerWithConfig(feedStore AllFeedsGetter, feedAndItemsGetter F
 	if config.BufferItems <= 0 {
 		config.BufferItems = 64
 	}
+	if config.MaxConcurrentFetches <= 0 {
+		config.MaxConcurrentFetches = DefaultMaxConcurrentResourceFetches
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultResourceRefreshInterval
+	}
 
-	// Create Ristretto cache for resource content
+	// Create Ristretto cache for resource content. Entries cost their size in
+	// bytes, so MaxCost bounds the cache's memory and evictions reflect real
+	// cache pressure.
+	metrics := &ResourceCacheMetrics{}
 	ristrettoCache, _ := ristretto.NewCache[string, string](&ristretto.Config[string, string]{
 		NumCounters: config.NumCounters,
 		MaxCost:     config.MaxCost,
 		BufferItems: config.BufferItems,
+		OnEvict: func(*ristretto.Item[string]) {
+			metrics.recordEviction()
+		},
 	})
 
 	ristrettoStore := ristretto_store.NewRistretto(ristrettoCache)
@@ -139,12 +201,33 @@ func NewResourceManagerWithConfig(feedStore AllFeedsGetter, feedAndItemsGetter F
 		sessions:             make(map[string]*ResourceSession),
 		resourceCache:        resourceCache,
 		cacheConfig:          config,
-		cacheMetrics:         &ResourceCacheMetrics{},
+		cacheMetrics:         metrics,
 		invalidationHooks:    make([]func(string), 0),
 		pendingNotifications: make(map[string]time.Time),
+		fetchGate:            model.NewFetchGate(config.MaxConcurrentFetches),
+		refreshLimiter:       newRefreshLimiter(config.RefreshInterval),
 	}
 }
 
+// fetchContext attaches the manager's FetchGate to ctx. The feed store takes
+// a slot around each network fetch made with the returned context, so cache
+// hits never wait and a feeds://all read can't fan out past the bound. A
+// ResourceManager built without NewResourceManagerWithConfig has no gate and
+// doesn't limit fetches.
+func (rm *ResourceManager) fetchContext(ctx context.Context) context.Context {
+	return model.WithFetchGate(ctx, rm.fetchGate)
+}
+
+// getAllFeeds calls the feed store's GetAllFeeds under the fetch gate.
+func (rm *ResourceManager) getAllFeeds(ctx context.Context) ([]*model.FeedResult, error) {
+	return rm.store.GetAllFeeds(rm.fetchContext(ctx))
+}
+
+// getFeedAndItems calls GetFeedAndItems under the fetch gate.
+func (rm *ResourceManager) getFeedAndItems(ctx context.Context, feedID string) (*model.FeedAndItemsResult, error) {
+	return rm.feedAndItemsGetter.GetFeedAndItems(rm.fetchContext(ctx), feedID)
+}
+
 // CreateSession creates a new resource session
 func (rm *ResourceManager) CreateSession(sessionID string) *ResourceSession {
 	rm.mu.Lock()
@@ -214,8 +297,17 @@ func (rm *ResourceManager) ListResources(ctx context.Context) ([]*mcp.Resource,
 		},
 	)
 
+	if _, ok := rm.diagnosticsProvider(); ok {
+		resources = append(resources, &mcp.Resource{
+			URI:         DiagnosticsURI,
+			Name:        "Diagnostics",
+			Description: "Recent feed fetch errors (with correlation IDs), circuit breaker states, and retry metrics",
+			MIMEType:    JSONMIMEType,
+		})
+	}
+
 	// Get all feeds to create individual feed resources
-	feedResults, err := rm.store.GetAllFeeds(ctx)
+	feedResults, err := rm.getAllFeeds(ctx)
 	if err != nil {
 		return nil, model.CreateRetryError(err, "", 0, 0).
 			WithOperation("list_resources").
@@ -224,7 +316,7 @@ func (rm *ResourceManager) ListResources(ctx context.Context) ([]*mcp.Resource,
 
 	// Create resources for each feed
 	for _, feed := range feedResults {
-		feedID := model.GenerateFeedID(feed.PublicURL)
+		feedID := feed.ID
 
 		// Add all three feed resources at once
 		resources = append(resources,
@@ -259,6 +351,8 @@ func (rm *ResourceManager) ReadResource(ctx context.Context, uri string) (*mcp.R
 		return rm.readFeedList(ctx)
 	case uri == ParameterDocsURI:
 		return rm.readParameterDocs(ctx)
+	case uri == DiagnosticsURI:
+		return rm.readDiagnostics(ctx)
 	case matchesTemplate(uri, FeedURI):
 		return rm.readFeed(ctx, uri)
 	case matchesTemplate(uri, FeedItemsURI):
@@ -290,7 +384,7 @@ func (rm *ResourceManager) readFeedList(ctx context.Context) (*mcp.ReadResourceR
 
 	rm.recordCacheMiss()
 
-	feedResults, err := rm.store.GetAllFeeds(ctx)
+	feedResults, err := rm.getAllFeeds(ctx)
 	if err != nil {
 		return nil, model.CreateRetryError(err, "", 0, 0).
 			WithOperation("read_feed_list").
@@ -300,7 +394,7 @@ func (rm *ResourceManager) readFeedList(ctx context.Context) (*mcp.ReadResourceR
 	// Create a simplified feed list for the resource
 	feedList := make([]map[string]any, 0, len(feedResults))
 	for _, feed := range feedResults {
-		feedID := model.GenerateFeedID(feed.PublicURL)
+		feedID := feed.ID
 		feedList = append(feedList, map[string]any{
 			"id":                   feedID,
 			keyTitle:               feed.Title,
@@ -323,7 +417,7 @@ func (rm *ResourceManager) readFeedList(ctx context.Context) (*mcp.ReadResourceR
 
 	// Cache the result with appropriate TTL for this resource type
 	ttl := rm.getTTLForResourceType(FeedListURI)
-	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl))
+	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
 
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
@@ -355,6 +449,14 @@ func (rm *ResourceManager) readParameterDocs(ctx context.Context) (*mcp.ReadReso
 					keyRequired:    false,
 					keyExample:     "until=2023-12-31T23:59:59Z",
 				},
+				"date_field": map[string]any{
+					keyDescription: "Item date that since and until compare against. published falls back to the updated date for items without one; updated (Atom <updated>) falls back to the published date",
+					keyFormat:      formatStringDoc,
+					keyValues:      []string{dateFieldPublished, dateFieldUpdated},
+					keyDefault:     dateFieldPublished,
+					keyRequired:    false,
+					keyExample:     "date_field=updated",
+				},
 				"limit": map[string]any{
 					keyDescription: "Maximum number of items to return",
 					keyFormat:      formatInteger,
@@ -381,6 +483,12 @@ func (rm *ResourceManager) readParameterDocs(ctx context.Context) (*mcp.ReadReso
 					keyRequired:    false,
 					keyExample:     "author=john%20smith",
 				},
+				"authors": map[string]any{
+					keyDescription: "Comma-separated author names; keeps items by any of them (case-insensitive)",
+					keyFormat:      docTextString,
+					keyRequired:    false,
+					keyExample:     "authors=jane%20smith,john%20doe",
+				},
 				"search": map[string]any{
 					keyDescription: "Full-text search across title, description, and content (case-insensitive)",
 					keyFormat:      docTextString,
@@ -410,6 +518,13 @@ func (rm *ResourceManager) readParameterDocs(ctx context.Context) (*mcp.ReadReso
 					keyRequired:    false,
 					keyExample:     "max_length=5000",
 				},
+				"substantive": map[string]any{
+					keyDescription: "Only substantive items (true) or only stubs (false): items whose title, description, and content total less than the server's minimum content length are stubs",
+					keyFormat:      "Boolean",
+					keyValues:      []string{"true", "false"},
+					keyRequired:    false,
+					keyExample:     "substantive=true",
+				},
 				"has_media": map[string]any{
 					keyDescription: "Filter items that contain media (images, videos)",
 					keyFormat:      "Boolean",
@@ -448,6 +563,14 @@ func (rm *ResourceManager) readParameterDocs(ctx context.Context) (*mcp.ReadReso
 					keyRequired:    false,
 					keyExample:     "format=markdown",
 				},
+				refreshURIParam: map[string]any{
+					keyDescription: "Fetch the feed again instead of serving cached content (feed and feed items resources). A feed is refreshed this way at most once per the server's refresh interval; reads within it are served as usual",
+					keyFormat:      "Boolean",
+					keyValues:      []string{"true", "false"},
+					keyDefault:     "false",
+					keyRequired:    false,
+					keyExample:     "refresh=true",
+				},
 			},
 			"usage_examples": []map[string]any{
 				{
@@ -491,6 +614,11 @@ func (rm *ResourceManager) readParameterDocs(ctx context.Context) (*mcp.ReadReso
 
 // readFeed reads a complete feed resource with optional filtering
 func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	uri, err := rm.applyRefresh(ctx, uri, FeedURI)
+	if err != nil {
+		return nil, err
+	}
+
 	// Try to get from cache first
 	cacheKey := rm.generateCacheKey(uri)
 	if cachedContent, err := rm.resourceCache.Get(ctx, cacheKey); err == nil && cachedContent != "" {
@@ -518,8 +646,11 @@ func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadR
 	if err != nil {
 		return nil, err
 	}
+	if filters.Substantive != nil {
+		filters.SubstantiveMinLength = rm.cacheConfig.SubstantiveMinLength
+	}
 
-	feedResult, err := rm.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	feedResult, err := rm.getFeedAndItems(ctx, feedID)
 	if err != nil {
 		// Check if this is a specific resource error
 		var feedErr *model.FeedError
@@ -538,7 +669,7 @@ func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadR
 	// If filters are applied, filter the items
 	if filters != nil && feedResult.Items != nil {
 		originalCount := len(feedResult.Items)
-		filteredItems := ApplyFilters(feedResult.Items, filters)
+		filteredItems := ApplyFilters(rm.searchCandidates(ctx, feedID, feedResult.Items, filters), filters)
 
 		// Create a copy of the result with filtered items
 		filteredResult := *feedResult
@@ -560,7 +691,7 @@ func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadR
 
 		// Cache the result with appropriate TTL
 		ttl := rm.getTTLForResourceType(uri)
-		_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl))
+		_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
 
 		return &mcp.ReadResourceResult{
 			Contents: []*mcp.ResourceContents{
@@ -581,7 +712,7 @@ func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadR
 
 	// Cache the result with appropriate TTL
 	ttl := rm.getTTLForResourceType(uri)
-	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl))
+	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
 
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
@@ -596,6 +727,11 @@ func (rm *ResourceManager) readFeed(ctx context.Context, uri string) (*mcp.ReadR
 
 // readFeedItems reads feed items with optional filtering
 func (rm *ResourceManager) readFeedItems(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	uri, err := rm.applyRefresh(ctx, uri, FeedItemsURI)
+	if err != nil {
+		return nil, err
+	}
+
 	// Try to get from cache first
 	cacheKey := rm.generateCacheKey(uri)
 	if cachedContent, err := rm.resourceCache.Get(ctx, cacheKey); err == nil && cachedContent != "" {
@@ -623,8 +759,11 @@ func (rm *ResourceManager) readFeedItems(ctx context.Context, uri string) (*mcp.
 	if err != nil {
 		return nil, err
 	}
+	if filters.Substantive != nil {
+		filters.SubstantiveMinLength = rm.cacheConfig.SubstantiveMinLength
+	}
 
-	feedResult, err := rm.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	feedResult, err := rm.getFeedAndItems(ctx, feedID)
 	if err != nil {
 		// Check if this is a specific resource error
 		var feedErr *model.FeedError
@@ -645,7 +784,7 @@ func (rm *ResourceManager) readFeedItems(ctx context.Context, uri string) (*mcp.
 	originalCount := len(originalItems)
 
 	// Apply filters
-	filteredItems := ApplyFilters(originalItems, filters)
+	filteredItems := ApplyFilters(rm.searchCandidates(ctx, feedID, originalItems, filters), filters)
 	filteredCount := len(filteredItems)
 
 	// Create filter summary
@@ -665,7 +804,7 @@ func (rm *ResourceManager) readFeedItems(ctx context.Context, uri string) (*mcp.
 
 	// Cache the result with appropriate TTL
 	ttl := rm.getTTLForResourceType(uri)
-	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl))
+	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
 
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
@@ -702,7 +841,7 @@ func (rm *ResourceManager) readFeedMeta(ctx context.Context, uri string) (*mcp.R
 		return nil, err
 	}
 
-	feedResult, err := rm.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	feedResult, err := rm.getFeedAndItems(ctx, feedID)
 	if err != nil {
 		// Check if this is a specific resource error
 		var feedErr *model.FeedError
@@ -747,6 +886,25 @@ func (rm *ResourceManager) readFeedMeta(ctx context.Context, uri string) (*mcp.R
 		metadata["image"] = feedResult.Feed.Image
 	}
 
+	// A feed that has moved should have its configured URL updated
+	redirectedTo := model.FeedRedirectedTo(feedResult.Feed)
+	metadata["moved_permanently"] = redirectedTo != ""
+	if redirectedTo != "" {
+		metadata["redirected_to"] = redirectedTo
+	}
+
+	if iconURL := rm.resolveFeedIcon(ctx, feedID, feedResult); iconURL != "" {
+		metadata["icon_url"] = iconURL
+	}
+	if provider := rm.capabilities.feedTagsProvider; provider != nil {
+		if tags, err := provider.FeedTags(ctx); err == nil && len(tags[feedID]) > 0 {
+			metadata[keyTags] = tags[feedID]
+		}
+	}
+	if feedResult.FetchError == "" {
+		metadata["content_hash"] = model.ContentHash(feedResult.Items)
+	}
+
 	contentJSON, err := marshalJSONContent(metadata, uri)
 	if err != nil {
 		return nil, err
@@ -754,7 +912,7 @@ func (rm *ResourceManager) readFeedMeta(ctx context.Context, uri string) (*mcp.R
 
 	// Cache the result with appropriate TTL
 	ttl := rm.getTTLForResourceType(uri)
-	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl))
+	_ = rm.resourceCache.Set(ctx, cacheKey, contentJSON, store.WithExpiration(ttl), store.WithCost(int64(len(contentJSON))))
 
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
@@ -767,6 +925,21 @@ func (rm *ResourceManager) readFeedMeta(ctx context.Context, uri string) (*mcp.R
 	}, nil
 }
 
+// resolveFeedIcon returns the feed's icon URL, or "" when it has none. When the
+// feed getter can resolve icons (the store discovers favicons), that is used;
+// otherwise, or if resolving fails, only the feed's own <image> is considered.
+func (rm *ResourceManager) resolveFeedIcon(ctx context.Context, feedID string, feedResult *FeedAndItemsResult) string {
+	if resolver := rm.capabilities.feedIconResolver; resolver != nil {
+		if iconURL, err := resolver.ResolveFeedIcon(rm.fetchContext(ctx), feedID); err == nil {
+			return iconURL
+		}
+	}
+	if feedResult.Feed != nil && feedResult.Feed.Image != nil {
+		return feedResult.Feed.Image.URL
+	}
+	return ""
+}
+
 // Subscribe adds a resource subscription for a session
 func (rs *ResourceSession) Subscribe(uri string) {
 	rs.mu.Lock()
@@ -932,6 +1105,9 @@ func (rm *ResourceManager) getTTLForResourceType(uri string) time.Duration {
 	if strings.Contains(uri, "feeds://all") || strings.Contains(uri, "feeds://list") {
 		return rm.cacheConfig.FeedListTTL
 	}
+	if strings.HasPrefix(uri, DiagnosticsURI) {
+		return rm.cacheConfig.DiagnosticsTTL
+	}
 	// Default for other resource types (individual feeds)
 	return rm.cacheConfig.DefaultTTL
 }
@@ -950,7 +1126,9 @@ func (rm *ResourceManager) GetCacheMetrics() ResourceCacheMetrics {
 
 // InvalidateCache invalidates all cached resources and triggers notification hooks
 func (rm *ResourceManager) InvalidateCache(ctx context.Context) error {
+	rm.cacheMetrics.clearing.Store(true)
 	err := rm.resourceCache.Clear(ctx)
+	rm.cacheMetrics.clearing.Store(false)
 	if err == nil {
 		rm.recordCacheInvalidation()
 		// Trigger invalidation hooks for all resources
@@ -1064,6 +1242,41 @@ func (rm *ResourceManager) GetAllSubscribedURIs() []string {
 	return uris
 }
 
+// SubscriberCounts returns, for each feed ID with at least one subscriber, the
+// number of sessions subscribed to any of that feed's resources. A session
+// subscribed to both a feed and its items counts once.
+func (rm *ResourceManager) SubscriberCounts() map[string]int {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, session := range rm.sessions {
+		feedIDs := make(map[string]bool)
+		session.mu.RLock()
+		for uri := range session.subscriptions {
+			if feedID, ok := subscribedFeedID(uri); ok {
+				feedIDs[feedID] = true
+			}
+		}
+		session.mu.RUnlock()
+		for feedID := range feedIDs {
+			counts[feedID]++
+		}
+	}
+	return counts
+}
+
+// subscribedFeedID maps a subscribed resource URI to the feed it belongs to.
+// Feed-independent resources such as feeds://all report false.
+func subscribedFeedID(uri string) (string, bool) {
+	for _, template := range []string{FeedURI, FeedItemsURI, FeedMetaURI} {
+		if feedID, err := extractFeedIDFromURI(uri, template); err == nil {
+			return feedID, true
+		}
+	}
+	return "", false
+}
+
 // GetSubscriptionCount returns the number of active subscriptions for this session
 func (rs *ResourceSession) GetSubscriptionCount() int {
 	rs.mu.RLock()
@@ -1111,7 +1324,7 @@ func (rm *ResourceManager) DetectResourceChanges(ctx context.Context) ([]string,
 	// - Database-based change tracking
 
 	// Check if the feed list has changed by comparing current feeds with cached state
-	feedResults, err := rm.store.GetAllFeeds(ctx)
+	feedResults, err := rm.getAllFeeds(ctx)
 	if err != nil {
 		return nil, model.CreateRetryError(err, "", 0, 0).
 			WithOperation("detect_changes").
@@ -1124,7 +1337,7 @@ func (rm *ResourceManager) DetectResourceChanges(ctx context.Context) ([]string,
 
 	// Check individual feeds for changes
 	for _, feed := range feedResults {
-		feedID := model.GenerateFeedID(feed.PublicURL)
+		feedID := feed.ID
 
 		// For each feed, assume all its resources might have changed
 		// In a real implementation, you'd check timestamps, content hashes, etc.
@@ -1137,3 +1350,22 @@ func (rm *ResourceManager) DetectResourceChanges(ctx context.Context) ([]string,
 
 	return changedURIs, nil
 }
+
+// searchCandidates narrows items to those matching the search filter using the
+// getter's search index, when it implements ItemSearcher and can serve the
+// query. Every other filter (and pagination) is still applied by ApplyFilters,
+// and the index preserves feed order, so the result matches a full scan.
+func (rm *ResourceManager) searchCandidates(ctx context.Context, feedID string, items []*gofeed.Item, filters *FilterParams) []*gofeed.Item {
+	if filters == nil || filters.Search == "" {
+		return items
+	}
+	searcher := rm.capabilities.itemSearcher
+	if searcher == nil {
+		return items
+	}
+	matches, ok, err := searcher.SearchFeedItems(rm.fetchContext(ctx), feedID, filters.Search)
+	if err != nil || !ok {
+		return items
+	}
+	return matches
+}
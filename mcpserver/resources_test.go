@@ -3,7 +3,11 @@ package mcpserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -624,3 +628,194 @@ func TestResourceCaching(t *testing.T) {
 		}
 	})
 }
+
+// mockIconFeedAndItemsGetter adds FeedIconResolver to the resource mock.
+type mockIconFeedAndItemsGetter struct {
+	mockResourceFeedAndItemsGetter
+	icons map[string]string
+	err   error
+}
+
+func (m *mockIconFeedAndItemsGetter) ResolveFeedIcon(ctx context.Context, feedID string) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return m.icons[feedID], nil
+}
+
+// TestReadFeedMetadataResource_IconURL verifies icon_url is reported from the
+// feed's image or an icon resolver, and omitted when there is no icon.
+func TestReadFeedMetadataResource_IconURL(t *testing.T) {
+	ctx := context.Background()
+	withImage := &model.FeedAndItemsResult{
+		ID: "with-image", PublicURL: testFeedURL1, Title: "With Image",
+		Feed: &model.Feed{Title: "With Image", Image: &gofeed.Image{URL: "https://example.com/logo.png"}},
+	}
+	withoutImage := &model.FeedAndItemsResult{
+		ID: "without-image", PublicURL: testFeedURL2, Title: "Without Image",
+		Feed: &model.Feed{Title: "Without Image"},
+	}
+	feeds := map[string]*model.FeedAndItemsResult{"with-image": withImage, "without-image": withoutImage}
+
+	readIcon := func(t *testing.T, rm *ResourceManager, feedID string) (string, bool) {
+		t.Helper()
+		uri := expandURITemplate(FeedMetaURI, map[string]string{"feedId": feedID})
+		result, err := rm.ReadResource(ctx, uri)
+		if err != nil {
+			t.Fatalf("ReadResource(%s) failed: %v", uri, err)
+		}
+		var meta map[string]any
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), &meta); err != nil {
+			t.Fatalf("unmarshal metadata: %v", err)
+		}
+		icon, ok := meta["icon_url"].(string)
+		return icon, ok
+	}
+
+	t.Run("feed image without resolver", func(t *testing.T) {
+		rm := NewResourceManager(&mockResourceAllFeedsGetter{}, &mockResourceFeedAndItemsGetter{feeds: feeds})
+		if icon, _ := readIcon(t, rm, "with-image"); icon != "https://example.com/logo.png" {
+			t.Errorf("icon_url = %q, want feed image URL", icon)
+		}
+		if _, ok := readIcon(t, rm, "without-image"); ok {
+			t.Error("icon_url should be omitted for a feed without an icon")
+		}
+	})
+
+	t.Run("resolver favicon fallback", func(t *testing.T) {
+		getter := &mockIconFeedAndItemsGetter{
+			mockResourceFeedAndItemsGetter: mockResourceFeedAndItemsGetter{feeds: feeds},
+			icons:                          map[string]string{"without-image": "https://example.com/favicon.ico"},
+		}
+		rm := NewResourceManager(&mockResourceAllFeedsGetter{}, getter)
+		rm.capabilities.feedIconResolver = getter
+		if icon, _ := readIcon(t, rm, "without-image"); icon != "https://example.com/favicon.ico" {
+			t.Errorf("icon_url = %q, want resolved favicon", icon)
+		}
+	})
+
+	t.Run("feed image when the resolver fails", func(t *testing.T) {
+		getter := &mockIconFeedAndItemsGetter{
+			mockResourceFeedAndItemsGetter: mockResourceFeedAndItemsGetter{feeds: feeds},
+			err:                            errors.New("feed fetch failed"),
+		}
+		rm := NewResourceManager(&mockResourceAllFeedsGetter{}, getter)
+		rm.capabilities.feedIconResolver = getter
+		if icon, _ := readIcon(t, rm, "with-image"); icon != "https://example.com/logo.png" {
+			t.Errorf("icon_url = %q, want feed image URL", icon)
+		}
+		if _, ok := readIcon(t, rm, "without-image"); ok {
+			t.Error("icon_url should be omitted for a feed without an icon")
+		}
+	})
+}
+
+// slowFeedStore stands in for the feed store: like its loader, each feed
+// fetch takes a slot of the context's fetch gate, and GetAllFeeds fetches
+// every feed in its own goroutine. It records the peak number of fetches in
+// flight, holding each one open for delay.
+type slowFeedStore struct {
+	feedIDs  []string
+	delay    time.Duration
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (m *slowFeedStore) fetch(ctx context.Context, feedID string) (*model.FeedAndItemsResult, error) {
+	release, err := model.AcquireFetchSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(m.delay)
+	return &model.FeedAndItemsResult{ID: feedID, Title: feedID}, nil
+}
+
+func (m *slowFeedStore) GetFeedAndItems(ctx context.Context, feedID string) (*model.FeedAndItemsResult, error) {
+	return m.fetch(ctx, feedID)
+}
+
+func (m *slowFeedStore) GetAllFeeds(ctx context.Context) ([]*model.FeedResult, error) {
+	results := make([]*model.FeedResult, len(m.feedIDs))
+	var wg sync.WaitGroup
+	for i, id := range m.feedIDs {
+		wg.Go(func() {
+			results[i] = &model.FeedResult{ID: id}
+			if feed, err := m.fetch(ctx, id); err == nil {
+				results[i].Title = feed.Title
+			}
+		})
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func TestResourceManager_MaxConcurrentFetches(t *testing.T) {
+	feedStore := &slowFeedStore{delay: 20 * time.Millisecond}
+	rm := NewResourceManagerWithConfig(feedStore, feedStore, &ResourceCacheConfig{MaxConcurrentFetches: 2})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		wg.Go(func() {
+			// Distinct feeds, so every read misses the cache and fetches.
+			uri := expandURITemplate(FeedURI, map[string]string{"feedId": fmt.Sprintf("feed-%d", i)})
+			if _, err := rm.ReadResource(context.Background(), uri); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("ReadResource failed: %v", err)
+	}
+	if peak := feedStore.peak.Load(); peak < 1 || peak > 2 {
+		t.Errorf("peak concurrent fetches = %d, want 1..2", peak)
+	}
+}
+
+func TestResourceManager_MaxConcurrentFetchesFanOut(t *testing.T) {
+	feedStore := &slowFeedStore{delay: 20 * time.Millisecond}
+	for i := range 10 {
+		feedStore.feedIDs = append(feedStore.feedIDs, fmt.Sprintf("feed-%d", i))
+	}
+	rm := NewResourceManagerWithConfig(feedStore, feedStore, &ResourceCacheConfig{MaxConcurrentFetches: 2})
+
+	// One read, but GetAllFeeds fetches all ten feeds at once.
+	if _, err := rm.ReadResource(context.Background(), FeedListURI); err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if peak := feedStore.peak.Load(); peak < 1 || peak > 2 {
+		t.Errorf("peak concurrent fetches = %d, want 1..2", peak)
+	}
+}
+
+func TestResourceManager_FetchSlotContextCancelled(t *testing.T) {
+	feedStore := &slowFeedStore{}
+	rm := NewResourceManagerWithConfig(feedStore, feedStore, &ResourceCacheConfig{MaxConcurrentFetches: 1})
+	release, err := model.AcquireFetchSlot(rm.fetchContext(context.Background()))
+	if err != nil {
+		t.Fatalf("AcquireFetchSlot failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	uri := expandURITemplate(FeedURI, map[string]string{"feedId": "waiting"})
+	_, err = rm.ReadResource(ctx, uri)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ReadResource with all slots taken = %v, want context.DeadlineExceeded", err)
+	}
+	if feedStore.peak.Load() != 0 {
+		t.Error("fetch ran without a free slot")
+	}
+}
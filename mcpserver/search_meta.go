@@ -0,0 +1,54 @@
+package mcpserver
+
+// Reasons a get_syndication_feed_items page came back empty.
+const (
+	emptyReasonNoData     = "no_data"         // the feed has no items
+	emptyReasonNoMatches  = "no_matches"      // the filters matched none of the items
+	emptyReasonPastEnd    = "offset_past_end" // items matched, but offset skipped them all
+	emptyReasonPastCursor = "past_cursor"     // items matched, but none is older than the cursor
+)
+
+// SearchMeta explains an empty get_syndication_feed_items page, so a client
+// can tell a feed with no data from filters that matched nothing. Its
+// FilterSummary counts the feed items scanned (total_items), those that
+// matched the filters (filtered_items), and the filters applied.
+type SearchMeta struct {
+	Reason string `json:"reason"`
+	*FilterSummary
+}
+
+// newSearchMeta describes a page that returned no items, given the number of
+// feed items scanned and the number that matched the filters.
+func newSearchMeta(args GetSyndicationFeedParams, params ParsedFeedParams, scanned, matched, substantiveMinLength int) *SearchMeta {
+	filters := &FilterParams{HasMedia: params.HasMedia, Substantive: params.Substantive, Authors: params.Authors}
+	if params.Substantive != nil {
+		filters.SubstantiveMinLength = substantiveMinLength
+	}
+	if args.Limit != nil {
+		filters.Limit = new(params.Limit)
+	}
+	if args.Offset != nil {
+		filters.Offset = new(params.Offset)
+	}
+
+	reason := emptyReasonPastEnd
+	switch {
+	case scanned == 0:
+		reason = emptyReasonNoData
+	case matched == 0:
+		reason = emptyReasonNoMatches
+	case params.CursorPaging:
+		reason = emptyReasonPastCursor
+	}
+	summary := CreateFilterSummary(scanned, matched, filters)
+	if params.UnreadOnly {
+		if summary.AppliedFilters == nil {
+			summary.AppliedFilters = make(map[string]any)
+		}
+		summary.AppliedFilters["unread_only"] = true
+	}
+	return &SearchMeta{
+		Reason:        reason,
+		FilterSummary: summary,
+	}
+}
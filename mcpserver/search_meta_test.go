@@ -0,0 +1,106 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// feedItemsMeta is the part of the get_syndication_feed_items metadata block
+// these tests look at.
+type feedItemsMeta struct {
+	ReturnedItems int         `json:"returned_items"`
+	SearchMeta    *SearchMeta `json:"search_meta"`
+	ContentHash   string      `json:"content_hash"`
+}
+
+// feedItemsMetadata calls get_syndication_feed_items and decodes its metadata
+// block.
+func feedItemsMetadata(t *testing.T, session *mcp.ClientSession, args map[string]any) feedItemsMeta {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetSyndicationFeedItems, Arguments: args})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool(%v): %v, %+v", args, err, result)
+	}
+	var meta feedItemsMeta
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &meta); err != nil {
+		t.Fatalf("unmarshal metadata: %v", err)
+	}
+	return meta
+}
+
+func TestGetFeedItems_SearchMeta(t *testing.T) {
+	withMedia := &gofeed.Item{Title: "Photo", Content: `<p><img src="https://example.com/a.png"></p>`}
+	tests := []struct {
+		name        string
+		items       []*gofeed.Item
+		args        map[string]any
+		wantReason  string
+		wantScanned int
+		wantMatched int
+		wantFilters map[string]any
+	}{
+		{
+			name:        "no matches",
+			items:       makeTestItems(5),
+			args:        map[string]any{"hasMedia": true},
+			wantReason:  emptyReasonNoMatches,
+			wantScanned: 5,
+			wantFilters: map[string]any{"has_media": true},
+		},
+		{
+			name:        "no data",
+			items:       nil,
+			args:        map[string]any{},
+			wantReason:  emptyReasonNoData,
+			wantScanned: 0,
+		},
+		{
+			name:        "offset past the matches",
+			items:       append(makeTestItems(3), withMedia),
+			args:        map[string]any{"hasMedia": true, "offset": 1, "limit": 5},
+			wantReason:  emptyReasonPastEnd,
+			wantScanned: 4,
+			wantMatched: 1,
+			wantFilters: map[string]any{"has_media": true, "offset": float64(1), "limit": float64(5)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", tt.items)
+			args := map[string]any{keyID: "feed-1", "includeSearchMeta": true}
+			maps.Copy(args, tt.args)
+
+			meta := feedItemsMetadata(t, session, args)
+			sm := meta.SearchMeta
+			if meta.ReturnedItems != 0 || sm == nil || sm.FilterSummary == nil {
+				t.Fatalf("returned %d items with search_meta %+v, want an empty page with search_meta", meta.ReturnedItems, sm)
+			}
+			if sm.Reason != tt.wantReason || sm.TotalItems != tt.wantScanned || sm.FilteredItems != tt.wantMatched {
+				t.Errorf("search_meta = {%s, scanned %d, matched %d}, want {%s, %d, %d}",
+					sm.Reason, sm.TotalItems, sm.FilteredItems, tt.wantReason, tt.wantScanned, tt.wantMatched)
+			}
+			if !maps.Equal(sm.AppliedFilters, tt.wantFilters) {
+				t.Errorf("applied_filters = %v, want %v", sm.AppliedFilters, tt.wantFilters)
+			}
+
+			// Without the option an empty page carries no explanation.
+			delete(args, "includeSearchMeta")
+			if meta := feedItemsMetadata(t, session, args); meta.SearchMeta != nil {
+				t.Errorf("search_meta = %+v without includeSearchMeta", meta.SearchMeta)
+			}
+		})
+	}
+}
+
+func TestGetFeedItems_SearchMetaOnlyWhenEmpty(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", makeTestItems(3))
+	meta := feedItemsMetadata(t, session, map[string]any{keyID: "feed-1", "includeSearchMeta": true})
+	if meta.ReturnedItems != 3 || meta.SearchMeta != nil {
+		t.Errorf("returned %d items with search_meta %+v, want 3 and none", meta.ReturnedItems, meta.SearchMeta)
+	}
+}
@@ -0,0 +1,99 @@
+package mcpserver
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// seenCursorVersion prefixes every seen-items cursor so the format can change.
+const seenCursorVersion byte = 1
+
+// maxSeenCursorItems caps the item hashes a cursor carries. Once a client has
+// polled past it, the items it returned longest ago are forgotten and could be
+// returned again.
+const maxSeenCursorItems = 1000
+
+// seenCursor is the set of items a client has already been given by
+// merge_feeds. It travels to and from the client as an opaque token, so the
+// server keeps no per-client state: a version byte followed by the 64-bit
+// FNV-1a hash of each item's itemDedupKey, most recently returned first, in
+// unpadded URL-safe base64.
+type seenCursor struct {
+	hashes []uint64
+	set    map[uint64]bool
+}
+
+// decodeSeenCursor parses a cursor returned by an earlier call. An empty token
+// is an empty cursor.
+func decodeSeenCursor(token string) (*seenCursor, error) {
+	cursor := &seenCursor{set: make(map[uint64]bool)}
+	if token == "" {
+		return cursor, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) == 0 || data[0] != seenCursorVersion || (len(data)-1)%8 != 0 {
+		return nil, model.CreateParameterError(toolMergeFeeds, "cursor", "invalid cursor",
+			"Pass the cursor returned by the previous call unchanged, or omit it to start over")
+	}
+	for data = data[1:]; len(data) > 0; data = data[8:] {
+		cursor.add(binary.BigEndian.Uint64(data))
+	}
+	return cursor, nil
+}
+
+func (c *seenCursor) add(hash uint64) {
+	if !c.set[hash] {
+		c.set[hash] = true
+		c.hashes = append(c.hashes, hash)
+	}
+}
+
+// seen reports whether the item was returned before. Items without a dedup
+// key can't be recognized and are never treated as seen.
+func (c *seenCursor) seen(item *gofeed.Item) bool {
+	key := itemDedupKey(item)
+	return key != "" && c.set[seenItemHash(key)]
+}
+
+// next returns the cursor for the following call: the items just returned,
+// then those already seen, up to maxSeenCursorItems.
+func (c *seenCursor) next(returned []*gofeed.Item) string {
+	next := &seenCursor{set: make(map[uint64]bool, len(returned)+len(c.hashes))}
+	for _, item := range returned {
+		if key := itemDedupKey(item); key != "" {
+			next.add(seenItemHash(key))
+		}
+	}
+	for _, hash := range c.hashes {
+		next.add(hash)
+	}
+	hashes := next.hashes[:min(len(next.hashes), maxSeenCursorItems)]
+
+	data := make([]byte, 1, 1+8*len(hashes))
+	data[0] = seenCursorVersion
+	for _, hash := range hashes {
+		data = binary.BigEndian.AppendUint64(data, hash)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// excludeSeen drops the items the cursor has seen.
+func (c *seenCursor) excludeSeen(items []*gofeed.Item) []*gofeed.Item {
+	if len(c.hashes) == 0 {
+		return items
+	}
+	return slices.DeleteFunc(items, c.seen)
+}
+
+// seenItemHash hashes an item dedup key for a cursor.
+func seenItemHash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -21,7 +23,6 @@ import (
 	gocache "github.com/eko/gocache/lib/v4/cache"
 	"github.com/eko/gocache/lib/v4/store"
 	ristrettostore "github.com/eko/gocache/store/ristretto/v4"
-	"github.com/gocolly/colly"
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/mmcdole/gofeed"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -70,11 +71,66 @@ type Config struct {
 	AllFeedsGetter     AllFeedsGetter
 	FeedAndItemsGetter FeedAndItemsGetter
 	DynamicFeedManager DynamicFeedManager // Optional: for runtime feed management
-	Transport          model.Transport
+	// Optional feed store capabilities. Each one enables the tools and the
+	// resource and tool fields that need it; nil leaves them out.
+	CircuitBreakerResetter CircuitBreakerResetter // reset_circuit_breaker
+	FeedHistoryProvider    FeedHistoryProvider    // export_feed_history
+	FeedPinger             FeedPinger             // ping_feeds
+	FeedCachePurger        FeedCachePurger        // purge_feed_cache, and refresh=true resource reads
+	DuplicateFeedsProvider DuplicateFeedsProvider // list_duplicate_feeds
+	DiagnosticsProvider    DiagnosticsProvider    // feeds://diagnostics and get_server_metrics
+	ConfigProvider         ConfigProvider         // store settings in get_config
+	FeedTagsProvider       FeedTagsProvider       // feed tags
+	FeedIconResolver       FeedIconResolver       // feed icons in feed metadata
+	ItemSearcher           ItemSearcher           // indexed item search
+	FeedGenerationReporter FeedGenerationReporter // tool result cache invalidation
+	Transport              model.Transport
 	// HTTP server configuration (for streamable-http transport)
 	HTTPPort           string
 	HTTPStateless      bool
 	HTTPSessionTimeout time.Duration
+	// HTTPCompression gzips HTTP responses for clients that send
+	// Accept-Encoding: gzip. Nil means enabled; it has no effect on stdio.
+	HTTPCompression *bool
+	// Tool selection: when EnabledTools is non-empty only those tools are
+	// registered; DisabledTools are then removed. Names must come from ToolNames.
+	EnabledTools  []string
+	DisabledTools []string
+	// MaxConcurrentResourceFetches bounds the feed fetches resource reads run
+	// at once. Zero means DefaultMaxConcurrentResourceFetches.
+	MaxConcurrentResourceFetches int
+	// ResourceRefreshInterval is the minimum time between two forced
+	// refreshes of one feed by resource reads with refresh=true. Zero means
+	// DefaultResourceRefreshInterval.
+	ResourceRefreshInterval time.Duration
+	// fetch_link settings: FetchLinkTimeout bounds each request and
+	// FetchLinkMaxAttempts caps retries of transient failures (zero means
+	// DefaultFetchLinkTimeout and DefaultFetchLinkMaxAttempts).
+	// AllowPrivateIPs lets it reach private addresses, as for feeds.
+	FetchLinkTimeout     time.Duration
+	FetchLinkMaxAttempts int
+	AllowPrivateIPs      bool
+	// ToolResultCacheTTL caches the results of the aggregation tools
+	// (estimate_feed_frequency, get_feed_categories, list_feeds_by_activity,
+	// feed_overlap) for this long, per tool and parameters. Entries are also
+	// dropped when feeds refresh, given a FeedGenerationReporter. Zero
+	// disables the cache.
+	ToolResultCacheTTL time.Duration
+	// FileExportDir lets export_feed_data write exports to files (its
+	// outputPath parameter) inside this directory. Empty disables file export.
+	FileExportDir string
+	// SubstantiveMinLength is the content length below which an item counts
+	// as a stub: flagged "stub" in get_syndication_feed_items and dropped by
+	// its substantive filter. Zero means DefaultSubstantiveMinLength.
+	SubstantiveMinLength int
+	// ThumbnailSize is the width, in pixels, get_syndication_feed_items picks
+	// each item's thumbnail for among the sizes a Media RSS feed offers. Zero
+	// means DefaultThumbnailSize.
+	ThumbnailSize int
+	// ReadStateFile, when set, persists the read state kept by mark_read,
+	// mark_unread, and mark_all_read across restarts for clients without a
+	// session ID (see defaultReadSession). Empty keeps it in memory only.
+	ReadStateFile string
 }
 
 // Server implements an MCP server for serving syndication feeds
@@ -82,6 +138,7 @@ type Server struct {
 	allFeedsGetter       AllFeedsGetter
 	feedAndItemsGetter   FeedAndItemsGetter
 	dynamicFeedManager   DynamicFeedManager // Optional: for runtime feed management
+	capabilities         storeCapabilities  // Optional feed store capabilities
 	resourceManager      *ResourceManager
 	sessionID            string
 	transport            model.Transport
@@ -93,6 +150,15 @@ type Server struct {
 	httpPort           string
 	httpStateless      bool
 	httpSessionTimeout time.Duration
+	httpCompression    bool
+	tools              toolSelection // Which tools to register
+	fetchLinkConfig    fetchLinkConfig
+	articleCache       *gocache.Cache[string] // Extracted article text by link
+	toolResultCache    *toolResultCache       // Aggregation tool results; nil when disabled
+	fileExportDir      string                 // Absolute base directory for export files; empty when disabled
+	substantiveMinLen  int                    // Content length below which an item is a stub
+	thumbnailSize      int                    // Preferred item thumbnail width in pixels
+	readStates         *readStates            // Items each client session has marked read
 }
 
 // generateSessionID creates a unique session ID for this server instance
@@ -118,6 +184,22 @@ func NewServer(config *Config) (*Server, error) {
 			WithOperation("create_server").
 			WithComponent("mcp_server")
 	}
+	tools, err := newToolSelection(config.EnabledTools, config.DisabledTools)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := newStoreCapabilities(config)
+	if err := capabilities.checkEnabledTools(config.EnabledTools); err != nil {
+		return nil, err
+	}
+	var fileExportDir string
+	if config.FileExportDir != "" {
+		if fileExportDir, err = filepath.Abs(config.FileExportDir); err != nil {
+			return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "invalid file export directory", err).
+				WithOperation("create_server").
+				WithComponent("mcp_server")
+		}
+	}
 	// Set HTTP defaults if not specified
 	httpPort := config.HTTPPort
 	if httpPort == "" {
@@ -133,17 +215,38 @@ func NewServer(config *Config) (*Server, error) {
 		allFeedsGetter:     config.AllFeedsGetter,
 		feedAndItemsGetter: config.FeedAndItemsGetter,
 		dynamicFeedManager: config.DynamicFeedManager,
+		capabilities:       capabilities,
 		sessionID:          generateSessionID(),
 		httpPort:           httpPort,
 		httpStateless:      config.HTTPStateless,
 		httpSessionTimeout: httpSessionTimeout,
+		httpCompression:    config.HTTPCompression == nil || *config.HTTPCompression,
+		tools:              tools,
+		fetchLinkConfig:    newFetchLinkConfig(config),
+		fileExportDir:      fileExportDir,
+		substantiveMinLen:  cmp.Or(config.SubstantiveMinLength, DefaultSubstantiveMinLength),
+		thumbnailSize:      cmp.Or(config.ThumbnailSize, DefaultThumbnailSize),
 	}
 
 	// Initialize image cache and HTTP client
 	if err := server.initializeImageCache(); err != nil {
 		return nil, err
 	}
-	server.resourceManager = NewResourceManager(config.AllFeedsGetter, config.FeedAndItemsGetter)
+	if err := server.initializeArticleCache(); err != nil {
+		return nil, err
+	}
+	if server.readStates, err = newReadStates(config.ReadStateFile); err != nil {
+		return nil, err
+	}
+	if server.toolResultCache, err = newToolResultCache(config.ToolResultCacheTTL, config.FeedGenerationReporter); err != nil {
+		return nil, err
+	}
+	resourceCacheConfig := DefaultResourceCacheConfig()
+	resourceCacheConfig.MaxConcurrentFetches = config.MaxConcurrentResourceFetches
+	resourceCacheConfig.RefreshInterval = config.ResourceRefreshInterval
+	resourceCacheConfig.SubstantiveMinLength = server.substantiveMinLen
+	server.resourceManager = NewResourceManagerWithConfig(config.AllFeedsGetter, config.FeedAndItemsGetter, resourceCacheConfig)
+	server.resourceManager.capabilities = capabilities
 
 	// Set up cache invalidation hook to trigger resource change notifications
 	server.setupCacheInvalidationHooks()
@@ -156,23 +259,41 @@ type FetchLinkParams struct {
 	URL string
 }
 
+// AllSyndicationFeedsParams contains parameters for the all_syndication_feeds tool.
+type AllSyndicationFeedsParams struct {
+	OrderByHealth string `json:"orderByHealth,omitempty"` // unhealthy_first or unhealthy_last (default: by title)
+}
+
 // GetSyndicationFeedParams contains parameters for the get_syndication_feed_items tool.
 type GetSyndicationFeedParams struct {
-	ID               string `json:"ID"`
-	Limit            *int   `json:"limit,omitempty"`            // Maximum items to return (default: 50, max: 100)
-	Offset           *int   `json:"offset,omitempty"`           // Number of items to skip (default: 0)
-	IncludeContent   *bool  `json:"includeContent,omitempty"`   // Include full content/description (default: true)
-	MaxContentLength *int   `json:"maxContentLength,omitempty"` // Max length for content fields in characters (default: unlimited)
-	IncludeImages    *bool  `json:"includeImages,omitempty"`    // Include image ResourceLinks (default: false)
-	EmbedImages      *bool  `json:"embedImages,omitempty"`      // Fetch and embed images as base64 ImageContent for inline display (default: false, requires includeImages=true)
+	ID                string   `json:"ID"`
+	Limit             *int     `json:"limit,omitempty"`             // Maximum items to return (default: 50, max: 100)
+	Offset            *int     `json:"offset,omitempty"`            // Number of items to skip (default: 0)
+	IncludeContent    *bool    `json:"includeContent,omitempty"`    // Include full content/description (default: true)
+	MaxContentLength  *int     `json:"maxContentLength,omitempty"`  // Max length for content fields in characters (default: unlimited)
+	IncludeImages     *bool    `json:"includeImages,omitempty"`     // Include image ResourceLinks (default: false)
+	EmbedImages       *bool    `json:"embedImages,omitempty"`       // Fetch and embed images as base64 ImageContent for inline display (default: false, requires includeImages=true)
+	MaxResponseBytes  *int     `json:"maxResponseBytes,omitempty"`  // Stop adding items once the response approaches this size (default: 0, unlimited)
+	Order             string   `json:"order,omitempty"`             // newest, oldest, or feed (default: feed)
+	HasMedia          *bool    `json:"hasMedia,omitempty"`          // Only items with (true) or without (false) images, video, or audio
+	IncludeRawDates   *bool    `json:"includeRawDates,omitempty"`   // Add published_raw and published_parsed (default: false)
+	IncludeSearchMeta *bool    `json:"includeSearchMeta,omitempty"` // Explain an empty page with search_meta (default: false)
+	DateField         string   `json:"dateField,omitempty"`         // published or updated: the date order sorts by (default: published)
+	Plaintext         *bool    `json:"plaintext,omitempty"`         // Convert HTML content/description to plain text (default: false)
+	Substantive       *bool    `json:"substantive,omitempty"`       // Only substantive items (true) or only stubs (false)
+	Authors           []string `json:"authors,omitempty"`           // Only items by any of these authors (case-insensitive)
+	UnreadOnly        *bool    `json:"unreadOnly,omitempty"`        // Only items this session hasn't marked read (default: false)
+	CursorPaging      *bool    `json:"cursorPaging,omitempty"`      // Page newest first by cursor instead of offset (default: false)
+	Cursor            string   `json:"cursor,omitempty"`            // next_cursor from the previous page; implies cursorPaging
 }
 
 // AddFeedParams contains parameters for the add_feed tool.
 type AddFeedParams struct {
-	URL         string `json:"url"`
-	Title       string `json:"title,omitempty"`
-	Category    string `json:"category,omitempty"`
-	Description string `json:"description,omitempty"`
+	URL         string   `json:"url"`
+	Title       string   `json:"title,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
 // RemoveFeedParams contains parameters for the remove_feed tool.
@@ -186,23 +307,45 @@ type RefreshFeedParams struct {
 	FeedID string `json:"feedId"`
 }
 
+// UpdateFeedParams contains parameters for the update_feed tool.
+type UpdateFeedParams struct {
+	FeedID      string   `json:"feedId"`
+	Title       string   `json:"title,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Alias       string   `json:"alias,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"` // Replaces the feed's tags; [] clears them
+}
+
 // MergeFeedsParams contains parameters for the merge_feeds tool.
 type MergeFeedsParams struct {
-	FeedIDs     []string `json:"feedIds"`
-	Title       string   `json:"title,omitempty"`
-	MaxItems    int      `json:"maxItems,omitempty"`
-	SortBy      string   `json:"sortBy,omitempty"`      // date, title, source
-	Deduplicate bool     `json:"deduplicate,omitempty"` // Remove duplicate items
+	FeedIDs           []string `json:"feedIds,omitempty"`
+	Tags              []string `json:"tags,omitempty"` // Only feeds carrying every tag
+	Title             string   `json:"title,omitempty"`
+	MaxItems          int      `json:"maxItems,omitempty"`
+	MaxPerSource      int      `json:"maxPerSource,omitempty"`      // Newest items taken from each feed before maxItems applies
+	SortBy            string   `json:"sortBy,omitempty"`            // date, updated, title, source
+	Deduplicate       bool     `json:"deduplicate,omitempty"`       // Remove duplicate items
+	DedupeKey         string   `json:"dedupeKey,omitempty"`         // title_link, link, guid, or title (default: link, else title)
+	DedupeWindowHours int      `json:"dedupeWindowHours,omitempty"` // Only items published within this many hours of each other are duplicates
+	SeenSince         string   `json:"seenSince,omitempty"`         // RFC 3339; drop items published at or before it
+	Cursor            string   `json:"cursor,omitempty"`            // From the previous call; drop the items it returned
+	Fields            []string `json:"fields,omitempty"`            // Item fields to return (default: every field)
+	Format            string   `json:"format,omitempty"`            // rss, atom, opml, or csv: return the merge as an export document
 }
 
 // ExportFeedDataParams contains parameters for the export_feed_data tool.
 type ExportFeedDataParams struct {
-	FeedIDs    []string `json:"feedIds,omitempty"`    // Specific feeds to export (empty = all)
-	Format     string   `json:"format"`               // json, csv, opml, rss, atom
-	Since      string   `json:"since,omitempty"`      // ISO 8601 date
-	Until      string   `json:"until,omitempty"`      // ISO 8601 date
-	MaxItems   int      `json:"maxItems,omitempty"`   // Limit exported items
-	IncludeAll bool     `json:"includeAll,omitempty"` // Include feed metadata
+	FeedIDs        []string `json:"feedIds,omitempty"`        // Specific feeds to export (empty = all)
+	ExcludeFeedIDs []string `json:"excludeFeedIds,omitempty"` // Feeds to leave out, even if listed in FeedIDs
+	Tags           []string `json:"tags,omitempty"`           // Only feeds carrying every tag
+	Format         string   `json:"format"`                   // json, csv, opml, rss, atom
+	Since          string   `json:"since,omitempty"`          // ISO 8601 date
+	Until          string   `json:"until,omitempty"`          // ISO 8601 date
+	MaxItems       int      `json:"maxItems,omitempty"`       // Limit exported items
+	IncludeAll     bool     `json:"includeAll,omitempty"`     // Include feed metadata
+	Compress       string   `json:"compress,omitempty"`       // none (default) or gzip
+	OutputPath     string   `json:"outputPath,omitempty"`     // File to write, relative to the export directory
 }
 
 // MergedFeedResult represents the result of merging multiple feeds.
@@ -214,6 +357,40 @@ type MergedFeedResult struct {
 	SourceFeeds []string       `json:"source_feeds"`
 	TotalItems  int            `json:"total_items"`
 	CreatedAt   time.Time      `json:"created_at"`
+	// Cursor identifies the items returned so far; passing it to the next
+	// call leaves them out, so a client can poll for new items only.
+	Cursor string `json:"cursor"`
+
+	// sources maps each item to the feed it came from; feeds lists the
+	// merged feeds in order.
+	sources map[*gofeed.Item]*FeedAndItemsResult
+	feeds   []*FeedAndItemsResult
+}
+
+// projectedMergedFeed is a MergedFeedResult whose items carry only the
+// fields selected by merge_feeds' fields option.
+type projectedMergedFeed struct {
+	*MergedFeedResult
+	Items []map[string]any `json:"items"`
+}
+
+// project returns the merged feed with each item reduced to fields (see
+// projectItem); the source field is the item's source feed.
+func (m *MergedFeedResult) project(fields []string) *projectedMergedFeed {
+	projected := &projectedMergedFeed{MergedFeedResult: m, Items: make([]map[string]any, 0, len(m.Items))}
+	for _, item := range m.Items {
+		projected.Items = append(projected.Items, projectItem(item, fields, m.sourceTitle(item)))
+	}
+	return projected
+}
+
+// sourceTitle returns the title, else the ID, of the feed item came from.
+func (m *MergedFeedResult) sourceTitle(item *gofeed.Item) string {
+	source, ok := m.sources[item]
+	if !ok {
+		return ""
+	}
+	return cmp.Or(source.Feed.Title, source.ID)
 }
 
 // Run starts the MCP server and handles client connections until context is canceled
@@ -253,55 +430,96 @@ func (s *Server) createMCPServer() *mcp.Server {
 
 // registerCoreTools registers the core feed-related tools
 func (s *Server) registerCoreTools(srv *mcp.Server) {
-	s.addFetchLinkTool(srv)
-	s.addAllFeedsTool(srv)
-	s.addGetFeedItemsTool(srv)
+	if s.tools.enabled(toolFetchLink) {
+		s.addFetchLinkTool(srv)
+	}
+	if s.tools.enabled(toolAllSyndicationFeeds) {
+		s.addAllFeedsTool(srv)
+	}
+	if s.tools.enabled(toolGetSyndicationFeedItems) {
+		s.addGetFeedItemsTool(srv)
+	}
+	if s.tools.enabled(toolListFeedIndex) {
+		s.addFeedIndexTool(srv)
+	}
+	if s.tools.enabled(toolGetFeedTree) {
+		s.addFeedTreeTool(srv)
+	}
+	if s.tools.enabled(toolListMovedFeeds) {
+		s.addMovedFeedsTool(srv)
+	}
+	if s.tools.enabled(toolListFeedsByActivity) {
+		s.addFeedsByActivityTool(srv)
+	}
+	if s.tools.enabled(toolGetPodcastEpisodes) {
+		s.addPodcastEpisodesTool(srv)
+	}
+	if s.tools.enabled(toolEstimateFeedFrequency) {
+		s.addEstimateFeedFrequencyTool(srv)
+	}
+	if s.tools.enabled(toolGetFeedCategories) {
+		s.addFeedCategoriesTool(srv)
+	}
+	if s.tools.enabled(toolFetchFeedFullContent) {
+		s.addFetchFeedFullContentTool(srv)
+	}
+	if s.tools.enabled(toolMarkRead) {
+		s.addMarkReadTool(srv)
+	}
+	if s.tools.enabled(toolMarkUnread) {
+		s.addMarkUnreadTool(srv)
+	}
+	if s.tools.enabled(toolMarkAllRead) {
+		s.addMarkAllReadTool(srv)
+	}
+	if resetter := s.capabilities.circuitBreakerResetter; resetter != nil && s.tools.enabled(toolResetCircuitBreaker) {
+		s.addResetCircuitBreakerTool(srv, resetter)
+	}
+	if provider := s.capabilities.feedHistoryProvider; provider != nil && s.tools.enabled(toolExportFeedHistory) {
+		s.addExportFeedHistoryTool(srv, provider)
+	}
+	if pinger := s.capabilities.feedPinger; pinger != nil && s.tools.enabled(toolPingFeeds) {
+		s.addPingFeedsTool(srv, pinger)
+	}
+	if purger := s.capabilities.feedCachePurger; purger != nil && s.tools.enabled(toolPurgeFeedCache) {
+		s.addPurgeFeedCacheTool(srv, purger)
+	}
+	if provider := s.capabilities.duplicateFeedsProvider; provider != nil && s.tools.enabled(toolListDuplicateFeeds) {
+		s.addDuplicateFeedsTool(srv, provider)
+	}
+	if s.tools.enabled(toolGetServerMetrics) {
+		s.addServerMetricsTool(srv)
+	}
+	if s.tools.enabled(toolGetConfig) {
+		s.addConfigTool(srv)
+	}
 }
 
-// addFetchLinkTool adds the fetch_link tool
-func (s *Server) addFetchLinkTool(srv *mcp.Server) {
-	fetchLinkTool := &mcp.Tool{
-		Name:        toolFetchLink,
-		Description: fetchLinkDescription,
+// addAllFeedsTool adds the all_syndication_feeds tool
+func (s *Server) addAllFeedsTool(srv *mcp.Server) {
+	allFeedsTool := &mcp.Tool{
+		Name:        toolAllSyndicationFeeds,
+		Description: "list available feedItem resources",
 		InputSchema: &jsonschema.Schema{
-			Type:     typeObject,
-			Required: []string{keyURL},
+			Type: typeObject,
 			Properties: map[string]*jsonschema.Schema{
-				keyURL: {
+				"orderByHealth": {
 					Type:        typeString,
-					Description: linkURLDescription,
+					Description: "Group feeds by health, by title within each group (default: by title only). unhealthy_first: feeds with an open circuit breaker, then feeds with a fetch error, then healthy feeds. unhealthy_last: the reverse.",
+					Enum:        []any{healthOrderUnhealthyFirst, healthOrderUnhealthyLast},
 				},
 			},
 		},
 	}
-	mcp.AddTool(srv, fetchLinkTool, func(ctx context.Context, req *mcp.CallToolRequest, args FetchLinkParams) (*mcp.CallToolResult, any, error) {
-		c := colly.NewCollector()
-		var data []byte
-		c.OnResponse(func(response *colly.Response) {
-			data = response.Body
-		})
-		err := c.Visit(args.URL)
-		if err != nil {
+	mcp.AddTool(srv, allFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args AllSyndicationFeedsParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
 			return nil, nil, err
 		}
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
-		}, nil, nil
-	})
-}
-
-// addAllFeedsTool adds the all_syndication_feeds tool
-func (s *Server) addAllFeedsTool(srv *mcp.Server) {
-	allFeedsTool := &mcp.Tool{
-		Name:        toolAllSyndicationFeeds,
-		Description: "list available feedItem resources",
-		InputSchema: &jsonschema.Schema{Type: typeObject},
-	}
-	mcp.AddTool(srv, allFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
 		feedResults, err := s.allFeedsGetter.GetAllFeeds(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
+		feedResults = orderFeedsByHealth(feedResults, args.OrderByHealth)
 		content := make([]mcp.Content, 0, len(feedResults))
 		for _, feedResult := range feedResults {
 			data, err := json.Marshal(feedResult)
@@ -357,18 +575,94 @@ func (s *Server) addGetFeedItemsTool(srv *mcp.Server) {
 					Type:        typeBoolean,
 					Description: "Fetch and embed images as base64 ImageContent for inline display (default: false). Requires includeImages=true. Images are cached, rate-limited, and subject to: 1MB size limit per image (Claude Desktop constraint), circuit breaker protection (3 failures = skip host), 5s timeout per fetch. Failed fetches are skipped gracefully.",
 				},
+				"maxResponseBytes": {
+					Type:        typeInteger,
+					Description: "Approximate maximum size of the response in bytes (default: 0, unlimited). Items stop being added once the limit would be exceeded; the metadata then sets truncated_by_size=true and next_offset to continue from. At least one item is always returned. With embedImages, images are embedded only into the room left after the items; the rest stay links.",
+					Minimum:     &[]float64{0}[0],
+				},
+				"order": {
+					Type:        typeString,
+					Description: "Item order applied before pagination (default: feed). newest: by publish date, newest first, undated items last. oldest: by publish date, oldest first, undated items first. feed: as published.",
+					Enum:        []any{orderNewest, orderOldest, orderFeed},
+				},
+				"dateField": {
+					Type:        typeString,
+					Description: "Date that order=newest/oldest sorts by (default: published). published: when the item was first published, falling back to its updated date. updated: when it was last updated (Atom <updated>), falling back to its published date. Items with neither sort as undated.",
+					Enum:        []any{dateFieldPublished, dateFieldUpdated},
+				},
+				"plaintext": {
+					Type:        typeBoolean,
+					Description: "Convert HTML content and description to plain text when includeContent=true (default: false): tags are removed, entities decoded, paragraphs separated by blank lines, and list items prefixed with \"- \". maxContentLength then applies to the plain text. Saves tokens on HTML-heavy feeds; images are still reported in images/lead_image.",
+				},
+				"includeRawDates": {
+					Type:        typeBoolean,
+					Description: "Add published_raw (the publish date exactly as the feed wrote it) and published_parsed (RFC3339, null when the raw date couldn't be parsed) to each item (default: false).",
+				},
+				"hasMedia": {
+					Type:        typeBoolean,
+					Description: "When true, return only items with images, video, or audio (media enclosures or <img>/<video>/<audio>/<picture> in the content); when false, only items without. Applied before pagination, so total_items counts matching items. Omit for all items.",
+				},
+				"substantive": {
+					Type:        typeBoolean,
+					Description: "When true, return only substantive items, leaving out stubs (items whose title, description, and content total less than the server's minimum content length, e.g. title-only items); when false, only stubs. Applied before pagination. Omit for all items; stubs are flagged with stub=true either way.",
+				},
+				"authors": {
+					Type:        "array",
+					Description: "Return only items by any of these authors, matched case-insensitively against the item's author and authors list. Applied before pagination. Omit for all items.",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				"unreadOnly": {
+					Type:        typeBoolean,
+					Description: "When true, return only items this session hasn't marked read with mark_read or mark_all_read (default: false). Applied before pagination.",
+				},
+				"cursorPaging": {
+					Type:        typeBoolean,
+					Description: "Page through items newest first by cursor instead of offset, for infinite scroll (default: false). The metadata carries next_cursor while older items remain; pass it as cursor to get the next older batch. Unlike offset, a cursor doesn't shift when new items arrive, so no item is skipped or repeated. Items with the same date are ordered by stable ID. Can't be combined with offset or order=oldest/feed.",
+				},
+				"cursor": {
+					Type:        typeString,
+					Description: "The next_cursor returned by the previous page; returns the items older than the last one that page returned. Implies cursorPaging=true. Keep the filters and dateField the same across pages.",
+				},
+				"includeSearchMeta": {
+					Type:        typeBoolean,
+					Description: "When the page has no items, add search_meta to the metadata explaining why (default: false): reason (no_data: the feed has no items; no_matches: the filters matched none; offset_past_end: offset skipped every match; past_cursor: no match is older than cursor), total_items (feed items scanned), filtered_items (items matching the filters), and applied_filters.",
+				},
 			},
 		},
 	}
 	mcp.AddTool(srv, getSyndicationFeedTool, func(ctx context.Context, req *mcp.CallToolRequest, args GetSyndicationFeedParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
 		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, args.ID)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		params := s.parsePaginationParams(args)
-		paginatedItems, paginationInfo := s.applyPagination(feedResult.Items, params.Limit, params.Offset)
-		content := s.buildFeedContent(ctx, feedResult, paginatedItems, paginationInfo, params.IncludeContent, params.MaxContentLength, params.IncludeImages, params.EmbedImages)
+		items := filterByAuthors(filterBySubstance(filterByMedia(feedResult.Items, params.HasMedia), params.Substantive, s.substantiveMinLen), params.Authors)
+		if params.UnreadOnly {
+			items = s.readStates.unread(readSessionKey(req), items)
+		}
+		ordered := orderItems(items, params.Order, params.DateField)
+		if params.CursorPaging {
+			cursor, err := decodeItemCursor(params.Cursor)
+			if err != nil {
+				return nil, nil, err
+			}
+			ordered = itemsAfterCursor(items, cursor, params.DateField)
+		}
+		paginatedItems, paginationInfo := s.applyPagination(ordered, params.Limit, params.Offset)
+		if params.CursorPaging {
+			paginationInfo.CursorDateField = params.DateField
+			paginationInfo.Cursor = params.Cursor
+		}
+		if params.IncludeSearchMeta && len(paginatedItems) == 0 && params.Offset >= len(ordered) {
+			paginationInfo.SearchMeta = newSearchMeta(args, params, len(feedResult.Items), len(items), s.substantiveMinLen)
+		}
+		content := s.buildFeedContent(ctx, feedResult, paginatedItems, paginationInfo, params)
 
 		return &mcp.CallToolResult{
 			Content: content,
@@ -386,6 +680,8 @@ func (s *Server) parsePaginationParams(args GetSyndicationFeedParams) ParsedFeed
 		MaxContentLength: DefaultContentLength,
 		IncludeImages:    false,
 		EmbedImages:      false,
+		Order:            orderFeed,
+		DateField:        dateFieldPublished,
 	}
 
 	// Parse limit
@@ -426,6 +722,41 @@ func (s *Server) parsePaginationParams(args GetSyndicationFeedParams) ParsedFeed
 		params.EmbedImages = false
 	}
 
+	// Parse maxResponseBytes
+	if args.MaxResponseBytes != nil {
+		params.MaxResponseBytes = max(*args.MaxResponseBytes, 0)
+	}
+
+	// Parse order
+	if args.Order == orderNewest || args.Order == orderOldest {
+		params.Order = args.Order
+	}
+	if args.DateField == dateFieldUpdated {
+		params.DateField = dateFieldUpdated
+	}
+	if args.Plaintext != nil {
+		params.Plaintext = *args.Plaintext
+	}
+
+	params.HasMedia = args.HasMedia
+	params.Substantive = args.Substantive
+	params.Authors = args.Authors
+	if args.IncludeRawDates != nil {
+		params.IncludeRawDates = *args.IncludeRawDates
+	}
+	if args.IncludeSearchMeta != nil {
+		params.IncludeSearchMeta = *args.IncludeSearchMeta
+	}
+	if args.UnreadOnly != nil {
+		params.UnreadOnly = *args.UnreadOnly
+	}
+	if args.Cursor != "" || (args.CursorPaging != nil && *args.CursorPaging) {
+		params.CursorPaging = true
+		params.Cursor = args.Cursor
+		params.Order = orderNewest
+		params.Offset = 0
+	}
+
 	return params
 }
 
@@ -436,16 +767,35 @@ type PaginationInfo struct {
 	Offset        int
 	Limit         int
 	HasMore       bool
+	// SearchMeta, when set, explains why the page is empty.
+	SearchMeta *SearchMeta
+	// CursorDateField is set for cursor paging, to the date field the items
+	// are ordered by; the metadata then carries next_cursor instead of
+	// next_offset. Cursor is the cursor the page started after, if any.
+	CursorDateField string
+	Cursor          string
 }
 
 // ParsedFeedParams holds the parsed and validated feed request parameters
 type ParsedFeedParams struct {
-	Limit            int
-	Offset           int
-	IncludeContent   bool
-	MaxContentLength int
-	IncludeImages    bool
-	EmbedImages      bool
+	Limit             int
+	Offset            int
+	IncludeContent    bool
+	MaxContentLength  int
+	IncludeImages     bool
+	EmbedImages       bool
+	MaxResponseBytes  int
+	Order             string
+	HasMedia          *bool
+	Substantive       *bool
+	Authors           []string
+	UnreadOnly        bool
+	CursorPaging      bool
+	Cursor            string
+	IncludeRawDates   bool
+	IncludeSearchMeta bool
+	DateField         string
+	Plaintext         bool
 }
 
 // applyPagination slices items based on limit and offset
@@ -466,17 +816,31 @@ func (s *Server) applyPagination(items []*gofeed.Item, limit, offset int) ([]*go
 	}
 }
 
-// buildFeedContent creates the MCP content response with feed metadata and items
-func (s *Server) buildFeedContent(ctx context.Context, feedResult *model.FeedAndItemsResult, items []*gofeed.Item, info PaginationInfo, includeContent bool, maxContentLength int, includeImages, embedImages bool) []mcp.Content {
-	content := make([]mcp.Content, 0, 1+len(items))
-
+// buildFeedContent creates the MCP content response with feed metadata and
+// items, rendered as params asks.
+//
+// When params.MaxResponseBytes is positive, items are added only while the cumulative
+// marshaled size of the content (metadata included) stays within it; the
+// metadata then reports truncated_by_size and the next_offset (or, for cursor
+// paging, the next_cursor) to resume from.
+// At least one item is always returned, so a client paging by next_offset makes
+// progress even when a single item is larger than the limit. Items are chosen
+// with their images as links; images are then embedded, in order, into
+// whatever budget is left, so images never crowd out items, items past the
+// limit never trigger image downloads, and an image that doesn't fit stays a
+// link.
+func (s *Server) buildFeedContent(ctx context.Context, feedResult *model.FeedAndItemsResult, items []*gofeed.Item, info PaginationInfo, params ParsedFeedParams) []mcp.Content {
 	type FeedMetadataWithPagination struct {
 		*model.FeedMetadata
-		TotalItems    int  `json:"total_items"`
-		ReturnedItems int  `json:"returned_items"`
-		Offset        int  `json:"offset"`
-		Limit         int  `json:"limit"`
-		HasMore       bool `json:"has_more"`
+		TotalItems      int         `json:"total_items"`
+		ReturnedItems   int         `json:"returned_items"`
+		Offset          int         `json:"offset"`
+		Limit           int         `json:"limit"`
+		HasMore         bool        `json:"has_more"`
+		NextOffset      *int        `json:"next_offset,omitempty"`
+		NextCursor      string      `json:"next_cursor,omitempty"`
+		TruncatedBySize bool        `json:"truncated_by_size,omitempty"`
+		SearchMeta      *SearchMeta `json:"search_meta,omitempty"`
 	}
 
 	feedMetadataWithPagination := &FeedMetadataWithPagination{
@@ -486,47 +850,155 @@ func (s *Server) buildFeedContent(ctx context.Context, feedResult *model.FeedAnd
 		Offset:        info.Offset,
 		Limit:         info.Limit,
 		HasMore:       info.HasMore,
+		SearchMeta:    info.SearchMeta,
+	}
+
+	// Reserve room for the metadata as it would look after a size truncation,
+	// so the final response stays within budget whichever way it ends up.
+	itemBudget := 0
+	if params.MaxResponseBytes > 0 {
+		worstCase := *feedMetadataWithPagination
+		worstCase.HasMore = true
+		worstCase.TruncatedBySize = true
+		if info.CursorDateField != "" {
+			// The longest cursor the page could end on.
+			worstCase.NextCursor = info.Cursor
+			for _, item := range items {
+				if next := newItemCursor(item, info.CursorDateField); len(next) > len(worstCase.NextCursor) {
+					worstCase.NextCursor = next
+				}
+			}
+		} else {
+			worstCase.NextOffset = new(info.Offset + len(items))
+		}
+		data, _ := json.Marshal(&worstCase)
+		itemBudget = params.MaxResponseBytes - len(data)
+	}
+
+	// Images are embedded below, once the items that fit are known.
+	itemParams := params
+	itemParams.EmbedImages = false
+	itemContent := make([]mcp.Content, 0, len(items))
+	usedBytes := 0
+	returned := 0
+	for i, item := range items {
+		blocks := s.buildItemContent(ctx, item, i, itemParams)
+		size := marshaledContentSize(blocks)
+		if params.MaxResponseBytes > 0 && returned > 0 && usedBytes+size > itemBudget {
+			feedMetadataWithPagination.TruncatedBySize = true
+			break
+		}
+		usedBytes += size
+		returned++
+		itemContent = append(itemContent, blocks...)
+	}
+	if params.IncludeImages && params.EmbedImages {
+		room := math.MaxInt
+		if params.MaxResponseBytes > 0 {
+			room = itemBudget - usedBytes
+		}
+		itemContent, _ = s.embedImageLinks(ctx, itemContent, room)
 	}
 
+	if feedMetadataWithPagination.TruncatedBySize {
+		feedMetadataWithPagination.ReturnedItems = returned
+		feedMetadataWithPagination.HasMore = true
+	}
+	if feedMetadataWithPagination.HasMore {
+		switch {
+		case info.CursorDateField == "":
+			feedMetadataWithPagination.NextOffset = new(info.Offset + returned)
+		case returned > 0:
+			feedMetadataWithPagination.NextCursor = newItemCursor(items[returned-1], info.CursorDateField)
+		default:
+			feedMetadataWithPagination.NextCursor = info.Cursor
+		}
+	}
+
+	content := make([]mcp.Content, 0, 1+len(itemContent))
 	data, _ := json.Marshal(feedMetadataWithPagination)
 	content = append(content, &mcp.TextContent{Text: string(data)})
+	return append(content, itemContent...)
+}
 
-	for i, item := range items {
-		processedItem := processItemForOutput(item, includeContent, maxContentLength)
-		itemData, _ := json.Marshal(processedItem)
-		content = append(content, &mcp.TextContent{Text: string(itemData)})
+// buildItemContent returns the content blocks for a single item: its JSON text
+// followed by any image links or embedded images, each tagged with itemIndex.
+// Only the rendering fields of params apply.
+func (s *Server) buildItemContent(ctx context.Context, item *gofeed.Item, itemIndex int, params ParsedFeedParams) []mcp.Content {
+	processedItem := processItemForOutput(item, params.IncludeContent, params.MaxContentLength, params.Plaintext)
+	output := newItemOutput(item, processedItem, s.thumbnailSize)
+	output.Stub = item != nil && isStub(item, s.substantiveMinLen)
+	if params.IncludeRawDates && item != nil {
+		output.rawDates = newRawDates(item)
+	}
+	itemData, _ := json.Marshal(output)
+	blocks := []mcp.Content{&mcp.TextContent{Text: string(itemData)}}
 
-		// Add images if requested
-		if includeImages {
-			imageLinks := extractImageLinks(item)
+	if !params.IncludeImages {
+		return blocks
+	}
 
-			// Limit images per item
-			if len(imageLinks) > MaxImagesPerItem {
-				imageLinks = imageLinks[:MaxImagesPerItem]
-			}
+	imageLinks := extractImageLinks(item)
 
-			for _, link := range imageLinks {
-				if embedImages {
-					// Fetch and embed image as ImageContent
-					imageContent, err := s.fetchAndEmbedImage(ctx, link.URI, link.MIMEType, i)
-					if err != nil {
-						// Log error but continue with other images (graceful degradation)
-						// Fall back to ResourceLink on failure
-						link.Meta = mcp.Meta{keyItemIndex: i}
-						content = append(content, link)
-						continue
-					}
-					content = append(content, imageContent)
-				} else {
-					// Return as ResourceLink (lightweight URL reference)
-					link.Meta = mcp.Meta{keyItemIndex: i}
-					content = append(content, link)
-				}
-			}
+	// Limit images per item
+	if len(imageLinks) > MaxImagesPerItem {
+		imageLinks = imageLinks[:MaxImagesPerItem]
+	}
+
+	for _, link := range imageLinks {
+		// Return as ResourceLink (lightweight URL reference)
+		link.Meta = mcp.Meta{keyItemIndex: itemIndex}
+		blocks = append(blocks, link)
+	}
+
+	if params.EmbedImages {
+		blocks, _ = s.embedImageLinks(ctx, blocks, math.MaxInt)
+	}
+	return blocks
+}
+
+// embedImageLinks replaces the image links among blocks with the fetched
+// images, as long as the response grows by no more than room bytes, and
+// returns the blocks with how much they grew. No image is fetched once room is
+// used up; an image that fails to fetch, or doesn't fit, stays a link.
+func (s *Server) embedImageLinks(ctx context.Context, blocks []mcp.Content, room int) ([]mcp.Content, int) {
+	grown := 0
+	for i, block := range blocks {
+		link, ok := block.(*mcp.ResourceLink)
+		if !ok {
+			continue
+		}
+		if grown >= room {
+			break
+		}
+		itemIndex, _ := link.Meta[keyItemIndex].(int)
+		imageContent, err := s.fetchAndEmbedImage(ctx, link.URI, link.MIMEType, itemIndex)
+		if err != nil {
+			// Graceful degradation: keep the link
+			continue
+		}
+		growth := marshaledContentSize([]mcp.Content{imageContent}) - marshaledContentSize([]mcp.Content{link})
+		if growth > 0 && grown+growth > room {
+			continue
 		}
+		blocks[i] = imageContent
+		grown += growth
 	}
+	return blocks, grown
+}
 
-	return content
+// marshaledContentSize returns the combined JSON-encoded size of content blocks,
+// approximating their contribution to the tool response on the wire.
+func marshaledContentSize(blocks []mcp.Content) int {
+	size := 0
+	for _, block := range blocks {
+		data, err := json.Marshal(block)
+		if err != nil {
+			continue
+		}
+		size += len(data)
+	}
+	return size
 }
 
 // runTransport starts the MCP server with the configured transport
@@ -543,20 +1015,28 @@ func (s *Server) runTransport(ctx context.Context, srv *mcp.Server) error {
 	}
 }
 
-// runStreamableHTTPTransport starts the HTTP server with Streamable HTTP transport
-func (s *Server) runStreamableHTTPTransport(ctx context.Context, srv *mcp.Server) error {
+// httpHandler returns the Streamable HTTP handler for srv, gzipping responses
+// when compression is enabled.
+func (s *Server) httpHandler(srv *mcp.Server) http.Handler {
 	// Create Streamable HTTP handler per MCP spec
-	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
+	var handler http.Handler = mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return srv
 	}, &mcp.StreamableHTTPOptions{
 		Stateless:      s.httpStateless,
 		SessionTimeout: s.httpSessionTimeout,
 	})
+	if s.httpCompression {
+		handler = gzipHandler(handler)
+	}
+	return handler
+}
 
+// runStreamableHTTPTransport starts the HTTP server with Streamable HTTP transport
+func (s *Server) runStreamableHTTPTransport(ctx context.Context, srv *mcp.Server) error {
 	// Create HTTP server with security settings
 	httpServer := &http.Server{
 		Addr:              fmt.Sprintf(":%s", s.httpPort),
-		Handler:           handler,
+		Handler:           s.httpHandler(srv),
 		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attacks
 	}
 
@@ -585,17 +1065,50 @@ func (s *Server) runStreamableHTTPTransport(ctx context.Context, srv *mcp.Server
 
 // addAggregationTools adds feed aggregation tools to the server
 func (s *Server) addAggregationTools(srv *mcp.Server) {
-	// Add merge_feeds tool
+	if s.tools.enabled(toolMergeFeeds) {
+		s.addMergeFeedsTool(srv)
+	}
+	if s.tools.enabled(toolExportFeedData) {
+		s.addExportFeedDataTool(srv)
+	}
+	if s.tools.enabled(toolFeedOverlap) {
+		s.addFeedOverlapTool(srv)
+	}
+	if s.tools.enabled(toolFindItem) {
+		s.addFindItemTool(srv)
+	}
+	if s.tools.enabled(toolFindItemsLinkingTo) {
+		s.addFindItemsLinkingToTool(srv)
+	}
+	if s.tools.enabled(toolGetItemsOnDate) {
+		s.addItemsOnDateTool(srv)
+	}
+	if s.tools.enabled(toolCompareFreshness) {
+		s.addCompareFreshnessTool(srv)
+	}
+	if s.tools.enabled(toolKeywordCooccurrence) {
+		s.addKeywordCooccurrenceTool(srv)
+	}
+}
+
+// addMergeFeedsTool adds the merge_feeds tool
+func (s *Server) addMergeFeedsTool(srv *mcp.Server) {
 	mergeFeedsTool := &mcp.Tool{
-		Name:        "merge_feeds",
+		Name:        toolMergeFeeds,
 		Description: "Merge multiple feeds into a single aggregated feed with deduplication and sorting",
 		InputSchema: &jsonschema.Schema{
-			Type:     typeObject,
-			Required: []string{keyFeedIDs},
+			Type: typeObject,
 			Properties: map[string]*jsonschema.Schema{
 				keyFeedIDs: {
 					Type:        "array",
-					Description: "Array of feed IDs to merge",
+					Description: "Array of feed IDs to merge (required unless tags is given)",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				keyTags: {
+					Type:        "array",
+					Description: "Merge only feeds carrying all of these tags; with feedIds, narrows that list",
 					Items: &jsonschema.Schema{
 						Type: typeString,
 					},
@@ -609,25 +1122,76 @@ func (s *Server) addAggregationTools(srv *mcp.Server) {
 					Description: "Maximum number of items to include (0 for no limit)",
 					Minimum:     &[]float64{0}[0],
 				},
+				"maxPerSource": {
+					Type:        typeInteger,
+					Description: "Maximum number of newest items to take from each feed before maxItems applies (0 for no limit)",
+					Minimum:     &[]float64{0}[0],
+				},
 				"sortBy": {
 					Type:        typeString,
-					Description: "Sort order: date (default), title, source",
-					Enum:        []any{sortByDate, keyTitle, valueSource},
+					Description: "Sort order: date (default; newest published first, using the updated date for items without one), updated (newest updated first, using the published date for items never updated), title, source",
+					Enum:        []any{sortByDate, dateFieldUpdated, keyTitle, valueSource},
 				},
 				"deduplicate": {
 					Type:        typeBoolean,
-					Description: "Remove duplicate items based on title and link",
+					Description: "Remove duplicate items, matched by normalized link (or title when an item has no link)",
+				},
+				"dedupeKey": {
+					Type:        typeString,
+					Description: "Fields that make two items duplicates when deduplicate=true (default: link, or title when an item has no link). title_link: title and link both match (least aggressive). link: link only. guid: GUID only (items syndicated under different GUIDs stay). title: title only (most aggressive). Items missing the selected fields are never dropped.",
+					Enum:        []any{dedupeKeyTitleLink, dedupeKeyLink, dedupeKeyGUID, dedupeKeyTitle},
+				},
+				"dedupeWindowHours": {
+					Type:        typeInteger,
+					Description: "With deduplicate=true, only treat items as duplicates when they were published within this many hours of each other, so a recurring column sharing a title stays (default: 0, any distance). Undated items match regardless.",
+				},
+				"seenSince": {
+					Type:        typeString,
+					Description: "RFC 3339 timestamp; leave out items published at or before it (undated items are kept)",
+				},
+				"cursor": {
+					Type:        typeString,
+					Description: "The cursor returned by a previous merge_feeds call; leaves out the items already returned (matched by normalized link, or title), for incremental polling",
+				},
+				"fields": {
+					Type:        "array",
+					Description: "Item fields to return, to keep large merges small (default: every field). source is the title of the feed the item came from.",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+						Enum: []any{keyTitle, "link", "published", "updated", keyDescription, "content", "author", "guid", "categories", "enclosures", "image", valueSource},
+					},
+				},
+				keyFormat: {
+					Type:        typeString,
+					Description: "Return the merge as an export document instead of the JSON result: rss or atom (one feed with the merged items, titled with title), opml (the merged feeds), or csv (one row per item). The export has no cursor and can't be combined with fields",
+					Enum:        []any{formatRSS, formatAtom, formatOPML, formatCSV},
 				},
 			},
 		},
 	}
 	mcp.AddTool(srv, mergeFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args MergeFeedsParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
 		mergedFeed, err := s.mergeFeeds(ctx, args)
 		if err != nil {
 			return nil, nil, err
 		}
+		if args.Format != "" {
+			exported, err := exportMergedFeed(mergedFeed, args.Format)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: exported}},
+			}, nil, nil
+		}
 
-		data, err := json.Marshal(mergedFeed)
+		var result any = mergedFeed
+		if len(args.Fields) > 0 {
+			result = mergedFeed.project(args.Fields)
+		}
+		data, err := json.Marshal(result)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -636,10 +1200,12 @@ func (s *Server) addAggregationTools(srv *mcp.Server) {
 			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
 		}, nil, nil
 	})
+}
 
-	// Add export_feed_data tool
+// addExportFeedDataTool adds the export_feed_data tool
+func (s *Server) addExportFeedDataTool(srv *mcp.Server) {
 	exportFeedDataTool := &mcp.Tool{
-		Name:        "export_feed_data",
+		Name:        toolExportFeedData,
 		Description: "Export feed data in various formats (JSON, CSV, OPML, RSS, Atom)",
 		InputSchema: &jsonschema.Schema{
 			Type:     typeObject,
@@ -652,6 +1218,20 @@ func (s *Server) addAggregationTools(srv *mcp.Server) {
 						Type: typeString,
 					},
 				},
+				"excludeFeedIds": {
+					Type:        "array",
+					Description: "Feed IDs to leave out of the export, e.g. noisy or broken feeds; they aren't fetched. Applies to feedIds too",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
+				keyTags: {
+					Type:        "array",
+					Description: "Export only feeds carrying all of these tags; with feedIds, narrows that list",
+					Items: &jsonschema.Schema{
+						Type: typeString,
+					},
+				},
 				keyFormat: {
 					Type:        typeString,
 					Description: "Export format",
@@ -674,11 +1254,27 @@ func (s *Server) addAggregationTools(srv *mcp.Server) {
 					Type:        typeBoolean,
 					Description: "Include all feed metadata and statistics",
 				},
+				"compress": {
+					Type:        typeString,
+					Description: "gzip returns the export gzip-compressed and base64-encoded in a JSON object ({format, encoding, size, compressed_size, data}); none (default) returns it as is",
+					Enum:        []any{compressNone, compressGzip},
+				},
+				"outputPath": {
+					Type:        typeString,
+					Description: "Write the export to this file, relative to the server's export directory, and return its path and size instead of the content (requires --allow-file-export). With compress=gzip the file holds the gzip data",
+				},
 			},
 		},
 	}
 	mcp.AddTool(srv, exportFeedDataTool, func(ctx context.Context, req *mcp.CallToolRequest, args ExportFeedDataParams) (*mcp.CallToolResult, any, error) {
-		exportedData, err := s.exportFeedData(ctx, &args)
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		export := s.exportFeedData
+		if args.OutputPath != "" {
+			export = s.exportFeedDataToFile
+		}
+		exportedData, err := export(ctx, &args)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -696,16 +1292,30 @@ func (s *Server) addDynamicFeedTools(srv *mcp.Server) {
 		return
 	}
 
-	s.addAddFeedTool(srv)
-	s.addRemoveFeedTool(srv)
-	s.addListManagedFeedsTool(srv)
-	s.addRefreshFeedTool(srv)
+	if s.tools.enabled(toolAddFeed) {
+		s.addAddFeedTool(srv)
+	}
+	if s.tools.enabled(toolRemoveFeed) {
+		s.addRemoveFeedTool(srv)
+	}
+	if s.tools.enabled(toolListManagedFeeds) {
+		s.addListManagedFeedsTool(srv)
+	}
+	if s.tools.enabled(toolRefreshFeed) {
+		s.addRefreshFeedTool(srv)
+	}
+	if s.tools.enabled(toolUpdateFeed) {
+		s.addUpdateFeedTool(srv)
+	}
+	if s.tools.enabled(toolImportOPML) {
+		s.addImportOPMLTool(srv)
+	}
 }
 
 // addAddFeedTool adds the add_feed tool to the server
 func (s *Server) addAddFeedTool(srv *mcp.Server) {
 	addFeedTool := &mcp.Tool{
-		Name:        "add_feed",
+		Name:        toolAddFeed,
 		Description: "Add a new RSS/Atom/JSON feed at runtime",
 		InputSchema: &jsonschema.Schema{
 			Type:     typeObject,
@@ -727,10 +1337,18 @@ func (s *Server) addAddFeedTool(srv *mcp.Server) {
 					Type:        typeString,
 					Description: "Optional description",
 				},
+				keyTags: {
+					Type:        "array",
+					Items:       &jsonschema.Schema{Type: typeString},
+					Description: "Optional tags for organizing feeds beyond one category, e.g. priority:high or team:infra; stored lowercased",
+				},
 			},
 		},
 	}
 	mcp.AddTool(srv, addFeedTool, func(ctx context.Context, req *mcp.CallToolRequest, args AddFeedParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
 		config := FeedConfig(args)
 
 		feedInfo, err := s.dynamicFeedManager.AddFeed(ctx, config)
@@ -752,7 +1370,7 @@ func (s *Server) addAddFeedTool(srv *mcp.Server) {
 // addRemoveFeedTool adds the remove_feed tool to the server
 func (s *Server) addRemoveFeedTool(srv *mcp.Server) {
 	removeFeedTool := &mcp.Tool{
-		Name:        "remove_feed",
+		Name:        toolRemoveFeed,
 		Description: "Remove a feed by ID or URL",
 		InputSchema: &jsonschema.Schema{
 			Type: typeObject,
@@ -773,17 +1391,16 @@ func (s *Server) addRemoveFeedTool(srv *mcp.Server) {
 		},
 	}
 	mcp.AddTool(srv, removeFeedTool, func(ctx context.Context, req *mcp.CallToolRequest, args RemoveFeedParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
 		var feedInfo *RemovedFeedInfo
 		var err error
 
 		if args.FeedID != "" {
 			feedInfo, err = s.dynamicFeedManager.RemoveFeed(ctx, args.FeedID)
-		} else if args.URL != "" {
-			feedInfo, err = s.dynamicFeedManager.RemoveFeedByURL(ctx, args.URL)
 		} else {
-			return nil, nil, model.NewFeedError(model.ErrorTypeValidation, "either feedId or url must be provided").
-				WithOperation("remove_feed").
-				WithComponent("mcp_server")
+			feedInfo, err = s.dynamicFeedManager.RemoveFeedByURL(ctx, args.URL)
 		}
 
 		if err != nil {
@@ -804,8 +1421,8 @@ func (s *Server) addRemoveFeedTool(srv *mcp.Server) {
 // addListManagedFeedsTool adds the list_managed_feeds tool to the server
 func (s *Server) addListManagedFeedsTool(srv *mcp.Server) {
 	listManagedFeedsTool := &mcp.Tool{
-		Name:        "list_managed_feeds",
-		Description: "List all managed feeds with metadata and status",
+		Name:        toolListManagedFeeds,
+		Description: "List all managed feeds with metadata, status and resource subscriber counts",
 		InputSchema: &jsonschema.Schema{Type: typeObject}, // No parameters needed
 	}
 	mcp.AddTool(srv, listManagedFeedsTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
@@ -813,6 +1430,10 @@ func (s *Server) addListManagedFeedsTool(srv *mcp.Server) {
 		if err != nil {
 			return nil, nil, err
 		}
+		counts := s.resourceManager.SubscriberCounts()
+		for i := range feeds {
+			feeds[i].Subscribers = counts[feeds[i].FeedID]
+		}
 
 		data, err := json.Marshal(feeds)
 		if err != nil {
@@ -828,7 +1449,7 @@ func (s *Server) addListManagedFeedsTool(srv *mcp.Server) {
 // addRefreshFeedTool adds the refresh_feed tool to the server
 func (s *Server) addRefreshFeedTool(srv *mcp.Server) {
 	refreshFeedTool := &mcp.Tool{
-		Name:        "refresh_feed",
+		Name:        toolRefreshFeed,
 		Description: "Force refresh a specific feed to get latest content",
 		InputSchema: &jsonschema.Schema{
 			Type:     typeObject,
@@ -842,6 +1463,9 @@ func (s *Server) addRefreshFeedTool(srv *mcp.Server) {
 		},
 	}
 	mcp.AddTool(srv, refreshFeedTool, func(ctx context.Context, req *mcp.CallToolRequest, args RefreshFeedParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
 		refreshInfo, err := s.dynamicFeedManager.RefreshFeed(ctx, args.FeedID)
 		if err != nil {
 			return nil, nil, err
@@ -858,6 +1482,73 @@ func (s *Server) addRefreshFeedTool(srv *mcp.Server) {
 	})
 }
 
+// addUpdateFeedTool adds the update_feed tool to the server
+func (s *Server) addUpdateFeedTool(srv *mcp.Server) {
+	updateFeedTool := &mcp.Tool{
+		Name:        toolUpdateFeed,
+		Description: "Rename, recategorize, or alias a managed feed in place; omitted fields keep their current values. Returns the updated feed info.",
+		InputSchema: &jsonschema.Schema{
+			Type:     typeObject,
+			Required: []string{keyFeedID},
+			Properties: map[string]*jsonschema.Schema{
+				keyFeedID: {
+					Type:        typeString,
+					Description: "Feed ID to update",
+				},
+				keyTitle: {
+					Type:        typeString,
+					Description: "New human-readable title",
+				},
+				"category": {
+					Type:        typeString,
+					Description: "New category",
+				},
+				"alias": {
+					Type:        typeString,
+					Description: "New short name for the feed: letters, digits, '-', '_' or '.', unique among feeds",
+				},
+				keyDescription: {
+					Type:        typeString,
+					Description: "New description",
+				},
+				keyTags: {
+					Type:        "array",
+					Items:       &jsonschema.Schema{Type: typeString},
+					Description: "New tags, replacing the current ones; an empty list clears them",
+				},
+			},
+		},
+	}
+	mcp.AddTool(srv, updateFeedTool, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateFeedParams) (*mcp.CallToolResult, any, error) {
+		if err := args.validate(); err != nil {
+			return nil, nil, err
+		}
+		feedInfo, err := s.dynamicFeedManager.UpdateFeedMetadata(ctx, args.FeedID, FeedMetadata{
+			Title:       args.Title,
+			Category:    args.Category,
+			Description: args.Description,
+			Alias:       args.Alias,
+			Tags:        args.Tags,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if args.Tags != nil && s.resourceManager != nil {
+			// The feed's meta resource reports its tags
+			_ = s.resourceManager.InvalidateFeedCache(ctx, feedInfo.FeedID)
+		}
+
+		data, err := json.Marshal(feedInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
 // addResourceHandlers adds MCP Resource handlers to the server
 func (s *Server) addResourceHandlers(srv *mcp.Server) {
 	// Get all resources from ResourceManager and add them
@@ -1036,6 +1727,11 @@ func (s *Server) addPrompts(srv *mcp.Server) {
 					Description: "Type of summary: 'brief', 'detailed', or 'executive' (default: 'brief')",
 					Required:    false,
 				},
+				{
+					Name:        keyLocale,
+					Description: "Language for the report's static labels: 'en' or 'es' (default: 'en'); feed data is not translated",
+					Required:    false,
+				},
 			},
 		},
 		s.handleSummarizeFeeds,
@@ -1101,24 +1797,94 @@ func (s *Server) addPrompts(srv *mcp.Server) {
 					Description: "Time period for the report (e.g., '7d', '30d', '90d')",
 					Required:    false,
 				},
+				{
+					Name:        keyLocale,
+					Description: "Language for the report's static labels: 'en' or 'es' (default: 'en'); feed data is not translated",
+					Required:    false,
+				},
 			},
 		},
 		s.handleGenerateFeedReport,
 	)
+
+	srv.AddPrompt(
+		&mcp.Prompt{
+			Name:        "generate_daily_digest",
+			Description: "Generate a ready-to-send HTML email digest of recent items, with per-feed sections of linked titles, dates, and excerpts",
+			Arguments: []*mcp.PromptArgument{
+				{
+					Name:        "feed_ids",
+					Description: "Comma-separated list of feed IDs to include (optional - defaults to all feeds)",
+					Required:    false,
+				},
+				{
+					Name:        "since",
+					Description: "Include items published within this period (e.g., '24h', '7d') or since an RFC3339 timestamp - defaults to '24h'",
+					Required:    false,
+				},
+				{
+					Name:        "max_items_per_feed",
+					Description: "Maximum number of items per feed, newest first (default: 10)",
+					Required:    false,
+				},
+			},
+		},
+		s.handleGenerateDailyDigest,
+	)
+
+	srv.AddPrompt(
+		&mcp.Prompt{
+			Name:        "summarize_item",
+			Description: "Summarize a single item from its full text, with its title, source, and date for context; fetches the linked article when the feed only has a summary",
+			Arguments: []*mcp.PromptArgument{
+				{
+					Name:        "feed_id",
+					Description: "Feed ID from all_syndication_feeds",
+					Required:    true,
+				},
+				{
+					Name:        "item_id",
+					Description: "The item's stable_id, GUID, or link, from get_syndication_feed_items",
+					Required:    true,
+				},
+				{
+					Name:        "fetch_article",
+					Description: "When to use the linked article's text: 'auto' (when the feed has no full content), 'always', or 'never' (default: 'auto')",
+					Required:    false,
+				},
+			},
+		},
+		s.handleSummarizeItem,
+	)
 }
 
 // mergeFeeds implements the feed merging logic
 func (s *Server) mergeFeeds(ctx context.Context, args MergeFeedsParams) (*MergedFeedResult, error) {
 	var allItems []*gofeed.Item
 	var feedTitles []string
+	var feeds []*FeedAndItemsResult
+	sources := make(map[*gofeed.Item]*FeedAndItemsResult)
 
 	// Default values
 	if args.SortBy == "" {
 		args.SortBy = sortByDate
 	}
 
+	seenSince, err := parseTimestampParam(toolMergeFeeds, "seenSince", args.SeenSince)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeSeenCursor(args.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	feedIDs, err := s.selectFeedsByTags(ctx, toolMergeFeeds, args.FeedIDs, args.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	// Fetch all specified feeds
-	for _, feedID := range args.FeedIDs {
+	for _, feedID := range feedIDs {
 		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
 		if err != nil {
 			// Continue with other feeds if one fails
@@ -1127,21 +1893,36 @@ func (s *Server) mergeFeeds(ctx context.Context, args MergeFeedsParams) (*Merged
 
 		if feedResult.Feed != nil {
 			feedTitles = append(feedTitles, feedResult.Feed.Title)
-			allItems = append(allItems, feedResult.Items...)
+			feeds = append(feeds, feedResult)
+			for _, item := range newestItems(feedResult.Items, args.MaxPerSource) {
+				sources[item] = feedResult
+				allItems = append(allItems, item)
+			}
 		}
 	}
 
 	// Deduplicate if requested
 	if args.Deduplicate {
-		allItems = deduplicateItems(allItems)
+		allItems = deduplicateItems(allItems, args.DedupeKey, time.Duration(args.DedupeWindowHours)*time.Hour)
 	}
 
+	// Leave out what the client has already seen
+	if !seenSince.IsZero() {
+		allItems = slices.DeleteFunc(allItems, func(item *gofeed.Item) bool {
+			date := itemDate(item, dateFieldPublished)
+			return date != nil && !date.After(seenSince)
+		})
+	}
+	allItems = cursor.excludeSeen(allItems)
+
 	// Sort items based on sortBy parameter
 	switch args.SortBy {
 	case keyTitle:
 		sortItemsByTitle(allItems)
 	case valueSource:
 		sortItemsBySource(allItems)
+	case dateFieldUpdated:
+		sortItemsByDateField(allItems, dateFieldUpdated)
 	default: // "date"
 		sortItemsByDate(allItems)
 	}
@@ -1154,7 +1935,7 @@ func (s *Server) mergeFeeds(ctx context.Context, args MergeFeedsParams) (*Merged
 	// Create merged feed title
 	title := args.Title
 	if title == "" {
-		title = fmt.Sprintf("Merged Feed (%d sources)", len(args.FeedIDs))
+		title = fmt.Sprintf("Merged Feed (%d sources)", len(feedIDs))
 	}
 
 	// Create merged feed result
@@ -1166,6 +1947,9 @@ func (s *Server) mergeFeeds(ctx context.Context, args MergeFeedsParams) (*Merged
 		SourceFeeds: feedTitles,
 		TotalItems:  len(allItems),
 		CreatedAt:   time.Now(),
+		Cursor:      cursor.next(allItems),
+		sources:     sources,
+		feeds:       feeds,
 	}
 
 	return mergedFeed, nil
@@ -1173,8 +1957,21 @@ func (s *Server) mergeFeeds(ctx context.Context, args MergeFeedsParams) (*Merged
 
 // exportFeedData implements the feed export logic
 func (s *Server) exportFeedData(ctx context.Context, args *ExportFeedDataParams) (string, error) {
+	exported, err := s.renderExport(ctx, args)
+	if err != nil || args.Compress != compressGzip {
+		return exported, err
+	}
+	return compressExport(args.Format, exported)
+}
+
+// renderExport fetches, filters, and formats the feeds to export, uncompressed
+func (s *Server) renderExport(ctx context.Context, args *ExportFeedDataParams) (string, error) {
 	// Get feeds to export
-	feedResults, err := s.getFeedsForExport(ctx, args.FeedIDs)
+	feedIDs, err := s.selectFeedsByTags(ctx, toolExportFeedData, args.FeedIDs, args.Tags)
+	if err != nil {
+		return "", err
+	}
+	feedResults, err := s.getFeedsForExport(ctx, feedIDs, args.ExcludeFeedIDs)
 	if err != nil {
 		return "", err
 	}
@@ -1186,17 +1983,20 @@ func (s *Server) exportFeedData(ctx context.Context, args *ExportFeedDataParams)
 	return s.exportInFormat(feedResults, args)
 }
 
-// getFeedsForExport retrieves the feeds that need to be exported
-func (s *Server) getFeedsForExport(ctx context.Context, feedIDs []string) ([]*FeedAndItemsResult, error) {
+// getFeedsForExport retrieves the feeds that need to be exported, skipping
+// the excluded feed IDs without fetching them
+func (s *Server) getFeedsForExport(ctx context.Context, feedIDs, excludeFeedIDs []string) ([]*FeedAndItemsResult, error) {
 	if len(feedIDs) == 0 {
-		return s.getAllFeedsForExport(ctx)
+		return s.getAllFeedsForExport(ctx, excludeFeedIDs)
 	}
 
-	return s.getSpecificFeedsForExport(ctx, feedIDs)
+	return s.getSpecificFeedsForExport(ctx, slices.DeleteFunc(slices.Clone(feedIDs), func(id string) bool {
+		return slices.Contains(excludeFeedIDs, id)
+	}))
 }
 
-// getAllFeedsForExport gets all feeds for export
-func (s *Server) getAllFeedsForExport(ctx context.Context) ([]*FeedAndItemsResult, error) {
+// getAllFeedsForExport gets all feeds for export except the excluded ones
+func (s *Server) getAllFeedsForExport(ctx context.Context, excludeFeedIDs []string) ([]*FeedAndItemsResult, error) {
 	allFeeds, err := s.allFeedsGetter.GetAllFeeds(ctx)
 	if err != nil {
 		return nil, err
@@ -1204,6 +2004,9 @@ func (s *Server) getAllFeedsForExport(ctx context.Context) ([]*FeedAndItemsResul
 
 	feedResults := make([]*FeedAndItemsResult, 0, len(allFeeds))
 	for _, feed := range allFeeds {
+		if slices.Contains(excludeFeedIDs, feed.ID) {
+			continue
+		}
 		feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feed.ID)
 		if err != nil {
 			continue
@@ -1253,22 +2056,21 @@ func (s *Server) exportInFormat(feedResults []*FeedAndItemsResult, args *ExportF
 	case formatCSV:
 		return exportAsCSV(feedResults)
 	case formatOPML:
-		return exportAsOPML(feedResults)
+		return exportAsOPML(feedResults, exportOPMLTitle)
 	case formatRSS:
-		return exportAsRSS(feedResults)
+		return exportAsRSS(feedResults, exportTitle, exportDescription)
 	case formatAtom:
-		return exportAsAtom(feedResults)
+		return exportAsAtom(feedResults, exportTitle, exportDescription)
 	default:
-		return "", model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("unsupported export format: %s", args.Format)).
-			WithOperation("export_feed_data").
-			WithComponent("mcp_server")
+		return "", model.CreateParameterError(toolExportFeedData, keyFormat, fmt.Sprintf("unsupported export format: %s", args.Format),
+			"Use one of: "+strings.Join(exportFormats, ", "))
 	}
 }
 
 // Helper functions for item processing
 
 // processItemForOutput processes a feed item based on content inclusion and length limits
-func processItemForOutput(item *gofeed.Item, includeContent bool, maxContentLength int) *gofeed.Item {
+func processItemForOutput(item *gofeed.Item, includeContent bool, maxContentLength int, plaintext bool) *gofeed.Item {
 	if item == nil {
 		return nil
 	}
@@ -1276,6 +2078,12 @@ func processItemForOutput(item *gofeed.Item, includeContent bool, maxContentLeng
 	// Create a copy to avoid modifying the original
 	processedItem := *item
 
+	// Convert HTML before truncating, so the length limit applies to the text
+	if includeContent && plaintext {
+		processedItem.Content = htmlToPlaintext(processedItem.Content)
+		processedItem.Description = htmlToPlaintext(processedItem.Description)
+	}
+
 	// Strip content fields if not requested
 	if !includeContent {
 		processedItem.Content = ""
@@ -1523,56 +2331,176 @@ func (s *Server) fetchAndEmbedImage(ctx context.Context, imageURL, mimeType stri
 
 // Helper functions for feed merging and export
 
-// deduplicateItems removes duplicate items based on title and link
-func deduplicateItems(items []*gofeed.Item) []*gofeed.Item {
-	seen := make(map[string]bool)
+// deduplicateItems removes duplicate items from different feeds, keeping the
+// first of each set of items sharing a key (see itemDedupKeyFor). A positive
+// window narrows duplicates to items published within it of a kept item with
+// the same key, so the same title weeks apart stays; an undated item can't be
+// told apart and matches any kept item with its key.
+func deduplicateItems(items []*gofeed.Item, dedupeKey string, window time.Duration) []*gofeed.Item {
+	seen := make(map[string][]*time.Time) // publish dates of the kept items, by key
 	var unique []*gofeed.Item
 
 	for _, item := range items {
-		// Create a unique key based on title and link
-		key := fmt.Sprintf("%s|%s", item.Title, item.Link)
-		if !seen[key] {
-			seen[key] = true
+		key := itemDedupKeyFor(item, dedupeKey)
+		if key == "" {
 			unique = append(unique, item)
+			continue
+		}
+		kept, ok := seen[key]
+		date := itemDate(item, dateFieldPublished)
+		if ok && (window <= 0 || slices.ContainsFunc(kept, func(keptDate *time.Time) bool {
+			return keptDate == nil || date == nil || date.Sub(*keptDate).Abs() <= window
+		})) {
+			continue
 		}
+		seen[key] = append(kept, date)
+		unique = append(unique, item)
 	}
-
 	return unique
 }
 
-// sortItemsByDate sorts items by published date (newest first)
-func sortItemsByDate(items []*gofeed.Item) {
-	slices.SortFunc(items, func(a, b *gofeed.Item) int {
-		// Handle nil PublishedParsed dates: non-nil sorts before nil
-		if a.PublishedParsed == nil || b.PublishedParsed == nil {
-			switch {
-			case a.PublishedParsed != nil:
-				return -1
-			case b.PublishedParsed != nil:
-				return 1
-			default:
-				return 0
-			}
+// itemDedupKey identifies an item across feeds: by its normalized link, or by
+// its normalized title when it has no link. Items with neither get an empty
+// key and are never treated as duplicates. Stable IDs aren't used here: they
+// prefer the GUID, and each feed carrying an article usually gives it its own.
+func itemDedupKey(item *gofeed.Item) string {
+	if link := model.NormalizeItemLink(item.Link); link != "" {
+		return "link:" + link
+	}
+	if title := normalizedItemTitle(item); title != "" {
+		return "title:" + title
+	}
+	return ""
+}
+
+// newestItems returns the n most recently published items, or all items when n
+// is not positive. It sorts a copy, leaving the caller's slice untouched.
+func newestItems(items []*gofeed.Item, n int) []*gofeed.Item {
+	if n <= 0 || len(items) <= n {
+		return items
+	}
+	sorted := slices.Clone(items)
+	sortItemsByDate(sorted)
+	return sorted[:n]
+}
+
+// feedHealthRank ranks a feed's health for orderFeedsByHealth: 0 for an open
+// circuit breaker, 1 for a fetch error, 2 for a healthy feed.
+func feedHealthRank(feed *model.FeedResult) int {
+	switch {
+	case feed.CircuitBreakerOpen:
+		return 0
+	case feed.FetchError != "":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// orderFeedsByHealth groups feeds by feedHealthRank, least healthy first for
+// unhealthy_first and last for unhealthy_last, keeping the incoming order
+// within each group. Any other order returns feeds unchanged. It sorts a copy.
+func orderFeedsByHealth(feeds []*model.FeedResult, order string) []*model.FeedResult {
+	if order != healthOrderUnhealthyFirst && order != healthOrderUnhealthyLast {
+		return feeds
+	}
+	sorted := slices.Clone(feeds)
+	slices.SortStableFunc(sorted, func(a, b *model.FeedResult) int {
+		if order == healthOrderUnhealthyLast {
+			a, b = b, a
 		}
-		// Sort newest first
-		return b.PublishedParsed.Compare(*a.PublishedParsed)
+		return cmp.Compare(feedHealthRank(a), feedHealthRank(b))
 	})
+	return sorted
+}
+
+// filterByMedia returns the items whose hasMedia result matches want, or all
+// items when want is nil.
+func filterByMedia(items []*gofeed.Item, want *bool) []*gofeed.Item {
+	if want == nil {
+		return items
+	}
+	var matched []*gofeed.Item
+	for _, item := range items {
+		if hasMedia(item) == *want {
+			matched = append(matched, item)
+		}
+	}
+	return matched
 }
 
-// sortItemsByTitle sorts items alphabetically by title
+// filterByAuthors keeps the items by any of authors (see hasAnyAuthor), or
+// returns items unchanged when authors is empty.
+func filterByAuthors(items []*gofeed.Item, authors []string) []*gofeed.Item {
+	if len(authors) == 0 {
+		return items
+	}
+	var matched []*gofeed.Item
+	for _, item := range items {
+		if hasAnyAuthor(item, authors) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// orderItems returns items in the requested order: newest or oldest by the
+// date for dateField (see itemDate), or unchanged for feed order. Sorting works
+// on a copy so the cached feed keeps its publisher order, and is stable so
+// items sharing a date keep their relative feed order. Undated items go last
+// for newest and first for oldest.
+func orderItems(items []*gofeed.Item, order, dateField string) []*gofeed.Item {
+	if order != orderNewest && order != orderOldest {
+		return items
+	}
+	dir := 1
+	if order == orderOldest {
+		dir = -1
+	}
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b *gofeed.Item) int {
+		return dir * compareItemDates(a, b, dateField)
+	})
+	return sorted
+}
+
+// sortItemsByDate sorts items by published date (newest first), using the
+// updated date for items that have no published date.
+func sortItemsByDate(items []*gofeed.Item) {
+	sortItemsByDateField(items, dateFieldPublished)
+}
+
+// sortItemsByDateField sorts items newest first by the date for field.
+func sortItemsByDateField(items []*gofeed.Item, field string) {
+	slices.SortStableFunc(items, func(a, b *gofeed.Item) int {
+		return cmp.Or(compareItemDates(a, b, field), compareItemTiebreak(a, b))
+	})
+}
+
+// sortItemsByTitle sorts items alphabetically by title, newest first within
+// a title
 func sortItemsByTitle(items []*gofeed.Item) {
-	slices.SortFunc(items, func(a, b *gofeed.Item) int {
-		return cmp.Compare(a.Title, b.Title)
+	slices.SortStableFunc(items, func(a, b *gofeed.Item) int {
+		return cmp.Or(cmp.Compare(a.Title, b.Title), compareItemDates(a, b, dateFieldPublished), compareItemTiebreak(a, b))
 	})
 }
 
-// sortItemsBySource sorts items by source feed title
+// sortItemsBySource sorts items by source feed title, newest first within a
+// source
 func sortItemsBySource(items []*gofeed.Item) {
-	slices.SortFunc(items, func(a, b *gofeed.Item) int {
-		return cmp.Compare(getItemSource(a), getItemSource(b))
+	slices.SortStableFunc(items, func(a, b *gofeed.Item) int {
+		return cmp.Or(cmp.Compare(getItemSource(a), getItemSource(b)), compareItemDates(a, b, dateFieldPublished), compareItemTiebreak(a, b))
 	})
 }
 
+// compareItemTiebreak orders items whose sort keys are equal by title, then
+// link, then GUID. The sortItemsBy functions fall back on it, and sort
+// stably, so items with equal keys come out in the same order on every call,
+// whatever order the feeds were gathered in.
+func compareItemTiebreak(a, b *gofeed.Item) int {
+	return cmp.Or(cmp.Compare(a.Title, b.Title), cmp.Compare(a.Link, b.Link), cmp.Compare(a.GUID, b.GUID))
+}
+
 // getItemSource extracts source information from a feed item
 func getItemSource(item *gofeed.Item) string {
 	if item.Custom != nil && item.Custom[valueSource] != "" {
@@ -1629,15 +2557,16 @@ func filterItemsByDateRange(items []*gofeed.Item, sinceTime, untilTime time.Time
 
 // itemInDateRange checks if an item falls within the date range
 func itemInDateRange(item *gofeed.Item, sinceTime, untilTime time.Time) bool {
-	if item.PublishedParsed == nil {
+	date := itemDate(item, dateFieldPublished)
+	if date == nil {
 		return true
 	}
 
-	if !sinceTime.IsZero() && item.PublishedParsed.Before(sinceTime) {
+	if !sinceTime.IsZero() && date.Before(sinceTime) {
 		return false
 	}
 
-	if !untilTime.IsZero() && item.PublishedParsed.After(untilTime) {
+	if !untilTime.IsZero() && date.After(untilTime) {
 		return false
 	}
 
@@ -1646,6 +2575,13 @@ func itemInDateRange(item *gofeed.Item, sinceTime, untilTime time.Time) bool {
 
 // Export format implementations
 
+// Titles and description of export_feed_data's OPML, RSS, and Atom documents.
+const (
+	exportOPMLTitle   = "Feed Export"
+	exportTitle       = "Combined Feed Export"
+	exportDescription = "Combined feed containing items from multiple sources"
+)
+
 // exportAsJSON exports feed results as JSON
 func exportAsJSON(feedResults []*FeedAndItemsResult, includeAll bool) (string, error) {
 	data := struct {
@@ -1696,12 +2632,12 @@ func exportAsCSV(feedResults []*FeedAndItemsResult) (string, error) {
 }
 
 // exportAsOPML exports feed results as OPML
-func exportAsOPML(feedResults []*FeedAndItemsResult) (string, error) {
+func exportAsOPML(feedResults []*FeedAndItemsResult, title string) (string, error) {
 	var result strings.Builder
 	result.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
 <opml version="1.0">
 <head>
-<title>Feed Export</title>
+<title>` + escapeXML(title) + `</title>
 <dateCreated>` + time.Now().Format(time.RFC1123Z) + `</dateCreated>
 </head>
 <body>
@@ -1719,14 +2655,14 @@ func exportAsOPML(feedResults []*FeedAndItemsResult) (string, error) {
 	return result.String(), nil
 }
 
-// exportAsRSS exports feed results as RSS 2.0
-func exportAsRSS(feedResults []*FeedAndItemsResult) (string, error) {
+// exportAsRSS exports feed results as one RSS 2.0 channel
+func exportAsRSS(feedResults []*FeedAndItemsResult, title, description string) (string, error) {
 	var result strings.Builder
 	result.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
 <rss version="2.0">
 <channel>
-<title>Combined Feed Export</title>
-<description>Combined feed containing items from multiple sources</description>
+<title>` + escapeXML(title) + `</title>
+<description>` + escapeXML(description) + `</description>
 <lastBuildDate>` + time.Now().Format(time.RFC1123Z) + `</lastBuildDate>
 `)
 
@@ -1741,8 +2677,7 @@ func exportAsRSS(feedResults []*FeedAndItemsResult) (string, error) {
 <link>` + escapeXML(item.Link) + `</link>
 <description>` + escapeXML(item.Description) + `</description>
 <pubDate>` + pubDate + `</pubDate>
-<guid>` + escapeXML(item.Link) + `</guid>
-</item>
+` + rssGUIDElement(item) + `</item>
 `)
 		}
 	}
@@ -1753,13 +2688,13 @@ func exportAsRSS(feedResults []*FeedAndItemsResult) (string, error) {
 	return result.String(), nil
 }
 
-// exportAsAtom exports feed results as Atom 1.0
-func exportAsAtom(feedResults []*FeedAndItemsResult) (string, error) {
+// exportAsAtom exports feed results as one Atom 1.0 feed
+func exportAsAtom(feedResults []*FeedAndItemsResult, title, subtitle string) (string, error) {
 	var result strings.Builder
 	result.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
 <feed xmlns="http://www.w3.org/2005/Atom">
-<title>Combined Feed Export</title>
-<subtitle>Combined feed containing items from multiple sources</subtitle>
+<title>` + escapeXML(title) + `</title>
+<subtitle>` + escapeXML(subtitle) + `</subtitle>
 <updated>` + time.Now().Format(time.RFC3339) + `</updated>
 <id>urn:feed-mcp:export:` + fmt.Sprintf("%d", time.Now().Unix()) + `</id>
 `)
@@ -1775,8 +2710,7 @@ func exportAsAtom(feedResults []*FeedAndItemsResult) (string, error) {
 <link href="` + escapeXML(item.Link) + `"/>
 <summary>` + escapeXML(item.Description) + `</summary>
 <updated>` + updatedDate + `</updated>
-<id>` + escapeXML(item.Link) + `</id>
-</entry>
+` + atomIDElement(item) + `</entry>
 `)
 		}
 	}
@@ -1786,6 +2720,45 @@ func exportAsAtom(feedResults []*FeedAndItemsResult) (string, error) {
 	return result.String(), nil
 }
 
+// exportItemID returns the identifier an exported item carries: its original
+// GUID, so identity survives an export and re-import, else its link, else a
+// hash of its title and publish date. It is "" when the item has none of them.
+func exportItemID(item *gofeed.Item) string {
+	if guid := strings.TrimSpace(item.GUID); guid != "" {
+		return guid
+	}
+	if link := strings.TrimSpace(item.Link); link != "" {
+		return link
+	}
+	if hash := model.StableID(item, []model.StableIDSource{model.StableIDHash}); hash != "" {
+		return "urn:feed-mcp:item:" + strings.TrimPrefix(hash, string(model.StableIDHash)+":")
+	}
+	return ""
+}
+
+// rssGUIDElement renders the item's <guid> line, marked isPermaLink="false"
+// unless it is the item's link.
+func rssGUIDElement(item *gofeed.Item) string {
+	id := exportItemID(item)
+	switch id {
+	case "":
+		return ""
+	case strings.TrimSpace(item.Link):
+		return "<guid>" + escapeXML(id) + "</guid>\n"
+	default:
+		return `<guid isPermaLink="false">` + escapeXML(id) + "</guid>\n"
+	}
+}
+
+// atomIDElement renders the entry's <id> line.
+func atomIDElement(item *gofeed.Item) string {
+	id := exportItemID(item)
+	if id == "" {
+		return ""
+	}
+	return "<id>" + escapeXML(id) + "</id>\n"
+}
+
 // Utility functions for escaping
 
 // escapeCSVField escapes a field for CSV format
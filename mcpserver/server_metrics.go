@@ -0,0 +1,129 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ServerMetrics is the get_server_metrics snapshot. The retry, feed timing,
+// and circuit breaker sections come from the store's DiagnosticsProvider and
+// are omitted when the store doesn't implement it.
+type ServerMetrics struct {
+	GeneratedAt     time.Time              `json:"generated_at"`
+	Feeds           FeedCounts             `json:"feeds"`
+	ResourceCache   ResourceCacheSnapshot  `json:"resource_cache"`
+	RetryMetrics    *RetryMetricsSnapshot  `json:"retry_metrics,omitempty"`
+	CircuitBreakers *CircuitBreakerSummary `json:"circuit_breakers,omitempty"`
+	FeedTimings     []FeedTiming           `json:"feed_timings,omitempty"`
+}
+
+// FeedCounts counts the configured feeds by health. A feed is errored when
+// its last fetch failed or its circuit breaker is open, as in list_feed_index.
+type FeedCounts struct {
+	Total       int `json:"total"`
+	Healthy     int `json:"healthy"`
+	Errored     int `json:"errored"`
+	CircuitOpen int `json:"circuit_open"`
+}
+
+// ResourceCacheSnapshot mirrors ResourceCacheMetrics, adding the hit rate as
+// a percentage of lookups (0 before the first lookup).
+type ResourceCacheSnapshot struct {
+	Hits             uint64  `json:"hits"`
+	Misses           uint64  `json:"misses"`
+	Evictions        uint64  `json:"evictions"`
+	InvalidationHits uint64  `json:"invalidation_hits"`
+	HitRate          float64 `json:"hit_rate"`
+}
+
+// CircuitBreakerSummary counts circuit breakers by state alongside each
+// breaker's status.
+type CircuitBreakerSummary struct {
+	Closed   int                    `json:"closed"`
+	HalfOpen int                    `json:"half_open"`
+	Open     int                    `json:"open"`
+	Breakers []CircuitBreakerStatus `json:"breakers"`
+}
+
+// addServerMetricsTool adds the get_server_metrics tool
+func (s *Server) addServerMetricsTool(srv *mcp.Server) {
+	serverMetricsTool := &mcp.Tool{
+		Name:        toolGetServerMetrics,
+		Description: "Get one snapshot of operational metrics for dashboards: feed counts (total/healthy/errored), resource cache hits and misses, and, when the store reports them, retry metrics, circuit breaker states, and per-feed fetch timings",
+		InputSchema: &jsonschema.Schema{Type: typeObject},
+	}
+	mcp.AddTool(srv, serverMetricsTool, func(ctx context.Context, req *mcp.CallToolRequest, args any) (*mcp.CallToolResult, any, error) {
+		metrics, err := s.serverMetrics(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := json.Marshal(metrics)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil, nil
+	})
+}
+
+// serverMetrics gathers the get_server_metrics snapshot. Counting feeds by
+// health loads any feed that isn't cached, as all_syndication_feeds does.
+func (s *Server) serverMetrics(ctx context.Context) (*ServerMetrics, error) {
+	feedResults, err := s.allFeedsGetter.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &ServerMetrics{GeneratedAt: time.Now().UTC()}
+	metrics.Feeds.Total = len(feedResults)
+	for _, feedResult := range feedResults {
+		if feedResult.CircuitBreakerOpen {
+			metrics.Feeds.CircuitOpen++
+		}
+		if feedResult.FetchError != "" || feedResult.CircuitBreakerOpen {
+			metrics.Feeds.Errored++
+		}
+	}
+	metrics.Feeds.Healthy = metrics.Feeds.Total - metrics.Feeds.Errored
+
+	if s.resourceManager != nil {
+		cacheMetrics := s.resourceManager.GetCacheMetrics()
+		metrics.ResourceCache = ResourceCacheSnapshot{
+			Hits:             cacheMetrics.Hits,
+			Misses:           cacheMetrics.Misses,
+			Evictions:        cacheMetrics.Evictions,
+			InvalidationHits: cacheMetrics.InvalidationHits,
+		}
+		if lookups := cacheMetrics.Hits + cacheMetrics.Misses; lookups > 0 {
+			metrics.ResourceCache.HitRate = float64(cacheMetrics.Hits) / float64(lookups) * 100
+		}
+	}
+
+	provider := s.capabilities.diagnosticsProvider
+	if provider == nil {
+		return metrics, nil
+	}
+	diagnostics, err := provider.GetDiagnostics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RetryMetrics = &diagnostics.RetryMetrics
+	metrics.FeedTimings = diagnostics.FeedTimings
+	breakers := &CircuitBreakerSummary{Breakers: diagnostics.CircuitBreakers}
+	for _, status := range diagnostics.CircuitBreakers {
+		switch status.State {
+		case "open":
+			breakers.Open++
+		case "half-open":
+			breakers.HalfOpen++
+		default:
+			breakers.Closed++
+		}
+	}
+	metrics.CircuitBreakers = breakers
+	return metrics, nil
+}
@@ -0,0 +1,127 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockMetricsGetter adds DiagnosticsProvider to the tool feed getter.
+type mockMetricsGetter struct {
+	mockFeedAndItemsGetter
+	diagnostics *Diagnostics
+}
+
+func (m *mockMetricsGetter) GetDiagnostics(ctx context.Context) (*Diagnostics, error) {
+	return m.diagnostics, nil
+}
+
+// callServerMetrics calls get_server_metrics on a server built from config
+// and decodes the snapshot.
+func callServerMetrics(t *testing.T, config *Config) (ServerMetrics, map[string]json.RawMessage) {
+	t.Helper()
+	config.Transport = model.StdioTransport
+	session := newTestClientSession(t, config)
+	ctx := context.Background()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolGetServerMetrics, Arguments: map[string]any{}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	var metrics ServerMetrics
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &metrics); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := json.Unmarshal([]byte(text), &sections); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return metrics, sections
+}
+
+func TestGetServerMetricsTool(t *testing.T) {
+	fetched := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	getter := &mockMetricsGetter{diagnostics: &Diagnostics{
+		RetryMetrics: RetryMetricsSnapshot{TotalAttempts: 7, TotalRetries: 2, SuccessfulFeeds: 3, FailedFeeds: 1, RetrySuccessRate: 75},
+		CircuitBreakers: []CircuitBreakerStatus{
+			{FeedID: "a", State: "closed"},
+			{FeedID: "b", State: "open", ConsecutiveFailures: 3},
+			{FeedID: "c", State: "half-open"},
+		},
+		FeedTimings: []FeedTiming{{FeedID: "a", URL: "https://a.example/feed", Fetches: 2, LastDurationMs: 120, AverageDurationMs: 100, LastFetchedAt: fetched}},
+	}}
+	metrics, sections := callServerMetrics(t, &Config{
+		AllFeedsGetter: &mockAllFeedsGetter{feeds: []*model.FeedResult{
+			{ID: "a"},
+			{ID: "b", CircuitBreakerOpen: true},
+			{ID: "c", FetchError: "HTTP 500"},
+		}},
+		FeedAndItemsGetter:  getter,
+		DiagnosticsProvider: getter,
+	})
+
+	for _, section := range []string{"generated_at", "feeds", "resource_cache", "retry_metrics", "circuit_breakers", "feed_timings"} {
+		if _, ok := sections[section]; !ok {
+			t.Errorf("snapshot has no %s section", section)
+		}
+	}
+	if want := (FeedCounts{Total: 3, Healthy: 1, Errored: 2, CircuitOpen: 1}); metrics.Feeds != want {
+		t.Errorf("feeds = %+v, want %+v", metrics.Feeds, want)
+	}
+	if metrics.RetryMetrics == nil || *metrics.RetryMetrics != getter.diagnostics.RetryMetrics {
+		t.Errorf("retry_metrics = %+v, want %+v", metrics.RetryMetrics, getter.diagnostics.RetryMetrics)
+	}
+	if cb := metrics.CircuitBreakers; cb == nil || cb.Closed != 1 || cb.Open != 1 || cb.HalfOpen != 1 || len(cb.Breakers) != 3 {
+		t.Errorf("circuit_breakers = %+v, want one of each state", cb)
+	}
+	if len(metrics.FeedTimings) != 1 || metrics.FeedTimings[0].AverageDurationMs != 100 || !metrics.FeedTimings[0].LastFetchedAt.Equal(fetched) {
+		t.Errorf("feed_timings = %+v", metrics.FeedTimings)
+	}
+	if metrics.GeneratedAt.IsZero() {
+		t.Error("generated_at is zero")
+	}
+}
+
+func TestGetServerMetricsTool_WithoutDiagnostics(t *testing.T) {
+	metrics, sections := callServerMetrics(t, &Config{
+		AllFeedsGetter:     &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "a"}}},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+	})
+	if metrics.Feeds.Total != 1 || metrics.Feeds.Healthy != 1 {
+		t.Errorf("feeds = %+v, want one healthy feed", metrics.Feeds)
+	}
+	if _, ok := sections["resource_cache"]; !ok {
+		t.Error("snapshot has no resource_cache section")
+	}
+	for _, section := range []string{"retry_metrics", "circuit_breakers", "feed_timings"} {
+		if _, ok := sections[section]; ok {
+			t.Errorf("snapshot has a %s section without a DiagnosticsProvider", section)
+		}
+	}
+}
+
+func TestServerMetrics_ResourceCacheHitRate(t *testing.T) {
+	s := &Server{
+		allFeedsGetter:     &mockAllFeedsGetter{},
+		feedAndItemsGetter: &mockFeedAndItemsGetter{},
+		resourceManager:    createTestResourceManager(),
+	}
+	s.resourceManager.recordCacheHit()
+	s.resourceManager.recordCacheHit()
+	s.resourceManager.recordCacheHit()
+	s.resourceManager.recordCacheMiss()
+
+	metrics, err := s.serverMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("serverMetrics: %v", err)
+	}
+	if want := (ResourceCacheSnapshot{Hits: 3, Misses: 1, HitRate: 75}); metrics.ResourceCache != want {
+		t.Errorf("resource_cache = %+v, want %+v", metrics.ResourceCache, want)
+	}
+}
@@ -0,0 +1,74 @@
+package mcpserver
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// storeCapabilities holds the optional feed store capabilities set in Config.
+// A nil capability leaves out the tools, and the resource and tool fields,
+// that need it.
+type storeCapabilities struct {
+	circuitBreakerResetter CircuitBreakerResetter
+	feedHistoryProvider    FeedHistoryProvider
+	feedPinger             FeedPinger
+	feedCachePurger        FeedCachePurger
+	duplicateFeedsProvider DuplicateFeedsProvider
+	diagnosticsProvider    DiagnosticsProvider
+	configProvider         ConfigProvider
+	feedTagsProvider       FeedTagsProvider
+	feedIconResolver       FeedIconResolver
+	itemSearcher           ItemSearcher
+}
+
+// newStoreCapabilities collects the capabilities config provides.
+func newStoreCapabilities(config *Config) storeCapabilities {
+	return storeCapabilities{
+		circuitBreakerResetter: config.CircuitBreakerResetter,
+		feedHistoryProvider:    config.FeedHistoryProvider,
+		feedPinger:             config.FeedPinger,
+		feedCachePurger:        config.FeedCachePurger,
+		duplicateFeedsProvider: config.DuplicateFeedsProvider,
+		diagnosticsProvider:    config.DiagnosticsProvider,
+		configProvider:         config.ConfigProvider,
+		feedTagsProvider:       config.FeedTagsProvider,
+		feedIconResolver:       config.FeedIconResolver,
+		itemSearcher:           config.ItemSearcher,
+	}
+}
+
+// unavailableTools returns the tools that can't be registered because the
+// capability they need is missing.
+func (c storeCapabilities) unavailableTools() []string {
+	var names []string
+	for _, tool := range []struct {
+		name      string
+		available bool
+	}{
+		{toolResetCircuitBreaker, c.circuitBreakerResetter != nil},
+		{toolExportFeedHistory, c.feedHistoryProvider != nil},
+		{toolPingFeeds, c.feedPinger != nil},
+		{toolPurgeFeedCache, c.feedCachePurger != nil},
+		{toolListDuplicateFeeds, c.duplicateFeedsProvider != nil},
+	} {
+		if !tool.available {
+			names = append(names, tool.name)
+		}
+	}
+	return names
+}
+
+// checkEnabledTools fails when enable names a tool that can't be registered,
+// rather than leaving it out silently.
+func (c storeCapabilities) checkEnabledTools(enable []string) error {
+	for _, name := range c.unavailableTools() {
+		if slices.Contains(enable, name) {
+			return model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("tool %q is enabled, but the feed store doesn't support it", name)).
+				WithOperation("create_server").
+				WithComponent("mcp_server")
+		}
+	}
+	return nil
+}
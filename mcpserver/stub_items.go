@@ -0,0 +1,34 @@
+package mcpserver
+
+import (
+	"cmp"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// DefaultSubstantiveMinLength is the content length (title, description, and
+// content, as getContentLength counts it) below which an item is a stub when
+// no other threshold is configured.
+const DefaultSubstantiveMinLength = 100
+
+// isStub reports whether an item has less than minLength of content, such as
+// a title-only item with an empty body. A minLength of zero means
+// DefaultSubstantiveMinLength.
+func isStub(item *gofeed.Item, minLength int) bool {
+	return getContentLength(item) < cmp.Or(minLength, DefaultSubstantiveMinLength)
+}
+
+// filterBySubstance returns only substantive items when want is true, only
+// stubs when it is false, or all items when it is nil.
+func filterBySubstance(items []*gofeed.Item, want *bool, minLength int) []*gofeed.Item {
+	if want == nil {
+		return items
+	}
+	var matched []*gofeed.Item
+	for _, item := range items {
+		if !isStub(item, minLength) == *want {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,106 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// stubTestItems returns two title-only stubs around a substantive item.
+func stubTestItems() []*gofeed.Item {
+	return []*gofeed.Item{
+		{Title: "headline only"},
+		{Title: "full story", Content: "<p>" + strings.Repeat("Plenty of words. ", 10) + "</p>"},
+		{Title: "short", Description: "A one-line teaser."},
+	}
+}
+
+func TestIsStub(t *testing.T) {
+	items := stubTestItems()
+	tests := []struct {
+		minLength int
+		want      []bool
+	}{
+		{0, []bool{true, false, true}},    // DefaultSubstantiveMinLength
+		{10, []bool{false, false, false}}, // only empty items are stubs
+		{1000, []bool{true, true, true}},
+	}
+	for _, tt := range tests {
+		for i, item := range items {
+			if got := isStub(item, tt.minLength); got != tt.want[i] {
+				t.Errorf("isStub(%q, %d) = %v, want %v", item.Title, tt.minLength, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestGetFeedItemsTool_Substantive(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", stubTestItems())
+
+	call := func(substantive *bool) (titles []string, stubs []string) {
+		t.Helper()
+		args := map[string]any{keyID: "feed-1"}
+		if substantive != nil {
+			args["substantive"] = *substantive
+		}
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetSyndicationFeedItems, Arguments: args})
+		if err != nil || result.IsError {
+			t.Fatalf("CallTool: %v, %+v", err, result)
+		}
+		for _, block := range result.Content[1:] {
+			var item map[string]any
+			if err := json.Unmarshal([]byte(block.(*mcp.TextContent).Text), &item); err != nil {
+				t.Fatalf("unmarshal item: %v", err)
+			}
+			title := item["title"].(string)
+			titles = append(titles, title)
+			if item["stub"] == true {
+				stubs = append(stubs, title)
+			}
+		}
+		return titles, stubs
+	}
+
+	titles, stubs := call(nil)
+	if !slices.Equal(titles, []string{"headline only", "full story", "short"}) {
+		t.Errorf("all items = %v", titles)
+	}
+	if !slices.Equal(stubs, []string{"headline only", "short"}) {
+		t.Errorf("flagged stubs = %v, want [headline only short]", stubs)
+	}
+	if titles, _ := call(new(true)); !slices.Equal(titles, []string{"full story"}) {
+		t.Errorf("substantive=true: items %v, want [full story]", titles)
+	}
+	if titles, _ := call(new(false)); !slices.Equal(titles, []string{"headline only", "short"}) {
+		t.Errorf("substantive=false: items %v, want [headline only short]", titles)
+	}
+}
+
+func TestApplyFilters_Substantive(t *testing.T) {
+	filters, err := ParseURIParameters("feeds://feed/feed-1/items?substantive=true")
+	if err != nil {
+		t.Fatalf("ParseURIParameters: %v", err)
+	}
+	got := ApplyFilters(stubTestItems(), filters)
+	if len(got) != 1 || got[0].Title != "full story" {
+		t.Errorf("substantive=true kept %d items, want only full story", len(got))
+	}
+	if summary := CreateFilterSummary(3, len(got), filters); summary.AppliedFilters["substantive"] != true {
+		t.Errorf("applied_filters = %v, want substantive=true", summary.AppliedFilters)
+	}
+
+	filters.Substantive = new(false)
+	filters.SubstantiveMinLength = 10
+	if got := ApplyFilters(stubTestItems(), filters); len(got) != 0 {
+		t.Errorf("with a 10-character minimum, %d items are stubs, want 0", len(got))
+	}
+
+	if _, err := ParseURIParameters("feeds://feed/feed-1/items?substantive=maybe"); err == nil {
+		t.Error("expected an error for substantive=maybe")
+	}
+}
@@ -2,11 +2,14 @@ package mcpserver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
 	"github.com/richardwooding/feed-mcp/model"
 )
 
@@ -352,3 +355,88 @@ func TestResourceSessionMethods(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceManagerSubscriberCounts(t *testing.T) {
+	rm := NewResourceManager(&mockAllFeedsGetter{}, &mockFeedAndItemsGetter{})
+	for _, id := range []string{"session-1", "session-2", "session-3"} {
+		rm.CreateSession(id)
+	}
+
+	subscriptions := map[string][]string{
+		// Subscribing to several resources of one feed counts the session once.
+		"session-1": {"feeds://feed/feed-a", "feeds://feed/feed-a/items", "feeds://feed/feed-a/meta"},
+		"session-2": {"feeds://feed/feed-a/items?limit=5", "feeds://feed/feed-b"},
+		// Feed-independent resources are not attributed to any feed.
+		"session-3": {FeedListURI, DiagnosticsURI},
+	}
+	for sessionID, uris := range subscriptions {
+		for _, uri := range uris {
+			if err := rm.Subscribe(sessionID, uri); err != nil {
+				t.Fatalf("Subscribe(%s, %s): %v", sessionID, uri, err)
+			}
+		}
+	}
+
+	counts := rm.SubscriberCounts()
+	want := map[string]int{"feed-a": 2, "feed-b": 1}
+	if len(counts) != len(want) {
+		t.Errorf("SubscriberCounts() = %v, want %v", counts, want)
+	}
+	for feedID, n := range want {
+		if counts[feedID] != n {
+			t.Errorf("SubscriberCounts()[%s] = %d, want %d", feedID, counts[feedID], n)
+		}
+	}
+
+	if err := rm.Unsubscribe("session-2", "feeds://feed/feed-b"); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+	rm.RemoveSession("session-1")
+	counts = rm.SubscriberCounts()
+	if counts["feed-a"] != 1 || counts["feed-b"] != 0 {
+		t.Errorf("after unsubscribe SubscriberCounts() = %v, want feed-a:1", counts)
+	}
+}
+
+func TestListManagedFeedsSubscribers(t *testing.T) {
+	manager := &mockDynamicFeedManager{feeds: map[string]*ManagedFeedInfo{
+		"feed-1": {FeedID: "feed-1", Title: "Subscribed"},
+		"feed-2": {FeedID: "feed-2", Title: "Unsubscribed"},
+	}}
+	srv, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "feed-1"}, {ID: "feed-2"}}},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		DynamicFeedManager: manager,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	session := connectTestClient(t, srv)
+	ctx := context.Background()
+
+	// One subscription arrives over MCP, a second from another session.
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "feeds://feed/feed-1/items"}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	srv.resourceManager.CreateSession("other-session")
+	if err := srv.resourceManager.Subscribe("other-session", "feeds://feed/feed-1"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: toolListManagedFeeds})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	var feeds []ManagedFeedInfo
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &feeds); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := make(map[string]int, len(feeds))
+	for _, feed := range feeds {
+		got[feed.FeedID] = feed.Subscribers
+	}
+	if got["feed-1"] != 2 || got["feed-2"] != 0 {
+		t.Errorf("subscribers = %v, want feed-1:2 feed-2:0", got)
+	}
+}
@@ -0,0 +1,132 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/time/rate"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// summarizeItemMaxChars caps the item text put into the summarize_item
+// prompt, so a very long article doesn't crowd out the conversation.
+const summarizeItemMaxChars = 20000
+
+// summarize_item fetch_article values.
+const (
+	fetchArticleAuto   = "auto"
+	fetchArticleAlways = "always"
+	fetchArticleNever  = "never"
+)
+
+// handleSummarizeItem builds a prompt asking for a summary of one item, with
+// its full text and its title, source, and date for context. The text is the
+// item's content; with fetch_article=auto (the default) the linked article is
+// extracted instead when the feed only carries a summary, and with always
+// whenever the item has a link.
+func (s *Server) handleSummarizeItem(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	feedID := strings.TrimSpace(getStringArg(req.Params.Arguments, "feed_id", ""))
+	itemID := strings.TrimSpace(getStringArg(req.Params.Arguments, "item_id", ""))
+	if feedID == "" || itemID == "" {
+		return createErrorPromptResult("feed_id and item_id are required"), nil
+	}
+	fetchArticle := getStringArg(req.Params.Arguments, "fetch_article", fetchArticleAuto)
+	switch fetchArticle {
+	case fetchArticleAuto, fetchArticleAlways, fetchArticleNever:
+	default:
+		return createErrorPromptResult(fmt.Sprintf("Invalid fetch_article '%s': use auto, always, or never", fetchArticle)), nil
+	}
+
+	feedResult, err := s.feedAndItemsGetter.GetFeedAndItems(ctx, feedID)
+	if err != nil {
+		return createErrorPromptResult(fmt.Sprintf("Failed to get feed '%s': %v", feedID, err)), nil
+	}
+	item := findFeedItem(feedResult.Items, itemID)
+	if item == nil {
+		return createErrorPromptResult(fmt.Sprintf("No item '%s' in feed '%s': pass the item's stable_id, GUID, or link from get_syndication_feed_items", itemID, feedID)), nil
+	}
+
+	text, textSource := s.summarizeItemText(ctx, item, fetchArticle)
+	source := feedResult.Title
+	if feedResult.Feed != nil && source == "" {
+		source = feedResult.Feed.Title
+	}
+	date := "unknown"
+	if published := itemDate(item, dateFieldPublished); published != nil {
+		date = published.UTC().Format("2006-01-02 15:04 UTC")
+	}
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = "(untitled)"
+	}
+
+	promptContent := fmt.Sprintf(`Summarize the following item from the feed "%s" in a few sentences, then list its key points. Mention the source and date where they matter, and don't add facts that aren't in the text.
+
+**Title:** %s
+**Source:** %s
+**Published:** %s
+**Link:** %s
+**Text from:** %s
+
+---
+
+%s`, source, title, source, date, cmp.Or(item.Link, "none"), textSource, truncateWords(text, summarizeItemMaxChars))
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Summary of %q from %s", title, source),
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: roleUser,
+				Content: &mcp.TextContent{
+					Text: promptContent,
+				},
+			},
+		},
+	}, nil
+}
+
+// summarizeItemText returns the item's text and where it came from. The feed's
+// content (else description) is used unless fetchArticle calls for the linked
+// article: always, or auto when the feed has no content beyond a description.
+// When the article can't be fetched, the feed's text is used after all.
+func (s *Server) summarizeItemText(ctx context.Context, item *gofeed.Item, fetchArticle string) (text, source string) {
+	content := htmlToPlaintext(item.Content)
+	text, source = content, "feed content"
+	if text == "" {
+		text, source = htmlToPlaintext(item.Description), "feed summary"
+	}
+
+	wantArticle := fetchArticle == fetchArticleAlways || (fetchArticle == fetchArticleAuto && content == "")
+	if !wantArticle || item.Link == "" {
+		return text, source
+	}
+	article, err := s.articleText(ctx, rate.NewLimiter(rate.Inf, 1), item)
+	if err != nil || strings.TrimSpace(article) == "" {
+		if err != nil {
+			source += fmt.Sprintf(" (the linked article couldn't be fetched: %v)", err)
+		}
+		return text, source
+	}
+	return article, "linked article"
+}
+
+// findFeedItem returns the item whose stable ID, GUID, or link (compared
+// normalized) is id, or nil.
+func findFeedItem(items []*gofeed.Item, id string) *gofeed.Item {
+	link := model.NormalizeItemLink(id)
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if model.ItemStableID(item) == id || strings.TrimSpace(item.GUID) == id ||
+			(item.Link != "" && model.NormalizeItemLink(item.Link) == link) {
+			return item
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func summarizeItemText(t *testing.T, s *Server, args map[string]string) string {
+	t.Helper()
+	result, err := s.handleSummarizeItem(context.Background(), &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Arguments: args}})
+	if err != nil {
+		t.Fatalf("handleSummarizeItem() failed: %v", err)
+	}
+	validatePromptResult(t, result)
+	text, ok := result.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", result.Messages[0].Content)
+	}
+	return text.Text
+}
+
+func TestSummarizeItem(t *testing.T) {
+	var articleRequests atomic.Int32
+	pages := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		articleRequests.Add(1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<html><body><nav>Menu</nav><article><p>The full article explains the new scheduler in depth.</p></article></body></html>`))
+	}))
+	defer pages.Close()
+
+	published := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	items := []*gofeed.Item{
+		{Title: "Full story", GUID: "full-1", Link: "https://blog.example.com/full", PublishedParsed: &published,
+			Content: "<p>The complete post body lives <b>in the feed</b>.</p>", Description: "A teaser."},
+		{Title: "Teaser only", GUID: "teaser-1", Link: pages.URL + "/article", Description: "<p>Just a teaser.</p>"},
+		{Title: "Broken link", GUID: "broken-1", Link: pages.URL + "/missing", Description: "Only the summary survives."},
+	}
+	s, err := NewServer(&Config{
+		Transport:       model.StdioTransport,
+		AllFeedsGetter:  &mockAllFeedsGetter{},
+		AllowPrivateIPs: true,
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+			"blog": {ID: "blog", Title: "Example Blog", Items: items},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	// Feed content is used as-is, looked up by GUID; no article is fetched.
+	text := summarizeItemText(t, s, map[string]string{"feed_id": "blog", "item_id": "full-1"})
+	for _, want := range []string{"The complete post body lives in the feed.", "**Title:** Full story", "**Source:** Example Blog", "**Published:** 2026-03-14 09:30 UTC", "**Text from:** feed content"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, text)
+		}
+	}
+	if got := articleRequests.Load(); got != 0 {
+		t.Errorf("fetched %d articles for an item with full content, want 0", got)
+	}
+
+	// A teaser-only item, looked up by link, gets the linked article's text.
+	text = summarizeItemText(t, s, map[string]string{"feed_id": "blog", "item_id": pages.URL + "/article"})
+	if !strings.Contains(text, "The full article explains the new scheduler in depth.") || !strings.Contains(text, "**Text from:** linked article") {
+		t.Errorf("prompt doesn't carry the linked article:\n%s", text)
+	}
+	if strings.Contains(text, "Menu") {
+		t.Errorf("prompt includes page navigation:\n%s", text)
+	}
+
+	// fetch_article=never keeps the teaser.
+	text = summarizeItemText(t, s, map[string]string{"feed_id": "blog", "item_id": "teaser-1", "fetch_article": "never"})
+	if !strings.Contains(text, "Just a teaser.") || !strings.Contains(text, "**Text from:** feed summary") {
+		t.Errorf("fetch_article=never prompt:\n%s", text)
+	}
+
+	// When the article can't be fetched, the summary is used and the failure noted.
+	text = summarizeItemText(t, s, map[string]string{"feed_id": "blog", "item_id": "broken-1"})
+	if !strings.Contains(text, "Only the summary survives.") || !strings.Contains(text, "couldn't be fetched") {
+		t.Errorf("prompt for an unreachable article:\n%s", text)
+	}
+}
+
+func TestSummarizeItem_Errors(t *testing.T) {
+	s := &Server{feedAndItemsGetter: &mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"blog": {ID: "blog", Items: []*gofeed.Item{{Title: "Post", GUID: "post-1"}}},
+	}}}
+	for name, args := range map[string]map[string]string{
+		"missing item_id":   {"feed_id": "blog"},
+		"unknown feed":      {"feed_id": "missing", "item_id": "post-1"},
+		"unknown item":      {"feed_id": "blog", "item_id": "post-2"},
+		"bad fetch_article": {"feed_id": "blog", "item_id": "post-1", "fetch_article": "sometimes"},
+	} {
+		if text := summarizeItemText(t, s, args); !strings.HasPrefix(text, "Error:") {
+			t.Errorf("%s: prompt = %q, want an error", name, text)
+		}
+	}
+}
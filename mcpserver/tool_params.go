@@ -0,0 +1,419 @@
+package mcpserver
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// Tool handlers validate their arguments before doing any work, so a bad
+// parameter fails fast with a validation FeedError that names the parameter
+// and says how to fix it, rather than surfacing later as a generic error or
+// being silently ignored.
+
+const (
+	suggestFeedID    = "Pass a feed ID from the all_syndication_feeds tool"
+	suggestTimestamp = "Use an RFC 3339 timestamp such as 2024-01-15T10:30:00Z"
+)
+
+// exportFormats are the formats export_feed_data accepts.
+var exportFormats = []string{formatJSON, formatCSV, formatOPML, formatRSS, formatAtom}
+
+// requireParam reports a missing or blank required parameter.
+func requireParam(tool, field, value, suggestion string) error {
+	if strings.TrimSpace(value) == "" {
+		return model.CreateParameterError(tool, field, field+" is required", suggestion)
+	}
+	return nil
+}
+
+// checkOneOf reports a value outside allowed. An empty value selects the
+// tool's default and is always accepted.
+func checkOneOf(tool, field, value string, allowed ...string) error {
+	if value == "" || slices.Contains(allowed, value) {
+		return nil
+	}
+	return model.CreateParameterError(tool, field, fmt.Sprintf("invalid %s %q", field, value),
+		"Use one of: "+strings.Join(allowed, ", "))
+}
+
+// checkFields reports a fields entry that isn't one of itemFieldNames.
+func checkFields(tool string, fields []string) error {
+	for _, field := range fields {
+		if field == "" {
+			return model.CreateParameterError(tool, "fields", "fields entries cannot be empty", "Use any of: "+strings.Join(itemFieldNames, ", "))
+		}
+		if err := checkOneOf(tool, "fields", field, itemFieldNames...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAuthors reports a blank authors entry, which would match no author.
+func checkAuthors(tool string, authors []string) error {
+	for _, author := range authors {
+		if strings.TrimSpace(author) == "" {
+			return model.CreateParameterError(tool, "authors", "authors entries cannot be empty", "Pass author names, e.g. [\"Jane Smith\"]")
+		}
+	}
+	return nil
+}
+
+// checkNonNegative reports a negative count or offset.
+func checkNonNegative(tool, field string, value int) error {
+	if value < 0 {
+		return model.CreateParameterError(tool, field, fmt.Sprintf("%s must not be negative, got %d", field, value),
+			fmt.Sprintf("Use 0 or a positive %s", field))
+	}
+	return nil
+}
+
+// checkNonNegativePtr is checkNonNegative for optional parameters.
+func checkNonNegativePtr(tool, field string, value *int) error {
+	if value == nil {
+		return nil
+	}
+	return checkNonNegative(tool, field, *value)
+}
+
+// checkOutputPath reports an output path that is absolute or climbs out of
+// the export directory with "..".
+func checkOutputPath(tool, value string) error {
+	if value != "" && !filepath.IsLocal(value) {
+		return model.CreateParameterError(tool, "outputPath", fmt.Sprintf("outputPath must be a relative path inside the export directory, got %q", value),
+			"Use a relative path without .., e.g. exports/feeds.json")
+	}
+	return nil
+}
+
+// parseTimestampParam parses an optional RFC 3339 parameter; an empty value
+// is the zero time.
+func parseTimestampParam(tool, field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, model.CreateParameterError(tool, field, fmt.Sprintf("invalid %s %q", field, value), suggestTimestamp)
+	}
+	return t, nil
+}
+
+// firstError returns the first non-nil error, so each validate method reads
+// as a list of checks in parameter order.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p AllSyndicationFeedsParams) validate() error {
+	return checkOneOf(toolAllSyndicationFeeds, "orderByHealth", p.OrderByHealth, healthOrderUnhealthyFirst, healthOrderUnhealthyLast)
+}
+
+func (p GetSyndicationFeedParams) validate() error {
+	const tool = toolGetSyndicationFeedItems
+	return firstError(
+		requireParam(tool, keyID, p.ID, suggestFeedID),
+		checkNonNegativePtr(tool, "limit", p.Limit),
+		checkNonNegativePtr(tool, "offset", p.Offset),
+		checkNonNegativePtr(tool, "maxContentLength", p.MaxContentLength),
+		checkNonNegativePtr(tool, "maxResponseBytes", p.MaxResponseBytes),
+		checkOneOf(tool, "order", p.Order, orderNewest, orderOldest, orderFeed),
+		checkOneOf(tool, "dateField", p.DateField, dateFieldPublished, dateFieldUpdated),
+		checkAuthors(tool, p.Authors),
+		p.checkCursorPaging(),
+	)
+}
+
+// checkCursorPaging reports a malformed cursor, and cursor paging combined
+// with an offset or an order other than newest.
+func (p GetSyndicationFeedParams) checkCursorPaging() error {
+	const tool = toolGetSyndicationFeedItems
+	if p.Cursor == "" && (p.CursorPaging == nil || !*p.CursorPaging) {
+		return nil
+	}
+	if p.Offset != nil && *p.Offset > 0 {
+		return model.CreateParameterError(tool, "offset", "offset cannot be combined with cursor paging",
+			"Omit offset; pass the next_cursor from the previous page as cursor instead")
+	}
+	if p.Order != "" && p.Order != orderNewest {
+		return model.CreateParameterError(tool, "order", fmt.Sprintf("cursor paging returns items newest first, but order is %q", p.Order),
+			"Omit order, or use order=newest")
+	}
+	_, err := decodeItemCursor(p.Cursor)
+	return err
+}
+
+func (p ResetCircuitBreakerParams) validate() error {
+	const tool = toolResetCircuitBreaker
+	switch {
+	case p.FeedID == "" && !p.All:
+		return model.CreateParameterError(tool, keyFeedID, "feedId is required unless all is true", "Pass a feed ID from all_syndication_feeds, or all=true to reset every feed")
+	case p.FeedID != "" && p.All:
+		return model.CreateParameterError(tool, keyFeedID, "feedId and all=true cannot be combined", "Pass either a feed ID or all=true")
+	}
+	return nil
+}
+
+func (p ExportFeedHistoryParams) validate() error {
+	const tool = toolExportFeedHistory
+	return firstError(
+		requireParam(tool, keyFeedID, p.FeedID, suggestFeedID),
+		checkNonNegative(tool, "limit", p.Limit),
+	)
+}
+
+func (p PurgeFeedCacheParams) validate() error {
+	return requireParam(toolPurgeFeedCache, keyFeedID, p.FeedID, suggestFeedID)
+}
+
+func (p FetchLinkParams) validate() error {
+	return requireParam(toolFetchLink, keyURL, p.URL, "Pass the http or https URL of the page to fetch")
+}
+
+func (p MergeFeedsParams) validate() error {
+	const tool = toolMergeFeeds
+	if len(p.FeedIDs) == 0 && len(p.Tags) == 0 {
+		return model.CreateParameterError(tool, keyFeedIDs, "feedIds must list at least one feed, or tags must be given", suggestFeedID)
+	}
+	if err := firstError(
+		checkTags(tool, p.Tags),
+		checkNonNegative(tool, "maxItems", p.MaxItems),
+		checkNonNegative(tool, "maxPerSource", p.MaxPerSource),
+		checkOneOf(tool, "sortBy", p.SortBy, sortByDate, dateFieldUpdated, keyTitle, valueSource),
+		checkOneOf(tool, "dedupeKey", p.DedupeKey, dedupeKeyNames...),
+		checkNonNegative(tool, "dedupeWindowHours", p.DedupeWindowHours),
+		checkFields(tool, p.Fields),
+		checkOneOf(tool, keyFormat, p.Format, mergeExportFormats...),
+	); err != nil {
+		return err
+	}
+	if p.Format != "" && len(p.Fields) > 0 {
+		return model.CreateParameterError(tool, "fields", "fields can't be combined with format",
+			"Leave out fields; export documents have a fixed set of item fields")
+	}
+	if _, err := parseTimestampParam(tool, "seenSince", p.SeenSince); err != nil {
+		return err
+	}
+	_, err := decodeSeenCursor(p.Cursor)
+	return err
+}
+
+func (p ExportFeedDataParams) validate() error {
+	const tool = toolExportFeedData
+	if p.Format == "" {
+		return model.CreateParameterError(tool, keyFormat, "format is required",
+			"Use one of: "+strings.Join(exportFormats, ", "))
+	}
+	if err := firstError(
+		checkOneOf(tool, keyFormat, p.Format, exportFormats...),
+		checkTags(tool, p.Tags),
+		checkNonNegative(tool, "maxItems", p.MaxItems),
+		checkOneOf(tool, "compress", p.Compress, compressNone, compressGzip),
+		checkOutputPath(tool, p.OutputPath),
+	); err != nil {
+		return err
+	}
+	since, err := parseTimestampParam(tool, "since", p.Since)
+	if err != nil {
+		return err
+	}
+	until, err := parseTimestampParam(tool, "until", p.Until)
+	if err != nil {
+		return err
+	}
+	if !since.IsZero() && !until.IsZero() && since.After(until) {
+		return model.CreateParameterError(tool, "since", "since must not be after until", "Swap since and until, or widen the range")
+	}
+	return nil
+}
+
+func (p FeedOverlapParams) validate() error {
+	// With tags, the count is checked again once they have been resolved
+	distinct := slices.Compact(slices.Sorted(slices.Values(p.FeedIDs)))
+	if len(distinct) < 2 && len(p.Tags) == 0 {
+		return model.CreateParameterError(toolFeedOverlap, keyFeedIDs, "at least two distinct feedIds are required",
+			"Pass two or more different feed IDs from the all_syndication_feeds tool, or tags shared by two or more feeds")
+	}
+	return firstError(
+		checkTags(toolFeedOverlap, p.Tags),
+		checkOneOf(toolFeedOverlap, "dedupeKey", p.DedupeKey, dedupeKeyNames...),
+	)
+}
+
+func (p FindItemParams) validate() error {
+	guid, link := strings.TrimSpace(p.GUID) != "", strings.TrimSpace(p.Link) != ""
+	switch {
+	case !guid && !link:
+		return model.CreateParameterError(toolFindItem, "guid", "guid or link is required", "Pass the GUID or the link of the item to find")
+	case guid && link:
+		return model.CreateParameterError(toolFindItem, "guid", "guid and link cannot be combined", "Pass either a GUID or a link")
+	}
+	return nil
+}
+
+func (p FindItemsLinkingToParams) validate() error {
+	const tool = toolFindItemsLinkingTo
+	if err := requireParam(tool, "domain", p.Domain, "Pass a domain such as example.com"); err != nil {
+		return err
+	}
+	if normalizeDomain(p.Domain) == "" {
+		return model.CreateParameterError(tool, "domain", fmt.Sprintf("invalid domain %q", p.Domain), "Pass a domain such as example.com")
+	}
+	return nil
+}
+
+func (p GetItemsOnDateParams) validate() error {
+	const tool = toolGetItemsOnDate
+	switch {
+	case p.FeedID == "" && !p.All:
+		return model.CreateParameterError(tool, keyFeedID, "feedId is required unless all is true", "Pass a feed ID from all_syndication_feeds, or all=true to search every feed")
+	case p.FeedID != "" && p.All:
+		return model.CreateParameterError(tool, keyFeedID, "feedId and all=true cannot be combined", "Pass either a feed ID or all=true")
+	}
+	if err := requireParam(tool, "date", p.Date, "Use a calendar day as YYYY-MM-DD, such as 2024-03-15"); err != nil {
+		return err
+	}
+	_, _, err := p.day()
+	return err
+}
+
+func (p CompareFreshnessParams) validate() error {
+	const tool = toolCompareFreshness
+	if err := firstError(
+		requireParam(tool, keyFeedID, p.FeedID, suggestFeedID),
+		requireParam(tool, "referenceFeedId", p.ReferenceFeedID, "Pass the ID of an actively updated feed to compare against"),
+	); err != nil {
+		return err
+	}
+	if p.FeedID == p.ReferenceFeedID {
+		return model.CreateParameterError(tool, "referenceFeedId", "referenceFeedId must differ from feedId",
+			"Compare the feed against a different, actively updated feed")
+	}
+	return nil
+}
+
+func (p KeywordCooccurrenceParams) validate() error {
+	const tool = toolKeywordCooccurrence
+	for _, keyword := range p.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return model.CreateParameterError(tool, "keywords", "keywords entries cannot be empty", "Pass keywords or phrases, e.g. [\"ai\", \"regulation\"]")
+		}
+	}
+	switch n := len(cooccurrenceKeywords(p.Keywords)); {
+	case n < 2:
+		return model.CreateParameterError(tool, "keywords", "at least two distinct keywords are required",
+			"Pass two or more keywords to compare")
+	case n > maxCooccurrenceKeywords:
+		return model.CreateParameterError(tool, "keywords", fmt.Sprintf("at most %d keywords are allowed, got %d", maxCooccurrenceKeywords, n),
+			"Compare fewer keywords at a time")
+	}
+	if timeframe := strings.TrimSpace(p.Timeframe); timeframe != "" {
+		if d, err := parseDuration(timeframe); err != nil || d <= 0 {
+			return model.CreateParameterError(tool, keyTimeframe, fmt.Sprintf("invalid timeframe %q", p.Timeframe),
+				"Use a positive duration such as 24h, 7d, or 30d")
+		}
+	}
+	return nil
+}
+
+func (p EstimateFeedFrequencyParams) validate() error {
+	return requireParam(toolEstimateFeedFrequency, keyFeedID, p.FeedID, suggestFeedID)
+}
+
+func (p GetFeedCategoriesParams) validate() error {
+	return requireParam(toolGetFeedCategories, keyFeedID, p.FeedID, suggestFeedID)
+}
+
+func (p GetPodcastEpisodesParams) validate() error {
+	const tool = toolGetPodcastEpisodes
+	return firstError(
+		requireParam(tool, keyFeedID, p.FeedID, suggestFeedID),
+		checkNonNegativePtr(tool, "limit", p.Limit),
+		checkNonNegativePtr(tool, "offset", p.Offset),
+	)
+}
+
+func (p FetchFeedFullContentParams) validate() error {
+	const tool = toolFetchFeedFullContent
+	if err := firstError(
+		requireParam(tool, keyFeedID, p.FeedID, suggestFeedID),
+		checkNonNegative(tool, "maxItems", p.MaxItems),
+	); err != nil {
+		return err
+	}
+	if !p.Confirm {
+		return model.CreateParameterError(tool, "confirm", "fetch_feed_full_content fetches every item's linked page; set confirm=true to proceed",
+			"Set confirm=true to fetch the articles")
+	}
+	return nil
+}
+
+func (p MarkReadParams) validate() error {
+	return checkItemIDs(toolMarkRead, p.FeedID, p.ItemIDs)
+}
+
+func (p MarkUnreadParams) validate() error {
+	return checkItemIDs(toolMarkUnread, p.FeedID, p.ItemIDs)
+}
+
+// checkItemIDs reports a missing feed ID or an empty or blank list of item
+// IDs for mark_read and mark_unread.
+func checkItemIDs(tool, feedID string, itemIDs []string) error {
+	const suggestItemIDs = "Pass each item's stable_id, GUID, or link from get_syndication_feed_items"
+	if err := requireParam(tool, keyFeedID, feedID, suggestFeedID); err != nil {
+		return err
+	}
+	if len(itemIDs) == 0 {
+		return model.CreateParameterError(tool, "itemIds", "itemIds must list at least one item", suggestItemIDs)
+	}
+	for _, id := range itemIDs {
+		if strings.TrimSpace(id) == "" {
+			return model.CreateParameterError(tool, "itemIds", "itemIds entries cannot be empty", suggestItemIDs)
+		}
+	}
+	return nil
+}
+
+func (p MarkAllReadParams) validate() error {
+	return requireParam(toolMarkAllRead, keyFeedID, p.FeedID, suggestFeedID)
+}
+
+func (p AddFeedParams) validate() error {
+	return firstError(
+		requireParam(toolAddFeed, keyURLLower, p.URL, "Pass the http or https URL of an RSS, Atom, or JSON feed"),
+		checkTags(toolAddFeed, p.Tags),
+	)
+}
+
+func (p RemoveFeedParams) validate() error {
+	if strings.TrimSpace(p.FeedID) == "" && strings.TrimSpace(p.URL) == "" {
+		return model.CreateParameterError(toolRemoveFeed, keyFeedID, "either feedId or url must be provided",
+			"Pass the feed ID from list_managed_feeds, or the feed's URL")
+	}
+	return nil
+}
+
+func (p RefreshFeedParams) validate() error {
+	return requireParam(toolRefreshFeed, keyFeedID, p.FeedID, "Pass a feed ID from the list_managed_feeds tool")
+}
+
+func (p UpdateFeedParams) validate() error {
+	return firstError(
+		requireParam(toolUpdateFeed, keyFeedID, p.FeedID, "Pass a feed ID from the list_managed_feeds tool"),
+		checkTags(toolUpdateFeed, p.Tags),
+	)
+}
+
+func (p ImportOPMLParams) validate() error {
+	return requireParam(toolImportOPML, "opml", p.OPML, "Pass the OPML document's XML, e.g. an export from a feed reader")
+}
@@ -0,0 +1,189 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestToolParams_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    interface{ validate() error }
+		wantTool  string
+		wantField string
+	}{
+		{"all feeds bad orderByHealth", AllSyndicationFeedsParams{OrderByHealth: "healthiest"}, toolAllSyndicationFeeds, "orderByHealth"},
+		{"feed items missing ID", GetSyndicationFeedParams{}, toolGetSyndicationFeedItems, keyID},
+		{"feed items negative offset", GetSyndicationFeedParams{ID: "a", Offset: new(-1)}, toolGetSyndicationFeedItems, "offset"},
+		{"feed items bad order", GetSyndicationFeedParams{ID: "a", Order: "random"}, toolGetSyndicationFeedItems, "order"},
+		{"feed items bad dateField", GetSyndicationFeedParams{ID: "a", DateField: "modified"}, toolGetSyndicationFeedItems, "dateField"},
+		{"feed items blank author", GetSyndicationFeedParams{ID: "a", Authors: []string{"Jane", " "}}, toolGetSyndicationFeedItems, "authors"},
+		{"feed items bad cursor", GetSyndicationFeedParams{ID: "a", Cursor: "not-a-cursor"}, toolGetSyndicationFeedItems, "cursor"},
+		{"feed items cursor with offset", GetSyndicationFeedParams{ID: "a", CursorPaging: new(true), Offset: new(10)}, toolGetSyndicationFeedItems, "offset"},
+		{"feed items cursor oldest first", GetSyndicationFeedParams{ID: "a", CursorPaging: new(true), Order: orderOldest}, toolGetSyndicationFeedItems, "order"},
+		{"fetch link missing URL", FetchLinkParams{}, toolFetchLink, keyURL},
+		{"reset breaker without feed", ResetCircuitBreakerParams{}, toolResetCircuitBreaker, keyFeedID},
+		{"reset breaker feed and all", ResetCircuitBreakerParams{FeedID: "a", All: true}, toolResetCircuitBreaker, keyFeedID},
+		{"purge cache without feed", PurgeFeedCacheParams{}, toolPurgeFeedCache, keyFeedID},
+		{"mark read without feed", MarkReadParams{ItemIDs: []string{"a"}}, toolMarkRead, keyFeedID},
+		{"mark read without items", MarkReadParams{FeedID: "a"}, toolMarkRead, "itemIds"},
+		{"mark unread blank item", MarkUnreadParams{FeedID: "a", ItemIDs: []string{" "}}, toolMarkUnread, "itemIds"},
+		{"mark all read without feed", MarkAllReadParams{}, toolMarkAllRead, keyFeedID},
+		{"merge no feeds", MergeFeedsParams{}, toolMergeFeeds, keyFeedIDs},
+		{"merge bad sortBy", MergeFeedsParams{FeedIDs: []string{"a"}, SortBy: "size"}, toolMergeFeeds, "sortBy"},
+		{"merge bad dedupeKey", MergeFeedsParams{FeedIDs: []string{"a"}, DedupeKey: "hash"}, toolMergeFeeds, "dedupeKey"},
+		{"overlap bad dedupeKey", FeedOverlapParams{FeedIDs: []string{"a", "b"}, DedupeKey: "url"}, toolFeedOverlap, "dedupeKey"},
+		{"merge unknown field", MergeFeedsParams{FeedIDs: []string{"a"}, Fields: []string{keyTitle, "body"}}, toolMergeFeeds, "fields"},
+		{"merge negative maxItems", MergeFeedsParams{FeedIDs: []string{"a"}, MaxItems: -5}, toolMergeFeeds, "maxItems"},
+		{"merge bad seenSince", MergeFeedsParams{FeedIDs: []string{"a"}, SeenSince: "yesterday"}, toolMergeFeeds, "seenSince"},
+		{"merge bad cursor", MergeFeedsParams{FeedIDs: []string{"a"}, Cursor: "!!"}, toolMergeFeeds, "cursor"},
+		{"merge json format", MergeFeedsParams{FeedIDs: []string{"a"}, Format: formatJSON}, toolMergeFeeds, keyFormat},
+		{"merge format with fields", MergeFeedsParams{FeedIDs: []string{"a"}, Format: formatRSS, Fields: []string{keyTitle}}, toolMergeFeeds, "fields"},
+		{"export missing format", ExportFeedDataParams{}, toolExportFeedData, keyFormat},
+		{"export bad format", ExportFeedDataParams{Format: "xlsx"}, toolExportFeedData, keyFormat},
+		{"export bad since", ExportFeedDataParams{Format: formatJSON, Since: "2024-01-15"}, toolExportFeedData, "since"},
+		{"export bad until", ExportFeedDataParams{Format: formatJSON, Until: "tomorrow"}, toolExportFeedData, "until"},
+		{"export bad compress", ExportFeedDataParams{Format: formatJSON, Compress: "zstd"}, toolExportFeedData, "compress"},
+		{"export absolute outputPath", ExportFeedDataParams{Format: formatJSON, OutputPath: "/etc/feeds.json"}, toolExportFeedData, "outputPath"},
+		{"export escaping outputPath", ExportFeedDataParams{Format: formatJSON, OutputPath: "../feeds.json"}, toolExportFeedData, "outputPath"},
+		{"export since after until", ExportFeedDataParams{Format: formatJSON, Since: "2024-02-01T00:00:00Z", Until: "2024-01-01T00:00:00Z"}, toolExportFeedData, "since"},
+		{"merge negative dedupe window", MergeFeedsParams{FeedIDs: []string{"a"}, DedupeWindowHours: -1}, toolMergeFeeds, "dedupeWindowHours"},
+		{"overlap one distinct feed", FeedOverlapParams{FeedIDs: []string{"a", "a"}}, toolFeedOverlap, keyFeedIDs},
+		{"find item without guid or link", FindItemParams{}, toolFindItem, "guid"},
+		{"find item guid and link", FindItemParams{GUID: "a", Link: "https://example.com/a"}, toolFindItem, "guid"},
+		{"find items linking to without domain", FindItemsLinkingToParams{Domain: " "}, toolFindItemsLinkingTo, "domain"},
+		{"find items linking to bad domain", FindItemsLinkingToParams{Domain: "https://"}, toolFindItemsLinkingTo, "domain"},
+		{"history missing feedId", ExportFeedHistoryParams{}, toolExportFeedHistory, keyFeedID},
+		{"history negative limit", ExportFeedHistoryParams{FeedID: "a", Limit: -1}, toolExportFeedHistory, "limit"},
+		{"items on date without feed", GetItemsOnDateParams{Date: "2024-03-15"}, toolGetItemsOnDate, keyFeedID},
+		{"items on date feed and all", GetItemsOnDateParams{FeedID: "a", All: true, Date: "2024-03-15"}, toolGetItemsOnDate, keyFeedID},
+		{"items on date missing date", GetItemsOnDateParams{All: true}, toolGetItemsOnDate, "date"},
+		{"items on date bad date", GetItemsOnDateParams{All: true, Date: "15/03/2024"}, toolGetItemsOnDate, "date"},
+		{"compare freshness missing feed", CompareFreshnessParams{ReferenceFeedID: "b"}, toolCompareFreshness, keyFeedID},
+		{"compare freshness missing reference", CompareFreshnessParams{FeedID: "a"}, toolCompareFreshness, "referenceFeedId"},
+		{"compare freshness same feed", CompareFreshnessParams{FeedID: "a", ReferenceFeedID: "a"}, toolCompareFreshness, "referenceFeedId"},
+		{"keyword cooccurrence one keyword", KeywordCooccurrenceParams{Keywords: []string{"ai", "AI "}}, toolKeywordCooccurrence, "keywords"},
+		{"keyword cooccurrence blank keyword", KeywordCooccurrenceParams{Keywords: []string{"ai", " "}}, toolKeywordCooccurrence, "keywords"},
+		{"keyword cooccurrence bad timeframe", KeywordCooccurrenceParams{Keywords: []string{"ai", "chips"}, Timeframe: "soon"}, toolKeywordCooccurrence, keyTimeframe},
+		{"items on date bad timezone", GetItemsOnDateParams{All: true, Date: "2024-03-15", Timezone: "Mars/Olympus"}, toolGetItemsOnDate, "timezone"},
+		{"frequency missing feedId", EstimateFeedFrequencyParams{}, toolEstimateFeedFrequency, keyFeedID},
+		{"categories missing feedId", GetFeedCategoriesParams{}, toolGetFeedCategories, keyFeedID},
+		{"podcast negative limit", GetPodcastEpisodesParams{FeedID: "a", Limit: new(-1)}, toolGetPodcastEpisodes, "limit"},
+		{"full content unconfirmed", FetchFeedFullContentParams{FeedID: "a"}, toolFetchFeedFullContent, "confirm"},
+		{"add feed missing url", AddFeedParams{}, toolAddFeed, keyURLLower},
+		{"import opml missing opml", ImportOPMLParams{}, toolImportOPML, "opml"},
+		{"remove feed nothing given", RemoveFeedParams{}, toolRemoveFeed, keyFeedID},
+		{"refresh feed blank feedId", RefreshFeedParams{FeedID: "  "}, toolRefreshFeed, keyFeedID},
+		{"update feed missing feedId", UpdateFeedParams{Title: "x"}, toolUpdateFeed, keyFeedID},
+		{"add feed tag with comma", AddFeedParams{URL: "https://example.com/feed.xml", Tags: []string{"a,b"}}, toolAddFeed, keyTags},
+		{"update feed tag too long", UpdateFeedParams{FeedID: "a", Tags: []string{strings.Repeat("x", model.MaxFeedTagLength+1)}}, toolUpdateFeed, keyTags},
+		{"merge tag with comma", MergeFeedsParams{Tags: []string{"a,b"}}, toolMergeFeeds, keyTags},
+		{"export tag with comma", ExportFeedDataParams{Format: formatJSON, Tags: []string{"a,b"}}, toolExportFeedData, keyTags},
+		{"overlap tag with comma", FeedOverlapParams{Tags: []string{"a,b"}}, toolFeedOverlap, keyTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.validate()
+			var feedErr *model.FeedError
+			if !errors.As(err, &feedErr) {
+				t.Fatalf("validate() = %v, want a FeedError", err)
+			}
+			if feedErr.ErrorType != model.ErrorTypeValidation {
+				t.Errorf("ErrorType = %q, want %q", feedErr.ErrorType, model.ErrorTypeValidation)
+			}
+			if feedErr.Operation != tt.wantTool {
+				t.Errorf("Operation = %q, want %q", feedErr.Operation, tt.wantTool)
+			}
+			if feedErr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", feedErr.Field, tt.wantField)
+			}
+			if feedErr.Suggestion == "" {
+				t.Error("expected a suggestion")
+			}
+		})
+	}
+}
+
+func TestToolParams_ValidateAcceptsGoodParams(t *testing.T) {
+	valid := []interface{ validate() error }{
+		AllSyndicationFeedsParams{},
+		AllSyndicationFeedsParams{OrderByHealth: healthOrderUnhealthyFirst},
+		GetSyndicationFeedParams{ID: "a", Limit: new(10), Offset: new(0), Order: orderNewest},
+		GetSyndicationFeedParams{ID: "a", Order: orderOldest, DateField: dateFieldUpdated},
+		GetSyndicationFeedParams{ID: "a", Authors: []string{"Jane Smith", "Bob"}},
+		GetSyndicationFeedParams{ID: "a", CursorPaging: new(true), Order: orderNewest},
+		MergeFeedsParams{FeedIDs: []string{"a"}, SortBy: dateFieldUpdated},
+		FetchLinkParams{URL: "https://example.com"},
+		ResetCircuitBreakerParams{FeedID: "a"},
+		ResetCircuitBreakerParams{All: true},
+		PurgeFeedCacheParams{FeedID: "a"},
+		MarkReadParams{FeedID: "a", ItemIDs: []string{"urn:post:1"}},
+		MarkUnreadParams{FeedID: "a", ItemIDs: []string{"https://example.com/a"}},
+		MarkAllReadParams{FeedID: "a"},
+		ExportFeedHistoryParams{FeedID: "a", Limit: 5},
+		GetItemsOnDateParams{FeedID: "a", Date: "2024-03-15", Timezone: "America/New_York"},
+		CompareFreshnessParams{FeedID: "a", ReferenceFeedID: "b"},
+		KeywordCooccurrenceParams{Keywords: []string{"ai", "chips"}, Timeframe: "30d"},
+		MergeFeedsParams{FeedIDs: []string{"a"}, SortBy: valueSource, SeenSince: "2024-01-15T10:30:00Z"},
+		ExportFeedDataParams{Format: formatCSV, Since: "2024-01-01T00:00:00Z", Until: "2024-02-01T00:00:00Z"},
+		ExportFeedDataParams{Format: formatRSS, Compress: compressGzip},
+		ExportFeedDataParams{Format: formatCSV, OutputPath: "exports/feeds.csv"},
+		FeedOverlapParams{FeedIDs: []string{"a", "b"}},
+		FeedOverlapParams{FeedIDs: []string{"a", "b"}, DedupeKey: dedupeKeyTitleLink},
+		MergeFeedsParams{FeedIDs: []string{"a"}, Deduplicate: true, DedupeKey: dedupeKeyGUID},
+		MergeFeedsParams{FeedIDs: []string{"a"}, Fields: []string{keyTitle, "link", "published", valueSource}},
+		MergeFeedsParams{FeedIDs: []string{"a"}, Deduplicate: true, Format: formatAtom},
+		FindItemParams{GUID: "a"},
+		FindItemParams{Link: "https://example.com/a"},
+		FindItemsLinkingToParams{Domain: "example.com"},
+		EstimateFeedFrequencyParams{FeedID: "a"},
+		GetFeedCategoriesParams{FeedID: "a"},
+		GetPodcastEpisodesParams{FeedID: "a"},
+		FetchFeedFullContentParams{FeedID: "a", Confirm: true},
+		AddFeedParams{URL: "https://example.com/feed.xml"},
+		RemoveFeedParams{URL: "https://example.com/feed.xml"},
+		RefreshFeedParams{FeedID: "a"},
+		UpdateFeedParams{FeedID: "a", Category: "tech"},
+		UpdateFeedParams{FeedID: "a", Tags: []string{}},
+		AddFeedParams{URL: "https://example.com/feed.xml", Tags: []string{"priority:high"}},
+		ImportOPMLParams{OPML: "<opml/>"},
+		MergeFeedsParams{Tags: []string{"priority:high"}},
+		ExportFeedDataParams{Format: formatJSON, Tags: []string{"priority:high"}},
+		FeedOverlapParams{Tags: []string{"team:infra"}},
+	}
+	for _, params := range valid {
+		if err := params.validate(); err != nil {
+			t.Errorf("%T%+v: unexpected error %v", params, params, err)
+		}
+	}
+}
+
+// TestToolParams_HandlerRejectsBeforeWork checks that a bad parameter comes
+// back as a tool error naming the field and suggestion.
+func TestToolParams_HandlerRejectsBeforeWork(t *testing.T) {
+	session := buildTestServerSession(t, "feed-1", "https://example.com/feed.xml", nil)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      toolExportFeedData,
+		Arguments: map[string]any{keyFormat: formatJSON, "since": "last week"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected a tool error, got %+v", result)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, part := range []string{"Field: since", "Suggestion: " + suggestTimestamp, "Type: validation"} {
+		if !strings.Contains(text, part) {
+			t.Errorf("error text %q does not contain %q", text, part)
+		}
+	}
+}
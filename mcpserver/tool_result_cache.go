@@ -0,0 +1,129 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ristretto "github.com/dgraph-io/ristretto/v2"
+	gocache "github.com/eko/gocache/lib/v4/cache"
+	"github.com/eko/gocache/lib/v4/store"
+	ristrettostore "github.com/eko/gocache/store/ristretto/v4"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolResultCacheMaxBytes bounds the memory held by cached tool results.
+const toolResultCacheMaxBytes = 16 << 20
+
+// FeedGenerationReporter reports a counter that changes whenever the store's
+// feed data may have changed: a feed was fetched or refreshed, or a feed was
+// added or removed. Cached tool results are dropped as soon as it moves;
+// without one they are only dropped when their TTL passes.
+type FeedGenerationReporter interface {
+	FeedGeneration() uint64
+}
+
+// toolResultCache caches the results of aggregation tools (see
+// Config.ToolResultCacheTTL), keyed on the tool, its normalized parameters,
+// and the feed generation.
+type toolResultCache struct {
+	client *ristretto.Cache[string, string]
+	cache  *gocache.Cache[string]
+	ttl    time.Duration
+	// generation returns the feed generation; nil when the store doesn't
+	// report one.
+	generation func() uint64
+}
+
+// newToolResultCache returns a tool result cache holding results for ttl, or
+// nil when ttl is not positive.
+func newToolResultCache(ttl time.Duration, reporter FeedGenerationReporter) (*toolResultCache, error) {
+	if ttl <= 0 {
+		return nil, nil
+	}
+	client, err := ristretto.NewCache[string, string](&ristretto.Config[string, string]{
+		NumCounters: 10000,
+		MaxCost:     toolResultCacheMaxBytes,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool result cache: %w", err)
+	}
+	c := &toolResultCache{
+		client: client,
+		cache:  gocache.New[string](ristrettostore.NewRistretto(client)),
+		ttl:    ttl,
+	}
+	if reporter != nil {
+		c.generation = reporter.FeedGeneration
+	}
+	return c, nil
+}
+
+// fetchFailure is implemented by tool results that can describe a feed that
+// failed to fetch. cachedToolResult returns such results without caching
+// them, so the next call tries the feed again.
+type fetchFailure interface {
+	fetchFailed() bool
+}
+
+// key identifies a tool call. Parameters are keyed by their decoded form, so
+// calls that differ only in JSON key order or whitespace share an entry.
+func (c *toolResultCache) key(tool string, params []byte) string {
+	var generation uint64
+	if c.generation != nil {
+		generation = c.generation()
+	}
+	return fmt.Sprintf("%s:%d:%s", tool, generation, params)
+}
+
+// cachedToolResult returns tool's result for args from the tool result cache,
+// computing and caching it on a miss. Errors, and results for feeds that
+// failed to fetch (see fetchFailure), aren't cached. The result is
+// stored under the feed generation read after computing it, since computing
+// may itself fetch feeds that weren't cached and so move the generation.
+func (s *Server) cachedToolResult(ctx context.Context, tool string, args any, compute func() (any, error)) (*mcp.CallToolResult, error) {
+	textResult := func(text string) *mcp.CallToolResult {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}}
+	}
+	marshal := func() (string, error) {
+		result, err := compute()
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(result)
+		return string(data), err
+	}
+
+	c := s.toolResultCache
+	if c == nil {
+		text, err := marshal()
+		if err != nil {
+			return nil, err
+		}
+		return textResult(text), nil
+	}
+	params, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	if text, err := c.cache.Get(ctx, c.key(tool, params)); err == nil {
+		return textResult(text), nil
+	}
+	result, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if failure, ok := result.(fetchFailure); ok && failure.fetchFailed() {
+		return textResult(text), nil
+	}
+	_ = c.cache.Set(ctx, c.key(tool, params), text, store.WithExpiration(c.ttl), store.WithCost(int64(len(text))))
+	c.client.Wait() // make the entry visible to the next call
+	return textResult(text), nil
+}
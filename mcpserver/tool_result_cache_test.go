@@ -0,0 +1,127 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// countingGenerationGetter counts feed fetches and reports a feed generation
+// the test moves to simulate a refresh.
+type countingGenerationGetter struct {
+	mockFeedAndItemsGetter
+	fetches    atomic.Int32
+	generation atomic.Uint64
+}
+
+func (m *countingGenerationGetter) GetFeedAndItems(ctx context.Context, id string) (*model.FeedAndItemsResult, error) {
+	m.fetches.Add(1)
+	return m.mockFeedAndItemsGetter.GetFeedAndItems(ctx, id)
+}
+
+func (m *countingGenerationGetter) FeedGeneration() uint64 {
+	return m.generation.Load()
+}
+
+// toolCacheSession serves a server with the given tool result cache TTL over
+// an in-memory transport.
+func toolCacheSession(t *testing.T, ttl time.Duration) (*mcp.ClientSession, *countingGenerationGetter) {
+	t.Helper()
+	getter := &countingGenerationGetter{mockFeedAndItemsGetter: mockFeedAndItemsGetter{feedMap: map[string]*model.FeedAndItemsResult{
+		"a":    {ID: "a", Items: []*gofeed.Item{{Title: "One", Link: "https://example.com/1", Categories: []string{"go"}}}},
+		"b":    {ID: "b", Items: []*gofeed.Item{{Title: "One", Link: "https://example.com/1"}}},
+		"down": {ID: "down", FetchError: "connection refused"},
+	}}}
+	session := newTestClientSession(t, &Config{
+		Transport:              model.StdioTransport,
+		AllFeedsGetter:         &mockAllFeedsGetter{},
+		FeedAndItemsGetter:     getter,
+		FeedGenerationReporter: getter,
+		ToolResultCacheTTL:     ttl,
+	})
+	return session, getter
+}
+
+func TestToolResultCache(t *testing.T) {
+	session, getter := toolCacheSession(t, time.Minute)
+	categories := map[string]any{keyFeedID: "a"}
+
+	first := callToolText(t, session, toolGetFeedCategories, categories)
+	if second := callToolText(t, session, toolGetFeedCategories, categories); second != first {
+		t.Errorf("cached result %s differs from %s", second, first)
+	}
+	if got := getter.fetches.Load(); got != 1 {
+		t.Errorf("two identical calls fetched %d times, want 1", got)
+	}
+
+	// Other parameters, or another tool, are cached separately.
+	callToolText(t, session, toolGetFeedCategories, map[string]any{keyFeedID: "b"})
+	callToolText(t, session, toolEstimateFeedFrequency, categories)
+	if got := getter.fetches.Load(); got != 3 {
+		t.Errorf("fetched %d times after new parameters and a new tool, want 3", got)
+	}
+
+	// A refresh moves the generation, so every entry is recomputed.
+	getter.generation.Add(1)
+	callToolText(t, session, toolGetFeedCategories, categories)
+	if got := getter.fetches.Load(); got != 4 {
+		t.Errorf("fetched %d times after a refresh, want 4", got)
+	}
+	callToolText(t, session, toolGetFeedCategories, categories)
+	if got := getter.fetches.Load(); got != 4 {
+		t.Errorf("fetched %d times after a refresh and a repeat, want 4", got)
+	}
+
+	overlap := map[string]any{keyFeedIDs: []string{"a", "b"}}
+	callToolText(t, session, toolFeedOverlap, overlap)
+	callToolText(t, session, toolFeedOverlap, overlap)
+	if got := getter.fetches.Load(); got != 6 {
+		t.Errorf("two identical feed_overlap calls fetched %d feeds, want 2", got-4)
+	}
+}
+
+func TestToolResultCache_Disabled(t *testing.T) {
+	session, getter := toolCacheSession(t, 0)
+	for range 3 {
+		callToolText(t, session, toolGetFeedCategories, map[string]any{keyFeedID: "a"})
+	}
+	if got := getter.fetches.Load(); got != 3 {
+		t.Errorf("three calls without a cache fetched %d times, want 3", got)
+	}
+}
+
+func TestToolResultCache_ErrorsNotCached(t *testing.T) {
+	session, getter := toolCacheSession(t, time.Minute)
+	for range 2 {
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: toolGetFeedCategories, Arguments: map[string]any{keyFeedID: "missing"}})
+		if err != nil || !result.IsError {
+			t.Fatalf("CallTool(missing) = %+v, %v; want a tool error", result, err)
+		}
+	}
+	if got := getter.fetches.Load(); got != 2 {
+		t.Errorf("two failing calls fetched %d times, want 2", got)
+	}
+}
+
+func TestToolResultCache_FailedFetchesNotCached(t *testing.T) {
+	session, getter := toolCacheSession(t, time.Minute)
+	for range 2 {
+		var result FeedFrequencyResult
+		if err := json.Unmarshal([]byte(callToolText(t, session, toolEstimateFeedFrequency, map[string]any{keyFeedID: "down"})), &result); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if result.FetchError != "connection refused" {
+			t.Errorf("fetch_error = %q, want the feed's fetch error", result.FetchError)
+		}
+	}
+	if got := getter.fetches.Load(); got != 2 {
+		t.Errorf("two calls for a failing feed fetched %d times, want 2", got)
+	}
+}
@@ -0,0 +1,110 @@
+package mcpserver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// ErrUnknownTool is returned when a tool selection names a tool the server
+// does not provide.
+var ErrUnknownTool = errors.New("unknown tool")
+
+// ToolNames returns the names of every tool the server can register. The
+// runtime feed management tools are only registered when a DynamicFeedManager
+// is configured, regardless of selection, and reset_circuit_breaker,
+// export_feed_history, ping_feeds, purge_feed_cache, and list_duplicate_feeds
+// only when Config provides the store capability they need; enabling one of
+// those explicitly without it is an error.
+func ToolNames() []string {
+	return []string{
+		toolFetchLink,
+		toolAllSyndicationFeeds,
+		toolGetSyndicationFeedItems,
+		toolListFeedIndex,
+		toolGetFeedTree,
+		toolListMovedFeeds,
+		toolListFeedsByActivity,
+		toolGetPodcastEpisodes,
+		toolEstimateFeedFrequency,
+		toolGetFeedCategories,
+		toolFetchFeedFullContent,
+		toolMarkRead,
+		toolMarkUnread,
+		toolMarkAllRead,
+		toolResetCircuitBreaker,
+		toolExportFeedHistory,
+		toolPingFeeds,
+		toolPurgeFeedCache,
+		toolListDuplicateFeeds,
+		toolGetServerMetrics,
+		toolGetConfig,
+		toolMergeFeeds,
+		toolExportFeedData,
+		toolFeedOverlap,
+		toolFindItem,
+		toolFindItemsLinkingTo,
+		toolGetItemsOnDate,
+		toolCompareFreshness,
+		toolKeywordCooccurrence,
+		toolAddFeed,
+		toolRemoveFeed,
+		toolListManagedFeeds,
+		toolRefreshFeed,
+		toolUpdateFeed,
+		toolImportOPML,
+	}
+}
+
+// ValidateToolNames returns an error wrapping ErrUnknownTool if any name is
+// not in ToolNames.
+func ValidateToolNames(names []string) error {
+	known := ToolNames()
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("%w %q: valid options are %s", ErrUnknownTool, name, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// toolSelection records which tools an operator has enabled. The zero value
+// enables every tool.
+type toolSelection struct {
+	allowed map[string]bool // nil means all tools are allowed
+}
+
+// newToolSelection builds a selection from enable and disable lists. An empty
+// enable list means "all tools"; disabled tools are removed afterwards.
+func newToolSelection(enable, disable []string) (toolSelection, error) {
+	for _, names := range [][]string{enable, disable} {
+		if err := ValidateToolNames(names); err != nil {
+			return toolSelection{}, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "invalid tool selection", err).
+				WithOperation("create_server").
+				WithComponent("mcp_server")
+		}
+	}
+	if len(enable) == 0 && len(disable) == 0 {
+		return toolSelection{}, nil
+	}
+
+	if len(enable) == 0 {
+		enable = ToolNames()
+	}
+	allowed := make(map[string]bool, len(enable))
+	for _, name := range enable {
+		allowed[name] = true
+	}
+	for _, name := range disable {
+		delete(allowed, name)
+	}
+	return toolSelection{allowed: allowed}, nil
+}
+
+// enabled reports whether the named tool should be registered.
+func (t toolSelection) enabled(name string) bool {
+	return t.allowed == nil || t.allowed[name]
+}
@@ -0,0 +1,121 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// registeredToolNames builds a server with the given tool selection and returns
+// the names of the tools a client sees, sorted.
+func registeredToolNames(t *testing.T, enable, disable []string) []string {
+	t.Helper()
+	clientSession := newTestClientSession(t, &Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		EnabledTools:       enable,
+		DisabledTools:      disable,
+	})
+	ctx := context.Background()
+
+	result, err := clientSession.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestToolSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		enable  []string
+		disable []string
+		want    []string
+	}{
+		{
+			name: "all tools by default",
+			want: []string{toolAllSyndicationFeeds, toolCompareFreshness, toolEstimateFeedFrequency, toolExportFeedData, toolFeedOverlap, toolFetchFeedFullContent, toolFetchLink, toolFindItem, toolFindItemsLinkingTo, toolGetConfig, toolGetFeedCategories, toolGetFeedTree, toolGetItemsOnDate, toolGetPodcastEpisodes, toolGetServerMetrics, toolGetSyndicationFeedItems, toolKeywordCooccurrence, toolListFeedIndex, toolListFeedsByActivity, toolListMovedFeeds, toolMarkAllRead, toolMarkRead, toolMarkUnread, toolMergeFeeds},
+		},
+		{
+			name:    "disabled tool is not registered",
+			disable: []string{toolFetchLink},
+			want:    []string{toolAllSyndicationFeeds, toolCompareFreshness, toolEstimateFeedFrequency, toolExportFeedData, toolFeedOverlap, toolFetchFeedFullContent, toolFindItem, toolFindItemsLinkingTo, toolGetConfig, toolGetFeedCategories, toolGetFeedTree, toolGetItemsOnDate, toolGetPodcastEpisodes, toolGetServerMetrics, toolGetSyndicationFeedItems, toolKeywordCooccurrence, toolListFeedIndex, toolListFeedsByActivity, toolListMovedFeeds, toolMarkAllRead, toolMarkRead, toolMarkUnread, toolMergeFeeds},
+		},
+		{
+			name:   "enabled-only set excludes others",
+			enable: []string{toolAllSyndicationFeeds, toolGetSyndicationFeedItems},
+			want:   []string{toolAllSyndicationFeeds, toolGetSyndicationFeedItems},
+		},
+		{
+			name:    "disable applies after enable",
+			enable:  []string{toolAllSyndicationFeeds, toolFetchLink},
+			disable: []string{toolFetchLink},
+			want:    []string{toolAllSyndicationFeeds},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registeredToolNames(t, tt.enable, tt.disable)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("registered tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewServer_UnknownToolName(t *testing.T) {
+	_, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		DisabledTools:      []string{"fetch_everything"},
+	})
+	if !errors.Is(err, ErrUnknownTool) {
+		t.Fatalf("NewServer error = %v, want ErrUnknownTool", err)
+	}
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeConfiguration {
+		t.Errorf("expected configuration FeedError, got %v", err)
+	}
+}
+
+// TestNewServer_UnavailableEnabledTool verifies that explicitly enabling a tool
+// whose store capability is missing fails, while leaving the default
+// selection just omits it.
+func TestNewServer_UnavailableEnabledTool(t *testing.T) {
+	_, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		EnabledTools:       []string{toolAllSyndicationFeeds, toolPingFeeds},
+	})
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeConfiguration || !strings.Contains(err.Error(), toolPingFeeds) {
+		t.Fatalf("NewServer error = %v, want a configuration error naming %s", err, toolPingFeeds)
+	}
+
+	if slices.Contains(registeredToolNames(t, nil, nil), toolPingFeeds) {
+		t.Errorf("%s registered without a FeedPinger", toolPingFeeds)
+	}
+	session := newTestClientSession(t, &Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		FeedPinger:         &mockFeedPinger{},
+		EnabledTools:       []string{toolPingFeeds},
+	})
+	result, err := session.ListTools(context.Background(), nil)
+	if err != nil || len(result.Tools) != 1 || result.Tools[0].Name != toolPingFeeds {
+		t.Errorf("ListTools = %+v, %v; want only %s", result, err, toolPingFeeds)
+	}
+}
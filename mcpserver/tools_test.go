@@ -507,7 +507,7 @@ func TestTypeDefinitions(t *testing.T) {
 		serverType := reflect.TypeFor[Server]()
 
 		// Check that Server has the expected fields
-		expectedFields := []string{"allFeedsGetter", "feedAndItemsGetter", "dynamicFeedManager", "resourceManager", "sessionID", "transport", "imageCache", "imageCircuitBreakers", "imageCBMutex", "httpClient", "httpPort", "httpStateless", "httpSessionTimeout"}
+		expectedFields := []string{"allFeedsGetter", "feedAndItemsGetter", "dynamicFeedManager", "capabilities", "resourceManager", "sessionID", "transport", "imageCache", "imageCircuitBreakers", "imageCBMutex", "httpClient", "httpPort", "httpStateless", "httpSessionTimeout", "httpCompression", "tools", "fetchLinkConfig", "articleCache", "toolResultCache", "fileExportDir", "substantiveMinLen", "thumbnailSize", "readStates"}
 
 		if serverType.NumField() != len(expectedFields) {
 			t.Errorf("Expected %d fields in Server, got %d", len(expectedFields), serverType.NumField())
@@ -525,7 +525,7 @@ func TestTypeDefinitions(t *testing.T) {
 		configType := reflect.TypeFor[Config]()
 
 		// Check that Config has the expected fields
-		expectedFields := []string{"AllFeedsGetter", "FeedAndItemsGetter", "DynamicFeedManager", "Transport", "HTTPPort", "HTTPStateless", "HTTPSessionTimeout"}
+		expectedFields := []string{"AllFeedsGetter", "FeedAndItemsGetter", "DynamicFeedManager", "CircuitBreakerResetter", "FeedHistoryProvider", "FeedPinger", "FeedCachePurger", "DuplicateFeedsProvider", "DiagnosticsProvider", "ConfigProvider", "FeedTagsProvider", "FeedIconResolver", "ItemSearcher", "FeedGenerationReporter", "Transport", "HTTPPort", "HTTPStateless", "HTTPSessionTimeout", "HTTPCompression", "EnabledTools", "DisabledTools", "MaxConcurrentResourceFetches", "ResourceRefreshInterval", "FetchLinkTimeout", "FetchLinkMaxAttempts", "AllowPrivateIPs", "ToolResultCacheTTL", "FileExportDir", "SubstantiveMinLength", "ThumbnailSize", "ReadStateFile"}
 
 		if configType.NumField() != len(expectedFields) {
 			t.Errorf("Expected %d fields in Config, got %d", len(expectedFields), configType.NumField())
@@ -0,0 +1,134 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// mockDynamicFeedManager keeps managed feed metadata in memory. Only the
+// methods the update_feed and import_opml tests use do anything. AddFeed
+// fails with addErrs[url] when set.
+type mockDynamicFeedManager struct {
+	feeds   map[string]*ManagedFeedInfo
+	addErrs map[string]error
+}
+
+func (m *mockDynamicFeedManager) AddFeed(ctx context.Context, config FeedConfig) (*ManagedFeedInfo, error) {
+	if err := m.addErrs[config.URL]; err != nil {
+		return nil, err
+	}
+	if m.feeds == nil {
+		m.feeds = make(map[string]*ManagedFeedInfo)
+	}
+	feed := &ManagedFeedInfo{FeedID: model.GenerateFeedID(config.URL), URL: config.URL, Title: config.Title, Category: config.Category}
+	m.feeds[feed.FeedID] = feed
+	info := *feed
+	return &info, nil
+}
+
+func (m *mockDynamicFeedManager) RemoveFeed(ctx context.Context, feedID string) (*RemovedFeedInfo, error) {
+	return nil, nil
+}
+
+func (m *mockDynamicFeedManager) RemoveFeedByURL(ctx context.Context, url string) (*RemovedFeedInfo, error) {
+	return nil, nil
+}
+
+func (m *mockDynamicFeedManager) ListManagedFeeds(ctx context.Context) ([]ManagedFeedInfo, error) {
+	feeds := make([]ManagedFeedInfo, 0, len(m.feeds))
+	for _, feed := range m.feeds {
+		feeds = append(feeds, *feed)
+	}
+	return feeds, nil
+}
+
+func (m *mockDynamicFeedManager) RefreshFeed(ctx context.Context, feedID string) (*RefreshFeedInfo, error) {
+	return nil, nil
+}
+
+func (m *mockDynamicFeedManager) UpdateFeedMetadata(ctx context.Context, feedID string, metadata FeedMetadata) (*ManagedFeedInfo, error) {
+	feed := m.feeds[feedID]
+	if feed == nil {
+		return nil, model.NewFeedError(model.ErrorTypeValidation, "feed with ID "+feedID+" not found")
+	}
+	if metadata.Title != "" {
+		feed.Title = metadata.Title
+	}
+	if metadata.Category != "" {
+		feed.Category = metadata.Category
+	}
+	if metadata.Alias != "" {
+		feed.Alias = metadata.Alias
+	}
+	if metadata.Description != "" {
+		feed.Description = metadata.Description
+	}
+	info := *feed
+	return &info, nil
+}
+
+func (m *mockDynamicFeedManager) PauseFeed(ctx context.Context, feedID string) error {
+	return nil
+}
+
+func (m *mockDynamicFeedManager) ResumeFeed(ctx context.Context, feedID string) error {
+	return nil
+}
+
+func TestUpdateFeedTool(t *testing.T) {
+	manager := &mockDynamicFeedManager{feeds: map[string]*ManagedFeedInfo{
+		"feed-1": {FeedID: "feed-1", Title: "Old Title", Category: "misc", Description: "keep me"},
+	}}
+	srv, err := NewServer(&Config{
+		Transport:          model.StdioTransport,
+		AllFeedsGetter:     &mockAllFeedsGetter{feeds: []*model.FeedResult{{ID: "feed-1"}}},
+		FeedAndItemsGetter: &mockFeedAndItemsGetter{},
+		DynamicFeedManager: manager,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	session := connectTestClient(t, srv)
+	ctx := context.Background()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      toolUpdateFeed,
+		Arguments: map[string]any{keyFeedID: "feed-1", keyTitle: "New Title", "category": "tech"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool: %v, %+v", err, result)
+	}
+	var info ManagedFeedInfo
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &info); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if info.Title != "New Title" || info.Category != "tech" || info.Description != "keep me" {
+		t.Errorf("updated feed = %+v", info)
+	}
+
+	// The feed index groups by the managed category, so it follows the update.
+	index, err := srv.feedIndex(ctx)
+	if err != nil {
+		t.Fatalf("feedIndex: %v", err)
+	}
+	if len(index) != 1 || index[0].Category != "tech" {
+		t.Errorf("feed index = %+v, want category tech", index)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      toolUpdateFeed,
+		Arguments: map[string]any{keyFeedID: " ", keyTitle: "x"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Field: feedId") {
+		t.Errorf("blank feedId: got %+v, want a validation error", result)
+	}
+}
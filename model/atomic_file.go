@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WriteFileAtomic replaces path with data: the data is written to a temporary
+// file in the same directory, synced, and renamed over path, so a crash
+// mid-write leaves either the old or the new file.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }() // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+// MoveCorruptFile renames a state file that can't be decoded to
+// path.corrupt-<unix>, where it is kept for inspection, and returns the new
+// path. Loaders call it so that one bad write can't keep the server from
+// starting.
+func MoveCorruptFile(path string) (string, error) {
+	backup := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	if err := os.Rename(path, backup); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
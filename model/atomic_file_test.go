@@ -0,0 +1,46 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	for _, content := range []string{"first", "second"} {
+		if err := WriteFileAtomic(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFileAtomic(%q): %v", content, err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != content {
+			t.Errorf("content = %q, want %q", data, content)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only the file (temporary files left behind)", len(entries))
+	}
+}
+
+func TestMoveCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if _, err := MoveCorruptFile(path); err == nil {
+		t.Error("MoveCorruptFile of a missing file succeeded")
+	}
+	if err := os.WriteFile(path, []byte("bad"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := MoveCorruptFile(path)
+	if err != nil {
+		t.Fatalf("MoveCorruptFile: %v", err)
+	}
+	if matched, _ := filepath.Match(path+".corrupt-*", backup); !matched {
+		t.Errorf("backup = %q, want %s.corrupt-<unix>", backup, path)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "bad" {
+		t.Errorf("backup content = %q", data)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file still at %s (err %v)", path, err)
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// OriginalCategoriesKey is the item Custom key holding the item's categories as
+// published, JSON-encoded, when normalization changed them.
+const OriginalCategoriesKey = "feed_mcp_original_categories"
+
+// CategoryNormalizer unifies item categories across feeds: it trims and
+// lowercases them, then maps synonyms onto a canonical name, so "Tech",
+// " technology" and "Technology" can all filter and facet as one category.
+type CategoryNormalizer struct {
+	synonyms map[string]string // normalized alias -> normalized canonical name
+}
+
+// NewCategoryNormalizer returns a normalizer with the given synonym map, from
+// alias to canonical category. Keys and values are normalized too, so the map
+// may use any casing.
+func NewCategoryNormalizer(synonyms map[string]string) *CategoryNormalizer {
+	n := &CategoryNormalizer{synonyms: make(map[string]string, len(synonyms))}
+	for alias, canonical := range synonyms {
+		n.synonyms[normalizeCategoryText(alias)] = normalizeCategoryText(canonical)
+	}
+	return n
+}
+
+// Normalize returns the normalized form of one category.
+func (n *CategoryNormalizer) Normalize(category string) string {
+	normalized := normalizeCategoryText(category)
+	if canonical, ok := n.synonyms[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
+
+// NormalizeAll returns the normalized form of each category, dropping empty
+// and duplicate results.
+func (n *CategoryNormalizer) NormalizeAll(categories []string) []string {
+	normalized := make([]string, 0, len(categories))
+	for _, category := range categories {
+		if c := n.Normalize(category); c != "" && !slices.Contains(normalized, c) {
+			normalized = append(normalized, c)
+		}
+	}
+	return normalized
+}
+
+// Apply normalizes each item's categories in place, dropping empty and
+// duplicate results. When that changes an item's categories, the originals are
+// kept under OriginalCategoriesKey in the item's Custom map.
+func (n *CategoryNormalizer) Apply(items []*gofeed.Item) {
+	for _, item := range items {
+		if item == nil || len(item.Categories) == 0 {
+			continue
+		}
+		normalized := n.NormalizeAll(item.Categories)
+		if slices.Equal(normalized, item.Categories) {
+			continue
+		}
+		if original, err := json.Marshal(item.Categories); err == nil {
+			if item.Custom == nil {
+				item.Custom = make(map[string]string)
+			}
+			item.Custom[OriginalCategoriesKey] = string(original)
+		}
+		item.Categories = normalized
+	}
+}
+
+// OriginalCategories returns the item's categories as published: the ones
+// recorded by CategoryNormalizer.Apply, or the current ones if normalization
+// left them unchanged.
+func OriginalCategories(item *gofeed.Item) []string {
+	if raw, ok := item.Custom[OriginalCategoriesKey]; ok {
+		var original []string
+		if err := json.Unmarshal([]byte(raw), &original); err == nil {
+			return original
+		}
+	}
+	return item.Categories
+}
+
+// normalizeCategoryText trims, lowercases, and collapses internal whitespace.
+func normalizeCategoryText(category string) string {
+	return strings.Join(strings.Fields(strings.ToLower(category)), " ")
+}
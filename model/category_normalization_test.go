@@ -0,0 +1,58 @@
+package model
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestCategoryNormalizer_Normalize(t *testing.T) {
+	n := NewCategoryNormalizer(map[string]string{"Tech": "Technology", "ML": "machine  learning"})
+	tests := map[string]string{
+		"Technology":          "technology",
+		"  technology ":       "technology",
+		"TECH":                "technology",
+		"ml":                  "machine learning",
+		"Machine   Learning":  "machine learning",
+		"Science & Nature":    "science & nature",
+		"":                    "",
+		"   ":                 "",
+		"Artificial\tIntel":   "artificial intel",
+		"unmapped category  ": "unmapped category",
+	}
+	for input, want := range tests {
+		if got := n.Normalize(input); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestCategoryNormalizer_Apply(t *testing.T) {
+	n := NewCategoryNormalizer(map[string]string{"tech": "technology"})
+	items := []*gofeed.Item{
+		{Title: "a", Categories: []string{"Tech", "technology", " Go "}},
+		{Title: "b", Categories: []string{"technology", "go"}},
+		{Title: "c"},
+		nil,
+	}
+	n.Apply(items)
+
+	if want := []string{"technology", "go"}; !slices.Equal(items[0].Categories, want) {
+		t.Errorf("item a categories = %v, want %v", items[0].Categories, want)
+	}
+	if want := []string{"Tech", "technology", " Go "}; !slices.Equal(OriginalCategories(items[0]), want) {
+		t.Errorf("item a original categories = %v, want %v", OriginalCategories(items[0]), want)
+	}
+
+	// Already-normalized categories are left alone, with no originals recorded.
+	if _, ok := items[1].Custom[OriginalCategoriesKey]; ok {
+		t.Error("unchanged categories should not record originals")
+	}
+	if want := []string{"technology", "go"}; !slices.Equal(OriginalCategories(items[1]), want) {
+		t.Errorf("item b original categories = %v, want %v", OriginalCategories(items[1]), want)
+	}
+	if items[2].Categories != nil || items[2].Custom != nil {
+		t.Error("items without categories should be untouched")
+	}
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// Chapter is one chapter of a podcast episode. StartTime is in seconds from
+// the start of the episode.
+type Chapter struct {
+	StartTime float64 `json:"start_time"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+}
+
+// jsonChapters is the Podcasting 2.0 JSON chapters format, which some feeds
+// embed in their podcast:chapters element instead of linking to it.
+type jsonChapters struct {
+	Chapters []struct {
+		StartTime float64 `json:"startTime"`
+		Title     string  `json:"title"`
+		URL       string  `json:"url"`
+	} `json:"chapters"`
+}
+
+// ItemChapters returns the item's chapters, ordered by start time, from
+// Podlove Simple Chapters (psc:chapters) or JSON chapters embedded in a
+// podcast:chapters element. It returns nil when the item has neither;
+// podcast:chapters elements that only link to a chapters file are left to
+// ItemChaptersURL.
+func ItemChapters(item *gofeed.Item) []Chapter {
+	if item == nil {
+		return nil
+	}
+	chapters := pscChapters(item.Extensions)
+	if len(chapters) == 0 {
+		chapters = embeddedJSONChapters(item.Extensions)
+	}
+	slices.SortStableFunc(chapters, func(a, b Chapter) int {
+		return cmp.Compare(a.StartTime, b.StartTime)
+	})
+	return chapters
+}
+
+// ItemChaptersURL returns the URL of the chapters file a podcast:chapters
+// element links to, or "".
+func ItemChaptersURL(item *gofeed.Item) string {
+	if item == nil {
+		return ""
+	}
+	for _, element := range item.Extensions["podcast"]["chapters"] {
+		if url := strings.TrimSpace(element.Attrs["url"]); url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// pscChapters reads Podlove Simple Chapters. Chapters without a title or a
+// parseable start are skipped.
+func pscChapters(extensions ext.Extensions) []Chapter {
+	var chapters []Chapter
+	for _, list := range extensions["psc"]["chapters"] {
+		for _, element := range list.Children["chapter"] {
+			title := strings.TrimSpace(element.Attrs["title"])
+			start, ok := parseNormalPlayTime(element.Attrs["start"])
+			if title == "" || !ok {
+				continue
+			}
+			chapters = append(chapters, Chapter{StartTime: start, Title: title, URL: strings.TrimSpace(element.Attrs["href"])})
+		}
+	}
+	return chapters
+}
+
+// embeddedJSONChapters reads JSON chapters written inside a podcast:chapters
+// element. Chapters without a title are skipped.
+func embeddedJSONChapters(extensions ext.Extensions) []Chapter {
+	var chapters []Chapter
+	for _, element := range extensions["podcast"]["chapters"] {
+		value := strings.TrimSpace(element.Value)
+		if !strings.HasPrefix(value, "{") {
+			continue
+		}
+		var parsed jsonChapters
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			continue
+		}
+		for _, chapter := range parsed.Chapters {
+			if title := strings.TrimSpace(chapter.Title); title != "" && chapter.StartTime >= 0 {
+				chapters = append(chapters, Chapter{StartTime: chapter.StartTime, Title: title, URL: chapter.URL})
+			}
+		}
+	}
+	return chapters
+}
+
+// parseNormalPlayTime converts a Normal Play Time value — seconds, MM:SS, or
+// HH:MM:SS, each optionally with fractional seconds — to seconds.
+func parseNormalPlayTime(value string) (float64, bool) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) > 3 || parts[0] == "" {
+		return 0, false
+	}
+	var seconds float64
+	for i, part := range parts {
+		var n float64
+		if i == len(parts)-1 {
+			f, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return 0, false
+			}
+			n = f
+		} else {
+			whole, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, false
+			}
+			n = float64(whole)
+		}
+		if n < 0 {
+			return 0, false
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, true
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestItemChapters(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:psc="http://podlove.org/simple-chapters" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+<channel>
+  <title>Show</title>
+  <item>
+    <title>Simple chapters</title>
+    <psc:chapters version="1.2">
+      <psc:chapter start="01:02:03.250" title="Outro"/>
+      <psc:chapter start="0" title="Intro"/>
+      <psc:chapter start="5:00" title="News" href="https://example.com/news"/>
+      <psc:chapter start="later" title="Broken start"/>
+      <psc:chapter start="10:00"/>
+    </psc:chapters>
+  </item>
+  <item>
+    <title>Embedded JSON chapters</title>
+    <podcast:chapters type="application/json+chapters">{"version": "1.2.0", "chapters": [{"startTime": 90.5, "title": "Main topic"}, {"startTime": 0, "title": "Welcome", "url": "https://example.com"}]}</podcast:chapters>
+  </item>
+  <item>
+    <title>Linked chapters</title>
+    <podcast:chapters url="https://example.com/chapters.json" type="application/json+chapters"/>
+  </item>
+  <item>
+    <title>No chapters</title>
+  </item>
+</channel>
+</rss>`)
+	if err != nil {
+		t.Fatalf("parse feed: %v", err)
+	}
+
+	tests := []struct {
+		want    []Chapter
+		wantURL string
+	}{
+		{want: []Chapter{{StartTime: 0, Title: "Intro"}, {StartTime: 300, Title: "News", URL: "https://example.com/news"}, {StartTime: 3723.25, Title: "Outro"}}},
+		{want: []Chapter{{StartTime: 0, Title: "Welcome", URL: "https://example.com"}, {StartTime: 90.5, Title: "Main topic"}}},
+		{wantURL: "https://example.com/chapters.json"},
+		{},
+	}
+	for i, tt := range tests {
+		item := feed.Items[i]
+		if got := ItemChapters(item); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ItemChapters = %+v, want %+v", item.Title, got, tt.want)
+		}
+		if got := ItemChaptersURL(item); got != tt.wantURL {
+			t.Errorf("%s: ItemChaptersURL = %q, want %q", item.Title, got, tt.wantURL)
+		}
+	}
+}
+
+func TestParseNormalPlayTime(t *testing.T) {
+	valid := map[string]float64{"0": 0, "95": 95, "1:30": 90, "01:02:03": 3723, "00:00:01.5": 1.5}
+	for input, want := range valid {
+		if got, ok := parseNormalPlayTime(input); !ok || got != want {
+			t.Errorf("parseNormalPlayTime(%q) = %v, %v; want %v", input, got, ok, want)
+		}
+	}
+	for _, input := range []string{"", "abc", "1:2:3:4", "-5", "1:-2"} {
+		if got, ok := parseNormalPlayTime(input); ok {
+			t.Errorf("parseNormalPlayTime(%q) = %v, want an error", input, got)
+		}
+	}
+}
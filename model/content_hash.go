@@ -0,0 +1,42 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ContentHash summarizes a feed's items as an ETag-like value, so a client
+// can compare it between polls and skip a feed whose items haven't changed.
+// It covers each item's stable ID, title, and publish and update dates, in
+// feed order: the same items yield the same hash on every fetch, and adding,
+// removing, retitling, redating, or reordering items changes it. Other item
+// fields (content, links) aren't covered.
+func ContentHash(items []*gofeed.Item) string {
+	h := sha256.New()
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		fields := []string{
+			ItemStableID(item),
+			strings.TrimSpace(item.Title),
+			hashDate(item.PublishedParsed, item.Published),
+			hashDate(item.UpdatedParsed, item.Updated),
+		}
+		_, _ = h.Write([]byte(strings.Join(fields, "\x00") + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// hashDate is a date as ContentHash covers it: the parsed date in UTC, or the
+// raw string when it didn't parse.
+func hashDate(parsed *time.Time, raw string) string {
+	if parsed != nil {
+		return parsed.UTC().Format(time.RFC3339Nano)
+	}
+	return strings.TrimSpace(raw)
+}
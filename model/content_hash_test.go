@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func contentHashItems() []*gofeed.Item {
+	published := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	return []*gofeed.Item{
+		{GUID: "urn:1", Title: "First", PublishedParsed: &published, Content: "<p>Body</p>"},
+		{GUID: "urn:2", Title: "Second", Published: "sometime"},
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	base := ContentHash(contentHashItems())
+	if len(base) != 32 {
+		t.Fatalf("hash %q, want 32 hex characters", base)
+	}
+	if again := ContentHash(contentHashItems()); again != base {
+		t.Errorf("identical items hashed to %q and %q", base, again)
+	}
+
+	// A date in another zone is the same instant.
+	items := contentHashItems()
+	local := items[0].PublishedParsed.In(time.FixedZone("UTC+2", 2*60*60))
+	items[0].PublishedParsed = &local
+	if got := ContentHash(items); got != base {
+		t.Errorf("same instant in another zone changed the hash")
+	}
+	// Fields the hash doesn't cover.
+	items[0].Content = "<p>Edited</p>"
+	if got := ContentHash(items); got != base {
+		t.Errorf("content edit changed the hash")
+	}
+
+	updated := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	changes := map[string]func(items []*gofeed.Item) []*gofeed.Item{
+		"retitled": func(items []*gofeed.Item) []*gofeed.Item { items[1].Title = "Second, revised"; return items },
+		"updated":  func(items []*gofeed.Item) []*gofeed.Item { items[0].UpdatedParsed = &updated; return items },
+		"new GUID": func(items []*gofeed.Item) []*gofeed.Item { items[1].GUID = "urn:3"; return items },
+		"removed":  func(items []*gofeed.Item) []*gofeed.Item { return items[:1] },
+		"added": func(items []*gofeed.Item) []*gofeed.Item {
+			return append(items, &gofeed.Item{GUID: "urn:3", Title: "Third"})
+		},
+		"reordered": func(items []*gofeed.Item) []*gofeed.Item { return []*gofeed.Item{items[1], items[0]} },
+	}
+	for name, change := range changes {
+		if got := ContentHash(change(contentHashItems())); got == base {
+			t.Errorf("%s: hash unchanged", name)
+		}
+	}
+}
+
+func TestFeedAndItemsResult_ToMetadataContentHash(t *testing.T) {
+	result := &FeedAndItemsResult{ID: "feed", Items: contentHashItems()}
+	if got, want := result.ToMetadata().ContentHash, ContentHash(result.Items); got != want {
+		t.Errorf("ContentHash = %q, want %q", got, want)
+	}
+	failed := &FeedAndItemsResult{ID: "feed", FetchError: "HTTP 500"}
+	if got := failed.ToMetadata().ContentHash; got != "" {
+		t.Errorf("failed fetch has content hash %q", got)
+	}
+}
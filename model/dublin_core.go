@@ -0,0 +1,92 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// dublinCoreDateLayouts are the W3CDTF profiles of ISO 8601 that dc:date
+// uses, most precise first, followed by the RFC 1123 dates some feeds put
+// there instead.
+var dublinCoreDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	time.DateOnly,
+	"2006-01",
+	"2006",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// ApplyDublinCoreFallbacks fills in an item's author from dc:creator and its
+// publish date from dc:date when the feed's own fields leave them empty.
+// gofeed does this for RSS, but not for Atom entries, whose Dublin Core
+// elements only appear in Extensions. Items are updated in place.
+func ApplyDublinCoreFallbacks(items []*gofeed.Item) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		dc := item.DublinCoreExt
+		if dc == nil {
+			if item.Extensions["dc"] == nil {
+				continue
+			}
+			dc = ext.NewDublinCoreExtension(item.Extensions["dc"])
+		}
+		if !hasAuthor(item) {
+			if creator := firstNonBlank(dc.Creator); creator != "" {
+				item.Author = &gofeed.Person{Name: creator}
+				item.Authors = []*gofeed.Person{item.Author}
+			}
+		}
+		if item.PublishedParsed == nil {
+			for _, raw := range dc.Date {
+				if date, ok := parseDublinCoreDate(raw); ok {
+					item.PublishedParsed = &date
+					if item.Published == "" {
+						item.Published = strings.TrimSpace(raw)
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// hasAuthor reports whether an item names an author.
+func hasAuthor(item *gofeed.Item) bool {
+	if item.Author != nil && strings.TrimSpace(item.Author.Name) != "" {
+		return true
+	}
+	for _, author := range item.Authors {
+		if author != nil && strings.TrimSpace(author.Name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// firstNonBlank returns the first value that isn't blank, trimmed.
+func firstNonBlank(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseDublinCoreDate parses a dc:date value, in UTC when it has no zone.
+func parseDublinCoreDate(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range dublinCoreDateLayouts {
+		if date, err := time.Parse(layout, raw); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+func TestApplyDublinCoreFallbacks(t *testing.T) {
+	dcExtension := func(creator, date string) ext.Extensions {
+		return ext.Extensions{"dc": {
+			"creator": {{Name: "creator", Value: creator}},
+			"date":    {{Name: "date", Value: date}},
+		}}
+	}
+	items := []*gofeed.Item{
+		{Title: "extensions only", Extensions: dcExtension("Jane Doe", "2024-03-01")},
+		{Title: "parsed extension", DublinCoreExt: &ext.DublinCoreExtension{Creator: []string{" ", "Ann Lee"}, Date: []string{"not a date", "2024-03-02T09:30+02:00"}}},
+		{Title: "own fields", Author: &gofeed.Person{Name: "John Roe"}, PublishedParsed: new(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), Extensions: dcExtension("Ignored", "2020-01-01")},
+		{Title: "no dublin core"},
+		nil,
+	}
+	ApplyDublinCoreFallbacks(items)
+
+	tests := []struct {
+		author    string
+		published time.Time
+	}{
+		{"Jane Doe", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"Ann Lee", time.Date(2024, 3, 2, 7, 30, 0, 0, time.UTC)},
+		{"John Roe", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+	}
+	for i, tt := range tests {
+		item := items[i]
+		var author string
+		if item.Author != nil {
+			author = item.Author.Name
+		}
+		var published time.Time
+		if item.PublishedParsed != nil {
+			published = *item.PublishedParsed
+		}
+		if author != tt.author || !published.Equal(tt.published) {
+			t.Errorf("%s: author %q, published %v; want %q, %v", item.Title, author, published, tt.author, tt.published)
+		}
+	}
+	if items[0].Published != "2024-03-01" || len(items[0].Authors) != 1 {
+		t.Errorf("raw published = %q, authors = %v", items[0].Published, items[0].Authors)
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// EnclosureChecksKey is the item Custom key holding the results of verifying
+// the item's enclosures with HEAD requests, as a JSON array of EnclosureCheck.
+// It is set when the feed is fetched with enclosure verification enabled.
+const EnclosureChecksKey = "feed_mcp_enclosure_checks"
+
+// EnclosureCheck is the result of a HEAD request for one enclosure URL.
+// VerifiedSize and VerifiedType come from the response's Content-Length and
+// Content-Type; VerifiedSize is -1 when the server didn't send a length.
+// Unreachable enclosures carry the reason in Error.
+type EnclosureCheck struct {
+	URL          string `json:"url"`
+	Reachable    bool   `json:"reachable"`
+	VerifiedSize int64  `json:"verified_size,omitempty"`
+	VerifiedType string `json:"verified_type,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// SetEnclosureChecks stores checks under EnclosureChecksKey in the item's
+// Custom map. An empty list removes the key.
+func SetEnclosureChecks(item *gofeed.Item, checks []EnclosureCheck) {
+	if len(checks) == 0 {
+		delete(item.Custom, EnclosureChecksKey)
+		return
+	}
+	data, err := json.Marshal(checks)
+	if err != nil {
+		return
+	}
+	if item.Custom == nil {
+		item.Custom = make(map[string]string, 1)
+	}
+	item.Custom[EnclosureChecksKey] = string(data)
+}
+
+// ItemEnclosureChecks returns the enclosure checks recorded on the item,
+// keyed by enclosure URL, or nil when its enclosures weren't verified.
+func ItemEnclosureChecks(item *gofeed.Item) map[string]EnclosureCheck {
+	data := item.Custom[EnclosureChecksKey]
+	if data == "" {
+		return nil
+	}
+	var checks []EnclosureCheck
+	if err := json.Unmarshal([]byte(data), &checks); err != nil {
+		return nil
+	}
+	byURL := make(map[string]EnclosureCheck, len(checks))
+	for _, check := range checks {
+		byURL[check.URL] = check
+	}
+	return byURL
+}
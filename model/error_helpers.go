@@ -3,6 +3,7 @@ package model
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -27,6 +28,9 @@ func CreateNetworkError(err error, feedURL string) *FeedError {
 		if isTimeoutError(err) {
 			errorType = ErrorTypeTimeout
 			message = "Request timed out"
+		} else if IsTLSError(err) {
+			errorType = ErrorTypeTLS
+			message = "TLS handshake failed"
 		} else if isDNSError(err) {
 			errorType = ErrorTypeDNSResolution
 			message = "DNS resolution failed"
@@ -103,6 +107,16 @@ func CreateValidationError(err error, feedURL string) *FeedError {
 		WithComponent("url_validator")
 }
 
+// CreateParameterError creates a FeedError for an invalid tool parameter,
+// naming the parameter and how to correct it
+func CreateParameterError(tool, field, message, suggestion string) *FeedError {
+	return NewFeedError(ErrorTypeValidation, message).
+		WithField(field).
+		WithSuggestion(suggestion).
+		WithOperation(tool).
+		WithComponent("mcp_server")
+}
+
 // CreateCircuitBreakerError creates a FeedError for circuit breaker events
 func CreateCircuitBreakerError(feedURL, state string) *FeedError {
 	message := fmt.Sprintf("Circuit breaker is %s", state)
@@ -122,6 +136,8 @@ func CreateRetryError(lastErr error, feedURL string, attempt, maxAttempts int) *
 	feedErr := &FeedError{}
 	if errors.As(lastErr, &feedErr) {
 		errorType = feedErr.ErrorType
+	} else if IsTLSError(lastErr) {
+		errorType = ErrorTypeTLS
 	}
 
 	return NewFeedErrorWithCause(errorType, message, lastErr).
@@ -162,6 +178,26 @@ func isTimeoutError(err error) bool {
 	return false
 }
 
+// IsTLSError reports whether err is a TLS handshake failure: a protocol
+// version or other alert from either side, or a certificate that failed
+// verification. Handshake timeouts are timeouts, not TLS errors.
+func IsTLSError(err error) bool {
+	if err == nil || isTimeoutError(err) {
+		return false
+	}
+
+	var alertErr tls.AlertError
+	var recordErr tls.RecordHeaderError
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &alertErr) || errors.As(err, &recordErr) || errors.As(err, &certErr) {
+		return true
+	}
+
+	// Alerts received from the server are unexported types; match their text
+	errStr := err.Error()
+	return strings.Contains(errStr, "tls: ") || strings.Contains(errStr, "x509: ")
+}
+
 // isDNSError checks if the error is related to DNS resolution
 func isDNSError(err error) bool {
 	if err == nil {
@@ -22,6 +22,9 @@ const (
 	ErrorTypeConnectionFailed ErrorType = "connection_failed"
 	// ErrorTypeDNSResolution represents DNS resolution failures
 	ErrorTypeDNSResolution ErrorType = "dns_resolution"
+	// ErrorTypeTLS represents TLS handshake failures, such as a server that
+	// only supports TLS versions below the configured minimum
+	ErrorTypeTLS ErrorType = "tls"
 
 	// ErrorTypeHTTP represents general HTTP errors
 	ErrorTypeHTTP ErrorType = "http"
@@ -112,6 +115,7 @@ type FeedError struct {
 	URL       string `json:"url,omitempty"`       // Feed URL that caused the error
 	Operation string `json:"operation,omitempty"` // What operation was being performed
 	Component string `json:"component,omitempty"` // Which component generated the error
+	Field     string `json:"field,omitempty"`     // Tool parameter that failed validation
 
 	// HTTP-specific context
 	HTTPStatus  int               `json:"http_status,omitempty"`  // HTTP status code
@@ -159,6 +163,14 @@ func (fe *FeedError) Error() string {
 		parts = append(parts, fmt.Sprintf("Operation: %s", fe.Operation))
 	}
 
+	// Add the offending parameter and how to fix it
+	if fe.Field != "" {
+		parts = append(parts, fmt.Sprintf("Field: %s", fe.Field))
+		if fe.Suggestion != "" {
+			parts = append(parts, fmt.Sprintf("Suggestion: %s", fe.Suggestion))
+		}
+	}
+
 	// Add HTTP status if relevant
 	if fe.HTTPStatus != 0 {
 		parts = append(parts, fmt.Sprintf("HTTP Status: %d", fe.HTTPStatus))
@@ -213,6 +225,18 @@ func (fe *FeedError) WithComponent(component string) *FeedError {
 	return fe
 }
 
+// WithField names the tool parameter that failed validation
+func (fe *FeedError) WithField(field string) *FeedError {
+	fe.Field = field
+	return fe
+}
+
+// WithSuggestion replaces the default suggestion for the error type
+func (fe *FeedError) WithSuggestion(suggestion string) *FeedError {
+	fe.Suggestion = suggestion
+	return fe
+}
+
 // WithHTTP adds HTTP-specific context to the error
 func (fe *FeedError) WithHTTP(status int, headers http.Header) *FeedError {
 	fe.HTTPStatus = status
@@ -263,6 +287,7 @@ func getSuggestionForErrorType(errorType ErrorType) string {
 		ErrorTypeTimeout:           "Check network connectivity or increase timeout duration",
 		ErrorTypeConnectionFailed:  "Verify the URL is accessible and the server is running",
 		ErrorTypeDNSResolution:     "Check DNS settings and verify the domain name is correct",
+		ErrorTypeTLS:               "The TLS handshake failed: the server may only support TLS versions below the configured minimum (--min-tls-version) or present an invalid certificate",
 		ErrorTypeHTTPClientError:   "Verify the URL is correct and accessible",
 		ErrorTypeHTTPServerError:   "The server is experiencing issues, try again later",
 		ErrorTypeInvalidFormat:     "Ensure the feed URL returns valid RSS, Atom, or JSON feed content",
@@ -200,6 +200,12 @@ func TestCreateNetworkError(t *testing.T) {
 			expectedType: ErrorTypeConnectionFailed,
 			expectedMsg:  "Connection failed",
 		},
+		{
+			name:         "TLS version error",
+			inputError:   fmt.Errorf("remote error: tls: protocol version not supported"),
+			expectedType: ErrorTypeTLS,
+			expectedMsg:  "TLS handshake failed",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -311,3 +317,24 @@ func TestCreateValidationError(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateParameterError(t *testing.T) {
+	err := CreateParameterError("merge_feeds", "sortBy", `invalid sortBy "size"`, "Use one of: date, title, source")
+
+	if err.ErrorType != ErrorTypeValidation {
+		t.Errorf("expected error type %v, got %v", ErrorTypeValidation, err.ErrorType)
+	}
+	if err.Field != "sortBy" || err.Operation != "merge_feeds" || err.Component != "mcp_server" {
+		t.Errorf("unexpected context: field %q, operation %q, component %q", err.Field, err.Operation, err.Component)
+	}
+	if err.Suggestion != "Use one of: date, title, source" {
+		t.Errorf("expected the given suggestion, got %q", err.Suggestion)
+	}
+
+	errStr := err.Error()
+	for _, part := range []string{"Field: sortBy", "Suggestion: Use one of: date, title, source", "Type: validation"} {
+		if !strings.Contains(errStr, part) {
+			t.Errorf("expected error string to contain %q, got %q", part, errStr)
+		}
+	}
+}
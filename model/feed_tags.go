@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxFeedTagLength bounds a single feed tag.
+const MaxFeedTagLength = 64
+
+// NormalizeFeedTags trims and lowercases feed tags, drops blanks and
+// duplicates, and sorts the rest, so "Team:Infra" and " team:infra" are one
+// tag and tag lists compare equal whatever order they were given in. It
+// returns nil when no tags remain.
+func NormalizeFeedTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// ValidateFeedTags checks that each tag is at most MaxFeedTagLength
+// characters and has no comma, since tag lists are written comma-separated
+// on the command line. Blank tags are ignored, as NormalizeFeedTags drops
+// them.
+func ValidateFeedTags(tags []string) error {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case len(tag) > MaxFeedTagLength:
+			return fmt.Errorf("tag %q is longer than %d characters", tag, MaxFeedTagLength)
+		case strings.Contains(tag, ","):
+			return fmt.Errorf("tag %q contains a comma", tag)
+		}
+	}
+	return nil
+}
+
+// HasFeedTags reports whether the normalized tags include every one of want,
+// compared case-insensitively.
+func HasFeedTags(tags, want []string) bool {
+	for _, tag := range want {
+		if !slices.Contains(tags, strings.ToLower(strings.TrimSpace(tag))) {
+			return false
+		}
+	}
+	return true
+}
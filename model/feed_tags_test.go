@@ -0,0 +1,49 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFeedTags(t *testing.T) {
+	got := NormalizeFeedTags([]string{" Team:Infra", "priority:high", "", "team:infra", "  "})
+	if want := []string{"priority:high", "team:infra"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeFeedTags = %v, want %v", got, want)
+	}
+	if got := NormalizeFeedTags([]string{"", " "}); got != nil {
+		t.Errorf("NormalizeFeedTags(blank) = %v, want nil", got)
+	}
+}
+
+func TestValidateFeedTags(t *testing.T) {
+	if err := ValidateFeedTags([]string{"priority:high", "", strings.Repeat("x", MaxFeedTagLength)}); err != nil {
+		t.Errorf("ValidateFeedTags(valid) = %v", err)
+	}
+	for _, tags := range [][]string{
+		{strings.Repeat("x", MaxFeedTagLength+1)},
+		{"a,b"},
+	} {
+		if err := ValidateFeedTags(tags); err == nil {
+			t.Errorf("ValidateFeedTags(%q): expected an error", tags)
+		}
+	}
+}
+
+func TestHasFeedTags(t *testing.T) {
+	tags := []string{"priority:high", "team:infra"}
+	tests := []struct {
+		want []string
+		has  bool
+	}{
+		{nil, true},
+		{[]string{"team:infra"}, true},
+		{[]string{" Priority:High", "team:infra"}, true},
+		{[]string{"team:infra", "weekly"}, false},
+	}
+	for _, tt := range tests {
+		if got := HasFeedTags(tags, tt.want); got != tt.has {
+			t.Errorf("HasFeedTags(%v, %v) = %v, want %v", tags, tt.want, got, tt.has)
+		}
+	}
+}
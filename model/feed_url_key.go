@@ -0,0 +1,32 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// FeedURLKey reduces a feed URL to the parts that select the feed, so two
+// spellings of one feed compare equal: the scheme and host are lowercased, a
+// default port, the fragment, and a trailing slash are dropped. Unlike
+// NormalizeItemLink the scheme, "www.", and the whole query are kept, since
+// feeds often differ only in those.
+func FeedURLKey(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	key := scheme + "://" + host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
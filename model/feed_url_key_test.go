@@ -0,0 +1,24 @@
+package model
+
+import "testing"
+
+func TestFeedURLKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"https://example.com/feed", "HTTPS://Example.COM:443/feed/", true},
+		{"https://example.com/feed", "https://example.com/feed#latest", true},
+		{"http://example.com:80/feed", "http://example.com/feed", true},
+		{"https://[::1]:8080/feed", "https://[::1]:8080/feed/", true},
+		{"https://example.com/feed", "http://example.com/feed", false},
+		{"https://example.com/feed", "https://www.example.com/feed", false},
+		{"https://example.com/feed", "https://example.com/feed?lang=fr", false},
+		{"https://example.com/feed", "https://example.com:8443/feed", false},
+	}
+	for _, tt := range tests {
+		if got := FeedURLKey(tt.a) == FeedURLKey(tt.b); got != tt.same {
+			t.Errorf("FeedURLKey(%q) == FeedURLKey(%q) is %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
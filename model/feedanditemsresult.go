@@ -13,6 +13,9 @@ type FeedAndItemsResult struct {
 	Feed               *Feed          `json:"feed_result,omitempty"`
 	Items              []*gofeed.Item `json:"items,omitempty"`
 	CircuitBreakerOpen bool           `json:"circuit_breaker_open,omitempty"`
+	// HiddenFutureItems counts the items left out of Items because they are
+	// dated in the future (see store.Config.HideFutureItems).
+	HiddenFutureItems int `json:"hidden_future_items,omitempty"`
 }
 
 // FeedMetadata represents feed metadata without items
@@ -23,16 +26,27 @@ type FeedMetadata struct {
 	FetchError         string `json:"fetch_error,omitempty"`
 	Feed               *Feed  `json:"feed_result,omitempty"`
 	CircuitBreakerOpen bool   `json:"circuit_breaker_open,omitempty"`
+	// ContentHash is ContentHash of the feed's items, empty when the fetch
+	// failed.
+	ContentHash string `json:"content_hash,omitempty"`
+	// HiddenFutureItems is FeedAndItemsResult.HiddenFutureItems.
+	HiddenFutureItems int `json:"hidden_future_items,omitempty"`
 }
 
-// ToMetadata returns the feed metadata without items
+// ToMetadata returns the feed metadata without items, with a ContentHash of
+// the items
 func (f *FeedAndItemsResult) ToMetadata() *FeedMetadata {
-	return &FeedMetadata{
+	metadata := &FeedMetadata{
 		ID:                 f.ID,
 		PublicURL:          f.PublicURL,
 		Title:              f.Title,
 		FetchError:         f.FetchError,
 		Feed:               f.Feed,
 		CircuitBreakerOpen: f.CircuitBreakerOpen,
+		HiddenFutureItems:  f.HiddenFutureItems,
 	}
+	if f.FetchError == "" {
+		metadata.ContentHash = ContentHash(f.Items)
+	}
+	return metadata
 }
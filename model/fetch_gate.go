@@ -0,0 +1,121 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// FetchGate bounds how many upstream fetches run at once on behalf of the
+// contexts it is attached to (see WithFetchGate). The fetches themselves
+// acquire the slots (see AcquireFetchSlot), so a caller that fans out, or reads
+// from a cache, holds none while it isn't on the network.
+//
+// When every slot is taken, fetches wait in a priority queue: a freed slot
+// goes to the waiting fetch with the highest priority (see
+// AcquirePriorityFetchSlot), and to the one that has waited longest among
+// equals, so important feeds are fetched first under load.
+type FetchGate struct {
+	limit   int
+	mu      sync.Mutex
+	inUse   int
+	waiters []*fetchWaiter // in arrival order
+}
+
+// fetchWaiter is a fetch waiting for a FetchGate slot. ready is closed once
+// the slot has been handed to it.
+type fetchWaiter struct {
+	priority int
+	ready    chan struct{}
+}
+
+// NewFetchGate returns a gate allowing limit concurrent fetches. A limit below
+// one allows one.
+func NewFetchGate(limit int) *FetchGate {
+	return &FetchGate{limit: max(limit, 1)}
+}
+
+type fetchGateKey struct{}
+
+// WithFetchGate returns a context whose fetches are bounded by gate. A nil
+// gate returns ctx unchanged.
+func WithFetchGate(ctx context.Context, gate *FetchGate) context.Context {
+	if gate == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, fetchGateKey{}, gate)
+}
+
+// WithDefaultFetchGate is WithFetchGate, except that a gate already attached
+// to ctx is kept, so a caller's own bound isn't lifted.
+func WithDefaultFetchGate(ctx context.Context, gate *FetchGate) context.Context {
+	if _, ok := ctx.Value(fetchGateKey{}).(*FetchGate); ok {
+		return ctx
+	}
+	return WithFetchGate(ctx, gate)
+}
+
+// AcquireFetchSlot is AcquirePriorityFetchSlot at priority zero.
+func AcquireFetchSlot(ctx context.Context) (func(), error) {
+	return AcquirePriorityFetchSlot(ctx, 0)
+}
+
+// AcquirePriorityFetchSlot waits for a slot of the context's FetchGate and
+// returns the function that releases it. Fetches with a higher priority are
+// handed slots before those with a lower one. Without a gate it returns at
+// once. If ctx is done first it returns a timeout FeedError wrapping
+// ctx.Err().
+func AcquirePriorityFetchSlot(ctx context.Context, priority int) (func(), error) {
+	gate, _ := ctx.Value(fetchGateKey{}).(*FetchGate)
+	if gate == nil {
+		return func() {}, nil
+	}
+
+	gate.mu.Lock()
+	if gate.inUse < gate.limit && len(gate.waiters) == 0 {
+		gate.inUse++
+		gate.mu.Unlock()
+		return gate.release, nil
+	}
+	waiter := &fetchWaiter{priority: priority, ready: make(chan struct{})}
+	gate.waiters = append(gate.waiters, waiter)
+	gate.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return gate.release, nil
+	case <-ctx.Done():
+		gate.mu.Lock()
+		if i := slices.Index(gate.waiters, waiter); i >= 0 {
+			gate.waiters = slices.Delete(gate.waiters, i, i+1)
+			gate.mu.Unlock()
+		} else {
+			// The slot was handed over as ctx ended; pass it on.
+			gate.mu.Unlock()
+			gate.release()
+		}
+		return nil, NewFeedErrorWithCause(ErrorTypeTimeout, "gave up waiting for a feed fetch slot", ctx.Err()).
+			WithOperation("acquire_fetch_slot").
+			WithComponent("fetch_gate")
+	}
+}
+
+// release frees a slot, handing it straight to the highest-priority waiter,
+// the earliest among equals, if there is one.
+func (g *FetchGate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.waiters) == 0 {
+		g.inUse--
+		return
+	}
+	next := 0
+	for i, waiter := range g.waiters {
+		if waiter.priority > g.waiters[next].priority {
+			next = i
+		}
+	}
+	waiter := g.waiters[next]
+	g.waiters = slices.Delete(g.waiters, next, next+1)
+	close(waiter.ready)
+}
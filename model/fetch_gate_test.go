@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n fetches are queued on gate.
+func waitForWaiters(t *testing.T, gate *FetchGate, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		gate.mu.Lock()
+		queued := len(gate.waiters)
+		gate.mu.Unlock()
+		if queued == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d fetches queued, want %d", queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFetchGate_Priority(t *testing.T) {
+	gate := NewFetchGate(1)
+	ctx := WithFetchGate(context.Background(), gate)
+	release, err := AcquireFetchSlot(ctx)
+	if err != nil {
+		t.Fatalf("AcquireFetchSlot: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	waiters := []struct {
+		name     string
+		priority int
+	}{{"low", 1}, {"high", 5}, {"mid", 3}, {"high-later", 5}, {"default", 0}}
+	for i, waiter := range waiters {
+		wg.Go(func() {
+			release, err := AcquirePriorityFetchSlot(ctx, waiter.priority)
+			if err != nil {
+				t.Errorf("%s: %v", waiter.name, err)
+				return
+			}
+			mu.Lock()
+			order = append(order, waiter.name)
+			mu.Unlock()
+			release()
+		})
+		waitForWaiters(t, gate, i+1) // queue them in a known order
+	}
+	release()
+	wg.Wait()
+
+	if want := []string{"high", "high-later", "mid", "low", "default"}; !slices.Equal(order, want) {
+		t.Errorf("slots went to %v, want %v", order, want)
+	}
+	if gate.inUse != 0 {
+		t.Errorf("%d slots still in use", gate.inUse)
+	}
+}
+
+func TestFetchGate_CancelWhileWaiting(t *testing.T) {
+	gate := NewFetchGate(1)
+	ctx := WithFetchGate(context.Background(), gate)
+	release, err := AcquireFetchSlot(ctx)
+	if err != nil {
+		t.Fatalf("AcquireFetchSlot: %v", err)
+	}
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := AcquirePriorityFetchSlot(waitCtx, 10)
+		errCh <- err
+	}()
+	waitForWaiters(t, gate, 1)
+	cancel()
+	var feedErr *FeedError
+	if err := <-errCh; !errors.As(err, &feedErr) || feedErr.ErrorType != ErrorTypeTimeout {
+		t.Fatalf("cancelled wait = %v, want a timeout FeedError", err)
+	}
+	waitForWaiters(t, gate, 0)
+
+	// The cancelled fetch left no trace: the slot frees as usual.
+	release()
+	release, err = AcquireFetchSlot(ctx)
+	if err != nil {
+		t.Fatalf("AcquireFetchSlot after cancel: %v", err)
+	}
+	release()
+}
+
+func TestWithDefaultFetchGate(t *testing.T) {
+	callerGate, storeGate := NewFetchGate(1), NewFetchGate(1)
+	ctx := WithDefaultFetchGate(WithFetchGate(context.Background(), callerGate), storeGate)
+	if gate, _ := ctx.Value(fetchGateKey{}).(*FetchGate); gate != callerGate {
+		t.Error("the caller's gate was replaced")
+	}
+	ctx = WithDefaultFetchGate(context.Background(), storeGate)
+	if gate, _ := ctx.Value(fetchGateKey{}).(*FetchGate); gate != storeGate {
+		t.Error("the default gate wasn't attached")
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// DefaultFutureItemTolerance is how far past now an item's publish date may
+// lie before HideFutureItems hides it, absorbing small clock skew between a
+// feed's server and this one.
+const DefaultFutureItemTolerance = 5 * time.Minute
+
+// HideFutureItems returns the items whose publish date isn't after cutoff,
+// and how many were left out. Items without a publish date are kept. The
+// input slice is not modified, so it can be a cached feed's items.
+func HideFutureItems(items []*gofeed.Item, cutoff time.Time) (visible []*gofeed.Item, hidden int) {
+	for i, item := range items {
+		if item == nil || item.PublishedParsed == nil || !item.PublishedParsed.After(cutoff) {
+			if hidden > 0 {
+				visible = append(visible, item)
+			}
+			continue
+		}
+		if hidden == 0 {
+			visible = append(make([]*gofeed.Item, 0, len(items)-1), items[:i]...)
+		}
+		hidden++
+	}
+	if hidden == 0 {
+		return items, 0
+	}
+	return visible, hidden
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestHideFutureItems(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { return new(now.Add(d)) }
+	past := &gofeed.Item{Title: "past", PublishedParsed: at(-time.Hour)}
+	skewed := &gofeed.Item{Title: "skewed", PublishedParsed: at(2 * time.Minute)}
+	scheduled := &gofeed.Item{Title: "scheduled", PublishedParsed: at(24 * time.Hour)}
+	undated := &gofeed.Item{Title: "undated"}
+	items := []*gofeed.Item{past, scheduled, skewed, undated}
+
+	visible, hidden := HideFutureItems(items, now.Add(DefaultFutureItemTolerance))
+	if want := []*gofeed.Item{past, skewed, undated}; !slices.Equal(visible, want) || hidden != 1 {
+		t.Errorf("HideFutureItems = %d items, %d hidden; want past, skewed, undated and 1 hidden", len(visible), hidden)
+	}
+	if items[1] != scheduled {
+		t.Error("HideFutureItems modified its input")
+	}
+
+	visible, hidden = HideFutureItems([]*gofeed.Item{past, undated}, now)
+	if len(visible) != 2 || hidden != 0 {
+		t.Errorf("HideFutureItems without future items = %d items, %d hidden; want 2, 0", len(visible), hidden)
+	}
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ItemLanguageKey is the item Custom key holding the item's language,
+// assigned when the feed is fetched (see AssignItemLanguages).
+const ItemLanguageKey = "item_language"
+
+// minLanguageWordMatches is how many distinct common words of a language a
+// text must contain before it is taken to be in that language.
+const minLanguageWordMatches = 3
+
+// languageWords lists very common words of each language DetectLanguage
+// recognizes, keyed by ISO 639-1 code. Words shared between languages (such
+// as "de" or "que") are left out so they don't tip the balance.
+var languageWords = map[string][]string{
+	"en": {"the", "and", "that", "have", "for", "not", "with", "you", "this", "but", "from", "are", "was", "will"},
+	"es": {"con", "para", "una", "por", "como", "del", "los", "las", "más", "pero", "este", "está", "sus", "muy"},
+	"fr": {"les", "des", "est", "pour", "dans", "une", "sur", "avec", "pas", "qui", "mais", "sont", "aux", "cette"},
+	"de": {"und", "der", "die", "das", "ist", "nicht", "mit", "sich", "auf", "für", "ein", "eine", "auch", "wird"},
+}
+
+// languageNames maps language names accepted in filters to their codes.
+var languageNames = map[string]string{
+	"english": "en",
+	"spanish": "es",
+	"french":  "fr",
+	"german":  "de",
+}
+
+// languageWordCounts returns, for each recognized language, how many of its
+// common words appear in text.
+func languageWordCounts(text string) map[string]int {
+	words := make(map[string]bool)
+	for word := range strings.FieldsFuncSeq(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		words[word] = true
+	}
+	counts := make(map[string]int, len(languageWords))
+	for lang, common := range languageWords {
+		for _, word := range common {
+			if words[word] {
+				counts[lang]++
+			}
+		}
+	}
+	return counts
+}
+
+// DetectLanguage guesses the ISO 639-1 code of text from the common words it
+// contains. It returns "" when no language reaches the threshold or two
+// languages tie for the most matches.
+func DetectLanguage(text string) string {
+	best, bestCount, tied := "", 0, false
+	for lang, count := range languageWordCounts(text) {
+		switch {
+		case count > bestCount:
+			best, bestCount, tied = lang, count, false
+		case count == bestCount:
+			tied = true
+		}
+	}
+	if bestCount < minLanguageWordMatches || tied {
+		return ""
+	}
+	return best
+}
+
+// LanguageCode returns the ISO 639-1 code of a language DetectLanguage
+// recognizes, given as a code ("en") or an English name ("english"), or ""
+// for any other language.
+func LanguageCode(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if code, ok := languageNames[language]; ok {
+		return code
+	}
+	if _, ok := languageWords[language]; ok {
+		return language
+	}
+	return ""
+}
+
+// LooksLikeLanguage reports whether text contains enough common words of
+// language (see LanguageCode). It is false for languages DetectLanguage
+// doesn't recognize.
+func LooksLikeLanguage(text, language string) bool {
+	code := LanguageCode(language)
+	return code != "" && languageWordCounts(text)[code] >= minLanguageWordMatches
+}
+
+// ItemLanguageMetadata returns the language the item declares in its Dublin
+// Core dc:language or a language/lang custom element, or "".
+func ItemLanguageMetadata(item *gofeed.Item) string {
+	if item.DublinCoreExt != nil {
+		for _, lang := range item.DublinCoreExt.Language {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				return lang
+			}
+		}
+	}
+	for _, key := range []string{"language", "lang"} {
+		if lang := strings.TrimSpace(item.Custom[key]); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// DetectItemLanguage returns the item's declared language, else the language
+// detected from its title and text, else feedLanguage. Items in multilingual
+// feeds often differ from the feed's declared language, which is why it
+// comes last.
+func DetectItemLanguage(item *gofeed.Item, feedLanguage string) string {
+	if lang := ItemLanguageMetadata(item); lang != "" {
+		return lang
+	}
+	if lang := DetectLanguage(item.Title + " " + item.Description + " " + item.Content); lang != "" {
+		return lang
+	}
+	return strings.TrimSpace(feedLanguage)
+}
+
+// ItemLanguage returns the language assigned at fetch time, or detects it
+// for items that weren't fetched through the store.
+func ItemLanguage(item *gofeed.Item) string {
+	if lang := item.Custom[ItemLanguageKey]; lang != "" {
+		return lang
+	}
+	return DetectItemLanguage(item, "")
+}
+
+// AssignItemLanguages stores each item's language under ItemLanguageKey in
+// its Custom map, so detection runs once per fetch rather than per request.
+func AssignItemLanguages(items []*gofeed.Item, feedLanguage string) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		lang := DetectItemLanguage(item, feedLanguage)
+		if lang == "" {
+			continue
+		}
+		if item.Custom == nil {
+			item.Custom = make(map[string]string, 1)
+		}
+		item.Custom[ItemLanguageKey] = lang
+	}
+}
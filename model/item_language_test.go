@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The market was calm and traders say that this will not last", "en"},
+		{"Los precios suben para las familias, pero el gobierno está con ellas", "es"},
+		{"Les prix sont en hausse dans la ville, mais pas pour les loyers", "fr"},
+		{"Die Preise steigen und das ist nicht gut für die Stadt", "de"},
+		{"Short headline", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLooksLikeLanguage(t *testing.T) {
+	text := "The results are in and the team will have to wait for this"
+	if !LooksLikeLanguage(text, "en") || !LooksLikeLanguage(text, "English") {
+		t.Error("English text not recognized as en/English")
+	}
+	if LooksLikeLanguage(text, "es") {
+		t.Error("English text recognized as Spanish")
+	}
+	if LooksLikeLanguage(text, "ja") {
+		t.Error("unrecognized language reported as a match")
+	}
+}
+
+func TestDetectItemLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		item *gofeed.Item
+		want string
+	}{
+		{
+			name: "Dublin Core language wins",
+			item: &gofeed.Item{Title: "The news and the weather for you", DublinCoreExt: &ext.DublinCoreExtension{Language: []string{" pt-BR "}}},
+			want: "pt-BR",
+		},
+		{
+			name: "custom language element",
+			item: &gofeed.Item{Title: "Post", Custom: map[string]string{"lang": "it"}},
+			want: "it",
+		},
+		{
+			name: "detected from content",
+			item: &gofeed.Item{Title: "Resumen", Description: "Una semana con lluvias para los agricultores del norte"},
+			want: "es",
+		},
+		{
+			name: "feed language as fallback",
+			item: &gofeed.Item{Title: "Untitled"},
+			want: "en",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectItemLanguage(tt.item, "en"); got != tt.want {
+				t.Errorf("DetectItemLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignItemLanguages(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "Declared", Custom: map[string]string{"language": "de"}},
+		{Title: "Untitled"},
+		nil,
+	}
+	AssignItemLanguages(items, "")
+	if got := items[0].Custom[ItemLanguageKey]; got != "de" {
+		t.Errorf("declared item language = %q, want de", got)
+	}
+	if items[1].Custom != nil {
+		t.Errorf("undetectable item got custom %v", items[1].Custom)
+	}
+	if got := ItemLanguage(items[0]); got != "de" {
+		t.Errorf("ItemLanguage() = %q, want de", got)
+	}
+}
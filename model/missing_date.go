@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrInvalidMissingDateStrategy is returned when an unknown missing-date
+// strategy is specified.
+var ErrInvalidMissingDateStrategy = errors.New("invalid missing date strategy")
+
+// MissingDateStrategy controls how items without a parsed publish date are
+// treated. It is applied once when a feed is fetched, so sorting, date
+// filtering, and export all see the same dates.
+type MissingDateStrategy string
+
+// Missing-date strategies.
+const (
+	// MissingDateInclude keeps undated items as-is: they pass date filters and
+	// sort after dated items. This is the default.
+	MissingDateInclude MissingDateStrategy = "include"
+	// MissingDateExclude drops undated items.
+	MissingDateExclude MissingDateStrategy = "exclude"
+	// MissingDateUseUpdated uses the item's updated date as its publish date;
+	// items with neither date are kept undated, as with MissingDateInclude.
+	MissingDateUseUpdated MissingDateStrategy = "use_updated"
+	// MissingDateUseNow stamps undated items with the time the feed was fetched.
+	MissingDateUseNow MissingDateStrategy = "use_now"
+)
+
+// DefaultMissingDateStrategy is used when no strategy is configured.
+const DefaultMissingDateStrategy = MissingDateInclude
+
+// MissingDateStrategyNames returns the accepted strategy names.
+func MissingDateStrategyNames() []string {
+	return []string{
+		string(MissingDateInclude),
+		string(MissingDateExclude),
+		string(MissingDateUseUpdated),
+		string(MissingDateUseNow),
+	}
+}
+
+// ParseMissingDateStrategy converts a string to a MissingDateStrategy. An empty
+// string yields DefaultMissingDateStrategy.
+func ParseMissingDateStrategy(s string) (MissingDateStrategy, error) {
+	switch strategy := MissingDateStrategy(s); strategy {
+	case "":
+		return DefaultMissingDateStrategy, nil
+	case MissingDateInclude, MissingDateExclude, MissingDateUseUpdated, MissingDateUseNow:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("%w %q: valid options are %s",
+			ErrInvalidMissingDateStrategy, s, strings.Join(MissingDateStrategyNames(), ", "))
+	}
+}
+
+// ApplyMissingDateStrategy applies the strategy to items lacking a parsed publish
+// date and returns the resulting slice. Items are updated in place; with
+// MissingDateExclude the returned slice omits undated items. fetchedAt is the
+// timestamp used by MissingDateUseNow.
+func ApplyMissingDateStrategy(items []*gofeed.Item, strategy MissingDateStrategy, fetchedAt time.Time) []*gofeed.Item {
+	switch strategy {
+	case MissingDateExclude:
+		dated := make([]*gofeed.Item, 0, len(items))
+		for _, item := range items {
+			if item != nil && item.PublishedParsed != nil {
+				dated = append(dated, item)
+			}
+		}
+		return dated
+	case MissingDateUseUpdated:
+		for _, item := range items {
+			if item != nil && item.PublishedParsed == nil && item.UpdatedParsed != nil {
+				updated := *item.UpdatedParsed
+				item.PublishedParsed = &updated
+			}
+		}
+	case MissingDateUseNow:
+		for _, item := range items {
+			if item != nil && item.PublishedParsed == nil {
+				stamp := fetchedAt
+				item.PublishedParsed = &stamp
+			}
+		}
+	}
+	return items
+}
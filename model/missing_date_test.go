@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestParseMissingDateStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    MissingDateStrategy
+		wantErr bool
+	}{
+		{"", MissingDateInclude, false},
+		{"include", MissingDateInclude, false},
+		{"exclude", MissingDateExclude, false},
+		{"use_updated", MissingDateUseUpdated, false},
+		{"use_now", MissingDateUseNow, false},
+		{"sometimes", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMissingDateStrategy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMissingDateStrategy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidMissingDateStrategy) {
+			t.Errorf("ParseMissingDateStrategy(%q) error = %v, want ErrInvalidMissingDateStrategy", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseMissingDateStrategy(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestApplyMissingDateStrategy(t *testing.T) {
+	published := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	fetchedAt := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	newItems := func() []*gofeed.Item {
+		return []*gofeed.Item{
+			{Title: "dated", PublishedParsed: &published},
+			{Title: "updated-only", UpdatedParsed: &updated},
+			{Title: "undated"},
+		}
+	}
+
+	tests := []struct {
+		strategy MissingDateStrategy
+		want     map[string]*time.Time // title -> expected PublishedParsed; missing key = dropped
+	}{
+		{MissingDateInclude, map[string]*time.Time{"dated": &published, "updated-only": nil, "undated": nil}},
+		{MissingDateExclude, map[string]*time.Time{"dated": &published}},
+		{MissingDateUseUpdated, map[string]*time.Time{"dated": &published, "updated-only": &updated, "undated": nil}},
+		{MissingDateUseNow, map[string]*time.Time{"dated": &published, "updated-only": &fetchedAt, "undated": &fetchedAt}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			got := ApplyMissingDateStrategy(newItems(), tt.strategy, fetchedAt)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d items, want %d", len(got), len(tt.want))
+			}
+			for _, item := range got {
+				want, ok := tt.want[item.Title]
+				if !ok {
+					t.Errorf("unexpected item %q", item.Title)
+					continue
+				}
+				switch {
+				case want == nil && item.PublishedParsed != nil:
+					t.Errorf("%q: PublishedParsed = %v, want nil", item.Title, item.PublishedParsed)
+				case want != nil && (item.PublishedParsed == nil || !item.PublishedParsed.Equal(*want)):
+					t.Errorf("%q: PublishedParsed = %v, want %v", item.Title, item.PublishedParsed, want)
+				}
+			}
+		})
+	}
+}
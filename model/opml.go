@@ -2,6 +2,7 @@
 package model
 
 import (
+	"cmp"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -42,8 +43,29 @@ type OPML struct {
 	Body    OPMLBody `xml:"body"`
 }
 
+// OPMLFeed is a feed listed in an OPML document.
+type OPMLFeed struct {
+	URL      string
+	Title    string
+	Category string // Text of the innermost folder outline holding the feed, if any
+}
+
 // ExtractFeedURLsFromOPML parses OPML content and extracts all feed URLs
 func ExtractFeedURLsFromOPML(opmlContent []byte) ([]string, error) {
+	feeds, err := ExtractFeedsFromOPML(opmlContent)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, len(feeds))
+	for i, feed := range feeds {
+		urls[i] = feed.URL
+	}
+	return urls, nil
+}
+
+// ExtractFeedsFromOPML parses OPML content and extracts every feed with its
+// title and the folder it is filed under.
+func ExtractFeedsFromOPML(opmlContent []byte) ([]OPMLFeed, error) {
 	var opml OPML
 	if err := xml.Unmarshal(opmlContent, &opml); err != nil {
 		return nil, NewFeedErrorWithCause(ErrorTypeParsing, "failed to parse OPML content", err).
@@ -51,28 +73,35 @@ func ExtractFeedURLsFromOPML(opmlContent []byte) ([]string, error) {
 			WithComponent("opml_parser")
 	}
 
-	var urls []string
-	extractURLsFromOutlines(opml.Body.Outlines, &urls)
+	var feeds []OPMLFeed
+	extractFeedsFromOutlines(opml.Body.Outlines, "", &feeds)
 
-	if len(urls) == 0 {
+	if len(feeds) == 0 {
 		return nil, NewFeedError(ErrorTypeConfiguration, "no feed URLs found in OPML").
 			WithOperation("extract_feed_urls").
 			WithComponent("opml_parser")
 	}
 
-	return urls, nil
+	return feeds, nil
 }
 
-// extractURLsFromOutlines recursively extracts feed URLs from OPML outlines
-func extractURLsFromOutlines(outlines []OPMLOutline, urls *[]string) {
+// extractFeedsFromOutlines recursively extracts feeds from OPML outlines. An
+// outline without an xmlUrl is a folder, and names the category of the feeds
+// nested in it.
+func extractFeedsFromOutlines(outlines []OPMLOutline, category string, feeds *[]OPMLFeed) {
 	for _, outline := range outlines {
+		name := strings.TrimSpace(cmp.Or(outline.Title, outline.Text))
 		// If this outline has an xmlUrl, it's a feed
 		if outline.XMLURL != "" {
-			*urls = append(*urls, outline.XMLURL)
+			*feeds = append(*feeds, OPMLFeed{URL: outline.XMLURL, Title: name, Category: category})
 		}
 		// Recursively check nested outlines
 		if len(outline.Outlines) > 0 {
-			extractURLsFromOutlines(outline.Outlines, urls)
+			folder := category
+			if outline.XMLURL == "" && name != "" {
+				folder = name
+			}
+			extractFeedsFromOutlines(outline.Outlines, folder, feeds)
 		}
 	}
 }
@@ -140,6 +140,33 @@ func TestExtractFeedURLsFromOPML(t *testing.T) {
 	}
 }
 
+func TestExtractFeedsFromOPML(t *testing.T) {
+	opml := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+	<body>
+		<outline text="Unfiled" xmlUrl="https://example.com/unfiled.xml" />
+		<outline text="Tech">
+			<outline text="Go Blog" title="The Go Blog" xmlUrl="https://go.dev/blog/feed.atom" />
+			<outline text="Security">
+				<outline text="Krebs" xmlUrl="https://krebsonsecurity.com/feed/" />
+			</outline>
+		</outline>
+	</body>
+</opml>`
+	feeds, err := ExtractFeedsFromOPML([]byte(opml))
+	if err != nil {
+		t.Fatalf("ExtractFeedsFromOPML() error = %v", err)
+	}
+	expected := []OPMLFeed{
+		{URL: "https://example.com/unfiled.xml", Title: "Unfiled"},
+		{URL: "https://go.dev/blog/feed.atom", Title: "The Go Blog", Category: "Tech"},
+		{URL: "https://krebsonsecurity.com/feed/", Title: "Krebs", Category: "Security"},
+	}
+	if !reflect.DeepEqual(feeds, expected) {
+		t.Errorf("ExtractFeedsFromOPML() = %+v, want %+v", feeds, expected)
+	}
+}
+
 func TestLoadOPMLFromFile(t *testing.T) {
 	// Create a temporary OPML file
 	tmpDir := t.TempDir()
@@ -0,0 +1,16 @@
+package model
+
+// RedirectedToKey is the gofeed.Feed.Custom key holding the URL a feed has
+// permanently moved to: where its fetch ended after following one or more 301
+// or 308 redirects from the URL requested. It is absent when the fetch wasn't
+// permanently redirected.
+const RedirectedToKey = "feed_mcp_redirected_to"
+
+// FeedRedirectedTo returns the URL the feed has permanently moved to, or ""
+// when its last fetch wasn't permanently redirected.
+func FeedRedirectedTo(feed *Feed) string {
+	if feed == nil {
+		return ""
+	}
+	return feed.Custom[RedirectedToKey]
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryDelay calculates the delay for the next retry using exponential backoff.
+// Uses formula: baseDelay * 2^(attempt-1), capped at maxDelay.
+// Applies jitter (±50% random variance) when useJitter is true to prevent thundering herd.
+func RetryDelay(attempt int, baseDelay, maxDelay time.Duration, useJitter bool) time.Duration {
+	if attempt <= 0 {
+		return baseDelay
+	}
+
+	// Exponential backoff: baseDelay * 2^(attempt-1)
+	delay := min(
+		// Cap at maxDelay
+		time.Duration(float64(baseDelay)*math.Pow(2, float64(attempt-1))), maxDelay)
+
+	// Add jitter to avoid thundering herd
+	if useJitter && delay > 0 {
+		jitterRange := delay / 2
+		var jitter time.Duration
+		if jitterRange > 0 {
+			jitter = time.Duration(rand.Int63n(int64(jitterRange)))
+		} else {
+			jitter = 0
+		}
+		delay = max(
+			// Ensure delay is never negative
+			delay-jitterRange/2+jitter, 0)
+	}
+
+	return delay
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name        string
+		attempt     int
+		baseDelay   time.Duration
+		maxDelay    time.Duration
+		useJitter   bool
+		minExpected time.Duration
+		maxExpected time.Duration
+	}{
+		{"first attempt", 1, 100 * time.Millisecond, 10 * time.Second, false, 100 * time.Millisecond, 100 * time.Millisecond},
+		{"second attempt", 2, 100 * time.Millisecond, 10 * time.Second, false, 200 * time.Millisecond, 200 * time.Millisecond},
+		{"third attempt", 3, 100 * time.Millisecond, 10 * time.Second, false, 400 * time.Millisecond, 400 * time.Millisecond},
+		{"capped by max delay", 10, 100 * time.Millisecond, 1 * time.Second, false, 1 * time.Second, 1 * time.Second},
+		{"zero attempt", 0, 100 * time.Millisecond, 10 * time.Second, false, 100 * time.Millisecond, 100 * time.Millisecond},
+		{"with jitter", 2, 100 * time.Millisecond, 10 * time.Second, true, 100 * time.Millisecond, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay := RetryDelay(tt.attempt, tt.baseDelay, tt.maxDelay, tt.useJitter)
+
+			if delay < tt.minExpected || delay > tt.maxExpected {
+				t.Errorf("expected delay between %v and %v, got %v", tt.minExpected, tt.maxExpected, delay)
+			}
+		})
+	}
+}
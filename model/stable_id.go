@@ -0,0 +1,167 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// StableIDKey is the item Custom key holding the item's stable ID, assigned
+// when the feed is fetched (see AssignStableIDs).
+const StableIDKey = "stable_id"
+
+// ErrInvalidStableIDSource is returned when an unknown stable ID source is
+// specified.
+var ErrInvalidStableIDSource = errors.New("invalid stable ID source")
+
+// StableIDSource is one step of the fallback chain that identifies an item.
+type StableIDSource string
+
+// Stable ID sources. Each yields an ID prefixed with its name, so IDs from
+// different sources can never collide.
+const (
+	// StableIDGUID uses the item's GUID as published.
+	StableIDGUID StableIDSource = "guid"
+	// StableIDLink uses the item's link, normalized by NormalizeItemLink.
+	StableIDLink StableIDSource = "link"
+	// StableIDHash hashes the item's normalized title and publish date.
+	StableIDHash StableIDSource = "hash"
+)
+
+// DefaultStableIDChain is used when no chain is configured.
+var DefaultStableIDChain = []StableIDSource{StableIDGUID, StableIDLink, StableIDHash}
+
+// StableIDSourceNames returns the accepted source names.
+func StableIDSourceNames() []string {
+	return []string{string(StableIDGUID), string(StableIDLink), string(StableIDHash)}
+}
+
+// ParseStableIDChain converts source names to a fallback chain. An empty list
+// yields DefaultStableIDChain.
+func ParseStableIDChain(names []string) ([]StableIDSource, error) {
+	if len(names) == 0 {
+		return DefaultStableIDChain, nil
+	}
+	chain := make([]StableIDSource, 0, len(names))
+	for _, name := range names {
+		switch source := StableIDSource(name); source {
+		case StableIDGUID, StableIDLink, StableIDHash:
+			chain = append(chain, source)
+		default:
+			return nil, fmt.Errorf("%w %q: valid options are %s",
+				ErrInvalidStableIDSource, name, strings.Join(StableIDSourceNames(), ", "))
+		}
+	}
+	return chain, nil
+}
+
+// StableID returns the item's ID from the first source in chain that yields
+// one, or "" when none does (e.g. an item with no GUID, link, title or date).
+func StableID(item *gofeed.Item, chain []StableIDSource) string {
+	for _, source := range chain {
+		var value string
+		switch source {
+		case StableIDGUID:
+			value = strings.TrimSpace(item.GUID)
+		case StableIDLink:
+			value = NormalizeItemLink(item.Link)
+		case StableIDHash:
+			value = titleDateHash(item)
+		}
+		if value != "" {
+			return string(source) + ":" + value
+		}
+	}
+	return ""
+}
+
+// ItemStableID returns the stable ID assigned at fetch time, or computes one
+// with DefaultStableIDChain for items that weren't fetched through the store.
+func ItemStableID(item *gofeed.Item) string {
+	if id := item.Custom[StableIDKey]; id != "" {
+		return id
+	}
+	return StableID(item, DefaultStableIDChain)
+}
+
+// AssignStableIDs stores each item's stable ID under StableIDKey in its Custom
+// map, so every consumer (dedup, output) identifies items the same way.
+func AssignStableIDs(items []*gofeed.Item, chain []StableIDSource) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		id := StableID(item, chain)
+		if id == "" {
+			continue
+		}
+		if item.Custom == nil {
+			item.Custom = make(map[string]string, 1)
+		}
+		item.Custom[StableIDKey] = id
+	}
+}
+
+// DeduplicateItems drops items whose stable ID (see ItemStableID) matches an
+// earlier item's, keeping the first occurrence. Items without an ID are kept.
+// It returns the unique items and the number removed.
+func DeduplicateItems(items []*gofeed.Item) ([]*gofeed.Item, int) {
+	seen := make(map[string]bool, len(items))
+	unique := make([]*gofeed.Item, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			if key := ItemStableID(item); key != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+		}
+		unique = append(unique, item)
+	}
+	return unique, len(items) - len(unique)
+}
+
+// titleDateHash hashes the item's normalized title and its publish date (or
+// the raw date string when it didn't parse). It returns "" when the item has
+// neither.
+func titleDateHash(item *gofeed.Item) string {
+	title := strings.Join(strings.Fields(strings.ToLower(item.Title)), " ")
+	date := strings.TrimSpace(item.Published)
+	if item.PublishedParsed != nil {
+		date = item.PublishedParsed.UTC().Format(time.RFC3339)
+	}
+	if title == "" && date == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(title + "\x00" + date))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NormalizeItemLink reduces a link to the parts that identify the article: the
+// scheme, fragment, leading "www.", trailing slash, and utm_* tracking
+// parameters are dropped, and the host is lowercased.
+func NormalizeItemLink(link string) string {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+	query := u.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") {
+			query.Del(param)
+		}
+	}
+	normalized := strings.TrimPrefix(strings.ToLower(u.Host), "www.") + strings.TrimSuffix(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		normalized += "?" + encoded
+	}
+	return normalized
+}
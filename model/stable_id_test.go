@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestStableID(t *testing.T) {
+	published := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	hashOf := func(item *gofeed.Item) string { return StableID(item, []StableIDSource{StableIDHash}) }
+
+	tests := []struct {
+		name  string
+		item  *gofeed.Item
+		chain []StableIDSource
+		want  string
+	}{
+		{
+			name:  "GUID wins",
+			item:  &gofeed.Item{GUID: " urn:post:1 ", Link: "https://example.com/1"},
+			chain: DefaultStableIDChain,
+			want:  "guid:urn:post:1",
+		},
+		{
+			name:  "normalized link without a GUID",
+			item:  &gofeed.Item{Link: "http://www.Example.com/1/?utm_source=rss#top"},
+			chain: DefaultStableIDChain,
+			want:  "link:example.com/1",
+		},
+		{
+			name:  "chain order is respected",
+			item:  &gofeed.Item{GUID: "urn:post:1", Link: "https://example.com/1"},
+			chain: []StableIDSource{StableIDLink, StableIDGUID},
+			want:  "link:example.com/1",
+		},
+		{
+			name:  "no identifying fields",
+			item:  &gofeed.Item{Description: "just text"},
+			chain: DefaultStableIDChain,
+			want:  "",
+		},
+		{
+			name:  "sources outside the chain are ignored",
+			item:  &gofeed.Item{Title: "Post", PublishedParsed: &published},
+			chain: []StableIDSource{StableIDGUID, StableIDLink},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StableID(tt.item, tt.chain); got != tt.want {
+				t.Errorf("StableID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Without GUID or link, the hash is deterministic, ignores title case and
+	// spacing, and tells apart items that differ in title or date.
+	item := &gofeed.Item{Title: "Weekly  Notes", PublishedParsed: &published}
+	id := StableID(item, DefaultStableIDChain)
+	if !strings.HasPrefix(id, "hash:") || len(id) != len("hash:")+16 {
+		t.Fatalf("StableID() = %q, want a hash ID", id)
+	}
+	if again := StableID(&gofeed.Item{Title: "weekly notes", PublishedParsed: &published}, DefaultStableIDChain); again != id {
+		t.Errorf("same title and date hashed to %q and %q", id, again)
+	}
+	nextWeek := published.AddDate(0, 0, 7)
+	if other := hashOf(&gofeed.Item{Title: "Weekly Notes", PublishedParsed: &nextWeek}); other == id {
+		t.Error("different dates produced the same ID")
+	}
+	if other := hashOf(&gofeed.Item{Title: "Daily Notes", PublishedParsed: &published}); other == id {
+		t.Error("different titles produced the same ID")
+	}
+}
+
+func TestAssignStableIDs(t *testing.T) {
+	items := []*gofeed.Item{
+		{GUID: "a"},
+		{Title: "Untitled", Custom: map[string]string{"tags": "x"}},
+		{},
+		nil,
+	}
+	AssignStableIDs(items, DefaultStableIDChain)
+
+	if got := items[0].Custom[StableIDKey]; got != "guid:a" {
+		t.Errorf("items[0] stable_id = %q", got)
+	}
+	if got := items[1].Custom[StableIDKey]; !strings.HasPrefix(got, "hash:") || items[1].Custom["tags"] != "x" {
+		t.Errorf("items[1] custom = %v", items[1].Custom)
+	}
+	if items[2].Custom != nil {
+		t.Errorf("item without identifying fields got custom %v", items[2].Custom)
+	}
+
+	// ItemStableID prefers the assigned ID over recomputing it.
+	items[0].GUID = "changed"
+	if got := ItemStableID(items[0]); got != "guid:a" {
+		t.Errorf("ItemStableID() = %q, want the assigned guid:a", got)
+	}
+	if got := ItemStableID(&gofeed.Item{Link: "https://example.com/x/"}); got != "link:example.com/x" {
+		t.Errorf("ItemStableID() without an assigned ID = %q", got)
+	}
+}
+
+func TestDeduplicateItems(t *testing.T) {
+	first := &gofeed.Item{GUID: "a", Title: "First"}
+	items := []*gofeed.Item{
+		first,
+		{Link: "https://example.com/post"},
+		{GUID: "a", Title: "First, repeated"},
+		{Link: "https://www.example.com/post/?utm_source=rss"},
+		{},
+		{},
+	}
+	unique, removed := DeduplicateItems(items)
+	if removed != 2 || len(unique) != 4 {
+		t.Fatalf("DeduplicateItems() kept %d, removed %d; want 4 and 2", len(unique), removed)
+	}
+	if unique[0] != first {
+		t.Errorf("first occurrence not kept: %+v", unique[0])
+	}
+}
+
+func TestParseStableIDChain(t *testing.T) {
+	chain, err := ParseStableIDChain(nil)
+	if err != nil || !slices.Equal(chain, DefaultStableIDChain) {
+		t.Errorf("ParseStableIDChain(nil) = %v, %v; want the default chain", chain, err)
+	}
+	chain, err = ParseStableIDChain([]string{"link", "hash"})
+	if err != nil || !slices.Equal(chain, []StableIDSource{StableIDLink, StableIDHash}) {
+		t.Errorf("ParseStableIDChain(link,hash) = %v, %v", chain, err)
+	}
+	if _, err := ParseStableIDChain([]string{"guid", "title"}); !errors.Is(err, ErrInvalidStableIDSource) {
+		t.Errorf("expected ErrInvalidStableIDSource, got %v", err)
+	}
+}
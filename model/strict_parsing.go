@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ValidateFeedStructure checks that a parsed feed has the elements a real feed
+// is expected to carry: a detected feed type, a non-empty title, a link or at
+// least one item, and items that each have a title, link, or body. gofeed is
+// lenient, so an HTML page or error document served in place of a feed can
+// parse into a degenerate result; strict parsing uses this check to reject it.
+// The returned error is a FeedError of type ErrorTypeInvalidFormat.
+func ValidateFeedStructure(feed *gofeed.Feed, feedURL string) error {
+	var reason string
+	switch {
+	case feed == nil:
+		reason = "parser returned no feed"
+	case feed.FeedType == "":
+		reason = "feed type could not be detected"
+	case strings.TrimSpace(feed.Title) == "":
+		reason = "feed has no title"
+	case strings.TrimSpace(feed.Link) == "" && len(feed.Links) == 0 && len(feed.Items) == 0:
+		reason = "feed has neither a link nor any items"
+	default:
+		for i, item := range feed.Items {
+			if !hasItemSubstance(item) {
+				reason = fmt.Sprintf("item %d has no title, link, or content", i)
+				break
+			}
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+
+	return NewFeedError(ErrorTypeInvalidFormat, "strict parsing rejected feed: "+reason).
+		WithURL(feedURL).
+		WithOperation("validate_feed").
+		WithComponent("feed_parser")
+}
+
+// hasItemSubstance reports whether an item carries anything identifying.
+func hasItemSubstance(item *gofeed.Item) bool {
+	if item == nil {
+		return false
+	}
+	for _, s := range []string{item.Title, item.Link, item.Description, item.Content} {
+		if strings.TrimSpace(s) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestValidateFeedStructure(t *testing.T) {
+	tests := []struct {
+		feed    *gofeed.Feed
+		name    string
+		wantErr bool
+	}{
+		{name: "minimal valid", feed: &gofeed.Feed{FeedType: "rss", Title: "Feed", Items: []*gofeed.Item{{Title: "Post"}}}},
+		{name: "link without items", feed: &gofeed.Feed{FeedType: "atom", Title: "Feed", Link: "https://example.com"}},
+		{name: "nil feed", feed: nil, wantErr: true},
+		{name: "no feed type", feed: &gofeed.Feed{Title: "Feed", Link: "https://example.com"}, wantErr: true},
+		{name: "blank title", feed: &gofeed.Feed{FeedType: "rss", Title: "  ", Link: "https://example.com"}, wantErr: true},
+		{name: "no link or items", feed: &gofeed.Feed{FeedType: "rss", Title: "Feed"}, wantErr: true},
+		{name: "empty item", feed: &gofeed.Feed{FeedType: "rss", Title: "Feed", Items: []*gofeed.Item{{Title: "Post"}, {}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFeedStructure(tt.feed, "https://example.com/feed.xml")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err %v, wantErr %v", err, tt.wantErr)
+			}
+			var feedErr *FeedError
+			if err != nil && (!errors.As(err, &feedErr) || feedErr.ErrorType != ErrorTypeInvalidFormat) {
+				t.Errorf("expected invalid_format FeedError, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// Thumbnail is an image a feed offers as an item's thumbnail. Width and Height
+// are in pixels, zero when the feed didn't declare them.
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// size is the dimension thumbnails are compared by: the width, or the height
+// when only that is declared.
+func (t Thumbnail) size() int {
+	if t.Width > 0 {
+		return t.Width
+	}
+	return t.Height
+}
+
+// ItemThumbnails returns the thumbnails a Media RSS item offers, in document
+// order and without duplicate URLs: media:thumbnail elements, media:content
+// elements that are images (medium="image" or an image/* type) and the
+// thumbnails nested in any media:content, including those inside media:group.
+// It returns nil when the item has none.
+func ItemThumbnails(item *gofeed.Item) []Thumbnail {
+	if item == nil {
+		return nil
+	}
+	media := item.Extensions["media"]
+	if media == nil {
+		return nil
+	}
+	var thumbnails []Thumbnail
+	seen := make(map[string]bool)
+	add := func(element ext.Extension) {
+		url := strings.TrimSpace(element.Attrs["url"])
+		if url == "" || seen[url] {
+			return
+		}
+		seen[url] = true
+		thumbnails = append(thumbnails, Thumbnail{
+			URL:    url,
+			Width:  parseDimension(element.Attrs["width"]),
+			Height: parseDimension(element.Attrs["height"]),
+		})
+	}
+	collect := func(elements map[string][]ext.Extension) {
+		for _, element := range elements["thumbnail"] {
+			add(element)
+		}
+		for _, content := range elements["content"] {
+			if isImageContent(content) {
+				add(content)
+			}
+			for _, element := range content.Children["thumbnail"] {
+				add(element)
+			}
+		}
+	}
+	collect(media)
+	for _, group := range media["group"] {
+		collect(group.Children)
+	}
+	return thumbnails
+}
+
+// SelectThumbnail picks the thumbnail closest to target pixels: the smallest
+// at least target wide, otherwise the largest smaller one, so clients get an
+// image they can scale down rather than up whenever the feed has one. Sizes
+// are compared by width, or by height when a thumbnail declares only that.
+// Thumbnails without dimensions are chosen only when none declares any, and a
+// target of zero or less picks the largest. It returns false when there are
+// no thumbnails.
+func SelectThumbnail(thumbnails []Thumbnail, target int) (Thumbnail, bool) {
+	var larger, smaller *Thumbnail
+	for i := range thumbnails {
+		candidate := &thumbnails[i]
+		size := candidate.size()
+		switch {
+		case size <= 0:
+		case size >= target && target > 0:
+			if larger == nil || size < larger.size() {
+				larger = candidate
+			}
+		default:
+			if smaller == nil || size > smaller.size() {
+				smaller = candidate
+			}
+		}
+	}
+	switch {
+	case larger != nil:
+		return *larger, true
+	case smaller != nil:
+		return *smaller, true
+	case len(thumbnails) > 0:
+		return thumbnails[0], true
+	}
+	return Thumbnail{}, false
+}
+
+// isImageContent reports whether a media:content element is an image.
+func isImageContent(content ext.Extension) bool {
+	if strings.EqualFold(strings.TrimSpace(content.Attrs["medium"]), "image") {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(content.Attrs["type"])), "image/")
+}
+
+// parseDimension parses a pixel dimension, returning 0 when it is missing or
+// invalid.
+func parseDimension(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestItemThumbnails(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+  <title>Gallery</title>
+  <item>
+    <title>Several sizes</title>
+    <media:thumbnail url="https://example.com/small.jpg" width="120" height="90"/>
+    <media:group>
+      <media:content url="https://example.com/video.mp4" type="video/mp4">
+        <media:thumbnail url="https://example.com/medium.jpg" width="480" height="360"/>
+      </media:content>
+      <media:content url="https://example.com/large.jpg" medium="image" width="1280" height="960"/>
+      <media:content url="https://example.com/tall.png" type="image/png" height="640"/>
+    </media:group>
+    <media:thumbnail url="https://example.com/small.jpg" width="120" height="90"/>
+  </item>
+  <item>
+    <title>No media</title>
+  </item>
+</channel>
+</rss>`)
+	if err != nil {
+		t.Fatalf("parse feed: %v", err)
+	}
+
+	want := []Thumbnail{
+		{URL: "https://example.com/small.jpg", Width: 120, Height: 90},
+		{URL: "https://example.com/medium.jpg", Width: 480, Height: 360},
+		{URL: "https://example.com/large.jpg", Width: 1280, Height: 960},
+		{URL: "https://example.com/tall.png", Height: 640},
+	}
+	if got := ItemThumbnails(feed.Items[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("ItemThumbnails = %+v, want %+v", got, want)
+	}
+	if got := ItemThumbnails(feed.Items[1]); got != nil {
+		t.Errorf("ItemThumbnails without media = %+v, want nil", got)
+	}
+}
+
+func TestSelectThumbnail(t *testing.T) {
+	thumbnails := []Thumbnail{
+		{URL: "medium", Width: 480},
+		{URL: "small", Width: 120},
+		{URL: "tall", Height: 640},
+		{URL: "large", Width: 1280},
+		{URL: "unsized"},
+	}
+
+	tests := []struct {
+		target int
+		want   string
+	}{
+		{target: 100, want: "small"},  // smallest larger than the target
+		{target: 120, want: "small"},  // an exact match
+		{target: 300, want: "medium"}, // smallest larger than the target
+		{target: 600, want: "tall"},   // height counts when there's no width
+		{target: 1000, want: "large"}, // smallest larger than the target
+		{target: 2000, want: "large"}, // largest smaller than the target
+		{target: 0, want: "large"},    // no target: the largest
+	}
+	for _, tt := range tests {
+		got, ok := SelectThumbnail(thumbnails, tt.target)
+		if !ok || got.URL != tt.want {
+			t.Errorf("SelectThumbnail(%d) = %+v, %v, want %s", tt.target, got, ok, tt.want)
+		}
+	}
+
+	if got, ok := SelectThumbnail([]Thumbnail{{URL: "a"}, {URL: "b"}}, 300); !ok || got.URL != "a" {
+		t.Errorf("SelectThumbnail without dimensions = %+v, %v, want the first", got, ok)
+	}
+	if _, ok := SelectThumbnail(nil, 300); ok {
+		t.Error("SelectThumbnail(nil) reported a thumbnail")
+	}
+}
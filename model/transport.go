@@ -2,6 +2,8 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // ErrInvalidTransport is returned when an invalid transport type is specified.
@@ -27,6 +29,15 @@ const (
 	StreamableHTTPTransport // Streamable HTTP transport per MCP spec
 )
 
+// DefaultTransport is the transport used when none is specified.
+const DefaultTransport = StdioTransport
+
+// TransportNames returns the transport names accepted by ParseTransport, in the
+// order they are presented to users.
+func TransportNames() []string {
+	return []string{transportNameStdio, transportNameHTTPWithSSE, transportNameStreamableHTTP}
+}
+
 // ParseTransport converts a string to a Transport type
 func ParseTransport(transport string) (Transport, error) {
 	switch transport {
@@ -38,7 +49,8 @@ func ParseTransport(transport string) (Transport, error) {
 	case transportNameStreamableHTTP:
 		return StreamableHTTPTransport, nil
 	default:
-		return UndefinedTransport, ErrInvalidTransport
+		return UndefinedTransport, fmt.Errorf("%w %q: valid options are %s",
+			ErrInvalidTransport, transport, strings.Join(TransportNames(), ", "))
 	}
 }
 
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lowQuotaThreshold is the remaining-request count at or below which requests
+// to a host are paced out until its quota resets.
+const lowQuotaThreshold = 10
+
+// quotaRetention is how long a host's quota is kept after its reset time, so
+// the metrics still show it while bounding memory under host churn.
+const quotaRetention = time.Hour
+
+// assumedQuotaWindow is the reset window assumed for a host that reports a
+// low remaining quota without saying when it resets, so it is still paced.
+const assumedQuotaWindow = time.Minute
+
+// epochResetCutoff separates X-RateLimit-Reset values given as a Unix
+// timestamp (e.g. GitHub) from ones given as seconds until reset.
+const epochResetCutoff = 1_000_000_000
+
+// RateLimitQuota is the most recent request quota a host reported through
+// rate-limit response headers, plus how often fetches to it were slowed.
+type RateLimitQuota struct {
+	Reset             time.Time     // When the quota resets
+	ResetAssumed      bool          // Reset is now+assumedQuotaWindow: the host reported none
+	ObservedAt        time.Time     // When the headers were last seen
+	Host              string        // Host the quota applies to
+	Limit             int           // Quota size, or -1 if the server didn't say
+	Remaining         int           // Requests left in the current window
+	ThrottledRequests int64         // Requests delayed because the quota was low
+	TotalDelay        time.Duration // Total time those requests were delayed
+}
+
+// adaptiveThrottle is an http.RoundTripper that reads X-RateLimit-* (and the
+// unprefixed RateLimit-*) response headers and, when a host reports a low
+// remaining quota, spaces out further requests to it so the quota lasts until
+// the reset instead of running into 429s. It complements the fixed per-host
+// limiter in hostrate, which cannot be adjusted from outside.
+type adaptiveThrottle struct {
+	base   http.RoundTripper
+	now    func() time.Time
+	mu     sync.Mutex
+	quotas map[string]*RateLimitQuota
+}
+
+// newAdaptiveThrottle wraps base with header-driven throttling.
+func newAdaptiveThrottle(base http.RoundTripper) *adaptiveThrottle {
+	return &adaptiveThrottle{
+		base:   base,
+		now:    time.Now,
+		quotas: make(map[string]*RateLimitQuota),
+	}
+}
+
+// RoundTrip waits out any delay the host's last reported quota calls for, then
+// sends the request and records the quota from the response.
+func (a *adaptiveThrottle) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Hostname())
+
+	if delay := a.delayFor(host); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := a.base.RoundTrip(req)
+	if err == nil {
+		a.observe(host, resp.Header)
+	}
+	return resp, err
+}
+
+// delayFor returns how long to hold a request to host. With no quota left it
+// waits for the reset; with a low quota it spreads the remaining requests
+// evenly over the time left in the window.
+func (a *adaptiveThrottle) delayFor(host string) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	quota := a.quotas[host]
+	if quota == nil || quota.Remaining > lowQuotaThreshold {
+		return 0
+	}
+	window := quota.Reset.Sub(a.now())
+	if window <= 0 {
+		return 0
+	}
+	delay := window / time.Duration(quota.Remaining+1)
+	if quota.Remaining > 0 {
+		// Assume this request consumes one, so concurrent callers queue up
+		// behind each other rather than all taking the same slot.
+		quota.Remaining--
+	}
+	quota.ThrottledRequests++
+	quota.TotalDelay += delay
+	return delay
+}
+
+// observe records the quota reported in the response headers, if any.
+func (a *adaptiveThrottle) observe(host string, header http.Header) {
+	remaining, ok := headerInt(header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok {
+		return
+	}
+	now := a.now()
+	limit, ok := headerInt(header, "X-RateLimit-Limit", "RateLimit-Limit")
+	if !ok {
+		limit = -1
+	}
+	var reset time.Time
+	resetAssumed := false
+	if v, ok := headerInt(header, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+		if v >= epochResetCutoff {
+			reset = time.Unix(int64(v), 0)
+		} else {
+			reset = now.Add(time.Duration(v) * time.Second)
+		}
+	} else if remaining <= lowQuotaThreshold {
+		// Without a reset time a low quota would otherwise be ignored; pace
+		// requests over a default window instead.
+		reset = now.Add(assumedQuotaWindow)
+		resetAssumed = true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	quota := a.quotas[host]
+	if quota == nil {
+		quota = &RateLimitQuota{Host: host}
+		a.quotas[host] = quota
+	}
+	quota.Limit = limit
+	quota.Remaining = remaining
+	quota.Reset = reset
+	quota.ResetAssumed = resetAssumed
+	quota.ObservedAt = now
+
+	for h, q := range a.quotas {
+		if now.Sub(q.Reset) > quotaRetention && now.Sub(q.ObservedAt) > quotaRetention {
+			delete(a.quotas, h)
+		}
+	}
+}
+
+// snapshot returns a copy of the observed quotas, sorted by host.
+func (a *adaptiveThrottle) snapshot() []RateLimitQuota {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	quotas := make([]RateLimitQuota, 0, len(a.quotas))
+	for _, q := range a.quotas {
+		quotas = append(quotas, *q)
+	}
+	slices.SortFunc(quotas, func(x, y RateLimitQuota) int { return strings.Compare(x.Host, y.Host) })
+	return quotas
+}
+
+// headerInt returns the first of the named headers that holds a non-negative
+// integer.
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if v, err := strconv.Atoi(strings.TrimSpace(header.Get(name))); err == nil && v >= 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// GetRateLimitQuotas returns the request quotas hosts have reported through
+// rate-limit headers, and how often fetches to each were slowed to stay within
+// them. It is empty when the store uses a caller-supplied HTTP client.
+func (s *Store) GetRateLimitQuotas() []RateLimitQuota {
+	if s.throttle == nil {
+		return nil
+	}
+	return s.throttle.snapshot()
+}
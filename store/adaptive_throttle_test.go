@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAdaptiveThrottle_BacksOffAsQuotaRunsOut serves a quota that drops by one
+// per request and resets one second later, and checks that requests are held
+// back more as the remaining count approaches zero.
+func TestAdaptiveThrottle_BacksOffAsQuotaRunsOut(t *testing.T) {
+	var served int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&served, 1)
+		remaining := max(0, 2-int(n))
+		w.Header().Set("X-RateLimit-Limit", "3")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", "1")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, RequestsPerSecond: 1000, BurstCapacity: 1000})
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	get := func() time.Duration {
+		t.Helper()
+		start := time.Now()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		_ = resp.Body.Close()
+		return time.Since(start)
+	}
+
+	if d := get(); d > 200*time.Millisecond { // no quota known yet
+		t.Errorf("first request took %v, want no throttling", d)
+	}
+	if d := get(); d < 300*time.Millisecond { // 1 left, ~1s to reset: ~500ms spacing
+		t.Errorf("request with 1 remaining took %v, want it paced (~500ms)", d)
+	}
+	if d := get(); d < 700*time.Millisecond { // none left: wait for the reset
+		t.Errorf("request with 0 remaining took %v, want it held until reset (~1s)", d)
+	}
+
+	quotas := s.GetRateLimitQuotas()
+	if len(quotas) != 1 {
+		t.Fatalf("expected 1 observed quota, got %d", len(quotas))
+	}
+	q := quotas[0]
+	if q.Host != "127.0.0.1" || q.Limit != 3 || q.Remaining != 0 {
+		t.Errorf("unexpected quota: %+v", q)
+	}
+	if q.ThrottledRequests != 2 || q.TotalDelay <= 0 {
+		t.Errorf("expected 2 throttled requests with a delay, got %d / %v", q.ThrottledRequests, q.TotalDelay)
+	}
+}
+
+// TestAdaptiveThrottle_AmpleQuotaNotThrottled checks that hosts with plenty of
+// quota, or with no rate-limit headers, are never delayed.
+func TestAdaptiveThrottle_AmpleQuotaNotThrottled(t *testing.T) {
+	var base http.RoundTripper = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if req.URL.Host == "ample.example" {
+			header.Set("X-RateLimit-Remaining", "4999")
+			header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody, Request: req}, nil
+	})
+	throttle := newAdaptiveThrottle(base)
+
+	for _, url := range []string{"http://ample.example/feed", "http://plain.example/feed"} {
+		for range 3 {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+			if _, err := throttle.RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+		}
+	}
+
+	quotas := throttle.snapshot()
+	if len(quotas) != 1 || quotas[0].Host != "ample.example" {
+		t.Fatalf("expected a quota only for ample.example, got %+v", quotas)
+	}
+	if quotas[0].ThrottledRequests != 0 || quotas[0].Limit != -1 {
+		t.Errorf("unexpected quota: %+v", quotas[0])
+	}
+	if time.Until(quotas[0].Reset) < 59*time.Minute {
+		t.Errorf("epoch reset not parsed: %v", quotas[0].Reset)
+	}
+}
+
+// TestAdaptiveThrottle_ContextCanceledWhileWaiting checks that a held request
+// gives up when its context ends.
+func TestAdaptiveThrottle_ContextCanceledWhileWaiting(t *testing.T) {
+	throttle := newAdaptiveThrottle(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("RateLimit-Remaining", "0")
+		header.Set("RateLimit-Reset", "60")
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody, Request: req}, nil
+	}))
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://limited.example/", http.NoBody)
+	if _, err := throttle.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "http://limited.example/", http.NoBody)
+	start := time.Now()
+	if _, err := throttle.RoundTrip(req); err == nil {
+		t.Fatal("expected context error while waiting for quota reset")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("canceled request waited %v", d)
+	}
+}
+
+// TestAdaptiveThrottle_LowQuotaWithoutReset checks that a low remaining quota
+// with no reset header is paced over assumedQuotaWindow rather than ignored.
+func TestAdaptiveThrottle_LowQuotaWithoutReset(t *testing.T) {
+	throttle := newAdaptiveThrottle(nil)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	throttle.now = func() time.Time { return now }
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "1")
+	throttle.observe("limited.example", header)
+
+	if delay := throttle.delayFor("limited.example"); delay != assumedQuotaWindow/2 {
+		t.Errorf("delay with 1 remaining = %v, want %v", delay, assumedQuotaWindow/2)
+	}
+	quotas := throttle.snapshot()
+	if len(quotas) != 1 || !quotas[0].ResetAssumed || !quotas[0].Reset.Equal(now.Add(assumedQuotaWindow)) {
+		t.Errorf("quota = %+v, want an assumed reset %v from now", quotas, assumedQuotaWindow)
+	}
+
+	// A healthy quota without a reset header still isn't throttled.
+	header.Set("X-RateLimit-Remaining", "500")
+	throttle.observe("ample.example", header)
+	if delay := throttle.delayFor("ample.example"); delay != 0 {
+		t.Errorf("delay with ample quota = %v, want 0", delay)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
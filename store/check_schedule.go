@@ -0,0 +1,95 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// checkSchedule spaces out fetches of unhealthy feeds. After a feed's Nth
+// consecutive failed fetch it isn't checked again until intervals[N-1] has
+// passed (the last interval repeats once failures exceed the list); until then
+// loads fail fast with the last error instead of hitting the network. A
+// successful fetch resets the feed to the normal schedule.
+//
+// This is backoff between checks of a feed, complementing the per-request
+// retries in retryableFeedFetch and the circuit breaker's fixed open timeout.
+type checkSchedule struct {
+	intervals []time.Duration
+	now       func() time.Time
+	feeds     map[string]*feedCheckState // by feed URL; healthy feeds are absent
+	mu        sync.Mutex
+}
+
+// feedCheckState tracks an unhealthy feed's failures and next allowed check.
+type feedCheckState struct {
+	failures  int
+	lastErr   error
+	nextCheck time.Time
+}
+
+func newCheckSchedule(intervals []time.Duration) *checkSchedule {
+	return &checkSchedule{
+		intervals: intervals,
+		now:       time.Now,
+		feeds:     make(map[string]*feedCheckState),
+	}
+}
+
+// interval returns the wait before the next check after the given number of
+// consecutive failures, or zero for a healthy feed.
+func (cs *checkSchedule) interval(failures int) time.Duration {
+	if failures <= 0 || len(cs.intervals) == 0 {
+		return 0
+	}
+	return cs.intervals[min(failures, len(cs.intervals))-1]
+}
+
+// due returns nil when the feed may be fetched now, or an error wrapping the
+// last failure when it is still waiting out its check interval.
+func (cs *checkSchedule) due(feedURL string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	state := cs.feeds[feedURL]
+	if state == nil {
+		return nil
+	}
+	wait := state.nextCheck.Sub(cs.now())
+	if wait <= 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("feed is unhealthy after %d consecutive failures; next check in %s", state.failures, wait.Round(time.Second))
+	return model.NewFeedErrorWithCause(model.ErrorTypeResourceUnavailable, msg, state.lastErr).
+		WithURL(feedURL).
+		WithOperation("fetch_feed").
+		WithComponent("check_schedule")
+}
+
+// recordFailure counts a failed fetch and pushes the feed's next check out by
+// the escalated interval.
+func (cs *checkSchedule) recordFailure(feedURL string, err error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	state := cs.feeds[feedURL]
+	if state == nil {
+		state = &feedCheckState{}
+		cs.feeds[feedURL] = state
+	}
+	state.failures++
+	state.lastErr = err
+	state.nextCheck = cs.now().Add(cs.interval(state.failures))
+}
+
+// recordSuccess returns a feed to the normal schedule.
+func (cs *checkSchedule) recordSuccess(feedURL string) {
+	cs.remove(feedURL)
+}
+
+// remove forgets a feed's failure history.
+func (cs *checkSchedule) remove(feedURL string) {
+	cs.mu.Lock()
+	delete(cs.feeds, feedURL)
+	cs.mu.Unlock()
+}
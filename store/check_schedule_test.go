@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestCheckSchedule_Interval(t *testing.T) {
+	cs := newCheckSchedule([]time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute})
+	for failures, want := range []time.Duration{0, time.Minute, 5 * time.Minute, 30 * time.Minute, 30 * time.Minute, 30 * time.Minute} {
+		if got := cs.interval(failures); got != want {
+			t.Errorf("interval(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+func TestStore_FailedFeedBackoff(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Back</title><item><title>Post</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	disabled := false
+	s, err := NewStore(&Config{
+		Feeds:                 []string{srv.URL},
+		AllowPrivateIPs:       true,
+		RetryMaxAttempts:      1,
+		CircuitBreakerEnabled: &disabled,
+		FailedFeedBackoff:     []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.checkSchedule.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	// check fetches the feed and reports whether it reached the network.
+	check := func() (bool, error) {
+		before := requests.Load()
+		_, err := s.feedCacheManager.Get(ctx, srv.URL)
+		return requests.Load() > before, err
+	}
+
+	for _, wantInterval := range []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 30 * time.Minute} {
+		if fetched, err := check(); err == nil || !fetched {
+			t.Fatalf("expected a failed fetch, got err=%v fetched=%v", err, fetched)
+		}
+
+		// Until the interval passes, loads fail fast without a request.
+		clock = clock.Add(wantInterval - time.Second)
+		fetched, err := check()
+		if fetched {
+			t.Fatalf("feed re-checked %s into a %s interval", wantInterval-time.Second, wantInterval)
+		}
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeResourceUnavailable {
+			t.Fatalf("expected resource_unavailable FeedError while backing off, got %v", err)
+		}
+		if feedErr.Unwrap() == nil {
+			t.Error("backoff error should wrap the last fetch failure")
+		}
+		clock = clock.Add(time.Second)
+	}
+
+	// Recovery resets the schedule: the next failure waits the first interval.
+	healthy.Store(true)
+	if fetched, err := check(); err != nil || !fetched {
+		t.Fatalf("expected recovery fetch to succeed, got err=%v fetched=%v", err, fetched)
+	}
+	s.checkSchedule.recordFailure(srv.URL, errors.New("boom"))
+	clock = clock.Add(time.Minute)
+	if err := s.checkSchedule.due(srv.URL); err != nil {
+		t.Errorf("after recovery a single failure should back off one minute, got %v", err)
+	}
+}
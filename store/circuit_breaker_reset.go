@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// ResetCircuitBreakers implements mcpserver.CircuitBreakerResetter. gobreaker
+// has no way to close a breaker early, so each breaker is replaced with a new
+// one built from the same settings; fetches already running finish against
+// the old breaker. The feed's failed-feed backoff (see checkSchedule) is
+// cleared too, so the next fetch reaches the network rather than failing fast
+// with the old error. An empty feedID resets every feed's breaker.
+func (s *Store) ResetCircuitBreakers(_ context.Context, feedID string) ([]mcpserver.CircuitBreakerReset, error) {
+	if s.newBreaker == nil {
+		return nil, model.NewFeedError(model.ErrorTypeConfiguration, "circuit breakers are disabled").
+			WithOperation("reset_circuit_breaker").
+			WithComponent("feed_store")
+	}
+
+	s.feedsMu.Lock()
+	defer s.feedsMu.Unlock()
+
+	urls := make([]string, 0, len(s.circuitBreakers))
+	if feedID != "" {
+		url, ok := s.feeds[feedID]
+		if !ok {
+			return nil, model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", feedID)).
+				WithOperation("reset_circuit_breaker").
+				WithComponent("feed_store")
+		}
+		urls = append(urls, url)
+	} else {
+		for url := range s.circuitBreakers {
+			urls = append(urls, url)
+		}
+		slices.SortFunc(urls, strings.Compare)
+	}
+
+	resets := make([]mcpserver.CircuitBreakerReset, 0, len(urls))
+	for _, url := range urls {
+		previous := "closed"
+		if cb, ok := s.circuitBreakers[url]; ok {
+			previous = cb.State().String()
+		}
+		cb := s.newBreaker(url)
+		s.circuitBreakers[url] = cb
+		if s.checkSchedule != nil {
+			s.checkSchedule.remove(url)
+		}
+		resets = append(resets, mcpserver.CircuitBreakerReset{
+			FeedID:        s.feedIDLocked(url),
+			URL:           url,
+			PreviousState: previous,
+			State:         cb.State().String(),
+		})
+	}
+	return resets, nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_ResetCircuitBreakers(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Recovered</title><item><title>Back</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+	other := "https://example.com/other.xml"
+
+	enabled := true
+	s, err := NewStore(&Config{
+		Feeds:                          []string{srv.URL, other},
+		AllowPrivateIPs:                true,
+		CircuitBreakerEnabled:          &enabled,
+		CircuitBreakerFailureThreshold: 2,
+		RetryMaxAttempts:               1,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+
+	// Two failures open the breaker; the third fetch is short-circuited.
+	for range 2 {
+		if _, err := s.feedCacheManager.Get(ctx, srv.URL); err == nil {
+			t.Fatal("fetch of a failing feed succeeded")
+		}
+	}
+	failing.Store(false)
+	_, err = s.feedCacheManager.Get(ctx, srv.URL)
+	var fe *model.FeedError
+	if !errors.As(err, &fe) || fe.ErrorType != model.ErrorTypeCircuitBreaker || requests.Load() != 2 {
+		t.Fatalf("fetch with an open breaker = %v after %d requests, want a circuit breaker error after 2", err, requests.Load())
+	}
+
+	resets, err := s.ResetCircuitBreakers(ctx, feedID)
+	if err != nil {
+		t.Fatalf("ResetCircuitBreakers: %v", err)
+	}
+	if len(resets) != 1 || resets[0].FeedID != feedID || resets[0].URL != srv.URL ||
+		resets[0].PreviousState != "open" || resets[0].State != "closed" {
+		t.Errorf("resets = %+v, want %s open -> closed", resets, feedID)
+	}
+
+	// The next fetch reaches the recovered server.
+	result, err := s.GetFeedAndItems(ctx, feedID)
+	if err != nil {
+		t.Fatalf("fetch after reset: %v", err)
+	}
+	if result.FetchError != "" || result.CircuitBreakerOpen || result.Title != "Recovered" || requests.Load() != 3 {
+		t.Errorf("fetch after reset = %+v after %d requests, want Recovered after 3", result, requests.Load())
+	}
+
+	// An empty ID resets every feed, in URL order.
+	resets, err = s.ResetCircuitBreakers(ctx, "")
+	if err != nil {
+		t.Fatalf("ResetCircuitBreakers(all): %v", err)
+	}
+	if len(resets) != 2 || resets[0].URL != srv.URL || resets[1].URL != other {
+		t.Errorf("reset all = %+v, want both feeds", resets)
+	}
+
+	if _, err := s.ResetCircuitBreakers(ctx, "missing"); !errors.As(err, &fe) || fe.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("reset of an unknown feed = %v, want a validation error", err)
+	}
+}
+
+// TestStore_ResetCircuitBreakersClearsBackoff verifies that a reset also ends
+// the failed-feed backoff, which would otherwise keep failing fast.
+func TestStore_ResetCircuitBreakersClearsBackoff(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Recovered</title><item><title>Back</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	enabled := true
+	s, err := NewStore(&Config{
+		Feeds:                          []string{srv.URL},
+		AllowPrivateIPs:                true,
+		CircuitBreakerEnabled:          &enabled,
+		CircuitBreakerFailureThreshold: 1,
+		RetryMaxAttempts:               1,
+		FailedFeedBackoff:              []time.Duration{time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+
+	// One failure opens the breaker and starts an hour of backoff.
+	if _, err := s.feedCacheManager.Get(ctx, srv.URL); err == nil {
+		t.Fatal("fetch of a failing feed succeeded")
+	}
+	failing.Store(false)
+	if _, err := s.feedCacheManager.Get(ctx, srv.URL); err == nil || requests.Load() != 1 {
+		t.Fatalf("fetch during backoff = %v after %d requests, want a fast failure after 1", err, requests.Load())
+	}
+
+	if _, err := s.ResetCircuitBreakers(ctx, feedID); err != nil {
+		t.Fatalf("ResetCircuitBreakers: %v", err)
+	}
+	result, err := s.GetFeedAndItems(ctx, feedID)
+	if err != nil {
+		t.Fatalf("fetch after reset: %v", err)
+	}
+	if result.FetchError != "" || result.Title != "Recovered" || requests.Load() != 2 {
+		t.Errorf("fetch after reset = %+v after %d requests, want Recovered after 2", result, requests.Load())
+	}
+}
+
+func TestStore_ResetCircuitBreakersDisabled(t *testing.T) {
+	disabled := false
+	s, err := NewStore(&Config{Feeds: []string{"https://example.com/feed.xml"}, CircuitBreakerEnabled: &disabled})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	var fe *model.FeedError
+	if _, err := s.ResetCircuitBreakers(context.Background(), ""); !errors.As(err, &fe) || fe.ErrorType != model.ErrorTypeConfiguration {
+		t.Errorf("reset with breakers disabled = %v, want a configuration error", err)
+	}
+}
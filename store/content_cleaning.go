@@ -0,0 +1,149 @@
+package store
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// Prefixes of Config.ContentCleaning rules.
+const (
+	cleaningRuleCSS   = "css:"
+	cleaningRuleRegex = "regex:"
+)
+
+// contentCleaner strips junk (ad blocks, "read more" footers) from one feed's
+// item content and descriptions. Rules apply in the order configured.
+type contentCleaner struct {
+	rules []cleaningRule
+}
+
+// cleaningRule is one compiled rule: either a selector whose matching elements
+// are removed, or a pattern whose matches are deleted from the HTML.
+type cleaningRule struct {
+	selector cascadia.Selector
+	pattern  *regexp.Regexp
+}
+
+// ValidateContentCleaningRule reports whether rule is one that
+// Config.ContentCleaning accepts.
+func ValidateContentCleaningRule(rule string) error {
+	_, err := parseCleaningRule(rule, "")
+	return err
+}
+
+// newContentCleaners compiles the configured rules by feed URL, returning nil
+// when none are set.
+func newContentCleaners(config *Config) (map[string]*contentCleaner, error) {
+	if len(config.ContentCleaning) == 0 {
+		return nil, nil
+	}
+	cleaners := make(map[string]*contentCleaner, len(config.ContentCleaning))
+	for feedURL, rules := range config.ContentCleaning {
+		cleaner := &contentCleaner{rules: make([]cleaningRule, 0, len(rules))}
+		for _, rule := range rules {
+			compiled, err := parseCleaningRule(rule, feedURL)
+			if err != nil {
+				return nil, err
+			}
+			cleaner.rules = append(cleaner.rules, compiled)
+		}
+		cleaners[feedURL] = cleaner
+	}
+	return cleaners, nil
+}
+
+// parseCleaningRule compiles a "css:SELECTOR" or "regex:PATTERN" rule.
+// feedURL names the feed the rule belongs to, for errors.
+func parseCleaningRule(rule, feedURL string) (cleaningRule, error) {
+	invalid := func(reason string, cause error) error {
+		return model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, fmt.Sprintf("invalid content cleaning rule %q: %s", rule, reason), cause).
+			WithURL(feedURL).
+			WithOperation("create_store").
+			WithComponent("content_cleaner")
+	}
+	switch {
+	case strings.HasPrefix(rule, cleaningRuleCSS):
+		selector, err := cascadia.Compile(strings.TrimSpace(strings.TrimPrefix(rule, cleaningRuleCSS)))
+		if err != nil {
+			return cleaningRule{}, invalid("bad CSS selector", err)
+		}
+		return cleaningRule{selector: selector}, nil
+	case strings.HasPrefix(rule, cleaningRuleRegex):
+		pattern := strings.TrimPrefix(rule, cleaningRuleRegex)
+		if pattern == "" {
+			return cleaningRule{}, invalid("empty pattern", nil)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return cleaningRule{}, invalid("bad regular expression", err)
+		}
+		return cleaningRule{pattern: re}, nil
+	default:
+		return cleaningRule{}, invalid("must start with css: or regex:", nil)
+	}
+}
+
+// apply cleans every item's content and description.
+func (c *contentCleaner) apply(items []*gofeed.Item) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		item.Content = c.clean(item.Content)
+		item.Description = c.clean(item.Description)
+	}
+}
+
+// clean applies the rules to one HTML fragment.
+func (c *contentCleaner) clean(fragment string) string {
+	if fragment == "" {
+		return fragment
+	}
+	for _, rule := range c.rules {
+		if rule.pattern != nil {
+			fragment = rule.pattern.ReplaceAllString(fragment, "")
+		} else {
+			fragment = removeMatchingElements(fragment, rule.selector)
+		}
+	}
+	return fragment
+}
+
+// removeMatchingElements removes the elements selector matches, with their
+// contents, from an HTML fragment. A fragment with no matches is returned
+// unchanged rather than re-serialized.
+func removeMatchingElements(fragment string, selector cascadia.Selector) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return fragment
+	}
+	root := &html.Node{Type: html.DocumentNode}
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+	matches := selector.MatchAll(root)
+	if len(matches) == 0 {
+		return fragment
+	}
+	for _, match := range matches {
+		if match.Parent != nil {
+			match.Parent.RemoveChild(match)
+		}
+	}
+	var out strings.Builder
+	for node := root.FirstChild; node != nil; node = node.NextSibling {
+		if err := html.Render(&out, node); err != nil {
+			return fragment
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+const junkFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Junk</title>
+<item>
+  <title>Story</title>
+  <link>https://example.com/story</link>
+  <description><![CDATA[<p>The summary.</p><p class="read-more">Read more at Example</p>]]></description>
+  <content:encoded xmlns:content="http://purl.org/rss/1.0/modules/content/"><![CDATA[<p>The story.</p><div class="ad"><p>Buy things!</p></div><p>The end.</p>]]></content:encoded>
+</item>
+</channel></rss>`
+
+func TestStore_ContentCleaning(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(junkFeed))
+	}))
+	defer srv.Close()
+	cleanedURL, plainURL := srv.URL+"/cleaned", srv.URL+"/plain"
+
+	s, err := NewStore(&Config{
+		Feeds:           []string{cleanedURL, plainURL},
+		AllowPrivateIPs: true,
+		ContentCleaning: map[string][]string{
+			cleanedURL: {"css:div.ad", `regex:<p class="read-more">.*?</p>`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := s.GetFeedAndItems(ctx, model.GenerateFeedID(cleanedURL))
+	if err != nil || len(result.Items) != 1 {
+		t.Fatalf("GetFeedAndItems = %+v, %v", result, err)
+	}
+	item := result.Items[0]
+	if item.Content != "<p>The story.</p><p>The end.</p>" {
+		t.Errorf("Content = %q, want the ad block removed", item.Content)
+	}
+	if item.Description != "<p>The summary.</p>" {
+		t.Errorf("Description = %q, want the footer removed", item.Description)
+	}
+
+	// Rules only apply to the feed they are configured for.
+	result, err = s.GetFeedAndItems(ctx, model.GenerateFeedID(plainURL))
+	if err != nil || len(result.Items) != 1 {
+		t.Fatalf("GetFeedAndItems = %+v, %v", result, err)
+	}
+	if !strings.Contains(result.Items[0].Content, "Buy things!") {
+		t.Errorf("uncleaned feed Content = %q, want it unchanged", result.Items[0].Content)
+	}
+}
+
+func TestNewStore_InvalidContentCleaningRule(t *testing.T) {
+	for _, rule := range []string{"div.ad", "css:div[", "regex:(", "regex:"} {
+		_, err := NewStore(&Config{
+			Feeds:           []string{"https://example.com/feed"},
+			ContentCleaning: map[string][]string{"https://example.com/feed": {rule}},
+		})
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeConfiguration {
+			t.Errorf("rule %q: NewStore error = %v, want a configuration error", rule, err)
+		}
+	}
+}
+
+func TestContentCleaner_Clean(t *testing.T) {
+	cleaner, err := newContentCleaners(&Config{ContentCleaning: map[string][]string{
+		"feed": {"css:.ad, aside", "regex:(?i)\\s*Sponsored\\.?"},
+	}})
+	if err != nil {
+		t.Fatalf("newContentCleaners: %v", err)
+	}
+	tests := []struct{ in, want string }{
+		{`<p>Text</p><aside>Related</aside><span class="ad">x</span>`, `<p>Text</p>`},
+		{`<div><div class="ad"><p>nested</p></div><p>kept</p></div>`, `<div><p>kept</p></div>`},
+		{`<p>Nothing to strip &amp; no re-rendering</p >`, `<p>Nothing to strip &amp; no re-rendering</p >`},
+		{`<p>Great news. SPONSORED.</p>`, `<p>Great news.</p>`},
+		{``, ``},
+	}
+	for _, tt := range tests {
+		if got := cleaner["feed"].clean(tt.in); got != tt.want {
+			t.Errorf("clean(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// DefaultAcceptedContentTypes is the usual value for
+// Config.AcceptedContentTypes: the RSS, Atom, and JSON Feed media types, plus
+// the generic XML and JSON types many feeds are served as.
+var DefaultAcceptedContentTypes = []string{
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/rdf+xml",
+	"application/feed+json",
+	"application/json",
+	"application/xml",
+	"text/xml",
+}
+
+// checkContentType rejects a response whose Content-Type isn't one of
+// accepted, before its body is read. Media types are compared
+// case-insensitively and without parameters, so "text/xml; charset=utf-8"
+// matches "text/xml". An empty accepted list accepts everything.
+func checkContentType(feedURL, contentType string, accepted []string) error {
+	if len(accepted) == 0 {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && slices.ContainsFunc(accepted, func(t string) bool {
+		return strings.EqualFold(strings.TrimSpace(t), mediaType)
+	}) {
+		return nil
+	}
+	received := "no Content-Type"
+	if contentType != "" {
+		received = fmt.Sprintf("Content-Type %q", contentType)
+	}
+	return model.NewFeedError(model.ErrorTypeInvalidFormat,
+		fmt.Sprintf("response has %s, which is not an accepted feed type (accepted: %s)", received, strings.Join(accepted, ", "))).
+		WithURL(feedURL).
+		WithOperation("fetch_feed").
+		WithComponent("feed_fetcher")
+}
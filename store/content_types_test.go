@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_AcceptedContentTypes(t *testing.T) {
+	var htmlRequests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed":
+			w.Header().Set("Content-Type", "Application/RSS+XML; charset=utf-8")
+			_, _ = w.Write([]byte(selectionRSSBody))
+		case "/page":
+			// A feed body served as HTML is still rejected: the type is
+			// checked before anything is parsed.
+			htmlRequests.Add(1)
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(selectionRSSBody))
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{
+		Feeds:                []string{srv.URL + "/feed", srv.URL + "/page"},
+		AllowPrivateIPs:      true,
+		RetryMaxAttempts:     3,
+		RetryBaseDelay:       time.Millisecond,
+		AcceptedContentTypes: DefaultAcceptedContentTypes,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+"/feed"))
+	if err != nil || result.FetchError != "" || len(result.Items) != 1 {
+		t.Fatalf("accepted feed = %+v, %v; want it parsed", result, err)
+	}
+
+	result, err = s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+"/page"))
+	if err != nil || result.FetchError == "" {
+		t.Fatalf("text/html feed = %+v, %v; want a fetch error", result, err)
+	}
+	recent := s.errorLog.recent()
+	if len(recent) != 1 || recent[0].ErrorType != model.ErrorTypeInvalidFormat || !strings.Contains(recent[0].Cause, `"text/html; charset=utf-8"`) {
+		t.Errorf("recorded errors = %+v, want one content-type rejection", recent)
+	}
+	if got := htmlRequests.Load(); got != 1 {
+		t.Errorf("rejected feed requested %d times, want 1 (not retried)", got)
+	}
+}
+
+func TestCheckContentType(t *testing.T) {
+	accepted := []string{"application/rss+xml", "text/xml"}
+	tests := []struct {
+		contentType string
+		accepted    []string
+		wantErr     bool
+	}{
+		{"application/rss+xml", accepted, false},
+		{"TEXT/XML; charset=ISO-8859-1", accepted, false},
+		{"text/html", accepted, true},
+		{"", accepted, true},
+		{"not a media type", accepted, true},
+		{"text/html", nil, false},
+	}
+	for _, tt := range tests {
+		err := checkContentType("https://example.com/feed", tt.contentType, tt.accepted)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkContentType(%q, %v) = %v, want error %v", tt.contentType, tt.accepted, err, tt.wantErr)
+		}
+	}
+}
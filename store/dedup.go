@@ -0,0 +1,29 @@
+package store
+
+import (
+	"strconv"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// DuplicatesRemovedMetadataKey is the gofeed.Feed.Custom key holding how many
+// repeated items were dropped from the feed when it was fetched. It is absent
+// when the feed had no duplicates.
+const DuplicatesRemovedMetadataKey = "feed_mcp_duplicates_removed"
+
+// deduplicateFeedItems drops items the feed repeats within one response,
+// matched by stable ID, and records the number removed under
+// DuplicatesRemovedMetadataKey. Stable IDs must already be assigned.
+func deduplicateFeedItems(feed *gofeed.Feed) {
+	unique, removed := model.DeduplicateItems(feed.Items)
+	if removed == 0 {
+		return
+	}
+	feed.Items = unique
+	if feed.Custom == nil {
+		feed.Custom = make(map[string]string, 1)
+	}
+	feed.Custom[DuplicatesRemovedMetadataKey] = strconv.Itoa(removed)
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_DeduplicateWithinFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Repeats</title><link>https://example.com/</link>` +
+			`<item><title>One</title><guid>urn:post:1</guid></item>` +
+			`<item><title>Two</title><link>https://example.com/2</link></item>` +
+			`<item><title>One again</title><guid>urn:post:1</guid></item>` +
+			`<item><title>Two again</title><link>https://example.com/2?utm_source=rss</link></item>` +
+			`<item><title>One once more</title><guid>urn:post:1</guid></item>` +
+			`</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	fetch := func(dedup *bool) *model.FeedAndItemsResult {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, DeduplicateWithinFeed: dedup})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+		if err != nil || result.Feed == nil {
+			t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+		}
+		return result
+	}
+
+	result := fetch(nil)
+	if len(result.Items) != 2 || result.Items[0].Title != "One" || result.Items[1].Title != "Two" {
+		t.Errorf("deduplicated items = %d, want One and Two", len(result.Items))
+	}
+	if got := result.Feed.Custom[DuplicatesRemovedMetadataKey]; got != "3" {
+		t.Errorf("%s = %q, want 3", DuplicatesRemovedMetadataKey, got)
+	}
+
+	disabled := false
+	result = fetch(&disabled)
+	if len(result.Items) != 5 || result.Feed.Custom[DuplicatesRemovedMetadataKey] != "" {
+		t.Errorf("with deduplication disabled: %d items, custom %v", len(result.Items), result.Feed.Custom)
+	}
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// errorLogCapacity is how many recent fetch errors the store retains for
+// diagnostics; older ones are overwritten.
+const errorLogCapacity = 50
+
+// errorLog is a fixed-size ring buffer of recent fetch errors.
+type errorLog struct {
+	entries []mcpserver.RecentError
+	next    int // index the next entry is written to
+	full    bool
+	mu      sync.Mutex
+}
+
+func newErrorLog(capacity int) *errorLog {
+	return &errorLog{entries: make([]mcpserver.RecentError, capacity)}
+}
+
+// record adds a failed fetch of feedURL, overwriting the oldest entry once the
+// log is full. Errors that aren't FeedErrors are recorded as ErrorTypeUnknown
+// with a fresh correlation ID.
+func (l *errorLog) record(feedID, feedURL string, err error) {
+	var fe *model.FeedError
+	if !errors.As(err, &fe) {
+		fe = model.NewFeedErrorWithCause(model.ErrorTypeUnknown, err.Error(), err).
+			WithURL(feedURL).
+			WithOperation("fetch_feed").
+			WithComponent("feed_fetcher")
+	}
+	entry := mcpserver.RecentError{
+		ID:         fe.ID,
+		Timestamp:  fe.Timestamp,
+		FeedID:     feedID,
+		URL:        feedURL,
+		ErrorType:  fe.ErrorType,
+		Message:    fe.Message,
+		Operation:  fe.Operation,
+		Component:  fe.Component,
+		HTTPStatus: fe.HTTPStatus,
+	}
+	if fe.Cause != nil {
+		entry.Cause = fe.Cause.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// recent returns the retained errors, newest first.
+func (l *errorLog) recent() []mcpserver.RecentError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := l.next
+	if l.full {
+		n = len(l.entries)
+	}
+	out := make([]mcpserver.RecentError, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return out
+}
+
+// GetDiagnostics implements mcpserver.DiagnosticsProvider: recent fetch
+// errors, each feed's circuit breaker state and fetch timings, the retry
+// metrics, the rate-limit quotas hosts have reported (see
+// GetRateLimitQuotas), and the latest duplicate feed check's groups.
+func (s *Store) GetDiagnostics(_ context.Context) (*mcpserver.Diagnostics, error) {
+	metrics := s.GetRetryMetrics()
+	diagnostics := &mcpserver.Diagnostics{
+		RecentErrors:    s.errorLog.recent(),
+		CircuitBreakers: []mcpserver.CircuitBreakerStatus{},
+		RetryMetrics: mcpserver.RetryMetricsSnapshot{
+			TotalAttempts:         metrics.TotalAttempts,
+			TotalRetries:          metrics.TotalRetries,
+			SuccessfulFeeds:       metrics.SuccessfulFeeds,
+			FailedFeeds:           metrics.FailedFeeds,
+			RetrySuccessRate:      metrics.RetrySuccessRate,
+			ElapsedBudgetExceeded: metrics.ElapsedBudgetExceeded,
+		},
+		FeedTimings:    s.fetchTimings.snapshot(s.feedEntries()),
+		DuplicateFeeds: []mcpserver.DuplicateFeedGroup{},
+	}
+	if s.duplicates != nil {
+		diagnostics.DuplicateFeeds = s.duplicates.report().Groups
+	}
+
+	s.feedsMu.RLock()
+	for feedURL, cb := range s.circuitBreakers {
+		counts := cb.Counts()
+		diagnostics.CircuitBreakers = append(diagnostics.CircuitBreakers, mcpserver.CircuitBreakerStatus{
+			FeedID:              s.feedIDLocked(feedURL),
+			URL:                 feedURL,
+			State:               cb.State().String(),
+			Requests:            counts.Requests,
+			TotalFailures:       counts.TotalFailures,
+			ConsecutiveFailures: counts.ConsecutiveFailures,
+		})
+	}
+	s.feedsMu.RUnlock()
+
+	slices.SortFunc(diagnostics.CircuitBreakers, func(a, b mcpserver.CircuitBreakerStatus) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+
+	diagnostics.RateLimitQuotas = []mcpserver.RateLimitQuotaStatus{}
+	for _, quota := range s.GetRateLimitQuotas() {
+		status := mcpserver.RateLimitQuotaStatus{
+			Host:              quota.Host,
+			Remaining:         quota.Remaining,
+			Reset:             quota.Reset,
+			ResetAssumed:      quota.ResetAssumed,
+			ObservedAt:        quota.ObservedAt,
+			ThrottledRequests: quota.ThrottledRequests,
+			TotalDelayMs:      quota.TotalDelay.Milliseconds(),
+		}
+		if quota.Limit >= 0 {
+			status.Limit = new(quota.Limit)
+		}
+		diagnostics.RateLimitQuotas = append(diagnostics.RateLimitQuotas, status)
+	}
+	return diagnostics, nil
+}
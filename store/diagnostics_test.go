@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestErrorLog_Ring(t *testing.T) {
+	log := newErrorLog(3)
+	if got := log.recent(); len(got) != 0 {
+		t.Fatalf("empty log returned %d entries", len(got))
+	}
+	for _, url := range []string{"a", "b", "c", "d"} {
+		log.record("feed-"+url, url, errors.New("failed "+url))
+	}
+	got := log.recent()
+	if len(got) != 3 || got[0].URL != "d" || got[1].URL != "c" || got[2].URL != "b" {
+		t.Fatalf("recent() = %+v, want d, c, b", got)
+	}
+	if got[0].ErrorType != model.ErrorTypeUnknown || got[0].ID == "" || got[0].Message != "failed d" {
+		t.Errorf("plain error recorded as %+v", got[0])
+	}
+}
+
+func TestStore_Diagnostics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	enabled := true
+	s, err := NewStore(&Config{
+		Feeds:                          []string{srv.URL},
+		AllowPrivateIPs:                true,
+		CircuitBreakerEnabled:          &enabled,
+		CircuitBreakerFailureThreshold: 2,
+		RetryMaxAttempts:               1,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	ctx := context.Background()
+	var fetchErrs []*model.FeedError
+	for range 3 {
+		_, err := s.feedCacheManager.Get(ctx, srv.URL)
+		var fe *model.FeedError
+		if !errors.As(err, &fe) {
+			t.Fatalf("expected a FeedError, got %v", err)
+		}
+		fetchErrs = append(fetchErrs, fe)
+	}
+
+	diagnostics, err := s.GetDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("GetDiagnostics failed: %v", err)
+	}
+	if len(diagnostics.RecentErrors) != 3 {
+		t.Fatalf("expected 3 recent errors, got %d", len(diagnostics.RecentErrors))
+	}
+	newest := diagnostics.RecentErrors[0]
+	if newest.ID != fetchErrs[2].ID || newest.ErrorType != model.ErrorTypeCircuitBreaker {
+		t.Errorf("newest error = %+v, want circuit breaker error %s", newest, fetchErrs[2].ID)
+	}
+	if oldest := diagnostics.RecentErrors[2]; oldest.ID != fetchErrs[0].ID || oldest.FeedID != model.GenerateFeedID(srv.URL) {
+		t.Errorf("oldest error = %+v, want %s", oldest, fetchErrs[0].ID)
+	}
+
+	if len(diagnostics.CircuitBreakers) != 1 {
+		t.Fatalf("expected 1 circuit breaker, got %d", len(diagnostics.CircuitBreakers))
+	}
+	if cb := diagnostics.CircuitBreakers[0]; cb.State != "open" || cb.URL != srv.URL {
+		t.Errorf("circuit breaker = %+v, want open for %s", cb, srv.URL)
+	}
+	if diagnostics.RetryMetrics.FailedFeeds != 2 {
+		t.Errorf("FailedFeeds = %d, want 2", diagnostics.RetryMetrics.FailedFeeds)
+	}
+	// The short-circuited fetch counts too: it is a load of the feed that failed.
+	if len(diagnostics.FeedTimings) != 1 {
+		t.Fatalf("expected 1 feed timing, got %d", len(diagnostics.FeedTimings))
+	}
+	if timing := diagnostics.FeedTimings[0]; timing.URL != srv.URL || timing.Fetches != 3 || timing.Failures != 3 || timing.LastFetchedAt.IsZero() {
+		t.Errorf("feed timing = %+v, want 3 failed fetches of %s", timing, srv.URL)
+	}
+
+}
+
+func TestStore_DiagnosticsRateLimitQuotas(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "97")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Quota</title><item><title>x</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, RequestsPerSecond: 1000, BurstCapacity: 1000})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := s.feedCacheManager.Get(ctx, srv.URL); err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	// Diagnostics carry the quota the host reported.
+	diagnostics, err := s.GetDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("GetDiagnostics failed: %v", err)
+	}
+	if len(diagnostics.RateLimitQuotas) != 1 {
+		t.Fatalf("rate_limit_quotas = %+v, want one host", diagnostics.RateLimitQuotas)
+	}
+	quota := diagnostics.RateLimitQuotas[0]
+	if quota.Host != "127.0.0.1" || quota.Limit == nil || *quota.Limit != 100 || quota.Remaining != 97 || quota.ResetAssumed || quota.Reset.IsZero() {
+		t.Errorf("quota = %+v, want 97 of 100 remaining on 127.0.0.1 with a reported reset", quota)
+	}
+}
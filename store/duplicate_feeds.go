@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// duplicateFeedDetector flags feeds that are likely mirrors of each other:
+// feeds under different URLs whose latest fetches returned the same set of
+// items. A fingerprint of each feed's items is recorded after every
+// successful network fetch, and the background check started by
+// StartDuplicateFeedCheck groups the current feeds by fingerprint every
+// interval. Feeds that haven't been fetched, or have no items, aren't
+// compared.
+type duplicateFeedDetector struct {
+	interval     time.Duration
+	mu           sync.Mutex
+	fingerprints map[string]itemSetFingerprint // by feed URL
+	groups       []mcpserver.DuplicateFeedGroup
+	checkedAt    time.Time
+}
+
+// itemSetFingerprint identifies the set of items a fetch returned.
+type itemSetFingerprint struct {
+	hash  string
+	items int
+}
+
+func newDuplicateFeedDetector(interval time.Duration) *duplicateFeedDetector {
+	return &duplicateFeedDetector{
+		interval:     interval,
+		fingerprints: make(map[string]itemSetFingerprint),
+		groups:       []mcpserver.DuplicateFeedGroup{},
+	}
+}
+
+// fingerprintItems hashes the stable IDs and titles of items as a set, so the
+// same items in another order, or repeated, give the same fingerprint.
+func fingerprintItems(items []*gofeed.Item) itemSetFingerprint {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			keys = append(keys, model.ItemStableID(item)+"\x00"+strings.TrimSpace(item.Title))
+		}
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return itemSetFingerprint{hash: hex.EncodeToString(sum[:16]), items: len(keys)}
+}
+
+// record fingerprints the items just fetched from feedURL. A feed without
+// items is forgotten, since every empty feed would look like a mirror of the
+// others.
+func (d *duplicateFeedDetector) record(feedURL string, items []*gofeed.Item) {
+	fingerprint := fingerprintItems(items)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if fingerprint.items == 0 {
+		delete(d.fingerprints, feedURL)
+		return
+	}
+	d.fingerprints[feedURL] = fingerprint
+}
+
+// remove forgets a feed's fingerprint.
+func (d *duplicateFeedDetector) remove(feedURL string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.fingerprints, feedURL)
+}
+
+// check groups feeds, given sorted by ID, by fingerprint and keeps the groups
+// of two or more as the latest result. Groups are ordered by their first
+// feed ID.
+func (d *duplicateFeedDetector) check(feeds []feedEntry, now time.Time) []mcpserver.DuplicateFeedGroup {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var order []string
+	byHash := make(map[string]*mcpserver.DuplicateFeedGroup)
+	for _, feed := range feeds {
+		fingerprint, ok := d.fingerprints[feed.url]
+		if !ok {
+			continue
+		}
+		group, ok := byHash[fingerprint.hash]
+		if !ok {
+			group = &mcpserver.DuplicateFeedGroup{Fingerprint: fingerprint.hash, ItemCount: fingerprint.items}
+			byHash[fingerprint.hash] = group
+			order = append(order, fingerprint.hash)
+		}
+		group.Feeds = append(group.Feeds, mcpserver.DuplicateFeed{FeedID: feed.id, URL: feed.url})
+	}
+
+	groups := []mcpserver.DuplicateFeedGroup{}
+	for _, hash := range order {
+		if group := byHash[hash]; len(group.Feeds) > 1 {
+			groups = append(groups, *group)
+		}
+	}
+	d.groups = groups
+	d.checkedAt = now
+	return groups
+}
+
+// report returns the latest check's result.
+func (d *duplicateFeedDetector) report() *mcpserver.DuplicateFeedsReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &mcpserver.DuplicateFeedsReport{
+		CheckedAt:     d.checkedAt,
+		CheckInterval: d.interval.String(),
+		Groups:        slices.Clone(d.groups),
+	}
+}
+
+// StartDuplicateFeedCheck compares the feeds for identical content every
+// Config.DuplicateFeedCheckInterval in the background until ctx is done. It
+// does nothing when the check is off.
+func (s *Store) StartDuplicateFeedCheck(ctx context.Context) {
+	if s.duplicates == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.duplicates.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.checkDuplicateFeeds(now)
+			}
+		}
+	}()
+}
+
+// checkDuplicateFeeds runs one duplicate feed check, logging the groups found.
+func (s *Store) checkDuplicateFeeds(now time.Time) {
+	for _, group := range s.duplicates.check(s.feedEntries(), now) {
+		urls := make([]string, len(group.Feeds))
+		for i, feed := range group.Feeds {
+			urls[i] = feed.URL
+		}
+		model.DebugLogWithContext("Feeds serve identical content", "duplicate_feed_check", "check_duplicate_feeds", urls[0],
+			map[string]any{"mirrors": urls, "items": group.ItemCount})
+	}
+}
+
+// DuplicateFeeds implements mcpserver.DuplicateFeedsProvider. It returns the
+// latest background check's result, or an error when the check is off.
+func (s *Store) DuplicateFeeds(_ context.Context) (*mcpserver.DuplicateFeedsReport, error) {
+	if s.duplicates == nil {
+		return nil, model.NewFeedError(model.ErrorTypeConfiguration, "duplicate feed detection is disabled").
+			WithOperation("list_duplicate_feeds").
+			WithComponent("duplicate_feed_check").
+			WithSuggestion("Start the server with --duplicate-feed-check-interval set to a positive duration, e.g. 1h")
+	}
+	return s.duplicates.report(), nil
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+const (
+	mirroredRSSBody = `<?xml version="1.0"?><rss version="2.0"><channel><title>Original</title>
+<item><title>First</title><guid>urn:post:1</guid></item>
+<item><title>Second</title><guid>urn:post:2</guid></item>
+</channel></rss>`
+	// The mirror renames the channel and lists the same items in another order.
+	mirrorRSSBody = `<?xml version="1.0"?><rss version="2.0"><channel><title>Mirror</title>
+<item><title>Second</title><guid>urn:post:2</guid></item>
+<item><title>First</title><guid>urn:post:1</guid></item>
+</channel></rss>`
+	emptyRSSBody = `<?xml version="1.0"?><rss version="2.0"><channel><title>Empty</title></channel></rss>`
+)
+
+func TestStore_DuplicateFeeds(t *testing.T) {
+	bodies := map[string]string{
+		"/original": mirroredRSSBody,
+		"/mirror":   mirrorRSSBody,
+		"/other":    selectionRSSBody,
+		"/empty-1":  emptyRSSBody,
+		"/empty-2":  emptyRSSBody,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(bodies[r.URL.Path]))
+	}))
+	defer srv.Close()
+
+	var feeds []string
+	for path := range bodies {
+		feeds = append(feeds, srv.URL+path)
+	}
+	s, err := NewStore(&Config{
+		Feeds:                      feeds,
+		AllowPrivateIPs:            true,
+		DuplicateFeedCheckInterval: time.Hour,
+		RequestsPerSecond:          1000,
+		BurstCapacity:              1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	report, err := s.DuplicateFeeds(context.Background())
+	if err != nil || !report.CheckedAt.IsZero() || len(report.Groups) != 0 || report.CheckInterval != "1h0m0s" {
+		t.Fatalf("before the first check: %+v, %v; want an empty report", report, err)
+	}
+
+	for _, feedURL := range feeds {
+		if _, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(feedURL)); err != nil {
+			t.Fatalf("GetFeedAndItems(%s) failed: %v", feedURL, err)
+		}
+	}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.checkDuplicateFeeds(now)
+
+	report, err = s.DuplicateFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("DuplicateFeeds: %v", err)
+	}
+	if !report.CheckedAt.Equal(now) {
+		t.Errorf("checked_at = %v, want %v", report.CheckedAt, now)
+	}
+	if len(report.Groups) != 1 {
+		t.Fatalf("groups = %+v, want just the mirrored pair", report.Groups)
+	}
+	group := report.Groups[0]
+	got := map[string]bool{}
+	for _, feed := range group.Feeds {
+		got[feed.URL] = true
+	}
+	if len(group.Feeds) != 2 || !got[srv.URL+"/original"] || !got[srv.URL+"/mirror"] || group.ItemCount != 2 {
+		t.Errorf("group = %+v, want /original and /mirror with 2 items", group)
+	}
+
+	diagnostics, err := s.GetDiagnostics(context.Background())
+	if err != nil {
+		t.Fatalf("GetDiagnostics: %v", err)
+	}
+	if len(diagnostics.DuplicateFeeds) != 1 || diagnostics.DuplicateFeeds[0].Fingerprint != group.Fingerprint {
+		t.Errorf("diagnostics duplicate_feeds = %+v, want the same group", diagnostics.DuplicateFeeds)
+	}
+}
+
+func TestStore_DuplicateFeedCheckRunsInBackground(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(mirroredRSSBody))
+	}))
+	defer srv.Close()
+
+	feeds := []string{srv.URL + "/a", srv.URL + "/b"}
+	s, err := NewStore(&Config{
+		Feeds:                      feeds,
+		AllowPrivateIPs:            true,
+		DuplicateFeedCheckInterval: 10 * time.Millisecond,
+		RequestsPerSecond:          1000,
+		BurstCapacity:              1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	for _, feedURL := range feeds {
+		if _, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(feedURL)); err != nil {
+			t.Fatalf("GetFeedAndItems(%s) failed: %v", feedURL, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartDuplicateFeedCheck(ctx)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		report, err := s.DuplicateFeeds(ctx)
+		if err != nil {
+			t.Fatalf("DuplicateFeeds: %v", err)
+		}
+		if len(report.Groups) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("background check never flagged the mirrors: %+v", report)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStore_DuplicateFeedsDisabled(t *testing.T) {
+	s, err := NewStore(&Config{Feeds: []string{"https://example.com/feed"}})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	s.StartDuplicateFeedCheck(context.Background()) // a no-op when off
+	if _, err := s.DuplicateFeeds(context.Background()); err == nil {
+		t.Error("DuplicateFeeds with the check off: expected an error")
+	}
+	diagnostics, err := s.GetDiagnostics(context.Background())
+	if err != nil || diagnostics.DuplicateFeeds == nil || len(diagnostics.DuplicateFeeds) != 0 {
+		t.Errorf("diagnostics duplicate_feeds = %v, %v; want an empty list", diagnostics.DuplicateFeeds, err)
+	}
+}
+
+func TestFingerprintItems(t *testing.T) {
+	a := &gofeed.Item{Title: "A", GUID: "1"}
+	b := &gofeed.Item{Title: "B", GUID: "2"}
+	retitled := &gofeed.Item{Title: "A (updated)", GUID: "1"}
+
+	if fingerprintItems([]*gofeed.Item{a, b}) != fingerprintItems([]*gofeed.Item{b, a, a}) {
+		t.Error("order or repeats changed the fingerprint")
+	}
+	if fingerprintItems([]*gofeed.Item{a, b}) == fingerprintItems([]*gofeed.Item{retitled, b}) {
+		t.Error("a retitled item kept the fingerprint")
+	}
+	if got := fingerprintItems([]*gofeed.Item{a, nil, b}).items; got != 2 {
+		t.Errorf("items = %d, want 2", got)
+	}
+}
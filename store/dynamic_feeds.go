@@ -4,6 +4,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +36,8 @@ type DynamicFeedMetadata struct {
 	Title       string               `json:"title,omitempty"`
 	Category    string               `json:"category,omitempty"`
 	Description string               `json:"description,omitempty"`
+	Alias       string               `json:"alias,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
 	AddedAt     time.Time            `json:"addedAt"`
 	Source      mcpserver.FeedSource `json:"source"`
 	Status      string               `json:"status"` // active, error, paused
@@ -49,6 +52,11 @@ type DynamicStore struct {
 	feedMetadata      map[string]*DynamicFeedMetadata // feedID -> metadata
 	dynamicMutex      sync.RWMutex
 	allowRuntimeFeeds bool
+	// Feed store file persistence (see feed_state.go). stateGeneration is
+	// guarded by dynamicMutex; savedGeneration by persistMu.
+	persistMu       sync.Mutex
+	stateGeneration uint64
+	savedGeneration uint64
 }
 
 // NewDynamicStore creates a new dynamic feed store
@@ -71,6 +79,9 @@ func NewDynamicStore(config *Config, allowRuntimeFeeds bool) (*DynamicStore, err
 			feedMetadata:      make(map[string]*DynamicFeedMetadata),
 			allowRuntimeFeeds: allowRuntimeFeeds,
 		}
+		if err := ds.loadPersistedFeeds(); err != nil {
+			return nil, err
+		}
 
 		return ds, nil
 	}
@@ -90,6 +101,9 @@ func NewDynamicStore(config *Config, allowRuntimeFeeds bool) (*DynamicStore, err
 
 	// Initialize metadata for startup feeds
 	ds.initializeStartupFeedMetadata()
+	if err := ds.loadPersistedFeeds(); err != nil {
+		return nil, err
+	}
 
 	return ds, nil
 }
@@ -136,6 +150,7 @@ func (ds *DynamicStore) initializeStartupFeedMetadata() {
 
 	for _, entry := range ds.feedEntries() {
 		ds.feedMetadata[entry.id] = &DynamicFeedMetadata{
+			Tags:    ds.feedTags[entry.url],
 			AddedAt: time.Now(), // Approximate startup time
 			Source:  source,
 			Status:  statusActive,
@@ -143,6 +158,16 @@ func (ds *DynamicStore) initializeStartupFeedMetadata() {
 	}
 }
 
+// newCircuitBreaker builds a circuit breaker for a runtime feed, or returns nil
+// when circuit breaking is disabled. It uses the store's settings, defaults
+// applied, so runtime feeds trip like configured ones.
+func (ds *DynamicStore) newCircuitBreaker(url string) *gobreaker.CircuitBreaker {
+	if ds.newBreaker == nil {
+		return nil
+	}
+	return ds.newBreaker(url)
+}
+
 // alreadyExistsError builds the error returned when a feed URL is already
 // registered.
 func alreadyExistsError(url string) error {
@@ -151,6 +176,18 @@ func alreadyExistsError(url string) error {
 		WithComponent("dynamic_store")
 }
 
+// feedLimitError reports that adding a feed would exceed Config.MaxFeeds.
+func feedLimitError(limit int) error {
+	return model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed limit of %d reached; remove a feed before adding another", limit)).
+		WithOperation("add_feed").
+		WithComponent("dynamic_store")
+}
+
+// atFeedLimit reports whether the store already manages Config.MaxFeeds feeds.
+func (ds *DynamicStore) atFeedLimit() bool {
+	return ds.config.MaxFeeds > 0 && ds.feedCount() >= ds.config.MaxFeeds
+}
+
 // AddFeed implements DynamicFeedManager.AddFeed
 func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig) (*mcpserver.ManagedFeedInfo, error) {
 	if !ds.allowRuntimeFeeds {
@@ -166,12 +203,18 @@ func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig
 	if err := model.ValidateFeedURLContext(ctx, config.URL, ds.config.AllowPrivateIPs); err != nil {
 		return nil, err
 	}
+	if err := model.ValidateFeedTags(config.Tags); err != nil {
+		return nil, invalidFeedTagsError(err, "add_feed")
+	}
 
 	// Fast duplicate check before the (potentially slow) initial fetch. The
 	// feeds map contains all feeds, including dynamic ones.
 	if ds.urlRegistered(config.URL) {
 		return nil, alreadyExistsError(config.URL)
 	}
+	if ds.atFeedLimit() {
+		return nil, feedLimitError(ds.config.MaxFeeds)
+	}
 
 	// Fetch the feed initially to get its title and validate reachability. This
 	// is done WITHOUT holding dynamicMutex: a fetch can block for seconds doing
@@ -187,7 +230,11 @@ func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig
 		return nil, err
 	}
 
-	feedID := model.GenerateFeedID(config.URL)
+	// Deferred before the unlock so the feed store file is written after
+	// dynamicMutex is released.
+	var state *feedState
+	var generation uint64
+	defer func() { ds.saveFeedState(state, generation) }()
 
 	ds.dynamicMutex.Lock()
 	defer ds.dynamicMutex.Unlock()
@@ -203,31 +250,21 @@ func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig
 	if ds.urlRegistered(config.URL) {
 		return nil, alreadyExistsError(config.URL)
 	}
-
-	// Build a circuit breaker if circuit breaking is enabled.
-	var cb *gobreaker.CircuitBreaker
-	if ds.hasCircuitBreakers() {
-		settings := gobreaker.Settings{
-			Name:        fmt.Sprintf("feed-%s", config.URL),
-			MaxRequests: ds.config.CircuitBreakerMaxRequests,
-			Interval:    ds.config.CircuitBreakerInterval,
-			Timeout:     ds.config.CircuitBreakerTimeout,
-			ReadyToTrip: func(counts gobreaker.Counts) bool {
-				return counts.ConsecutiveFailures >= ds.config.CircuitBreakerFailureThreshold
-			},
-		}
-		cb = gobreaker.NewCircuitBreaker(settings)
+	if ds.atFeedLimit() {
+		return nil, feedLimitError(ds.config.MaxFeeds)
 	}
 
 	// Register the feed (and its breaker) in the base store. Runtime feeds are
 	// identified by their metadata Source, not a separate map.
-	ds.putFeed(feedID, config.URL, cb)
+	feedID := ds.availableFeedID(config.URL)
+	ds.putFeed(feedID, config.URL, ds.newCircuitBreaker(config.URL))
 
 	// Create metadata from the fetch performed above.
 	metadata := &DynamicFeedMetadata{
 		Title:       config.Title,
 		Category:    config.Category,
 		Description: config.Description,
+		Tags:        model.NormalizeFeedTags(config.Tags),
 		AddedAt:     time.Now(),
 		Source:      mcpserver.FeedSourceRuntime,
 		Status:      statusActive,
@@ -243,6 +280,7 @@ func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig
 	}
 
 	ds.feedMetadata[feedID] = metadata
+	state, generation = ds.feedStateLocked()
 
 	return &mcpserver.ManagedFeedInfo{
 		FeedID:      feedID,
@@ -256,6 +294,7 @@ func (ds *DynamicStore) AddFeed(ctx context.Context, config mcpserver.FeedConfig
 		ItemCount:   itemCount,
 		AddedAt:     metadata.AddedAt,
 		Source:      string(metadata.Source),
+		Tags:        metadata.Tags,
 	}, nil
 }
 
@@ -271,6 +310,10 @@ func (ds *DynamicStore) RemoveFeed(ctx context.Context, feedID string) (*mcpserv
 		ctx = context.Background()
 	}
 
+	var state *feedState
+	var generation uint64
+	defer func() { ds.saveFeedState(state, generation) }()
+
 	ds.dynamicMutex.Lock()
 	defer ds.dynamicMutex.Unlock()
 
@@ -302,6 +345,7 @@ func (ds *DynamicStore) RemoveFeed(ctx context.Context, feedID string) (*mcpserv
 	ds.deleteFeed(feedID, url)
 	delete(ds.feedMetadata, feedID)
 	_ = ds.feedCacheManager.Delete(ctx, url) // in-memory; deletion errors are not critical
+	state, generation = ds.feedStateLocked()
 
 	return &mcpserver.RemovedFeedInfo{
 		FeedID:       feedID,
@@ -361,46 +405,54 @@ func (ds *DynamicStore) ListManagedFeeds(ctx context.Context) ([]mcpserver.Manag
 	feeds := make([]mcpserver.ManagedFeedInfo, 0, len(snapshots))
 	for i := range snapshots {
 		snap := &snapshots[i]
-		// Get current item count and status (network fetch; no lock held).
-		cacheInfo := ds.checkFeedCache(ctx, snap.url)
-		itemCount := cacheInfo.ItemCount
-		status := cacheInfo.Status
-		var lastError string
-		var lastFetched time.Time
-
-		if cacheInfo.Found {
-			lastFetched = cacheInfo.LastFetched
-		} else {
-			lastError = cacheInfo.LastError
-			lastFetched = snap.meta.LastFetched // Keep original if cache fetch failed
-		}
-
-		// Title falls back to the freshly-fetched cacheInfo.Title when metadata
-		// is blank — startup/OPML feeds seed empty titles (see #114 lazy init)
-		// and rely on the first list_managed_feeds call to surface the real title.
-		title := snap.meta.Title
-		if title == "" && cacheInfo.Found {
-			title = cacheInfo.Title
-		}
-
-		feeds = append(feeds, mcpserver.ManagedFeedInfo{
-			FeedID:      snap.id,
-			URL:         snap.url,
-			Title:       title,
-			Category:    snap.meta.Category,
-			Description: snap.meta.Description,
-			Status:      status,
-			LastFetched: lastFetched,
-			LastError:   lastError,
-			ItemCount:   itemCount,
-			AddedAt:     snap.meta.AddedAt,
-			Source:      string(snap.meta.Source),
-		})
+		feeds = append(feeds, ds.managedFeedInfo(ctx, snap.id, snap.url, &snap.meta))
 	}
 
 	return feeds, nil
 }
 
+// managedFeedInfo reports a managed feed with its current item count and
+// status. It fetches the feed through the cache, so callers must not hold
+// dynamicMutex.
+func (ds *DynamicStore) managedFeedInfo(ctx context.Context, id, url string, meta *DynamicFeedMetadata) mcpserver.ManagedFeedInfo {
+	cacheInfo := ds.checkFeedCache(ctx, url)
+	itemCount := cacheInfo.ItemCount
+	status := cacheInfo.Status
+	var lastError string
+	var lastFetched time.Time
+
+	if cacheInfo.Found {
+		lastFetched = cacheInfo.LastFetched
+	} else {
+		lastError = cacheInfo.LastError
+		lastFetched = meta.LastFetched // Keep original if cache fetch failed
+	}
+
+	// Title falls back to the freshly-fetched cacheInfo.Title when metadata
+	// is blank — startup/OPML feeds seed empty titles (see #114 lazy init)
+	// and rely on the first list_managed_feeds call to surface the real title.
+	title := meta.Title
+	if title == "" && cacheInfo.Found {
+		title = cacheInfo.Title
+	}
+
+	return mcpserver.ManagedFeedInfo{
+		FeedID:      id,
+		URL:         url,
+		Title:       title,
+		Category:    meta.Category,
+		Description: meta.Description,
+		Alias:       meta.Alias,
+		Status:      status,
+		LastFetched: lastFetched,
+		LastError:   lastError,
+		ItemCount:   itemCount,
+		AddedAt:     meta.AddedAt,
+		Source:      string(meta.Source),
+		Tags:        meta.Tags,
+	}
+}
+
 // RefreshFeed implements DynamicFeedManager.RefreshFeed
 func (ds *DynamicStore) RefreshFeed(ctx context.Context, feedID string) (*mcpserver.RefreshFeedInfo, error) {
 	url, exists := ds.feedURL(feedID)
@@ -412,11 +464,7 @@ func (ds *DynamicStore) RefreshFeed(ctx context.Context, feedID string) (*mcpser
 		}, nil
 	}
 
-	// Clear from cache to force refresh
-	_ = ds.feedCacheManager.Delete(ctx, url) // Cache deletion errors are not critical
-
-	// Get fresh content
-	feed, err := ds.feedCacheManager.Get(ctx, url)
+	feed, err := ds.refreshFeed(ctx, url)
 
 	refreshInfo := &mcpserver.RefreshFeedInfo{
 		FeedID:      feedID,
@@ -454,17 +502,45 @@ func (ds *DynamicStore) RefreshFeed(ctx context.Context, feedID string) (*mcpser
 	return refreshInfo, nil
 }
 
-// UpdateFeedMetadata implements DynamicFeedManager.UpdateFeedMetadata
-func (ds *DynamicStore) UpdateFeedMetadata(ctx context.Context, feedID string, metadata mcpserver.FeedMetadata) error {
+// UpdateFeedMetadata implements DynamicFeedManager.UpdateFeedMetadata. Empty
+// fields leave the current value unchanged, except Tags, which replaces the
+// feed's tags whenever it is non-nil. A new alias must be URI-safe and
+// not already name another feed (see validateFeedAlias).
+func (ds *DynamicStore) UpdateFeedMetadata(ctx context.Context, feedID string, metadata mcpserver.FeedMetadata) (*mcpserver.ManagedFeedInfo, error) {
+	url, meta, err := ds.applyFeedMetadata(feedID, metadata)
+	if err != nil {
+		return nil, err
+	}
+	info := ds.managedFeedInfo(ctx, feedID, url, &meta)
+	return &info, nil
+}
+
+// applyFeedMetadata updates a feed's stored metadata and persists it for
+// runtime-added feeds, returning the feed's URL and a copy of the result.
+func (ds *DynamicStore) applyFeedMetadata(feedID string, metadata mcpserver.FeedMetadata) (string, DynamicFeedMetadata, error) {
+	var state *feedState
+	var generation uint64
+	defer func() { ds.saveFeedState(state, generation) }()
+
 	ds.dynamicMutex.Lock()
 	defer ds.dynamicMutex.Unlock()
 
 	feedMeta := ds.feedMetadata[feedID]
-	if feedMeta == nil {
-		return model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", feedID)).
+	url, exists := ds.feedURL(feedID)
+	if feedMeta == nil || !exists {
+		return "", DynamicFeedMetadata{}, model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", feedID)).
 			WithOperation("update_feed_metadata").
 			WithComponent("dynamic_store")
 	}
+	if err := model.ValidateFeedTags(metadata.Tags); err != nil {
+		return "", DynamicFeedMetadata{}, invalidFeedTagsError(err, "update_feed_metadata")
+	}
+	if metadata.Alias != "" && metadata.Alias != feedMeta.Alias {
+		if err := ds.validateFeedAliasLocked(feedID, metadata.Alias); err != nil {
+			return "", DynamicFeedMetadata{}, err
+		}
+		feedMeta.Alias = metadata.Alias
+	}
 
 	// Update metadata fields
 	if metadata.Title != "" {
@@ -476,10 +552,63 @@ func (ds *DynamicStore) UpdateFeedMetadata(ctx context.Context, feedID string, m
 	if metadata.Description != "" {
 		feedMeta.Description = metadata.Description
 	}
+	if metadata.Tags != nil {
+		feedMeta.Tags = model.NormalizeFeedTags(metadata.Tags)
+	}
+	if feedMeta.Source == mcpserver.FeedSourceRuntime {
+		state, generation = ds.feedStateLocked()
+	}
+
+	return url, *feedMeta, nil
+}
 
+// maxFeedAliasLength bounds feed aliases.
+const maxFeedAliasLength = 64
+
+// validateFeedAliasLocked checks that alias is URI-safe — letters, digits,
+// '-', '_' and '.', starting with a letter or digit, so it can appear in a
+// resource URI unescaped — and that no other feed uses it as its alias or ID,
+// ignoring case. Callers must hold dynamicMutex.
+func (ds *DynamicStore) validateFeedAliasLocked(feedID, alias string) error {
+	if !isURISafeAlias(alias) {
+		return model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("invalid alias %q", alias)).
+			WithField("alias").
+			WithSuggestion(fmt.Sprintf("Use up to %d letters, digits, '-', '_' or '.', starting with a letter or digit", maxFeedAliasLength)).
+			WithOperation("update_feed_metadata").
+			WithComponent("dynamic_store")
+	}
+	for id, meta := range ds.feedMetadata {
+		if id == feedID {
+			continue
+		}
+		if strings.EqualFold(id, alias) || strings.EqualFold(meta.Alias, alias) {
+			return model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("alias %q is already used by feed %s", alias, id)).
+				WithField("alias").
+				WithSuggestion("Choose an alias no other feed uses").
+				WithOperation("update_feed_metadata").
+				WithComponent("dynamic_store")
+		}
+	}
 	return nil
 }
 
+// isURISafeAlias reports whether alias matches the rules in
+// validateFeedAliasLocked.
+func isURISafeAlias(alias string) bool {
+	if alias == "" || len(alias) > maxFeedAliasLength {
+		return false
+	}
+	for i, r := range alias {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case i > 0 && (r == '-' || r == '_' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // PauseFeed implements DynamicFeedManager.PauseFeed
 func (ds *DynamicStore) PauseFeed(ctx context.Context, feedID string) error {
 	ds.dynamicMutex.Lock()
@@ -2,12 +2,15 @@ package store
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
 )
 
 // rssFeedServer starts a test server serving a minimal valid RSS feed with the
@@ -155,7 +158,7 @@ func TestDynamicStore_UpdateFeedMetadata_Success(t *testing.T) {
 	ctx := context.Background()
 	feedID := addRuntimeFeed(t, ds, srv.URL)
 
-	err := ds.UpdateFeedMetadata(ctx, feedID, mcpserver.FeedMetadata{
+	_, err := ds.UpdateFeedMetadata(ctx, feedID, mcpserver.FeedMetadata{
 		Title:       "Updated Title",
 		Category:    "news",
 		Description: "Updated description",
@@ -186,7 +189,7 @@ func TestDynamicStore_UpdateFeedMetadata_EmptyFieldsPreserved(t *testing.T) {
 	ds.feedMetadata[feedID].Title = "Keep Title"
 	ds.feedMetadata[feedID].Category = "keep-cat"
 
-	if err := ds.UpdateFeedMetadata(ctx, feedID, mcpserver.FeedMetadata{Description: "only desc"}); err != nil {
+	if _, err := ds.UpdateFeedMetadata(ctx, feedID, mcpserver.FeedMetadata{Description: "only desc"}); err != nil {
 		t.Fatalf("UpdateFeedMetadata: %v", err)
 	}
 
@@ -202,10 +205,74 @@ func TestDynamicStore_UpdateFeedMetadata_EmptyFieldsPreserved(t *testing.T) {
 	}
 }
 
+func TestDynamicStore_UpdateFeedMetadata_ReturnsInfoAndPersists(t *testing.T) {
+	srv := newFeedStateServer(t)
+	path := filepath.Join(t.TempDir(), "feeds.json")
+	ctx := context.Background()
+
+	ds, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+	feedID := addRuntimeFeed(t, ds, srv.URL+"/go")
+
+	info, err := ds.UpdateFeedMetadata(ctx, feedID, mcpserver.FeedMetadata{Title: "Go Blog", Category: "golang", Alias: "go-blog"})
+	if err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+	if info.FeedID != feedID || info.Title != "Go Blog" || info.Category != "golang" || info.Alias != "go-blog" || info.ItemCount != 1 {
+		t.Errorf("returned info = %+v", info)
+	}
+
+	restarted, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore after restart: %v", err)
+	}
+	feeds, err := restarted.ListManagedFeeds(ctx)
+	if err != nil {
+		t.Fatalf("ListManagedFeeds: %v", err)
+	}
+	if len(feeds) != 1 || feeds[0].Title != "Go Blog" || feeds[0].Category != "golang" || feeds[0].Alias != "go-blog" {
+		t.Errorf("updated metadata not restored: %+v", feeds)
+	}
+}
+
+func TestDynamicStore_UpdateFeedMetadata_Alias(t *testing.T) {
+	srv := newFeedStateServer(t)
+	ds := newRuntimeStore(t)
+	ctx := context.Background()
+	first := addRuntimeFeed(t, ds, srv.URL+"/first")
+	second := addRuntimeFeed(t, ds, srv.URL+"/second")
+
+	if _, err := ds.UpdateFeedMetadata(ctx, first, mcpserver.FeedMetadata{Alias: "news"}); err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+	// Setting a feed's own alias again is not a conflict.
+	if _, err := ds.UpdateFeedMetadata(ctx, first, mcpserver.FeedMetadata{Alias: "news"}); err != nil {
+		t.Errorf("re-setting own alias: %v", err)
+	}
+
+	before := *ds.feedMetadata[second]
+	for _, alias := range []string{"NEWS", first, "has space", "a/b", "-leading", strings.Repeat("x", maxFeedAliasLength+1)} {
+		_, err := ds.UpdateFeedMetadata(ctx, second, mcpserver.FeedMetadata{Title: "Changed", Alias: alias})
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeValidation || feedErr.Field != "alias" {
+			t.Errorf("alias %q: error = %v, want a validation error on alias", alias, err)
+		}
+	}
+	if md := ds.feedMetadata[second]; md.Title != before.Title || md.Alias != "" {
+		t.Errorf("rejected update changed metadata: %+v", md)
+	}
+
+	if _, err := ds.UpdateFeedMetadata(ctx, second, mcpserver.FeedMetadata{Alias: "news_2.v1"}); err != nil {
+		t.Errorf("valid alias rejected: %v", err)
+	}
+}
+
 func TestDynamicStore_UpdateFeedMetadata_NotFound(t *testing.T) {
 	ds := newRuntimeStore(t)
 
-	err := ds.UpdateFeedMetadata(context.Background(), "missing-id", mcpserver.FeedMetadata{Title: "x"})
+	_, err := ds.UpdateFeedMetadata(context.Background(), "missing-id", mcpserver.FeedMetadata{Title: "x"})
 	if err == nil {
 		t.Fatal("expected error for unknown feed ID")
 	}
@@ -280,3 +347,46 @@ func TestDynamicStore_ResumeFeed_NotFound(t *testing.T) {
 		t.Fatal("expected error for unknown feed ID")
 	}
 }
+
+func TestDynamicStore_AddFeed_MaxFeeds(t *testing.T) {
+	startup := rssFeedServer(t, "Startup Feed")
+	ds, err := NewDynamicStore(&Config{Feeds: []string{startup.URL}, AllowPrivateIPs: true, MaxFeeds: 3}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+
+	// The startup feed counts toward the cap, leaving room for two more.
+	first := addRuntimeFeed(t, ds, rssFeedServer(t, "One").URL)
+	addRuntimeFeed(t, ds, rssFeedServer(t, "Two").URL)
+
+	over := rssFeedServer(t, "Three").URL
+	_, err = ds.AddFeed(context.Background(), mcpserver.FeedConfig{URL: over})
+	if err == nil {
+		t.Fatal("expected an error adding a feed past the limit")
+	}
+	if !strings.Contains(err.Error(), "feed limit of 3 reached") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := len(ds.feeds); got != 3 {
+		t.Errorf("managed feeds = %d, want 3", got)
+	}
+
+	// Removing a feed frees a slot.
+	if _, err := ds.RemoveFeed(context.Background(), first); err != nil {
+		t.Fatalf("RemoveFeed: %v", err)
+	}
+	addRuntimeFeed(t, ds, over)
+}
+
+func TestNewDynamicStore_StartupFeedsExceedMaxFeeds(t *testing.T) {
+	_, err := NewDynamicStore(&Config{
+		Feeds:    []string{"https://example.com/a.xml", "https://example.com/b.xml"},
+		MaxFeeds: 1,
+	}, true)
+	if err == nil {
+		t.Fatal("expected an error when startup feeds exceed the limit")
+	}
+	if !strings.Contains(err.Error(), "2 feeds configured but the feed limit is 1") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -2,8 +2,10 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -191,6 +193,47 @@ func TestDynamicStore_ListManagedFeeds(t *testing.T) {
 	}
 }
 
+// TestDynamicStore_ListManagedFeeds_StableOrder verifies that managed feeds
+// are listed in feed ID order on every call rather than in map iteration order.
+func TestDynamicStore_ListManagedFeeds_StableOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 20)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/feed%d", srv.URL, i)
+	}
+	disabled := false
+	ds, err := NewDynamicStore(&Config{Feeds: urls, AllowPrivateIPs: true, CircuitBreakerEnabled: &disabled, RetryMaxAttempts: 1, RequestsPerSecond: 1000, BurstCapacity: 1000}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+
+	var first []string
+	for range 10 {
+		feeds, err := ds.ListManagedFeeds(context.Background())
+		if err != nil {
+			t.Fatalf("ListManagedFeeds: %v", err)
+		}
+		ids := make([]string, len(feeds))
+		for i, feed := range feeds {
+			ids[i] = feed.FeedID
+		}
+		if first == nil {
+			first = ids
+			if !slices.IsSorted(first) {
+				t.Fatalf("managed feeds not sorted by ID: %v", first)
+			}
+			continue
+		}
+		if !slices.Equal(ids, first) {
+			t.Fatalf("managed feed order changed between calls: %v, then %v", first, ids)
+		}
+	}
+}
+
 // TestDynamicStore_RefreshFeed tests refreshing a specific feed
 func TestDynamicStore_RefreshFeed(t *testing.T) {
 	config := Config{
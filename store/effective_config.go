@@ -0,0 +1,109 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"net/url"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+)
+
+// EffectiveConfig implements mcpserver.ConfigProvider, reporting the
+// configuration the store runs with after NewStore applied its defaults.
+// Per-feed header values and passwords in feed URLs are redacted.
+func (s *Store) EffectiveConfig(_ context.Context) (*mcpserver.StoreSettings, error) {
+	c := &s.settings
+	stableIDChain := make([]string, len(c.StableIDChain))
+	for i, source := range c.StableIDChain {
+		stableIDChain[i] = string(source)
+	}
+
+	settings := &mcpserver.StoreSettings{
+		FeedCount:        len(s.feedEntries()),
+		MaxFeeds:         c.MaxFeeds,
+		CacheExpireAfter: c.ExpireAfter.String(),
+		HTTP: mcpserver.StoreHTTPSettings{
+			Timeout:              c.Timeout.String(),
+			OverallFetchTimeout:  c.OverallFetchTimeout.String(),
+			ParseTimeout:         c.ParseTimeout.String(),
+			MaxIdleConns:         c.MaxIdleConns,
+			MaxConnsPerHost:      c.MaxConnsPerHost,
+			MaxIdleConnsPerHost:  c.MaxIdleConnsPerHost,
+			IdleConnTimeout:      c.IdleConnTimeout.String(),
+			MinTLSVersion:        tls.VersionName(cmp.Or(c.MinTLSVersion, tls.VersionTLS12)),
+			AllowPrivateIPs:      c.AllowPrivateIPs,
+			UpgradeInsecureFeeds: c.UpgradeInsecureFeeds,
+			AcceptedContentTypes: c.AcceptedContentTypes,
+		},
+		RateLimit: mcpserver.RateLimitSettings{
+			RequestsPerSecond: c.RequestsPerSecond,
+			BurstCapacity:     c.BurstCapacity,
+			IdleTimeout:       c.RateLimiterIdleTimeout.String(),
+		},
+		Retry: mcpserver.RetrySettings{
+			MaxAttempts:    c.RetryMaxAttempts,
+			BaseDelay:      c.RetryBaseDelay.String(),
+			MaxDelay:       c.RetryMaxDelay.String(),
+			MaxElapsedTime: c.RetryMaxElapsedTime.String(),
+			Jitter:         c.RetryJitter,
+			ParseErrors:    c.RetryParseErrors,
+		},
+		CircuitBreaker: mcpserver.CircuitBreakerSettings{
+			Enabled:          c.CircuitBreakerEnabled == nil || *c.CircuitBreakerEnabled,
+			MaxRequests:      c.CircuitBreakerMaxRequests,
+			Interval:         c.CircuitBreakerInterval.String(),
+			Timeout:          c.CircuitBreakerTimeout.String(),
+			FailureThreshold: c.CircuitBreakerFailureThreshold,
+		},
+		Parsing: mcpserver.ParsingSettings{
+			MissingDateStrategy:    string(c.MissingDateStrategy),
+			HideFutureItems:        c.HideFutureItems,
+			FutureItemTolerance:    c.FutureItemTolerance.String(),
+			StrictParsing:          c.StrictParsing,
+			LenientXML:             c.LenientXML,
+			StableIDChain:          stableIDChain,
+			ResolveRelativeURLs:    c.ResolveRelativeURLs == nil || *c.ResolveRelativeURLs,
+			DeduplicateWithinFeed:  c.DeduplicateWithinFeed == nil || *c.DeduplicateWithinFeed,
+			NormalizeCategories:    c.NormalizeCategories || len(c.CategorySynonyms) > 0,
+			SearchIndex:            c.EnableSearchIndex,
+			VerifyEnclosures:       c.VerifyEnclosures,
+			VerifyBodyCompleteness: c.VerifyBodyCompleteness == nil || *c.VerifyBodyCompleteness,
+		},
+		RefreshCron:                c.RefreshCron,
+		DuplicateFeedCheckInterval: c.DuplicateFeedCheckInterval.String(),
+		FeedStoreFile:              c.FeedStoreFile,
+		MaxConcurrentFetches:       c.MaxConcurrentFetches,
+	}
+	if len(c.UserAgents) > 0 {
+		settings.HTTP.UserAgents = c.UserAgents
+		settings.HTTP.UserAgentRotation = string(cmp.Or(c.UserAgentRotation, UserAgentRoundRobin))
+	}
+	if len(c.FeedPriorities) > 0 {
+		settings.FeedPriorities = make(map[string]int, len(c.FeedPriorities))
+		for feedURL, priority := range c.FeedPriorities {
+			settings.FeedPriorities[redactURLPassword(feedURL)] = priority
+		}
+	}
+	if len(c.PerFeedHeaders) > 0 {
+		settings.PerFeedHeaders = make(map[string]map[string]string, len(c.PerFeedHeaders))
+		for feedURL, headers := range c.PerFeedHeaders {
+			redacted := make(map[string]string, len(headers))
+			for name := range headers {
+				redacted[name] = mcpserver.RedactedValue
+			}
+			settings.PerFeedHeaders[redactURLPassword(feedURL)] = redacted
+		}
+	}
+	return settings, nil
+}
+
+// redactURLPassword masks the password in a URL's user info, if any, as
+// url.URL.Redacted does.
+func redactURLPassword(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/time/rate"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// Enclosure verification limits, per feed fetch.
+const (
+	// maxEnclosureChecks caps the enclosures checked in one fetch; later items
+	// (usually the oldest) are left unverified.
+	maxEnclosureChecks = 50
+	// enclosureCheckConcurrency bounds the HEAD requests in flight, and
+	// enclosureCheckRate the requests started per second, on top of the
+	// client's per-host rate limit.
+	enclosureCheckConcurrency = 4
+	enclosureCheckRate        = 4
+	// enclosureCheckTimeout bounds each HEAD request.
+	enclosureCheckTimeout = 10 * time.Second
+)
+
+// verifyEnclosures issues a HEAD request for each enclosure URL in the feed,
+// up to maxEnclosureChecks, and records what it finds on the item under
+// model.EnclosureChecksKey. URLs must already be resolved.
+func verifyEnclosures(ctx context.Context, client *http.Client, items []*gofeed.Item) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// Collect the URLs to check, each once even if several items share it.
+	var urls []string
+	seen := make(map[string]bool)
+collect:
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		for _, enclosure := range item.Enclosures {
+			if enclosure == nil || enclosure.URL == "" || seen[enclosure.URL] {
+				continue
+			}
+			if len(urls) == maxEnclosureChecks {
+				break collect
+			}
+			seen[enclosure.URL] = true
+			urls = append(urls, enclosure.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	results := make(map[string]model.EnclosureCheck, len(urls))
+	var mu sync.Mutex
+	limiter := rate.NewLimiter(rate.Limit(enclosureCheckRate), 1)
+	slots := make(chan struct{}, enclosureCheckConcurrency)
+	var wg sync.WaitGroup
+	for _, enclosureURL := range urls {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+			check := checkEnclosure(ctx, client, enclosureURL)
+			mu.Lock()
+			results[enclosureURL] = check
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		var checks []model.EnclosureCheck
+		for _, enclosure := range item.Enclosures {
+			if enclosure == nil {
+				continue
+			}
+			if check, ok := results[enclosure.URL]; ok {
+				checks = append(checks, check)
+			}
+		}
+		model.SetEnclosureChecks(item, checks)
+	}
+}
+
+// checkEnclosure sends a HEAD request for an enclosure URL and reports whether
+// it is reachable, with its Content-Length and Content-Type.
+func checkEnclosure(ctx context.Context, client *http.Client, enclosureURL string) model.EnclosureCheck {
+	check := model.EnclosureCheck{URL: enclosureURL}
+	parsed, err := url.Parse(enclosureURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		check.Error = "not an http(s) URL"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, enclosureCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, enclosureURL, http.NoBody)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		check.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		return check
+	}
+	check.Reachable = true
+	check.VerifiedSize = resp.ContentLength
+	check.VerifiedType = resp.Header.Get("Content-Type")
+	return check
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_VerifyEnclosures(t *testing.T) {
+	var heads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed":
+			// Relative enclosure URLs resolve against the channel link.
+			w.Header().Set("Content-Type", "application/rss+xml")
+			_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Podcast</title><link>http://` + r.Host + `/</link>` +
+				`<item><title>Episode 2</title><guid>ep2</guid><enclosure url="/ep2.mp3" length="100" type="audio/mpeg"/></item>` +
+				`<item><title>Episode 1</title><guid>ep1</guid><enclosure url="/missing.mp3" length="200" type="audio/mpeg"/></item>` +
+				`<item><title>Notes</title><guid>notes</guid></item>` +
+				`</channel></rss>`))
+		case "/ep2.mp3":
+			if r.Method != http.MethodHead {
+				t.Errorf("enclosure requested with %s, want HEAD", r.Method)
+			}
+			heads.Add(1)
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Header().Set("Content-Length", "123456")
+		default:
+			heads.Add(1)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	fetch := func(verify bool) *model.FeedAndItemsResult {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL + "/feed"}, AllowPrivateIPs: true, VerifyEnclosures: verify, RequestsPerSecond: 1000, BurstCapacity: 1000})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+"/feed"))
+		if err != nil || len(result.Items) != 3 {
+			t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+		}
+		return result
+	}
+
+	result := fetch(true)
+	checks := model.ItemEnclosureChecks(result.Items[0])
+	got, ok := checks[srv.URL+"/ep2.mp3"]
+	if !ok {
+		t.Fatalf("no check recorded for the reachable enclosure: %v", result.Items[0].Custom)
+	}
+	if !got.Reachable || got.VerifiedSize != 123456 || got.VerifiedType != "audio/mpeg" {
+		t.Errorf("reachable enclosure check = %+v, want size 123456 and type audio/mpeg", got)
+	}
+
+	missing := model.ItemEnclosureChecks(result.Items[1])[srv.URL+"/missing.mp3"]
+	if missing.Reachable || missing.Error != "HTTP 404" {
+		t.Errorf("missing enclosure check = %+v, want unreachable with HTTP 404", missing)
+	}
+	if model.ItemEnclosureChecks(result.Items[2]) != nil {
+		t.Error("item without enclosures has checks recorded")
+	}
+
+	heads.Store(0)
+	result = fetch(false)
+	if heads.Load() != 0 || model.ItemEnclosureChecks(result.Items[0]) != nil {
+		t.Errorf("with verification off: %d enclosure requests, checks %v", heads.Load(), result.Items[0].Custom)
+	}
+}
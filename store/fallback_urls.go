@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// FetchedURLMetadataKey records, in gofeed.Feed.Custom, the URL a feed with
+// fallback URLs (see Config.FallbackURLs), or an http:// feed upgraded to
+// https:// (see Config.UpgradeInsecureFeeds), was actually fetched from: the
+// feed's own URL, its https:// equivalent, or one of its fallbacks.
+const FetchedURLMetadataKey = "feed_mcp_fetched_url"
+
+// fetchFeed fetches url, retrying as configured and going through its circuit
+// breaker when there is one. With Config.UpgradeInsecureFeeds, an http:// url
+// is first tried once over https://. If url fails and url has fallback URLs, each is
+// tried in turn with the same retries, and the first to succeed is returned
+// along with the URL it came from. When every URL fails, the error is the
+// primary URL's, so the failure is reported against the feed itself.
+func (s *Store) fetchFeed(
+	ctx context.Context,
+	url string,
+	fp *gofeed.Parser,
+	config *Config,
+	circuitBreakerEnabled bool,
+) (feed *gofeed.Feed, fetchedURL string, err error) {
+	if config.UpgradeInsecureFeeds && isInsecureURL(url) {
+		if feed, secureURL, ok := s.fetchUpgraded(ctx, url, fp, config); ok {
+			return feed, secureURL, nil
+		}
+	}
+
+	if cb, exists := s.circuitBreaker(url); circuitBreakerEnabled && exists {
+		feed, err = s.fetchWithCircuitBreaker(ctx, url, fp, config, cb)
+	} else {
+		feed, err = retryableFeedFetch(ctx, url, fp, *config, s.retryMetrics, &s.metricsMutex)
+	}
+	if err == nil {
+		return feed, url, nil
+	}
+
+	for i, fallback := range config.FallbackURLs[url] {
+		if ctx.Err() != nil {
+			break
+		}
+		fallbackFeed, fallbackErr := retryableFeedFetch(ctx, fallback, fp, *config, s.retryMetrics, &s.metricsMutex)
+		if fallbackErr == nil {
+			model.DebugLogWithContext(
+				"Fetched feed from a fallback URL",
+				"feed_fetcher", "fallback_fetch", url,
+				map[string]any{"fallback_url": fallback, "fallback_index": i + 1},
+			)
+			return fallbackFeed, fallback, nil
+		}
+		model.DebugLogWithContext(
+			fmt.Sprintf("Fallback URL %d failed", i+1),
+			"feed_fetcher", "fallback_fetch", url,
+			map[string]any{"fallback_url": fallback, "error": fallbackErr.Error()},
+		)
+	}
+	return nil, "", err
+}
+
+// fetchUpgraded fetches the https:// equivalent of the http:// url once,
+// without retries: a host that doesn't serve HTTPS usually refuses the
+// connection or fails the handshake, and retrying would only delay the
+// http:// fetch.
+func (s *Store) fetchUpgraded(ctx context.Context, url string, fp *gofeed.Parser, config *Config) (*gofeed.Feed, string, bool) {
+	secureURL := upgradedURL(url)
+	attemptConfig := *config
+	attemptConfig.RetryMaxAttempts = 1
+	feed, err := retryableFeedFetch(ctx, secureURL, fp, attemptConfig, s.retryMetrics, &s.metricsMutex)
+	if err != nil {
+		model.DebugLogWithContext(
+			"HTTPS upgrade failed, fetching over HTTP",
+			"feed_fetcher", "upgrade_fetch", url,
+			map[string]any{"https_url": secureURL, "error": err.Error()},
+		)
+		return nil, "", false
+	}
+	return feed, secureURL, true
+}
+
+// isInsecureURL reports whether url is an http:// URL.
+func isInsecureURL(url string) bool {
+	return len(url) > len("http://") && strings.EqualFold(url[:len("http://")], "http://")
+}
+
+// upgradedURL returns the http:// url with an https:// scheme. An explicit
+// port is kept.
+func upgradedURL(url string) string {
+	return "https://" + url[len("http://"):]
+}
+
+// withUpgradedHeaderURLs returns perFeed with each http:// feed's headers also
+// registered under its https:// equivalent, so an upgraded fetch sends them.
+// A header set configured for the https:// URL itself is kept.
+func withUpgradedHeaderURLs(perFeed map[string]map[string]string) map[string]map[string]string {
+	upgraded := maps.Clone(perFeed)
+	for feedURL, headers := range perFeed {
+		if !isInsecureURL(feedURL) {
+			continue
+		}
+		if _, ok := upgraded[upgradedURL(feedURL)]; !ok {
+			upgraded[upgradedURL(feedURL)] = headers
+		}
+	}
+	return upgraded
+}
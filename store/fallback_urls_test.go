@@ -0,0 +1,216 @@
+package store
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_FallbackURLs(t *testing.T) {
+	var primaryRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed.rss", func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/broken.atom", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/feed.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		_, _ = w.Write([]byte(`{"version":"https://jsonfeed.org/version/1.1","title":"JSON Variant","items":[{"id":"1","title":"Hello","url":"posts/1"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	primary := srv.URL + "/feed.rss"
+	fallback := srv.URL + "/feed.json"
+	s, err := NewStore(&Config{
+		Feeds:            []string{primary},
+		AllowPrivateIPs:  true,
+		RetryMaxAttempts: 2,
+		RetryBaseDelay:   1,
+		FallbackURLs:     map[string][]string{primary: {srv.URL + "/broken.atom", fallback}},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(primary))
+	if err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	if result.FetchError != "" || result.Title != "JSON Variant" || len(result.Items) != 1 {
+		t.Fatalf("result = %+v, want the JSON fallback's feed", result)
+	}
+	if got := result.Feed.Custom[FetchedURLMetadataKey]; got != fallback {
+		t.Errorf("%s = %q, want %q", FetchedURLMetadataKey, got, fallback)
+	}
+	// A 404 is not retried, so the primary is requested once.
+	if got := primaryRequests.Load(); got != 1 {
+		t.Errorf("primary fetched %d times, want 1", got)
+	}
+	// Relative links resolve against the URL the feed came from.
+	if got, want := result.Items[0].Link, srv.URL+"/posts/1"; got != want {
+		t.Errorf("item link = %q, want %q", got, want)
+	}
+}
+
+func TestStore_FallbackURLsAllFail(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	primary := srv.URL + "/feed.rss"
+	s, err := NewStore(&Config{
+		Feeds:            []string{primary},
+		AllowPrivateIPs:  true,
+		RetryMaxAttempts: 1,
+		FallbackURLs:     map[string][]string{primary: {srv.URL + "/feed.atom"}},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	_, err = s.feedCacheManager.Get(context.Background(), primary)
+	fe, ok := err.(*model.FeedError)
+	if !ok || fe.URL != primary {
+		t.Errorf("fetch error = %v, want the primary URL's error", err)
+	}
+}
+
+// dualProtocolListener serves TLS and plain HTTP on one port, telling them
+// apart by the first byte of each connection (0x16 opens a TLS handshake).
+type dualProtocolListener struct {
+	net.Listener
+	tlsConfig *tls.Config
+}
+
+func (l *dualProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	first, err := reader.Peek(1)
+	peeked := &peekedConn{Conn: conn, reader: reader}
+	if err == nil && first[0] == 0x16 {
+		return tls.Server(peeked, l.tlsConfig), nil
+	}
+	return peeked, nil
+}
+
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) { return c.reader.Read(p) }
+
+func TestStore_UpgradeInsecureFeeds(t *testing.T) {
+	var plainRequests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := "Secure"
+		if r.TLS == nil {
+			plainRequests.Add(1)
+			title = "Plain"
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>` + title + `</title><item><title>Hello</title><link>/posts/1</link></item></channel></rss>`))
+	})
+
+	// tlsSrv supplies a certificate and a client that trusts it.
+	tlsSrv := httptest.NewTLSServer(handler)
+	defer tlsSrv.Close()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	dual := &http.Server{Handler: handler, ReadHeaderTimeout: time.Second}
+	go func() { _ = dual.Serve(&dualProtocolListener{Listener: listener, tlsConfig: tlsSrv.TLS}) }()
+	defer func() { _ = dual.Close() }()
+	plainOnly := httptest.NewServer(handler)
+	defer plainOnly.Close()
+
+	tests := []struct {
+		name        string
+		feedURL     string
+		upgrade     bool
+		wantTitle   string
+		wantFetched string
+	}{
+		{"both protocols", "http://" + listener.Addr().String() + "/feed.rss", true, "Secure", "https://" + listener.Addr().String() + "/feed.rss"},
+		{"http only", plainOnly.URL + "/feed.rss", true, "Plain", plainOnly.URL + "/feed.rss"},
+		{"upgrade disabled", "http://" + listener.Addr().String() + "/feed.rss", false, "Plain", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plainRequests.Store(0)
+			s, err := NewStore(&Config{
+				Feeds:                []string{tt.feedURL},
+				AllowPrivateIPs:      true,
+				HTTPClient:           tlsSrv.Client(),
+				RetryMaxAttempts:     1,
+				UpgradeInsecureFeeds: tt.upgrade,
+			})
+			if err != nil {
+				t.Fatalf("NewStore failed: %v", err)
+			}
+
+			result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(tt.feedURL))
+			if err != nil {
+				t.Fatalf("GetFeedAndItems: %v", err)
+			}
+			if result.FetchError != "" || result.Title != tt.wantTitle {
+				t.Fatalf("title = %q (error %q), want %q", result.Title, result.FetchError, tt.wantTitle)
+			}
+			if got := result.Feed.Custom[FetchedURLMetadataKey]; got != tt.wantFetched {
+				t.Errorf("%s = %q, want %q", FetchedURLMetadataKey, got, tt.wantFetched)
+			}
+			wantPlain := int32(1)
+			if tt.wantTitle == "Secure" {
+				wantPlain = 0
+			}
+			if got := plainRequests.Load(); got != wantPlain {
+				t.Errorf("plain HTTP requests = %d, want %d", got, wantPlain)
+			}
+			// Relative links resolve against the URL the feed came from.
+			if fetched := cmp.Or(tt.wantFetched, tt.feedURL); result.Items[0].Link != strings.Replace(fetched, "/feed.rss", "/posts/1", 1) {
+				t.Errorf("item link = %q, want it resolved against %s", result.Items[0].Link, fetched)
+			}
+		})
+	}
+}
+
+func TestWithUpgradedHeaderURLs(t *testing.T) {
+	headers := withUpgradedHeaderURLs(map[string]map[string]string{
+		"http://example.com/feed":  {"X-API-Key": "a"},
+		"http://example.org/feed":  {"X-API-Key": "b"},
+		"https://example.org/feed": {"X-API-Key": "c"},
+		"https://example.net/feed": {"X-API-Key": "d"},
+	})
+	want := map[string]string{
+		"http://example.com/feed":  "a",
+		"https://example.com/feed": "a",
+		"http://example.org/feed":  "b",
+		"https://example.org/feed": "c",
+		"https://example.net/feed": "d",
+	}
+	if len(headers) != len(want) {
+		t.Errorf("got %d header sets, want %d", len(headers), len(want))
+	}
+	for feedURL, key := range want {
+		if got := headers[feedURL]["X-API-Key"]; got != key {
+			t.Errorf("%s X-API-Key = %q, want %q", feedURL, got, key)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// feedHeaderTransport adds configured headers to requests for specific feed
+// URLs (see Config.PerFeedHeaders). Headers are matched on the exact request
+// URL, so they are not sent with redirects, icon lookups, or enclosure checks,
+// which keeps API keys from reaching other URLs.
+type feedHeaderTransport struct {
+	base    http.RoundTripper
+	headers map[string]http.Header
+}
+
+// newFeedHeaderTransport wraps base so that requests for the feed URLs in
+// perFeed carry their headers. A nil base means http.DefaultTransport.
+func newFeedHeaderTransport(base http.RoundTripper, perFeed map[string]map[string]string) *feedHeaderTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	headers := make(map[string]http.Header, len(perFeed))
+	for feedURL, values := range perFeed {
+		header := make(http.Header, len(values))
+		for name, value := range values {
+			header.Set(name, value)
+		}
+		headers[feedHeaderKey(feedURL)] = header
+	}
+	return &feedHeaderTransport{base: base, headers: headers}
+}
+
+// feedHeaderKey normalizes a feed URL the way a request built from it prints,
+// so configured URLs match req.URL.String().
+func feedHeaderKey(feedURL string) string {
+	parsed, err := url.Parse(feedURL)
+	if err != nil {
+		return feedURL
+	}
+	return parsed.String()
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *feedHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header, ok := t.headers[req.URL.String()]
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_PerFeedHeaders(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]http.Header{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.Header.Clone()
+		mu.Unlock()
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/other", http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	keyed, plain, moved := srv.URL+"/keyed", srv.URL+"/plain", srv.URL+"/moved"
+	s, err := NewStore(&Config{
+		Feeds:           []string{keyed, plain, moved},
+		AllowPrivateIPs: true,
+		PerFeedHeaders: map[string]map[string]string{
+			keyed: {"X-API-Key": "abc", "Accept": "application/rss+xml"},
+			moved: {"X-API-Key": "def"},
+		},
+		RequestsPerSecond: 1000,
+		BurstCapacity:     1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	for _, feedURL := range []string{keyed, plain, moved} {
+		if _, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(feedURL)); err != nil {
+			t.Fatalf("GetFeedAndItems(%s) failed: %v", feedURL, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := received["/keyed"]; got.Get("X-API-Key") != "abc" || got.Get("Accept") != "application/rss+xml" {
+		t.Errorf("configured feed headers = %v, want X-API-Key and Accept", got)
+	}
+	if got := received["/plain"].Get("X-API-Key"); got != "" {
+		t.Errorf("other feed sent X-API-Key %q", got)
+	}
+	if got := received["/moved"].Get("X-API-Key"); got != "def" {
+		t.Errorf("redirecting feed X-API-Key = %q, want def", got)
+	}
+	// Headers go only to the configured URL, not to where it redirects.
+	if got := received["/other"].Get("X-API-Key"); got != "" {
+		t.Errorf("redirect target received X-API-Key %q", got)
+	}
+}
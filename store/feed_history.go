@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// feedHistoryLimit caps the snapshots kept per feed; the oldest are dropped
+// first.
+const feedHistoryLimit = 100
+
+// feedHistory records a snapshot of each feed after every successful network
+// fetch, for FeedHistory. Cache hits never run the loader, so they add no
+// snapshots. The history is kept in memory and starts empty on each run.
+type feedHistory struct {
+	feeds map[string]*feedHistoryEntry // by feed URL
+	mu    sync.Mutex
+}
+
+// feedHistoryEntry is one feed's snapshots and the item stable IDs of the
+// latest, which the next snapshot is compared against.
+type feedHistoryEntry struct {
+	snapshots []mcpserver.FeedSnapshot
+	ids       map[string]struct{}
+}
+
+func newFeedHistory() *feedHistory {
+	return &feedHistory{feeds: make(map[string]*feedHistoryEntry)}
+}
+
+// record adds a snapshot of items, fetched from feedURL at fetchedAt.
+func (h *feedHistory) record(feedURL string, fetchedAt time.Time, items []*gofeed.Item) {
+	ids := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		if item != nil {
+			ids[model.ItemStableID(item)] = struct{}{}
+		}
+	}
+	snapshot := mcpserver.FeedSnapshot{
+		FetchedAt:   fetchedAt,
+		ItemCount:   len(ids),
+		ContentHash: model.ContentHash(items),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry, ok := h.feeds[feedURL]
+	if !ok {
+		entry = &feedHistoryEntry{}
+		h.feeds[feedURL] = entry
+	}
+	for id := range ids {
+		if _, seen := entry.ids[id]; !seen {
+			snapshot.Added++
+		}
+	}
+	for id := range entry.ids {
+		if _, kept := ids[id]; !kept {
+			snapshot.Removed++
+		}
+	}
+	snapshot.ItemDelta = snapshot.ItemCount - len(entry.ids)
+	entry.ids = ids
+	entry.snapshots = append(entry.snapshots, snapshot)
+	if over := len(entry.snapshots) - feedHistoryLimit; over > 0 {
+		entry.snapshots = slices.Delete(entry.snapshots, 0, over)
+	}
+}
+
+// snapshots returns a copy of feedURL's snapshots, oldest first.
+func (h *feedHistory) snapshots(feedURL string) []mcpserver.FeedSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry, ok := h.feeds[feedURL]; ok {
+		return slices.Clone(entry.snapshots)
+	}
+	return nil
+}
+
+// remove forgets a feed's history.
+func (h *feedHistory) remove(feedURL string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.feeds, feedURL)
+}
+
+// FeedHistory implements mcpserver.FeedHistoryProvider. It returns the
+// snapshots recorded since the store was created, oldest first, up to
+// feedHistoryLimit; a feed that hasn't been fetched yet has none.
+func (s *Store) FeedHistory(_ context.Context, feedID string) ([]mcpserver.FeedSnapshot, error) {
+	s.feedsMu.RLock()
+	url, ok := s.feeds[feedID]
+	s.feedsMu.RUnlock()
+	if !ok {
+		return nil, model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", feedID)).
+			WithOperation("export_feed_history").
+			WithComponent("feed_store")
+	}
+	return s.history.snapshots(url), nil
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// historyFeed returns an RSS body with an item for each GUID.
+func historyFeed(guids ...string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><rss version="2.0"><channel><title>History</title>`)
+	for _, guid := range guids {
+		fmt.Fprintf(&b, `<item><title>%s</title><guid>%s</guid></item>`, guid, guid)
+	}
+	b.WriteString(`</channel></rss>`)
+	return b.String()
+}
+
+func TestStore_FeedHistory(t *testing.T) {
+	bodies := []string{
+		historyFeed("a", "b"),
+		historyFeed("a", "b", "c", "d"),
+		historyFeed("c", "d", "e"),
+	}
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(fetches.Add(1)) - 1
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(bodies[min(n, len(bodies)-1)]))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+
+	if history, err := s.FeedHistory(ctx, feedID); err != nil || len(history) != 0 {
+		t.Fatalf("FeedHistory before any fetch = %v, %v; want none", history, err)
+	}
+
+	// The loadable cache stores loaded feeds asynchronously, so wait for each
+	// fetch to reach the cache before reading or refreshing it again.
+	waitCached := func(items int) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); s.cachedItemCount(ctx, srv.URL) != items; {
+			if time.Now().After(deadline) {
+				t.Fatalf("feed with %d items never reached the cache", items)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if _, err := s.GetFeedAndItems(ctx, feedID); err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	waitCached(2)
+	// A cache hit doesn't fetch, so it adds no snapshot.
+	if _, err := s.GetFeedAndItems(ctx, feedID); err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	for _, items := range []int{4, 3} {
+		if _, err := s.refreshFeed(ctx, srv.URL); err != nil {
+			t.Fatalf("refreshFeed: %v", err)
+		}
+		waitCached(items)
+	}
+
+	history, err := s.FeedHistory(ctx, feedID)
+	if err != nil {
+		t.Fatalf("FeedHistory: %v", err)
+	}
+	want := []struct{ count, delta, added, removed int }{
+		{2, 2, 2, 0},
+		{4, 2, 2, 0},
+		{3, -1, 1, 2},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("got %d snapshots, want %d: %+v", len(history), len(want), history)
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.ItemCount != w.count || got.ItemDelta != w.delta || got.Added != w.added || got.Removed != w.removed {
+			t.Errorf("snapshot %d = %+v, want count %d, delta %d, added %d, removed %d", i, got, w.count, w.delta, w.added, w.removed)
+		}
+		if got.ContentHash == "" {
+			t.Errorf("snapshot %d has no content hash", i)
+		}
+		if i > 0 && got.FetchedAt.Before(history[i-1].FetchedAt) {
+			t.Errorf("snapshot %d fetched at %v, before snapshot %d", i, got.FetchedAt, i-1)
+		}
+	}
+
+	var feedErr *model.FeedError
+	if _, err := s.FeedHistory(ctx, "missing"); !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("FeedHistory(missing) = %v, want a validation error", err)
+	}
+}
+
+func TestFeedHistory_Limit(t *testing.T) {
+	h := newFeedHistory()
+	for range feedHistoryLimit + 5 {
+		h.record("https://example.com/feed", time.Now(), nil)
+	}
+	if got := len(h.snapshots("https://example.com/feed")); got != feedHistoryLimit {
+		t.Errorf("kept %d snapshots, want %d", got, feedHistoryLimit)
+	}
+	h.remove("https://example.com/feed")
+	if got := h.snapshots("https://example.com/feed"); got != nil {
+		t.Errorf("snapshots after remove = %v, want none", got)
+	}
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// baseFeedID derives a feed's ID from its URL before collisions are resolved.
+// It is a variable so tests can force collisions.
+var baseFeedID = model.GenerateFeedID
+
+// assignFeedIDs maps each distinct URL to a feed ID. URLs whose base IDs
+// collide (GenerateFeedID keeps only the host and path, and hashes long ones)
+// are sorted, and all but the first get a numeric suffix ("-2", "-3", ...), so
+// a given set of URLs always gets the same IDs whatever order it is listed in.
+// Each collision is logged, since the suffixed IDs change if the first URL is
+// removed.
+func assignFeedIDs(urls []string) map[string]string {
+	byBase := make(map[string][]string)
+	for _, url := range urls {
+		base := baseFeedID(url)
+		if !slices.Contains(byBase[base], url) {
+			byBase[base] = append(byBase[base], url)
+		}
+	}
+
+	feeds := make(map[string]string, len(urls))
+	var collided []string
+	for base, group := range byBase {
+		feeds[base] = slices.Min(group)
+		if len(group) > 1 {
+			collided = append(collided, base)
+		}
+	}
+	// Suffixed IDs are assigned after every base ID is taken, in a fixed
+	// order, so a suffix never claims another URL's base ID.
+	slices.Sort(collided)
+	for _, base := range collided {
+		group := slices.Sorted(slices.Values(byBase[base]))
+		for _, url := range group[1:] {
+			id := suffixedFeedID(base, func(id string) bool { _, taken := feeds[id]; return taken })
+			feeds[id] = url
+			log.Printf("warning: feed %s has the same ID as %s (%s); using ID %s", url, group[0], base, id)
+		}
+	}
+	return feeds
+}
+
+// dedupeFeedURLs drops entries of urls that name a feed already listed, as
+// happens when an OPML file and command-line feeds overlap. Two URLs are the
+// same feed when model.FeedURLKey matches; the first spelling is kept and each
+// dropped one is logged. The order of the kept URLs is unchanged.
+func dedupeFeedURLs(urls []string) []string {
+	kept := make([]string, 0, len(urls))
+	seen := make(map[string]string, len(urls))
+	for _, u := range urls {
+		key := model.FeedURLKey(u)
+		if first, ok := seen[key]; ok {
+			log.Printf("feed %s duplicates %s; ignoring it", u, first)
+			continue
+		}
+		seen[key] = u
+		kept = append(kept, u)
+	}
+	return kept
+}
+
+// suffixedFeedID returns base with the first numeric suffix, from "-2", that
+// isn't taken.
+func suffixedFeedID(base string, taken func(string) bool) string {
+	for n := 2; ; n++ {
+		if id := fmt.Sprintf("%s-%d", base, n); !taken(id) {
+			return id
+		}
+	}
+}
+
+// feedIDLocked returns the ID the feed with url is registered under, or its
+// base ID when it isn't registered. The caller holds feedsMu.
+func (s *Store) feedIDLocked(url string) string {
+	base := baseFeedID(url)
+	if existing, ok := s.feeds[base]; !ok || existing == url {
+		return base
+	}
+	for id, existing := range s.feeds {
+		if existing == url {
+			return id
+		}
+	}
+	return base
+}
+
+// feedIDFor is feedIDLocked under the read lock.
+func (s *Store) feedIDFor(url string) string {
+	s.feedsMu.RLock()
+	defer s.feedsMu.RUnlock()
+	return s.feedIDLocked(url)
+}
+
+// availableFeedID returns the ID a feed added at runtime with url gets: its
+// base ID, or a suffixed one when another URL already has that ID. The
+// caller must not hold feedsMu.
+func (s *Store) availableFeedID(url string) string {
+	s.feedsMu.RLock()
+	defer s.feedsMu.RUnlock()
+	base := baseFeedID(url)
+	existing, ok := s.feeds[base]
+	if !ok || existing == url {
+		return base
+	}
+	id := suffixedFeedID(base, func(id string) bool { _, taken := s.feeds[id]; return taken })
+	log.Printf("warning: feed %s has the same ID as %s (%s); using ID %s", url, existing, base, id)
+	return id
+}
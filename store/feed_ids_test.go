@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+)
+
+// stubFeedIDs replaces baseFeedID with ids, falling back to GenerateFeedID
+// for URLs it doesn't list, for the rest of the test.
+func stubFeedIDs(t *testing.T, ids map[string]string) {
+	t.Helper()
+	original := baseFeedID
+	baseFeedID = func(url string) string {
+		if id, ok := ids[url]; ok {
+			return id
+		}
+		return original(url)
+	}
+	t.Cleanup(func() { baseFeedID = original })
+}
+
+func TestAssignFeedIDs(t *testing.T) {
+	stubFeedIDs(t, map[string]string{
+		"https://a.example/feed": "shared",
+		"https://b.example/feed": "shared",
+		"https://c.example/feed": "shared-2", // a base ID a suffix would otherwise take
+		"https://d.example/feed": "solo",
+	})
+	urls := []string{"https://b.example/feed", "https://d.example/feed", "https://a.example/feed", "https://c.example/feed", "https://a.example/feed"}
+	want := map[string]string{
+		"shared":   "https://a.example/feed",
+		"shared-3": "https://b.example/feed",
+		"shared-2": "https://c.example/feed",
+		"solo":     "https://d.example/feed",
+	}
+	if got := assignFeedIDs(urls); !maps.Equal(got, want) {
+		t.Errorf("assignFeedIDs = %v, want %v", got, want)
+	}
+	// The same set of URLs gets the same IDs in any order.
+	slices.Reverse(urls)
+	if got := assignFeedIDs(urls); !maps.Equal(got, want) {
+		t.Errorf("assignFeedIDs(reversed) = %v, want %v", got, want)
+	}
+}
+
+func TestDedupeFeedURLs(t *testing.T) {
+	urls := []string{
+		"https://example.com/feed",
+		"HTTPS://Example.COM:443/feed/",
+		"https://example.com/feed#latest",
+		"https://example.com/feed",
+		"http://example.com/feed",          // different scheme
+		"https://www.example.com/feed",     // different host
+		"https://example.com/feed?lang=fr", // different query
+		"https://[::1]:8080/feed",
+		"https://[::1]:8080/feed/",
+	}
+	want := []string{
+		"https://example.com/feed",
+		"http://example.com/feed",
+		"https://www.example.com/feed",
+		"https://example.com/feed?lang=fr",
+		"https://[::1]:8080/feed",
+	}
+	if got := dedupeFeedURLs(urls); !slices.Equal(got, want) {
+		t.Errorf("dedupeFeedURLs = %q, want %q", got, want)
+	}
+}
+
+func TestStore_DuplicateFeedURLs(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+	urls := []string{srv.URL + "/feed", srv.URL + "/feed/", srv.URL + "/feed#top", srv.URL + "/feed"}
+
+	s, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, RetryMaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	feeds, err := s.GetAllFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllFeeds: %v", err)
+	}
+	if len(feeds) != 1 || feeds[0].PublicURL != urls[0] {
+		t.Errorf("feeds = %+v, want one feed for %s", feeds, urls[0])
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want the feed fetched once", got)
+	}
+
+	// The limit counts feeds after deduplication.
+	if _, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, MaxFeeds: 1}); err != nil {
+		t.Errorf("NewStore with MaxFeeds 1: %v", err)
+	}
+
+	// KeepDuplicateFeeds registers each distinct spelling.
+	s, err = NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, KeepDuplicateFeeds: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if got := len(s.feedEntries()); got != 3 {
+		t.Errorf("KeepDuplicateFeeds registered %d feeds, want 3", got)
+	}
+}
+
+func TestStore_FeedIDCollision(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := strings.Trim(r.URL.Path, "/")
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>` + title + `</title><item><title>Hello</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	first, second := srv.URL+"/first", srv.URL+"/second"
+	stubFeedIDs(t, map[string]string{first: "collision", second: "collision"})
+	s, err := NewStore(&Config{Feeds: []string{second, first}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	ctx := context.Background()
+	feeds, err := s.GetAllFeeds(ctx)
+	if err != nil {
+		t.Fatalf("GetAllFeeds: %v", err)
+	}
+	ids := make(map[string]string)
+	for _, feed := range feeds {
+		ids[feed.ID] = feed.Title
+	}
+	if want := map[string]string{"collision": "first", "collision-2": "second"}; !maps.Equal(ids, want) {
+		t.Fatalf("feeds by ID = %v, want %v", ids, want)
+	}
+	for id, title := range ids {
+		result, err := s.GetFeedAndItems(ctx, id)
+		if err != nil || result.Title != title {
+			t.Errorf("GetFeedAndItems(%s) = %+v, %v; want %s", id, result, err, title)
+		}
+	}
+	if !s.urlRegistered(second) {
+		t.Error("urlRegistered(second) = false for a feed registered under a suffixed ID")
+	}
+}
+
+func TestDynamicStore_FeedIDCollision(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Feed</title></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	configured, added := srv.URL+"/configured", srv.URL+"/added"
+	stubFeedIDs(t, map[string]string{configured: "collision", added: "collision"})
+	ds, err := NewDynamicStore(&Config{Feeds: []string{configured}, AllowPrivateIPs: true}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore failed: %v", err)
+	}
+
+	info, err := ds.AddFeed(context.Background(), mcpserver.FeedConfig{URL: added})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if info.FeedID != "collision-2" {
+		t.Errorf("added feed ID = %q, want collision-2", info.FeedID)
+	}
+	if url, ok := ds.feedURL("collision"); !ok || url != configured {
+		t.Errorf("configured feed = %q, %v; want it kept under its base ID", url, ok)
+	}
+}
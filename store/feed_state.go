@@ -0,0 +1,164 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// feedStateVersion is the current version of the feed store file format.
+const feedStateVersion = 1
+
+// feedState is the on-disk form of the runtime-added feed set written to
+// Config.FeedStoreFile.
+type feedState struct {
+	Version int             `json:"version"`
+	Feeds   []persistedFeed `json:"feeds"`
+}
+
+// persistedFeed is one runtime-added feed and the metadata supplied for it.
+type persistedFeed struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Alias       string    `json:"alias,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	AddedAt     time.Time `json:"addedAt"`
+}
+
+// readFeedState loads the feed store file. A missing file yields an empty
+// state. A file that can't be decoded is moved aside (to path.corrupt-<unix>)
+// and an empty state is returned, so one bad write can't keep the server from
+// starting; the original is kept for inspection.
+func readFeedState(path string) (*feedState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &feedState{Version: feedStateVersion}, nil
+	}
+	if err != nil {
+		return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "failed to read feed store file", err).
+			WithOperation("load_feed_state").
+			WithComponent("dynamic_store")
+	}
+
+	var state feedState
+	if err := json.Unmarshal(data, &state); err != nil || state.Version > feedStateVersion {
+		if err == nil {
+			err = fmt.Errorf("unsupported version %d", state.Version)
+		}
+		backup, renameErr := model.MoveCorruptFile(path)
+		if renameErr != nil {
+			return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, "feed store file is unreadable and could not be moved aside", renameErr).
+				WithOperation("load_feed_state").
+				WithComponent("dynamic_store")
+		}
+		log.Printf("warning: feed store file %s is invalid (%v); moved to %s and starting with no runtime feeds", path, err, backup)
+		return &feedState{Version: feedStateVersion}, nil
+	}
+	return &state, nil
+}
+
+// writeFeedState atomically replaces the feed store file (see
+// model.WriteFileAtomic).
+func writeFeedState(path string, state *feedState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return model.WriteFileAtomic(path, data)
+}
+
+// feedStateLocked snapshots the runtime-added feeds, ordered by URL so the file
+// is stable across writes. Callers must hold dynamicMutex. The returned
+// generation orders snapshots taken by concurrent operations.
+func (ds *DynamicStore) feedStateLocked() (*feedState, uint64) {
+	state := &feedState{Version: feedStateVersion, Feeds: []persistedFeed{}}
+	for _, entry := range ds.feedEntries() {
+		meta := ds.feedMetadata[entry.id]
+		if meta == nil || meta.Source != mcpserver.FeedSourceRuntime {
+			continue
+		}
+		state.Feeds = append(state.Feeds, persistedFeed{
+			URL:         entry.url,
+			Title:       meta.Title,
+			Category:    meta.Category,
+			Description: meta.Description,
+			Alias:       meta.Alias,
+			Tags:        meta.Tags,
+			AddedAt:     meta.AddedAt,
+		})
+	}
+	slices.SortFunc(state.Feeds, func(a, b persistedFeed) int { return strings.Compare(a.URL, b.URL) })
+	ds.stateGeneration++
+	return state, ds.stateGeneration
+}
+
+// saveFeedState writes a snapshot taken by feedStateLocked, unless a newer
+// snapshot has already been written. It runs after dynamicMutex is released so
+// disk I/O never blocks other feed operations; persistMu serializes writers.
+// A failed write is logged rather than returned: the in-memory change has
+// already been applied and is retried on the next write.
+func (ds *DynamicStore) saveFeedState(state *feedState, generation uint64) {
+	if ds.config.FeedStoreFile == "" || state == nil {
+		return
+	}
+	ds.persistMu.Lock()
+	defer ds.persistMu.Unlock()
+	if generation <= ds.savedGeneration {
+		return
+	}
+	if err := writeFeedState(ds.config.FeedStoreFile, state); err != nil {
+		log.Printf("warning: failed to write feed store file %s: %v", ds.config.FeedStoreFile, err)
+		return
+	}
+	ds.savedGeneration = generation
+}
+
+// loadPersistedFeeds registers the feeds recorded in the feed store file as
+// runtime feeds. Like startup feeds they are fetched lazily on first use, and
+// the dial-time SSRF guard checks every destination when they are. Feeds that
+// are already registered (e.g. also passed on the command line) are skipped.
+func (ds *DynamicStore) loadPersistedFeeds() error {
+	if ds.config.FeedStoreFile == "" || !ds.allowRuntimeFeeds {
+		return nil
+	}
+	state, err := readFeedState(ds.config.FeedStoreFile)
+	if err != nil {
+		return err
+	}
+
+	ds.dynamicMutex.Lock()
+	defer ds.dynamicMutex.Unlock()
+	for _, feed := range state.Feeds {
+		if feed.URL == "" || ds.urlRegistered(feed.URL) {
+			continue
+		}
+		if ds.atFeedLimit() {
+			log.Printf("warning: feed limit of %d reached; not restoring %s from feed store file", ds.config.MaxFeeds, feed.URL)
+			continue
+		}
+		feedID := ds.availableFeedID(feed.URL)
+		ds.putFeed(feedID, feed.URL, ds.newCircuitBreaker(feed.URL))
+		ds.feedMetadata[feedID] = &DynamicFeedMetadata{
+			Title:       feed.Title,
+			Category:    feed.Category,
+			Description: feed.Description,
+			Alias:       feed.Alias,
+			Tags:        model.NormalizeFeedTags(feed.Tags),
+			AddedAt:     feed.AddedAt,
+			Source:      mcpserver.FeedSourceRuntime,
+			Status:      statusActive,
+		}
+	}
+	return nil
+}
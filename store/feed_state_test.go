@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// newFeedStateServer serves a minimal RSS feed on every path.
+func newFeedStateServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Feed ` + r.URL.Path + `</title><item><title>i</title></item></channel></rss>`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestDynamicStore_FeedStoreFile_RestoresAfterRestart adds and removes runtime
+// feeds, then builds a new store from the same file to simulate a restart.
+func TestDynamicStore_FeedStoreFile_RestoresAfterRestart(t *testing.T) {
+	srv := newFeedStateServer(t)
+	path := filepath.Join(t.TempDir(), "feeds.json")
+	ctx := context.Background()
+
+	ds, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+	for _, cfg := range []mcpserver.FeedConfig{
+		{URL: srv.URL + "/go", Title: "Go Blog", Category: "tech", Description: "Go news"},
+		{URL: srv.URL + "/cooking", Category: "food"},
+		{URL: srv.URL + "/gone"},
+	} {
+		if _, err := ds.AddFeed(ctx, cfg); err != nil {
+			t.Fatalf("AddFeed(%s): %v", cfg.URL, err)
+		}
+	}
+	if _, err := ds.RemoveFeedByURL(ctx, srv.URL+"/gone"); err != nil {
+		t.Fatalf("RemoveFeedByURL: %v", err)
+	}
+	if _, err := ds.UpdateFeedMetadata(ctx, model.GenerateFeedID(srv.URL+"/cooking"), mcpserver.FeedMetadata{Category: "recipes"}); err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+
+	restarted, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore after restart: %v", err)
+	}
+	feeds, err := restarted.ListManagedFeeds(ctx)
+	if err != nil {
+		t.Fatalf("ListManagedFeeds: %v", err)
+	}
+	got := make(map[string]mcpserver.ManagedFeedInfo, len(feeds))
+	for _, f := range feeds {
+		got[f.URL] = f
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 restored feeds, got %d: %v", len(got), feeds)
+	}
+	goFeed := got[srv.URL+"/go"]
+	if goFeed.Title != "Go Blog" || goFeed.Category != "tech" || goFeed.Description != "Go news" {
+		t.Errorf("metadata not restored: %+v", goFeed)
+	}
+	if goFeed.Source != string(mcpserver.FeedSourceRuntime) {
+		t.Errorf("Source = %q, want runtime", goFeed.Source)
+	}
+	if got[srv.URL+"/cooking"].Category != "recipes" {
+		t.Errorf("updated category not restored: %+v", got[srv.URL+"/cooking"])
+	}
+	if _, ok := got[srv.URL+"/gone"]; ok {
+		t.Error("removed feed was restored")
+	}
+
+	// Restored feeds are runtime feeds, so they remain removable.
+	if _, err := restarted.RemoveFeedByURL(ctx, srv.URL+"/go"); err != nil {
+		t.Errorf("RemoveFeedByURL on restored feed: %v", err)
+	}
+}
+
+// TestDynamicStore_FeedStoreFile_ConcurrentAdds verifies that concurrent adds
+// all reach the file: the last write always reflects the full feed set.
+func TestDynamicStore_FeedStoreFile_ConcurrentAdds(t *testing.T) {
+	srv := newFeedStateServer(t)
+	path := filepath.Join(t.TempDir(), "feeds.json")
+
+	ds, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path, RequestsPerSecond: 100, BurstCapacity: 100}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+	const n = 10
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := ds.AddFeed(context.Background(), mcpserver.FeedConfig{URL: fmt.Sprintf("%s/feed-%d", srv.URL, i)}); err != nil {
+				t.Errorf("AddFeed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	state, err := readFeedState(path)
+	if err != nil {
+		t.Fatalf("readFeedState: %v", err)
+	}
+	if len(state.Feeds) != n {
+		t.Errorf("file has %d feeds, want %d", len(state.Feeds), n)
+	}
+}
+
+// TestDynamicStore_FeedStoreFile_CorruptRecovery verifies that an unreadable
+// file is moved aside and the store starts with no runtime feeds.
+func TestDynamicStore_FeedStoreFile_CorruptRecovery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "feeds": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore with corrupt file: %v", err)
+	}
+	if len(ds.feedEntries()) != 0 {
+		t.Errorf("expected no feeds, got %d", len(ds.feedEntries()))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var backups int
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "feeds.json.corrupt-") {
+			backups++
+		}
+	}
+	if backups != 1 {
+		t.Errorf("expected corrupt file to be moved aside, dir has %v", entries)
+	}
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// newFeedTags validates and normalizes Config.FeedTags, keyed by feed URL.
+// It returns nil when no feed has tags.
+func newFeedTags(config *Config) (map[string][]string, error) {
+	var tags map[string][]string
+	for feedURL, feedTags := range config.FeedTags {
+		if err := model.ValidateFeedTags(feedTags); err != nil {
+			return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, fmt.Sprintf("invalid tags for feed: %v", err), err).
+				WithURL(feedURL).
+				WithOperation("create_store").
+				WithComponent("store_manager")
+		}
+		if normalized := model.NormalizeFeedTags(feedTags); normalized != nil {
+			if tags == nil {
+				tags = make(map[string][]string, len(config.FeedTags))
+			}
+			tags[feedURL] = normalized
+		}
+	}
+	return tags, nil
+}
+
+// FeedTags implements mcpserver.FeedTagsProvider, mapping the ID of each feed
+// with configured tags to its tags.
+func (s *Store) FeedTags(_ context.Context) (map[string][]string, error) {
+	tags := make(map[string][]string)
+	for _, entry := range s.feedEntries() {
+		if feedTags := s.feedTags[entry.url]; len(feedTags) > 0 {
+			tags[entry.id] = feedTags
+		}
+	}
+	return tags, nil
+}
+
+// FeedTags implements mcpserver.FeedTagsProvider from the feeds' metadata, so
+// tags given to add_feed or update_feed are included.
+func (ds *DynamicStore) FeedTags(_ context.Context) (map[string][]string, error) {
+	ds.dynamicMutex.RLock()
+	defer ds.dynamicMutex.RUnlock()
+	tags := make(map[string][]string)
+	for id, meta := range ds.feedMetadata {
+		if len(meta.Tags) > 0 {
+			tags[id] = meta.Tags
+		}
+	}
+	return tags, nil
+}
+
+// invalidFeedTagsError reports tags rejected by model.ValidateFeedTags.
+func invalidFeedTagsError(err error, operation string) error {
+	return model.NewFeedErrorWithCause(model.ErrorTypeValidation, fmt.Sprintf("invalid feed tags: %v", err), err).
+		WithOperation(operation).
+		WithComponent("dynamic_store").
+		WithSuggestion(fmt.Sprintf("Use tags of up to %d characters without commas, such as priority:high", model.MaxFeedTagLength))
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_FeedTagsFromConfig(t *testing.T) {
+	tagged, plain := "https://a.example/feed", "https://b.example/feed"
+	s, err := NewStore(&Config{
+		Feeds:    []string{tagged, plain},
+		FeedTags: map[string][]string{tagged: {"Team:Infra", "priority:high", "team:infra"}},
+	})
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	tags, err := s.FeedTags(context.Background())
+	if err != nil {
+		t.Fatalf("FeedTags: %v", err)
+	}
+	if len(tags) != 1 || !slices.Equal(tags[model.GenerateFeedID(tagged)], []string{"priority:high", "team:infra"}) {
+		t.Errorf("FeedTags = %v, want only %s tagged priority:high and team:infra", tags, tagged)
+	}
+
+	if _, err := NewStore(&Config{Feeds: []string{tagged}, FeedTags: map[string][]string{tagged: {"a,b"}}}); err == nil {
+		t.Error("expected NewStore to reject a tag containing a comma")
+	}
+}
+
+func TestDynamicStore_FeedTags(t *testing.T) {
+	srv := newFeedStateServer(t)
+	path := filepath.Join(t.TempDir(), "feeds.json")
+	ctx := context.Background()
+	startupURL := srv.URL + "/startup"
+
+	ds, err := NewDynamicStore(&Config{
+		Feeds:           []string{startupURL},
+		FeedTags:        map[string][]string{startupURL: {"news"}},
+		AllowPrivateIPs: true,
+		FeedStoreFile:   path,
+	}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore: %v", err)
+	}
+	info, err := ds.AddFeed(ctx, mcpserver.FeedConfig{URL: srv.URL + "/infra", Tags: []string{"Team:Infra", "priority:high"}})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if want := []string{"priority:high", "team:infra"}; !slices.Equal(info.Tags, want) {
+		t.Errorf("AddFeed tags = %v, want %v", info.Tags, want)
+	}
+	if _, err := ds.AddFeed(ctx, mcpserver.FeedConfig{URL: srv.URL + "/bad", Tags: []string{"a,b"}}); err == nil {
+		t.Error("expected AddFeed to reject a tag containing a comma")
+	}
+
+	tags, err := ds.FeedTags(ctx)
+	if err != nil {
+		t.Fatalf("FeedTags: %v", err)
+	}
+	if !slices.Equal(tags[model.GenerateFeedID(startupURL)], []string{"news"}) || len(tags) != 2 {
+		t.Errorf("FeedTags = %v, want the startup feed tagged news and the added feed", tags)
+	}
+
+	// A non-nil list replaces the tags; leaving Tags nil keeps them.
+	if _, err := ds.UpdateFeedMetadata(ctx, info.FeedID, mcpserver.FeedMetadata{Tags: []string{"weekly"}}); err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+	updated, err := ds.UpdateFeedMetadata(ctx, info.FeedID, mcpserver.FeedMetadata{Category: "ops"})
+	if err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+	if !slices.Equal(updated.Tags, []string{"weekly"}) {
+		t.Errorf("tags after update = %v, want [weekly]", updated.Tags)
+	}
+
+	restarted, err := NewDynamicStore(&Config{AllowPrivateIPs: true, FeedStoreFile: path}, true)
+	if err != nil {
+		t.Fatalf("NewDynamicStore after restart: %v", err)
+	}
+	feeds, err := restarted.ListManagedFeeds(ctx)
+	if err != nil {
+		t.Fatalf("ListManagedFeeds: %v", err)
+	}
+	if len(feeds) != 1 || !slices.Equal(feeds[0].Tags, []string{"weekly"}) {
+		t.Errorf("restored feeds = %+v, want the added feed tagged weekly", feeds)
+	}
+
+	// An empty list clears the tags.
+	cleared, err := restarted.UpdateFeedMetadata(ctx, feeds[0].FeedID, mcpserver.FeedMetadata{Tags: []string{}})
+	if err != nil {
+		t.Fatalf("UpdateFeedMetadata: %v", err)
+	}
+	if cleared.Tags != nil {
+		t.Errorf("tags after clearing = %v, want none", cleared.Tags)
+	}
+}
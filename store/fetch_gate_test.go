@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_ResourceFetchGate(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>` + r.URL.Path + `</title><item><title>x</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	var urls []string
+	for i := range 10 {
+		urls = append(urls, fmt.Sprintf("%s/feed%d", srv.URL, i))
+	}
+	s, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, RequestsPerSecond: 1000, BurstCapacity: 1000})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	rm := mcpserver.NewResourceManagerWithConfig(s, s, &mcpserver.ResourceCacheConfig{MaxConcurrentFetches: 2})
+
+	// feeds://all fans out to every feed; single-feed reads run alongside it.
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		if _, err := rm.ReadResource(context.Background(), mcpserver.FeedListURI); err != nil {
+			t.Errorf("ReadResource(%s) failed: %v", mcpserver.FeedListURI, err)
+		}
+	})
+	for _, feedURL := range urls[:5] {
+		wg.Go(func() {
+			uri := "feeds://feed/" + model.GenerateFeedID(feedURL)
+			if _, err := rm.ReadResource(context.Background(), uri); err != nil {
+				t.Errorf("ReadResource(%s) failed: %v", uri, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got < 1 || got > 2 {
+		t.Errorf("peak concurrent upstream fetches = %d, want 1..2", got)
+	}
+}
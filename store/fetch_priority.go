@@ -0,0 +1,24 @@
+package store
+
+import (
+	"cmp"
+	"slices"
+)
+
+// feedPriority returns the fetch priority configured for a feed URL in
+// Config.FeedPriorities; feeds without one have priority zero.
+func (s *Store) feedPriority(url string) int {
+	return s.feedPriorities[url]
+}
+
+// byPriority orders feed entries highest priority first, keeping their order
+// among equals, so fan-outs start the most important fetches first.
+func (s *Store) byPriority(entries []feedEntry) []feedEntry {
+	if len(s.feedPriorities) == 0 {
+		return entries
+	}
+	slices.SortStableFunc(entries, func(a, b feedEntry) int {
+		return cmp.Compare(s.feedPriority(b.url), s.feedPriority(a.url))
+	})
+	return entries
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_FetchPriority(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched = append(fetched, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>` + r.URL.Path + `</title><item><title>x</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	paths := []string{"/low", "/default", "/urgent", "/high"}
+	var feeds []string
+	for _, path := range paths {
+		feeds = append(feeds, srv.URL+path)
+	}
+	s, err := NewStore(&Config{
+		Feeds:                feeds,
+		AllowPrivateIPs:      true,
+		RequestsPerSecond:    1000,
+		BurstCapacity:        1000,
+		MaxConcurrentFetches: 1,
+		FeedPriorities: map[string]int{
+			srv.URL + "/low":    -1,
+			srv.URL + "/urgent": 100,
+			srv.URL + "/high":   10,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	// Hold the only fetch slot so every feed queues before any is fetched.
+	release, err := model.AcquireFetchSlot(model.WithFetchGate(context.Background(), s.fetchGate))
+	if err != nil {
+		t.Fatalf("AcquireFetchSlot: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.GetAllFeeds(context.Background())
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("GetAllFeeds failed: %v", err)
+	}
+
+	if want := []string{"/urgent", "/high", "/default", "/low"}; !slices.Equal(fetched, want) {
+		t.Errorf("fetch order = %v, want highest priority first %v", fetched, want)
+	}
+}
+
+func TestStore_FeedURLsByPriority(t *testing.T) {
+	feeds := []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}
+	s, err := NewStore(&Config{
+		Feeds:          feeds,
+		FeedPriorities: map[string]int{"https://example.com/c": 2, "https://example.com/b": 1},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if got, want := s.feedURLs(), []string{"https://example.com/c", "https://example.com/b", "https://example.com/a"}; !slices.Equal(got, want) {
+		t.Errorf("feedURLs = %v, want %v", got, want)
+	}
+}
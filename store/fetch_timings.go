@@ -0,0 +1,72 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+)
+
+// fetchTimings records how long each feed's network fetches take. Only
+// fetches that reach the network are counted: cache hits never run the
+// loader, and fetches refused by the failed-feed backoff return before it.
+type fetchTimings struct {
+	feeds map[string]*fetchTiming // by feed URL
+	mu    sync.Mutex
+}
+
+// fetchTiming accumulates one feed's fetches.
+type fetchTiming struct {
+	fetches  int64
+	failures int64
+	total    time.Duration
+	last     time.Duration
+	lastAt   time.Time
+}
+
+func newFetchTimings() *fetchTimings {
+	return &fetchTimings{feeds: make(map[string]*fetchTiming)}
+}
+
+// record adds a fetch of feedURL that started at start and took elapsed,
+// failing when failed is set.
+func (t *fetchTimings) record(feedURL string, start time.Time, elapsed time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing, ok := t.feeds[feedURL]
+	if !ok {
+		timing = &fetchTiming{}
+		t.feeds[feedURL] = timing
+	}
+	timing.fetches++
+	if failed {
+		timing.failures++
+	}
+	timing.total += elapsed
+	timing.last = elapsed
+	timing.lastAt = start
+}
+
+// snapshot returns the timings of the given feeds, in order, leaving out feeds
+// that haven't been fetched.
+func (t *fetchTimings) snapshot(entries []feedEntry) []mcpserver.FeedTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := make([]mcpserver.FeedTiming, 0, len(entries))
+	for _, entry := range entries {
+		timing, ok := t.feeds[entry.url]
+		if !ok {
+			continue
+		}
+		timings = append(timings, mcpserver.FeedTiming{
+			FeedID:            entry.id,
+			URL:               entry.url,
+			Fetches:           timing.fetches,
+			Failures:          timing.failures,
+			LastDurationMs:    timing.last.Milliseconds(),
+			AverageDurationMs: (timing.total / time.Duration(timing.fetches)).Milliseconds(),
+			LastFetchedAt:     timing.lastAt,
+		})
+	}
+	return timings
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// visibleItems applies Config.HideFutureItems to items read from the cache,
+// returning the items to serve and how many future-dated ones were hidden.
+// It runs on every read rather than at fetch time, so a scheduled post shows
+// up once its publish date arrives without waiting for a re-fetch.
+func (s *Store) visibleItems(items []*gofeed.Item) ([]*gofeed.Item, int) {
+	if !s.settings.HideFutureItems {
+		return items, 0
+	}
+	return model.HideFutureItems(items, time.Now().Add(s.settings.FutureItemTolerance))
+}
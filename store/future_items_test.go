@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStore_HideFutureItems(t *testing.T) {
+	pubDate := func(d time.Duration) string { return time.Now().Add(d).UTC().Format(time.RFC1123Z) }
+	body := `<rss version="2.0"><channel><title>Scheduled</title>` +
+		`<item><title>published</title><guid>1</guid><pubDate>` + pubDate(-time.Hour) + `</pubDate></item>` +
+		`<item><title>scheduled</title><guid>2</guid><pubDate>` + pubDate(48*time.Hour) + `</pubDate></item>` +
+		`<item><title>skewed</title><guid>3</guid><pubDate>` + pubDate(time.Minute) + `</pubDate></item>` +
+		`</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		hide       bool
+		wantItems  int
+		wantHidden int
+	}{
+		{hide: false, wantItems: 3, wantHidden: 0},
+		{hide: true, wantItems: 2, wantHidden: 1},
+	} {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, HideFutureItems: tt.hide})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), s.feedEntries()[0].id)
+		if err != nil || result.FetchError != "" {
+			t.Fatalf("GetFeedAndItems: %v %s", err, result.FetchError)
+		}
+		if len(result.Items) != tt.wantItems || result.HiddenFutureItems != tt.wantHidden {
+			t.Errorf("HideFutureItems=%v: %d items, %d hidden; want %d, %d", tt.hide, len(result.Items), result.HiddenFutureItems, tt.wantItems, tt.wantHidden)
+		}
+		for _, item := range result.Items {
+			if tt.hide && item.Title == "scheduled" {
+				t.Error("the future-dated item was served")
+			}
+		}
+		if got := result.ToMetadata().HiddenFutureItems; got != tt.wantHidden {
+			t.Errorf("HideFutureItems=%v: metadata reports %d hidden, want %d", tt.hide, got, tt.wantHidden)
+		}
+	}
+}
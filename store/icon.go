@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// maxIconPageBytes bounds how much of a feed's home page is read when looking
+// for a <link rel="icon"> tag; icon links live in <head>, near the top.
+const maxIconPageBytes = 512 * 1024
+
+// iconCacheEntry records a resolved icon URL (empty when the feed has none) and
+// when it was resolved, so both hits and misses are cached.
+type iconCacheEntry struct {
+	resolvedAt time.Time
+	iconURL    string
+}
+
+// ResolveFeedIcon implements mcpserver.FeedIconResolver. It prefers the feed's
+// own <image>, then a <link rel="icon"> on the feed's home page, then the site's
+// /favicon.ico. Results (including "no icon", returned as an empty string) are
+// cached for the store's ExpireAfter, and every lookup goes through the store's
+// HTTP client so per-host rate limits and dial-time SSRF checks apply.
+func (s *Store) ResolveFeedIcon(ctx context.Context, id string) (string, error) {
+	feedURL, exists := s.feedURL(id)
+	if !exists {
+		return "", model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", id)).
+			WithOperation("resolve_feed_icon").
+			WithComponent("feed_store")
+	}
+
+	s.iconMu.Lock()
+	entry, cached := s.icons[feedURL]
+	s.iconMu.Unlock()
+	if cached && time.Since(entry.resolvedAt) < s.iconTTL {
+		return entry.iconURL, nil
+	}
+
+	feed, err := s.feedCacheManager.Get(ctx, feedURL)
+	if err != nil {
+		return "", err
+	}
+
+	var iconURL string
+	if feed.Image != nil && feed.Image.URL != "" {
+		iconURL = resolveReference(firstNonEmpty(feed.Link, feedURL), feed.Image.URL)
+	} else {
+		release, err := model.AcquireFetchSlot(ctx)
+		if err != nil {
+			return "", err
+		}
+		iconURL = s.discoverSiteIcon(ctx, firstNonEmpty(feed.Link, feedURL))
+		release()
+	}
+
+	// A canceled lookup says nothing about the feed; don't cache it as a miss.
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	s.iconMu.Lock()
+	s.icons[feedURL] = iconCacheEntry{iconURL: iconURL, resolvedAt: time.Now()}
+	s.iconMu.Unlock()
+
+	return iconURL, nil
+}
+
+// discoverSiteIcon looks for an icon declared on the site's home page, falling
+// back to /favicon.ico at the site root. It returns "" when neither is found.
+func (s *Store) discoverSiteIcon(ctx context.Context, siteURL string) string {
+	base, err := url.Parse(siteURL)
+	if err != nil || base.Host == "" {
+		return ""
+	}
+
+	if body, ok := s.fetchForIcon(ctx, http.MethodGet, base.String()); ok {
+		href := findIconLink(body)
+		_ = body.Close()
+		if href != "" {
+			return resolveReference(base.String(), href)
+		}
+	}
+
+	favicon := (&url.URL{Scheme: base.Scheme, Host: base.Host, Path: "/favicon.ico"}).String()
+	if body, ok := s.fetchForIcon(ctx, http.MethodHead, favicon); ok {
+		_ = body.Close()
+		return favicon
+	}
+	return ""
+}
+
+// fetchForIcon issues a request with the store's HTTP client and returns the
+// size-limited body when the response is a 200.
+func (s *Store) fetchForIcon(ctx context.Context, method, target string) (io.ReadCloser, bool) {
+	req, err := http.NewRequestWithContext(ctx, method, target, http.NoBody)
+	if err != nil {
+		return nil, false
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, false
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, maxIconPageBytes), resp.Body}, true
+}
+
+// findIconLink returns the href of the first <link rel="icon"> (or
+// "shortcut icon") in an HTML document, stopping at <body>.
+func findIconLink(r io.Reader) string {
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch string(name) {
+			case "body":
+				return ""
+			case "link":
+				var rel, href string
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = tokenizer.TagAttr()
+					switch string(key) {
+					case "rel":
+						rel = strings.ToLower(string(val))
+					case "href":
+						href = strings.TrimSpace(string(val))
+					}
+				}
+				if href != "" && isIconRel(rel) {
+					return href
+				}
+			}
+		}
+	}
+}
+
+// isIconRel reports whether a rel attribute value declares a site icon.
+func isIconRel(rel string) bool {
+	for _, token := range strings.Fields(rel) {
+		if token == "icon" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveReference resolves ref against base, returning ref unchanged if
+// either fails to parse.
+func resolveReference(base, ref string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// iconTestServer serves an RSS feed at /feed plus an optional home page and
+// /favicon.ico, counting home page requests.
+type iconTestServer struct {
+	*httptest.Server
+	homeHits atomic.Int32
+}
+
+func newIconTestServer(t *testing.T, feedImage, homePage string, hasFavicon bool) *iconTestServer {
+	t.Helper()
+	ts := &iconTestServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		image := ""
+		if feedImage != "" {
+			image = `<image><url>` + feedImage + `</url><title>Logo</title><link>` + ts.URL + `/</link></image>`
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Icon Feed</title><link>` + ts.URL + `/</link>` + image +
+			`<item><title>Item 1</title><link>` + ts.URL + `/1</link></item></channel></rss>`))
+	})
+	mux.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		if !hasFavicon {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/x-icon")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		ts.homeHits.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(homePage))
+	})
+	ts.Server = httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func resolveIconForServer(t *testing.T, ts *iconTestServer) (*Store, string, string) {
+	t.Helper()
+	feedURL := ts.URL + "/feed"
+	s, err := NewStore(&Config{Feeds: []string{feedURL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	id := model.GenerateFeedID(feedURL)
+	icon, err := s.ResolveFeedIcon(context.Background(), id)
+	if err != nil {
+		t.Fatalf("ResolveFeedIcon failed: %v", err)
+	}
+	return s, id, icon
+}
+
+func TestResolveFeedIcon_PrefersFeedImage(t *testing.T) {
+	ts := newIconTestServer(t, "https://cdn.example.com/logo.png", `<link rel="icon" href="/home.png">`, true)
+	_, _, icon := resolveIconForServer(t, ts)
+	if icon != "https://cdn.example.com/logo.png" {
+		t.Errorf("icon = %q, want the feed <image> URL", icon)
+	}
+	if ts.homeHits.Load() != 0 {
+		t.Error("home page should not be fetched when the feed has an <image>")
+	}
+}
+
+func TestResolveFeedIcon_HomePageLinkIcon(t *testing.T) {
+	home := `<html><head><link rel="stylesheet" href="/s.css"><link rel="shortcut icon" href="/static/icon.png"></head><body></body></html>`
+	ts := newIconTestServer(t, "", home, true)
+	s, id, icon := resolveIconForServer(t, ts)
+	if want := ts.URL + "/static/icon.png"; icon != want {
+		t.Errorf("icon = %q, want %q", icon, want)
+	}
+
+	// A second lookup is served from the icon cache.
+	if _, err := s.ResolveFeedIcon(context.Background(), id); err != nil {
+		t.Fatalf("ResolveFeedIcon (cached) failed: %v", err)
+	}
+	if hits := ts.homeHits.Load(); hits != 1 {
+		t.Errorf("home page fetched %d times, want 1 (cached)", hits)
+	}
+}
+
+func TestResolveFeedIcon_FaviconFallback(t *testing.T) {
+	ts := newIconTestServer(t, "", `<html><head><title>No icon</title></head></html>`, true)
+	_, _, icon := resolveIconForServer(t, ts)
+	if want := ts.URL + "/favicon.ico"; icon != want {
+		t.Errorf("icon = %q, want %q", icon, want)
+	}
+}
+
+func TestResolveFeedIcon_NoIcon(t *testing.T) {
+	ts := newIconTestServer(t, "", `<html><head></head></html>`, false)
+	_, _, icon := resolveIconForServer(t, ts)
+	if icon != "" {
+		t.Errorf("icon = %q, want empty", icon)
+	}
+}
+
+func TestResolveFeedIcon_UnknownFeed(t *testing.T) {
+	s, err := NewStore(&Config{Feeds: []string{"http://example.com/feed"}})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if _, err := s.ResolveFeedIcon(context.Background(), "missing"); err == nil {
+		t.Error("expected error for unknown feed ID")
+	}
+}
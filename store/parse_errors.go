@@ -0,0 +1,111 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// errTruncatedBody marks a body that ends before the document does, as when
+// a server intermittently cuts a (often chunked) response short. Unlike a
+// structurally invalid feed, the next fetch may well succeed. Bodies caught
+// by checkBodyComplete before parsing are always retried; parse failures on
+// truncated bodies, seen only when that check is off, are retried when
+// Config.RetryParseErrors is set.
+var errTruncatedBody = errors.New("feed body appears truncated")
+
+// checkBodyComplete rejects a body that ended early, before it is parsed, so
+// a partial feed is neither misreported as malformed nor repaired into a feed
+// missing items by LenientXML. A body is incomplete when it is shorter than
+// the response's Content-Length (when known) or, per bodyTruncated, stops
+// with JSON values or XML elements still open.
+func checkBodyComplete(feedURL string, body []byte, contentLength int64) error {
+	if contentLength > 0 && int64(len(body)) < contentLength {
+		return truncatedBodyError(feedURL, fmt.Sprintf("received %d of the %d bytes in Content-Length", len(body), contentLength), nil)
+	}
+	if bodyTruncated(body) {
+		return truncatedBodyError(feedURL, fmt.Sprintf("the %d-byte body ends before the feed document does", len(body)), nil)
+	}
+	return nil
+}
+
+// truncatedBodyError reports a feed body cut short, detail saying how. It
+// wraps errTruncatedBody (and cause, if any), so the fetch is retried.
+func truncatedBodyError(feedURL, detail string, cause error) error {
+	err := errTruncatedBody
+	if cause != nil {
+		err = fmt.Errorf("%w: %w", errTruncatedBody, cause)
+	}
+	return model.NewFeedErrorWithCause(model.ErrorTypeNetwork, "feed response was truncated: "+detail, err).
+		WithURL(feedURL).
+		WithOperation("fetch_feed").
+		WithComponent("feed_fetcher").
+		WithSuggestion("The server closed the response early; this is usually transient and is retried")
+}
+
+// parseFailureError wraps a failure to parse a fetched body, classifying it
+// as malformed JSON or XML and marking it with errTruncatedBody when the body
+// stops mid-document. Parse failures aren't retried unless they are
+// truncations and RetryParseErrors is set.
+func parseFailureError(feedURL string, body []byte, err error) error {
+	errorType := model.ErrorTypeMalformedXML
+	if looksLikeJSON(body) {
+		errorType = model.ErrorTypeMalformedJSON
+	}
+	message := fmt.Sprintf("failed to parse the %d-byte feed body: %v", len(body), err)
+	if bodyTruncated(body) {
+		err = fmt.Errorf("%w: %w", errTruncatedBody, err)
+		message = fmt.Sprintf("failed to parse the %d-byte feed body, which appears truncated: %v", len(body), errors.Unwrap(err))
+	}
+	return model.NewFeedErrorWithCause(errorType, message, err).
+		WithURL(feedURL).
+		WithOperation("parse_feed").
+		WithComponent("feed_parser")
+}
+
+// isParseFailure reports whether err is a failure to parse a fetched body.
+func isParseFailure(err error) bool {
+	var feedErr *model.FeedError
+	return errors.As(err, &feedErr) &&
+		(feedErr.ErrorType == model.ErrorTypeMalformedXML || feedErr.ErrorType == model.ErrorTypeMalformedJSON)
+}
+
+// looksLikeJSON reports whether body starts like a JSON document.
+func looksLikeJSON(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	return len(body) > 0 && (body[0] == '{' || body[0] == '[')
+}
+
+// bodyTruncated reports whether body ends before its document does: it is
+// empty, or reading it as JSON or XML hits the end of input with values or
+// elements still open. A body that is complete but invalid (a mismatched
+// tag, an HTML page) isn't truncated.
+func bodyTruncated(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return true
+	}
+	if looksLikeJSON(body) {
+		var v any
+		return errors.Is(json.NewDecoder(bytes.NewReader(body)).Decode(&v), io.ErrUnexpectedEOF)
+	}
+
+	// Non-strict, so undeclared entities such as &nbsp; don't end the scan
+	// early; the charset doesn't matter for finding where elements close.
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	for {
+		_, err := decoder.Token()
+		if err == nil {
+			continue
+		}
+		var syntaxErr *xml.SyntaxError
+		return errors.Is(err, io.ErrUnexpectedEOF) || (errors.As(err, &syntaxErr) && syntaxErr.Msg == "unexpected EOF")
+	}
+}
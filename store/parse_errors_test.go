@@ -0,0 +1,204 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestBodyTruncated(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"empty", "  \n", true},
+		{"cut inside an item", `<rss version="2.0"><channel><title>x</title><item><tit`, true},
+		{"cut after an item", `<rss version="2.0"><channel><title>x</title><item><title>a</title></item>`, true},
+		{"cut in the prolog", `<?xml version="1.0"?><rs`, true},
+		{"cut JSON", `{"version":"https://jsonfeed.org/version/1.1","items":[{"id":"1"`, true},
+		{"complete", selectionRSSBody, false},
+		{"mismatched tag", `<rss version="2.0"><channel><title>x</title></item></channel></rss>`, false},
+		{"HTML page", `<html><body><p>Not a feed<br></body></html>`, false},
+		{"invalid JSON", `{"title": 5,}`, false},
+	}
+	for _, tt := range tests {
+		if got := bodyTruncated([]byte(tt.body)); got != tt.want {
+			t.Errorf("%s: bodyTruncated = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryableFetchError_ParseFailures(t *testing.T) {
+	truncated := parseFailureError("https://example.com/feed", []byte(`<rss><channel>`), errors.New("XML syntax error on line 1: unexpected EOF"))
+	invalid := parseFailureError("https://example.com/feed", []byte(`<html></html>`), errors.New("Failed to detect feed type"))
+
+	var feedErr *model.FeedError
+	if !errors.As(truncated, &feedErr) || feedErr.ErrorType != model.ErrorTypeMalformedXML || !errors.Is(truncated, errTruncatedBody) {
+		t.Fatalf("truncated error = %v, want a malformed_xml error marked truncated", truncated)
+	}
+	if errors.Is(invalid, errTruncatedBody) {
+		t.Fatalf("invalid error %v is marked truncated", invalid)
+	}
+	tests := []struct {
+		err              error
+		retryParseErrors bool
+		want             bool
+	}{
+		{truncated, false, false},
+		{truncated, true, true},
+		{invalid, false, false},
+		{invalid, true, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableFetchError(tt.err, tt.retryParseErrors); got != tt.want {
+			t.Errorf("isRetryableFetchError(%v, %v) = %v, want %v", tt.err, tt.retryParseErrors, got, tt.want)
+		}
+	}
+}
+
+func TestStore_RetryParseErrors(t *testing.T) {
+	newServer := func(first string) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/rss+xml")
+			if requests.Add(1) == 1 {
+				_, _ = w.Write([]byte(first))
+				return
+			}
+			_, _ = w.Write([]byte(selectionRSSBody))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &requests
+	}
+	fetch := func(url string, retryParseErrors bool) *model.FeedAndItemsResult {
+		t.Helper()
+		s, err := NewStore(&Config{
+			Feeds:            []string{url},
+			AllowPrivateIPs:  true,
+			RetryMaxAttempts: 3,
+			RetryBaseDelay:   time.Millisecond,
+			RetryParseErrors: retryParseErrors,
+			// Parse failures on truncated bodies only happen without the
+			// completeness check, which retries them regardless.
+			VerifyBodyCompleteness: new(false),
+		})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(url))
+		if err != nil {
+			t.Fatalf("GetFeedAndItems: %v", err)
+		}
+		return result
+	}
+	truncatedBody := selectionRSSBody[:len(selectionRSSBody)/2]
+	invalidBody := `<html><body><p>Not a feed</p></body></html>`
+
+	srv, requests := newServer(truncatedBody)
+	if result := fetch(srv.URL, true); result.FetchError != "" || len(result.Items) != 1 || requests.Load() != 2 {
+		t.Errorf("truncated then complete, retrying: error %q, %d items, %d requests; want the retry to recover it",
+			result.FetchError, len(result.Items), requests.Load())
+	}
+
+	srv, requests = newServer(truncatedBody)
+	if result := fetch(srv.URL, false); result.FetchError == "" || requests.Load() != 1 {
+		t.Errorf("truncated, not retrying: error %q, %d requests; want one failed request", result.FetchError, requests.Load())
+	}
+
+	srv, requests = newServer(invalidBody)
+	if result := fetch(srv.URL, true); result.FetchError == "" || requests.Load() != 1 {
+		t.Errorf("structurally invalid, retrying: error %q, %d requests; want one failed request", result.FetchError, requests.Load())
+	}
+}
+
+func TestFetchAndParseFeed_TruncatedResponses(t *testing.T) {
+	half := selectionRSSBody[:len(selectionRSSBody)/2]
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		switch r.URL.Path {
+		case "/interrupted":
+			// Flushing switches to chunked encoding; aborting then drops the
+			// connection without the terminating chunk.
+			_, _ = w.Write([]byte(half))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		case "/short":
+			w.Header().Set("Content-Length", strconv.Itoa(len(selectionRSSBody)))
+			_, _ = w.Write([]byte(half))
+		case "/unclosed":
+			// A well-formed chunked response carrying half a document.
+			_, _ = w.Write([]byte(half))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	for _, path := range []string{"/interrupted", "/short", "/unclosed"} {
+		_, err := fetchAndParseFeed(context.Background(), srv.URL+path, gofeed.NewParser(), parseOptions{lenientXML: true, verifyComplete: true})
+		if !errors.Is(err, errTruncatedBody) || isParseFailure(err) || !isRetryableFetchError(err, false) {
+			t.Errorf("%s: error %v, want a retryable truncation rather than a parse failure", path, err)
+		}
+	}
+
+	// Without the check, a cleanly ended half document is a parse failure.
+	_, err := fetchAndParseFeed(context.Background(), srv.URL+"/unclosed", gofeed.NewParser(), parseOptions{})
+	if !isParseFailure(err) || isRetryableFetchError(err, false) {
+		t.Errorf("unverified: error %v, want a parse failure that isn't retried", err)
+	}
+}
+
+func TestCheckBodyComplete(t *testing.T) {
+	body := []byte(selectionRSSBody)
+	if err := checkBodyComplete("https://example.com/feed", body, int64(len(body))); err != nil {
+		t.Errorf("complete body: %v", err)
+	}
+	if err := checkBodyComplete("https://example.com/feed", body, -1); err != nil {
+		t.Errorf("complete body without Content-Length: %v", err)
+	}
+	err := checkBodyComplete("https://example.com/feed", body, int64(len(body)+10))
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeNetwork || !errors.Is(err, errTruncatedBody) {
+		t.Errorf("short body: error %v, want a network error marked truncated", err)
+	}
+}
+
+func TestStore_RetriesInterruptedChunkedResponse(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		if requests.Add(1) == 1 {
+			_, _ = w.Write([]byte(selectionRSSBody[:len(selectionRSSBody)/2]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{
+		Feeds:            []string{srv.URL},
+		AllowPrivateIPs:  true,
+		RetryMaxAttempts: 3,
+		RetryBaseDelay:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+	if err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	if result.FetchError != "" || len(result.Items) != 1 || requests.Load() != 2 {
+		t.Errorf("error %q, %d items, %d requests; want the truncated response retried", result.FetchError, len(result.Items), requests.Load())
+	}
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// enormousFeed returns an RSS body with n items, large enough that parsing it
+// takes far longer than the parse timeouts used below.
+func enormousFeed(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><rss version="2.0"><channel><title>Huge</title>`)
+	for i := range n {
+		fmt.Fprintf(&b, `<item><title>Item %d</title><link>https://example.com/%d</link><description>Some text for item %d</description></item>`, i, i, i)
+	}
+	b.WriteString(`</channel></rss>`)
+	return []byte(b.String())
+}
+
+// enormousJSONFeed returns a JSON Feed body with n items.
+func enormousJSONFeed(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"version":"https://jsonfeed.org/version/1.1","title":"Huge","items":[`)
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":"%d","url":"https://example.com/%d","title":"Item %d","content_text":"Some text for item %d"}`, i, i, i, i)
+	}
+	b.WriteString(`]}`)
+	return []byte(b.String())
+}
+
+func TestStore_ParseTimeout(t *testing.T) {
+	huge := enormousFeed(200000)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		if r.URL.Path == "/small" {
+			_, _ = w.Write([]byte(selectionRSSBody))
+			return
+		}
+		requests.Add(1)
+		_, _ = w.Write(huge)
+	}))
+	defer srv.Close()
+
+	disabled := false
+	s, err := NewStore(&Config{
+		Feeds:                 []string{srv.URL + "/huge", srv.URL + "/small"},
+		AllowPrivateIPs:       true,
+		CircuitBreakerEnabled: &disabled,
+		RetryMaxAttempts:      3,
+		RetryBaseDelay:        time.Millisecond,
+		ParseTimeout:          20 * time.Millisecond,
+		RequestsPerSecond:     1000,
+		BurstCapacity:         1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+"/huge"))
+	if err != nil || result.FetchError == "" {
+		t.Fatalf("GetFeedAndItems = %+v, %v; want a fetch error", result, err)
+	}
+	recent := s.errorLog.recent()
+	if len(recent) != 1 || recent[0].ErrorType != model.ErrorTypeParsing || !strings.Contains(recent[0].Cause, "exceeded the 20ms parse timeout") {
+		t.Errorf("recorded errors = %+v, want one parse timeout", recent)
+	}
+	// The same body would time out again, so the fetch isn't retried.
+	if got := requests.Load(); got != 1 {
+		t.Errorf("huge feed requested %d times, want 1", got)
+	}
+
+	// A normal feed parses well within the same timeout.
+	result, err = s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+"/small"))
+	if err != nil || len(result.Items) != 1 {
+		t.Errorf("small feed = %v, %v", result, err)
+	}
+}
+
+func TestStore_ParseTimeoutJSON(t *testing.T) {
+	huge := enormousJSONFeed(100000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		_, _ = w.Write(huge)
+	}))
+	defer srv.Close()
+
+	disabled := false
+	s, err := NewStore(&Config{
+		Feeds:                 []string{srv.URL},
+		AllowPrivateIPs:       true,
+		CircuitBreakerEnabled: &disabled,
+		RetryMaxAttempts:      1,
+		ParseTimeout:          30 * time.Millisecond,
+		RequestsPerSecond:     1000,
+		BurstCapacity:         1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	// Reading the body takes a few milliseconds, decoding it about a hundred:
+	// the JSON parser reads everything before decoding, so the timeout has to
+	// cut the wait short rather than the reads.
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+	if err != nil || result.FetchError == "" {
+		t.Fatalf("GetFeedAndItems = %d items, %v; want a fetch error", len(result.Items), err)
+	}
+	recent := s.errorLog.recent()
+	if len(recent) != 1 || recent[0].ErrorType != model.ErrorTypeParsing || !strings.Contains(recent[0].Cause, "exceeded the 30ms parse timeout") {
+		t.Errorf("recorded errors = %+v, want one parse timeout", recent)
+	}
+}
@@ -0,0 +1,305 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/rss"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// Keys under which the parser used for a feed is recorded in gofeed.Feed.Custom
+// (and so in the feed metadata returned to clients).
+const (
+	// ParserMetadataKey holds the parser that produced the feed: rss, atom, or json.
+	ParserMetadataKey = "feed_mcp_parser"
+	// ParserSelectionMetadataKey records how that parser was chosen: from the
+	// response Content-Type, or by sniffing the body.
+	ParserSelectionMetadataKey = "feed_mcp_parser_selection"
+)
+
+// Parser names and selection methods recorded in feed metadata.
+const (
+	parserRSS  = "rss"
+	parserAtom = "atom"
+	parserJSON = "json"
+
+	selectionContentType = "content-type"
+	selectionSniffed     = "sniffed"
+)
+
+// parserForContentType maps a response Content-Type to the parser that handles
+// it, or "" when the type is missing or ambiguous (e.g. text/xml, which can be
+// RSS or Atom) and the body must be sniffed.
+func parserForContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "application/rss+xml":
+		return parserRSS
+	case "application/atom+xml":
+		return parserAtom
+	case "application/feed+json", "application/json":
+		return parserJSON
+	default:
+		return ""
+	}
+}
+
+// parseOptions are the Config settings that govern how fetchAndParseFeed
+// checks and parses a response.
+type parseOptions struct {
+	// lenientXML retries a body that fails to parse once sanitizeXML has
+	// repaired it.
+	lenientXML bool
+	// timeout, when positive, bounds parsing the received body (see
+	// parseTimeoutError).
+	timeout time.Duration
+	// acceptedTypes, when non-empty, rejects responses of any other
+	// Content-Type before the body is read (see checkContentType).
+	acceptedTypes []string
+	// verifyComplete fails a body that doesn't look complete (see
+	// checkBodyComplete) rather than parsing it.
+	verifyComplete bool
+}
+
+// newParseOptions returns the parse options config selects.
+func newParseOptions(config *Config) parseOptions {
+	return parseOptions{
+		lenientXML:     config.LenientXML,
+		timeout:        config.ParseTimeout,
+		acceptedTypes:  config.AcceptedContentTypes,
+		verifyComplete: config.VerifyBodyCompleteness == nil || *config.VerifyBodyCompleteness,
+	}
+}
+
+// fetchAndParseFeed fetches a feed like gofeed.Parser.ParseURLWithContext, but
+// picks the parser from the response Content-Type when it is unambiguous rather
+// than sniffing the body. If that parser rejects the body (a mislabeled
+// response), or the type is ambiguous, it falls back to gofeed's sniffing. The
+// parser used is recorded in the feed's Custom map. opts adds lenient XML
+// recovery, a parse timeout, a Content-Type allowlist, and completeness
+// checks. A body cut short in transit always fails with errTruncatedBody
+// rather than being parsed.
+// Where permanent redirects lead is recorded under model.RedirectedToKey (see
+// permanentRedirectTarget).
+func fetchAndParseFeed(ctx context.Context, feedURL string, fp *gofeed.Parser, opts parseOptions) (feed *gofeed.Feed, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", fp.UserAgent)
+	if fp.AuthConfig != nil && fp.AuthConfig.Username != "" && fp.AuthConfig.Password != "" {
+		req.SetBasicAuth(fp.AuthConfig.Username, fp.AuthConfig.Password)
+	}
+
+	client := fp.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if movedTo := permanentRedirectTarget(resp); movedTo != "" {
+		defer func() {
+			if feed != nil {
+				feed.Custom[model.RedirectedToKey] = movedTo
+			}
+		}()
+	}
+
+	// Same error shape as gofeed, which isRetryableError relies on.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if err := checkContentType(feedURL, contentType, opts.acceptedTypes); err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		// An interrupted chunked stream, or fewer bytes than Content-Length.
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, truncatedBodyError(feedURL, fmt.Sprintf("the connection ended after %d bytes", len(body)), err)
+		}
+		return nil, err
+	}
+	if opts.verifyComplete {
+		if err := checkBodyComplete(feedURL, body, resp.ContentLength); err != nil {
+			return nil, err
+		}
+	}
+
+	// Without a parse timeout parsing is unbounded, as gofeed's own parsers are.
+	if opts.timeout <= 0 {
+		feed, err := parseFeedBodyLenient(nil, body, contentType, fp, opts.lenientXML)
+		if err != nil {
+			return nil, parseFailureError(feedURL, body, err)
+		}
+		return feed, nil
+	}
+
+	// The XML parsers read the body incrementally and stop at the deadline
+	// (see contextReader), but the JSON parser reads it all before decoding,
+	// so parse in a goroutine and stop waiting at the deadline. A JSON parse
+	// that is still running then finishes in the background and is discarded:
+	// the timeout bounds the wait, not the work.
+	parseCtx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	type parseResult struct {
+		feed *gofeed.Feed
+		err  error
+	}
+	done := make(chan parseResult, 1)
+	go func() {
+		feed, err := parseFeedBodyLenient(parseCtx, body, contentType, fp, opts.lenientXML)
+		done <- parseResult{feed: feed, err: err}
+	}()
+	select {
+	case result := <-done:
+		if result.err != nil && errors.Is(parseCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, parseTimeoutError(feedURL, opts.timeout, len(body), parseCtx.Err())
+		}
+		if result.err != nil && ctx.Err() == nil {
+			return nil, parseFailureError(feedURL, body, result.err)
+		}
+		return result.feed, result.err
+	case <-parseCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, parseTimeoutError(feedURL, opts.timeout, len(body), parseCtx.Err())
+	}
+}
+
+// parseFeedBodyLenient parses body like parseFeedBody and, with lenientXML,
+// retries a body that fails to parse once sanitizeXML has repaired it.
+func parseFeedBodyLenient(ctx context.Context, body []byte, contentType string, fp *gofeed.Parser, lenientXML bool) (*gofeed.Feed, error) {
+	feed, err := parseFeedBody(ctx, body, contentType, fp)
+	if err == nil || !lenientXML || (ctx != nil && ctx.Err() != nil) {
+		return feed, err
+	}
+	if sanitized, fixes := sanitizeXML(body); len(fixes) > 0 {
+		if recovered, recoverErr := parseFeedBody(ctx, sanitized, contentType, fp); recoverErr == nil {
+			recovered.Custom[XMLRecoveryMetadataKey] = strings.Join(fixes, ",")
+			return recovered, nil
+		}
+	}
+	return feed, err
+}
+
+// parseTimeoutError reports a body that took longer than the parse timeout to
+// parse. It wraps context.DeadlineExceeded, so the fetch isn't retried: the
+// same body would time out again.
+func parseTimeoutError(feedURL string, parseTimeout time.Duration, bodySize int, cause error) error {
+	return model.NewFeedErrorWithCause(model.ErrorTypeParsing, fmt.Sprintf("parsing the %d-byte feed body exceeded the %s parse timeout", bodySize, parseTimeout), cause).
+		WithURL(feedURL).
+		WithOperation("parse_feed").
+		WithComponent("feed_parser")
+}
+
+// contextReader reads from r until ctx is done, then fails every read with the
+// context's error. Parsers read their input incrementally, so this stops a
+// parse that runs past its deadline.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// bodyReader returns a reader over body that stops at ctx's deadline, or a
+// plain reader when ctx is nil.
+func bodyReader(ctx context.Context, body []byte) io.Reader {
+	if ctx == nil {
+		return bytes.NewReader(body)
+	}
+	return &contextReader{ctx: ctx, r: bytes.NewReader(body)}
+}
+
+// parseFeedBody parses a fetched body with the parser named by its
+// Content-Type, falling back to sniffing, and records the parser used. A
+// non-nil ctx aborts parsing when it is done.
+func parseFeedBody(ctx context.Context, body []byte, contentType string, fp *gofeed.Parser) (*gofeed.Feed, error) {
+	if kind := parserForContentType(contentType); kind != "" {
+		if feed, err := parseAs(ctx, kind, body, fp); err == nil {
+			recordParser(feed, kind, selectionContentType)
+			return feed, nil
+		}
+	}
+	if ctx != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	feed, err := fp.Parse(bodyReader(ctx, body))
+	if err != nil {
+		return nil, err
+	}
+	recordParser(feed, feed.FeedType, selectionSniffed)
+	return feed, nil
+}
+
+// parseAs parses body with the named parser, translating the result with the
+// gofeed.Parser's translators (or gofeed's defaults).
+func parseAs(ctx context.Context, kind string, body []byte, fp *gofeed.Parser) (*gofeed.Feed, error) {
+	var (
+		parsed     any
+		err        error
+		translator gofeed.Translator
+	)
+	switch kind {
+	case parserRSS:
+		parsed, err = (&rss.Parser{}).Parse(bodyReader(ctx, body))
+		translator = fp.RSSTranslator
+		if translator == nil {
+			translator = &gofeed.DefaultRSSTranslator{}
+		}
+	case parserAtom:
+		parsed, err = (&atom.Parser{}).Parse(bodyReader(ctx, body))
+		translator = fp.AtomTranslator
+		if translator == nil {
+			translator = &gofeed.DefaultAtomTranslator{}
+		}
+	default:
+		parsed, err = (&json.Parser{}).Parse(bodyReader(ctx, body))
+		translator = fp.JSONTranslator
+		if translator == nil {
+			translator = &gofeed.DefaultJSONTranslator{}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return translator.Translate(parsed)
+}
+
+// recordParser stores the parser name and selection method in feed.Custom.
+func recordParser(feed *gofeed.Feed, kind, selection string) {
+	if feed.Custom == nil {
+		feed.Custom = make(map[string]string, 2)
+	}
+	feed.Custom[ParserMetadataKey] = kind
+	feed.Custom[ParserSelectionMetadataKey] = selection
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+const (
+	selectionRSSBody  = `<?xml version="1.0"?><rss version="2.0"><channel><title>RSS</title><item><title>r</title></item></channel></rss>`
+	selectionAtomBody = `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title><entry><title>a</title></entry></feed>`
+	selectionJSONBody = `{"version":"https://jsonfeed.org/version/1.1","title":"JSON","items":[{"id":"1","title":"j"}]}`
+)
+
+func TestParserForContentType(t *testing.T) {
+	tests := map[string]string{
+		"application/feed+json":              parserJSON,
+		"application/json; charset=utf-8":    parserJSON,
+		"application/rss+xml":                parserRSS,
+		"application/atom+xml;charset=UTF-8": parserAtom,
+		"text/xml":                           "",
+		"application/xml":                    "",
+		"text/html":                          "",
+		"":                                   "",
+		"not a media type;;":                 "",
+	}
+	for contentType, want := range tests {
+		if got := parserForContentType(contentType); got != want {
+			t.Errorf("parserForContentType(%q) = %q, want %q", contentType, got, want)
+		}
+	}
+}
+
+func TestFetchAndParseFeed_ParserSelection(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		body          string
+		wantParser    string
+		wantSelection string
+		wantTitle     string
+	}{
+		{"json feed by content type", "application/feed+json", selectionJSONBody, parserJSON, selectionContentType, "JSON"},
+		{"rss by content type", "application/rss+xml; charset=utf-8", selectionRSSBody, parserRSS, selectionContentType, "RSS"},
+		{"atom by content type", "application/atom+xml", selectionAtomBody, parserAtom, selectionContentType, "Atom"},
+		{"ambiguous xml is sniffed", "text/xml", selectionAtomBody, parserAtom, selectionSniffed, "Atom"},
+		{"mislabeled feed falls back to sniffing", "application/rss+xml", selectionAtomBody, parserAtom, selectionSniffed, "Atom"},
+		{"json under xml type falls back to sniffing", "application/atom+xml", selectionJSONBody, parserJSON, selectionSniffed, "JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			feed, err := fetchAndParseFeed(context.Background(), srv.URL, gofeed.NewParser(), parseOptions{verifyComplete: true})
+			if err != nil {
+				t.Fatalf("fetchAndParseFeed: %v", err)
+			}
+			if feed.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", feed.Title, tt.wantTitle)
+			}
+			if got := feed.Custom[ParserMetadataKey]; got != tt.wantParser {
+				t.Errorf("parser = %q, want %q", got, tt.wantParser)
+			}
+			if got := feed.Custom[ParserSelectionMetadataKey]; got != tt.wantSelection {
+				t.Errorf("selection = %q, want %q", got, tt.wantSelection)
+			}
+		})
+	}
+}
+
+func TestFetchAndParseFeed_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := fetchAndParseFeed(context.Background(), srv.URL, gofeed.NewParser(), parseOptions{verifyComplete: true})
+	if err == nil {
+		t.Fatal("expected error for 503 response")
+	}
+	if !isRetryableError(err) {
+		t.Errorf("5xx error %q should be retryable", err)
+	}
+}
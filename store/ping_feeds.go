@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+)
+
+// Ping limits for PingFeeds.
+const (
+	// pingConcurrency bounds the pings in flight; the client's per-host rate
+	// limit still applies to each.
+	pingConcurrency = 8
+	// pingTimeout bounds each ping, including a GET retried after HEAD was
+	// rejected.
+	pingTimeout = 5 * time.Second
+)
+
+// PingFeeds implements mcpserver.FeedPinger. Each feed's URL gets a HEAD
+// request through the store's client (so rate limits, private-IP protection,
+// and per-feed headers apply), or a GET when the server rejects HEAD; the
+// body is never read. Circuit breakers are bypassed and left untouched, so a
+// ping can show whether a feed with an open breaker has recovered.
+func (s *Store) PingFeeds(ctx context.Context) ([]mcpserver.FeedPing, error) {
+	entries := s.feedEntries()
+	client := s.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	pings := make([]mcpserver.FeedPing, len(entries))
+	slots := make(chan struct{}, pingConcurrency)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			pings[i] = pingFeed(ctx, client, entry)
+		})
+	}
+	wg.Wait()
+	return pings, ctx.Err()
+}
+
+// pingFeed pings one feed; see PingFeeds.
+func pingFeed(ctx context.Context, client *http.Client, entry feedEntry) mcpserver.FeedPing {
+	ping := mcpserver.FeedPing{FeedID: entry.id, URL: entry.url}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	resp, latency, err := pingRequest(ctx, client, http.MethodHead, entry.url)
+	ping.Method = http.MethodHead
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, latency, err = pingRequest(ctx, client, http.MethodGet, entry.url)
+		ping.Method = http.MethodGet
+	}
+	ping.LatencyMs = latency.Milliseconds()
+	if err != nil {
+		ping.Error = err.Error()
+		return ping
+	}
+	ping.Reachable = true
+	ping.Status = resp.StatusCode
+	if state := resp.TLS; state != nil {
+		ping.TLSVersion = tls.VersionName(state.Version)
+		ping.TLSCipherSuite = tls.CipherSuiteName(state.CipherSuite)
+		if len(state.PeerCertificates) > 0 {
+			expires := state.PeerCertificates[0].NotAfter.UTC()
+			ping.CertExpires = &expires
+		}
+	}
+	return ping
+}
+
+// pingRequest sends one request and closes the response body unread. The
+// latency runs from asking for a connection to the first response byte; it
+// falls back to the whole round trip when the transport reports neither.
+func pingRequest(ctx context.Context, client *http.Client, method, feedURL string) (*http.Response, time.Duration, error) {
+	var getConn, firstByte time.Time
+	trace := &httptrace.ClientTrace{
+		GetConn:              func(string) { getConn = time.Now() },
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), method, feedURL, http.NoBody)
+	if err != nil {
+		return nil, 0, err
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	end := time.Now()
+	if err != nil {
+		return nil, end.Sub(start), err
+	}
+	_ = resp.Body.Close()
+	if getConn.IsZero() || firstByte.IsZero() {
+		return resp, end.Sub(start), nil
+	}
+	return resp, firstByte.Sub(getConn), nil
+}
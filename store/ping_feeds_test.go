@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_PingFeeds(t *testing.T) {
+	var gets atomic.Int32
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		switch r.URL.Path {
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer secure.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL + "/feed"
+	closed.Close()
+
+	urls := []string{secure.URL + "/feed", secure.URL + "/no-head", secure.URL + "/slow", secure.URL + "/missing", closedURL}
+	s, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, HTTPClient: secure.Client()})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	pings, err := s.PingFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("PingFeeds: %v", err)
+	}
+	byURL := make(map[string]mcpserver.FeedPing, len(pings))
+	for _, ping := range pings {
+		if ping.FeedID != model.GenerateFeedID(ping.URL) {
+			t.Errorf("ping %+v has the wrong feed ID", ping)
+		}
+		byURL[ping.URL] = ping
+	}
+	if len(byURL) != len(urls) {
+		t.Fatalf("got pings for %d feeds, want %d: %+v", len(byURL), len(urls), pings)
+	}
+
+	ok := byURL[secure.URL+"/feed"]
+	if !ok.Reachable || ok.Status != http.StatusOK || ok.Method != http.MethodHead || ok.Error != "" {
+		t.Errorf("reachable feed = %+v", ok)
+	}
+	if ok.TLSVersion == "" || ok.TLSCipherSuite == "" || ok.CertExpires == nil {
+		t.Errorf("reachable feed TLS info = %+v, want version, cipher suite, and certificate expiry", ok)
+	}
+	if noHead := byURL[secure.URL+"/no-head"]; !noHead.Reachable || noHead.Method != http.MethodGet || noHead.Status != http.StatusOK {
+		t.Errorf("feed rejecting HEAD = %+v, want a GET retry", noHead)
+	}
+	if slow := byURL[secure.URL+"/slow"]; !slow.Reachable || slow.LatencyMs < 50 {
+		t.Errorf("slow feed = %+v, want a latency of at least 50ms", slow)
+	}
+	// A server that answers is reachable whatever the status.
+	if missing := byURL[secure.URL+"/missing"]; !missing.Reachable || missing.Status != http.StatusNotFound {
+		t.Errorf("missing feed = %+v, want reachable with status 404", missing)
+	}
+	if down := byURL[closedURL]; down.Reachable || down.Status != 0 || down.Error == "" || down.TLSVersion != "" {
+		t.Errorf("closed server = %+v, want unreachable with an error", down)
+	}
+	// Only the server that rejected HEAD was sent a GET.
+	if got := gets.Load(); got != 1 {
+		t.Errorf("GET requests = %d, want 1", got)
+	}
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// PurgeFeedCache implements mcpserver.FeedCachePurger. It evicts the parsed
+// feed, its resolved icon, its search index entries, and any failed-feed
+// backoff (see checkSchedule), and bumps the feed generation so cached tool
+// results are dropped too. Nothing is fetched: the next read goes through the
+// cache loader as if the feed were new.
+func (s *Store) PurgeFeedCache(ctx context.Context, feedID string) (*mcpserver.FeedCachePurge, error) {
+	s.feedsMu.RLock()
+	url, ok := s.feeds[feedID]
+	s.feedsMu.RUnlock()
+	if !ok {
+		return nil, model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", feedID)).
+			WithOperation("purge_feed_cache").
+			WithComponent("feed_store")
+	}
+
+	// Peek the inner cache so the check itself does not trigger a fetch.
+	feed, err := s.feedCache.Get(ctx, url)
+	wasCached := err == nil && feed != nil
+	_ = s.feedCacheManager.Delete(ctx, url) // in-memory; deletion errors are not critical
+
+	s.iconMu.Lock()
+	delete(s.icons, url)
+	s.iconMu.Unlock()
+
+	if s.searchIndex != nil {
+		s.searchIndex.remove(url)
+	}
+	if s.checkSchedule != nil {
+		s.checkSchedule.remove(url)
+	}
+	s.generation.Add(1)
+
+	return &mcpserver.FeedCachePurge{
+		FeedID:    feedID,
+		URL:       url,
+		WasCached: wasCached,
+	}, nil
+}
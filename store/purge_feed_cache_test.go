@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_PurgeFeedCache(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, RetryMaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+	read := func() {
+		t.Helper()
+		result, err := s.GetFeedAndItems(ctx, feedID)
+		if err != nil || result.FetchError != "" {
+			t.Fatalf("GetFeedAndItems: %v %+v", err, result)
+		}
+	}
+
+	// ristretto applies writes asynchronously; wait until the feed is cached.
+	read()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if feed, err := s.feedCache.Get(ctx, srv.URL); err == nil && feed != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("feed was never cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	read()
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("requests before purge = %d, want 1 (second read cached)", got)
+	}
+	generation := s.FeedGeneration()
+
+	purge, err := s.PurgeFeedCache(ctx, feedID)
+	if err != nil {
+		t.Fatalf("PurgeFeedCache: %v", err)
+	}
+	if purge.FeedID != feedID || purge.URL != srv.URL || !purge.WasCached {
+		t.Errorf("purge = %+v", purge)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("purge fetched the feed: %d requests", got)
+	}
+	if s.FeedGeneration() == generation {
+		t.Error("purge did not advance the feed generation")
+	}
+
+	// The next read is a cache miss and fetches again.
+	read()
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests after purge = %d, want 2", got)
+	}
+
+	// Purging a feed that is not cached reports so.
+	if _, err := s.PurgeFeedCache(ctx, feedID); err != nil {
+		t.Fatalf("PurgeFeedCache: %v", err)
+	}
+	purge, err = s.PurgeFeedCache(ctx, feedID)
+	if err != nil || purge.WasCached {
+		t.Errorf("second purge = %+v, %v, want WasCached false", purge, err)
+	}
+
+	var fe *model.FeedError
+	if _, err := s.PurgeFeedCache(ctx, "missing"); !errors.As(err, &fe) || fe.ErrorType != model.ErrorTypeValidation {
+		t.Errorf("unknown feed: err = %v, want a validation error", err)
+	}
+}
+
+// TestStore_PurgeFeedCacheClearsBackoff verifies that a purge ends the
+// failed-feed backoff, so the next read reloads instead of returning the
+// stale error.
+func TestStore_PurgeFeedCacheClearsBackoff(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	disabled := false
+	s, err := NewStore(&Config{
+		Feeds:                 []string{srv.URL},
+		AllowPrivateIPs:       true,
+		RetryMaxAttempts:      1,
+		CircuitBreakerEnabled: &disabled,
+		FailedFeedBackoff:     []time.Duration{time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+
+	if result, err := s.GetFeedAndItems(ctx, feedID); err != nil || result.FetchError == "" {
+		t.Fatalf("read of a failing feed = %+v, %v; want a fetch error", result, err)
+	}
+	failing.Store(false)
+	if result, _ := s.GetFeedAndItems(ctx, feedID); result.FetchError == "" || requests.Load() != 1 {
+		t.Fatalf("read during backoff = %+v after %d requests, want a fast failure after 1", result, requests.Load())
+	}
+
+	if _, err := s.PurgeFeedCache(ctx, feedID); err != nil {
+		t.Fatalf("PurgeFeedCache: %v", err)
+	}
+	result, err := s.GetFeedAndItems(ctx, feedID)
+	if err != nil || result.FetchError != "" || requests.Load() != 2 {
+		t.Errorf("read after purge = %+v, %v after %d requests; want a reload after 2", result, err, requests.Load())
+	}
+}
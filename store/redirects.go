@@ -0,0 +1,32 @@
+package store
+
+import (
+	"net/http"
+	"slices"
+)
+
+// permanentRedirectTarget returns the URL that resp's request chain reached
+// through permanent (301 or 308) redirects from the URL originally requested,
+// stopping at the first temporary one, since only a permanent redirect says
+// the feed's configured URL should change. It returns "" when the first hop
+// wasn't a permanent redirect.
+func permanentRedirectTarget(resp *http.Response) string {
+	// Each redirected request links back to the response that caused it, so
+	// walk back to the original request collecting the hops.
+	var hops []*http.Request
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		hops = append(hops, req)
+	}
+	slices.Reverse(hops)
+
+	var target string
+	for _, req := range hops {
+		switch req.Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+			target = req.URL.String()
+		default:
+			return target
+		}
+	}
+	return target
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_PermanentRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	})
+	mux.Handle("/moved", http.RedirectHandler("/new", http.StatusMovedPermanently))
+	mux.Handle("/moved-308", http.RedirectHandler("/new", http.StatusPermanentRedirect))
+	mux.Handle("/moved-twice", http.RedirectHandler("/moved", http.StatusMovedPermanently))
+	mux.Handle("/temporary", http.RedirectHandler("/new", http.StatusFound))
+	mux.Handle("/moved-then-temporary", http.RedirectHandler("/temporary", http.StatusMovedPermanently))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/moved", srv.URL + "/new"},
+		{"/moved-308", srv.URL + "/new"},
+		{"/moved-twice", srv.URL + "/new"},
+		{"/temporary", ""},
+		{"/moved-then-temporary", srv.URL + "/temporary"},
+		{"/new", ""},
+	}
+	feeds := make([]string, 0, len(tests))
+	for _, tt := range tests {
+		feeds = append(feeds, srv.URL+tt.path)
+	}
+	s, err := NewStore(&Config{Feeds: feeds, AllowPrivateIPs: true, RetryMaxAttempts: 1, RequestsPerSecond: 100, BurstCapacity: 100})
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+tt.path))
+			if err != nil {
+				t.Fatalf("GetFeedAndItems: %v", err)
+			}
+			if got := model.FeedRedirectedTo(result.Feed); got != tt.want {
+				t.Errorf("redirected to %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// maxRefreshWait caps how long the refresh loop sleeps, so feeds added at
+// runtime are picked up within a minute (the finest cron granularity).
+const maxRefreshWait = time.Minute
+
+// refreshScheduler re-fetches feeds on cron schedules, so operators can poll
+// at predictable times (say, business hours only) rather than whenever a cache
+// entry happens to expire. A feed follows its own schedule when it has one,
+// else the global schedule; feeds with neither are left to the cache TTL.
+//
+// Only cron's parser and Schedule.Next are used: the loop is driven by now and
+// after, so tests can run it against a controllable clock.
+type refreshScheduler struct {
+	global  cron.Schedule            // nil when only some feeds are scheduled
+	perFeed map[string]cron.Schedule // by feed URL
+	feeds   func() []string
+	refresh func(ctx context.Context, feedURL string)
+	now     func() time.Time
+	after   func(time.Duration) <-chan time.Time
+	next    map[string]time.Time // by feed URL; only touched by the run loop
+}
+
+// parseRefreshCron parses a standard five-field cron expression, or a
+// descriptor such as @hourly, optionally prefixed with CRON_TZ=<zone>.
+// feedURL names the feed a per-feed expression belongs to; it is empty for
+// the global one.
+func parseRefreshCron(expr, feedURL string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, model.NewFeedErrorWithCause(model.ErrorTypeConfiguration, fmt.Sprintf("invalid refresh cron expression %q", expr), err).
+			WithURL(feedURL).
+			WithOperation("create_store").
+			WithComponent("refresh_scheduler")
+	}
+	return schedule, nil
+}
+
+// ValidateRefreshCron reports whether expr is a cron expression that
+// Config.RefreshCron and Config.FeedRefreshCron accept.
+func ValidateRefreshCron(expr string) error {
+	_, err := parseRefreshCron(expr, "")
+	return err
+}
+
+// newRefreshScheduler parses the configured cron expressions, returning nil
+// when none are set.
+func newRefreshScheduler(config *Config) (*refreshScheduler, error) {
+	if config.RefreshCron == "" && len(config.FeedRefreshCron) == 0 {
+		return nil, nil
+	}
+	rs := &refreshScheduler{
+		perFeed: make(map[string]cron.Schedule, len(config.FeedRefreshCron)),
+		now:     time.Now,
+		after:   time.After,
+		next:    make(map[string]time.Time),
+	}
+	if config.RefreshCron != "" {
+		schedule, err := parseRefreshCron(config.RefreshCron, "")
+		if err != nil {
+			return nil, err
+		}
+		rs.global = schedule
+	}
+	for feedURL, expr := range config.FeedRefreshCron {
+		schedule, err := parseRefreshCron(expr, feedURL)
+		if err != nil {
+			return nil, err
+		}
+		rs.perFeed[feedURL] = schedule
+	}
+	return rs, nil
+}
+
+// schedule returns the feed's schedule, or nil when it has none.
+func (rs *refreshScheduler) schedule(feedURL string) cron.Schedule {
+	if schedule, ok := rs.perFeed[feedURL]; ok {
+		return schedule
+	}
+	return rs.global
+}
+
+// due returns the feeds whose refresh time has come by now and schedules
+// their next refresh. A feed seen for the first time is scheduled from now
+// without being refreshed, and feeds that have been removed are forgotten.
+func (rs *refreshScheduler) due(now time.Time) []string {
+	var due []string
+	current := make(map[string]bool)
+	for _, feedURL := range rs.feeds() {
+		schedule := rs.schedule(feedURL)
+		if schedule == nil {
+			continue
+		}
+		current[feedURL] = true
+		next, ok := rs.next[feedURL]
+		if ok && next.After(now) {
+			continue
+		}
+		if ok {
+			due = append(due, feedURL)
+		}
+		rs.next[feedURL] = schedule.Next(now)
+	}
+	for feedURL := range rs.next {
+		if !current[feedURL] {
+			delete(rs.next, feedURL)
+		}
+	}
+	return due
+}
+
+// wait returns how long to sleep until the next scheduled refresh, capped at
+// maxRefreshWait.
+func (rs *refreshScheduler) wait(now time.Time) time.Duration {
+	wait := maxRefreshWait
+	for _, next := range rs.next {
+		wait = min(wait, next.Sub(now))
+	}
+	return max(wait, 0)
+}
+
+// run refreshes feeds as they come due until ctx is done. Due feeds are
+// refreshed concurrently, within the per-host rate limits, and the loop waits
+// for them before scheduling the next round.
+func (rs *refreshScheduler) run(ctx context.Context) {
+	for {
+		var wg sync.WaitGroup
+		for _, feedURL := range rs.due(rs.now()) {
+			wg.Go(func() { rs.refresh(ctx, feedURL) })
+		}
+		wg.Wait()
+		select {
+		case <-ctx.Done():
+			return
+		case <-rs.after(rs.wait(rs.now())):
+		}
+	}
+}
+
+// StartRefreshSchedule refreshes feeds on the configured cron schedules
+// (Config.RefreshCron, Config.FeedRefreshCron) in the background until ctx is
+// done. It does nothing when no schedule is configured.
+func (s *Store) StartRefreshSchedule(ctx context.Context) {
+	if s.refreshScheduler == nil {
+		return
+	}
+	go s.refreshScheduler.run(ctx)
+}
+
+// scheduledRefresh re-fetches a feed for the refresh scheduler. Failures are
+// already recorded by the feed loader, so they are only logged here.
+func (s *Store) scheduledRefresh(ctx context.Context, feedURL string) {
+	if _, err := s.refreshFeed(model.WithDefaultFetchGate(ctx, s.fetchGate), feedURL); err != nil {
+		model.DebugLogWithContext("Scheduled feed refresh failed", "refresh_scheduler", "scheduled_refresh", feedURL,
+			map[string]any{statusError: err.Error()})
+	}
+}
+
+// feedURLs returns the URLs of the managed feeds, highest priority first, so
+// feeds that come due together are refreshed in priority order.
+func (s *Store) feedURLs() []string {
+	entries := s.byPriority(s.feedEntries())
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = entry.url
+	}
+	return urls
+}
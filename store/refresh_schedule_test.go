@@ -0,0 +1,181 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// weekdayMornings refreshes at 8am UTC, Monday to Friday.
+const weekdayMornings = "CRON_TZ=UTC 0 8 * * 1-5"
+
+func TestRefreshScheduler_Due(t *testing.T) {
+	feeds := []string{"https://example.com/a", "https://example.com/b", "https://example.com/unscheduled"}
+	rs, err := newRefreshScheduler(&Config{
+		FeedRefreshCron: map[string]string{
+			feeds[0]: weekdayMornings,
+			feeds[1]: "CRON_TZ=UTC 30 * * * *",
+		},
+	})
+	if err != nil {
+		t.Fatalf("newRefreshScheduler: %v", err)
+	}
+	rs.feeds = func() []string { return feeds }
+
+	// Friday 1 March 2024, 07:10 UTC.
+	start := time.Date(2024, 3, 1, 7, 10, 0, 0, time.UTC)
+	steps := []struct {
+		at   time.Duration // after start
+		want []string
+	}{
+		{0, nil}, // feeds are scheduled, not refreshed, when first seen
+		{19 * time.Minute, nil},
+		{20 * time.Minute, []string{feeds[1]}}, // 07:30
+		{49 * time.Minute, nil},
+		{50 * time.Minute, []string{feeds[0]}},                        // 08:00
+		{80 * time.Minute, []string{feeds[1]}},                        // 08:30
+		{49*time.Hour + 50*time.Minute, []string{feeds[1]}},           // Sunday 09:00: a skips the weekend
+		{72*time.Hour + 50*time.Minute, []string{feeds[0], feeds[1]}}, // Monday 08:00
+		{72*time.Hour + 51*time.Minute, nil},
+	}
+	for _, step := range steps {
+		now := start.Add(step.at)
+		if got := rs.due(now); !slices.Equal(got, step.want) {
+			t.Errorf("due(%s) = %v, want %v", now.Format(time.RFC1123), got, step.want)
+		}
+	}
+
+	// Removed feeds are forgotten.
+	feeds = feeds[:1]
+	rs.due(start.Add(73 * time.Hour))
+	if _, ok := rs.next["https://example.com/b"]; ok {
+		t.Error("removed feed still scheduled")
+	}
+}
+
+func TestRefreshScheduler_Wait(t *testing.T) {
+	rs := &refreshScheduler{next: map[string]time.Time{}}
+	now := time.Date(2024, 3, 1, 7, 59, 30, 0, time.UTC)
+	if got := rs.wait(now); got != maxRefreshWait {
+		t.Errorf("wait with nothing scheduled = %s, want %s", got, maxRefreshWait)
+	}
+	rs.next["a"] = now.Add(30 * time.Second)
+	rs.next["b"] = now.Add(time.Hour)
+	if got := rs.wait(now); got != 30*time.Second {
+		t.Errorf("wait = %s, want 30s", got)
+	}
+	rs.next["a"] = now.Add(-time.Second)
+	if got := rs.wait(now); got != 0 {
+		t.Errorf("wait with an overdue feed = %s, want 0", got)
+	}
+}
+
+func TestNewStore_InvalidRefreshCron(t *testing.T) {
+	for _, config := range []Config{
+		{Feeds: []string{"https://example.com/feed"}, RefreshCron: "every morning"},
+		{Feeds: []string{"https://example.com/feed"}, FeedRefreshCron: map[string]string{"https://example.com/feed": "61 * * * *"}},
+	} {
+		_, err := NewStore(&config)
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeConfiguration {
+			t.Errorf("NewStore(%q, %v) = %v, want a configuration error", config.RefreshCron, config.FeedRefreshCron, err)
+		}
+	}
+}
+
+func TestStore_RefreshSchedule(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Scheduled</title><item><title>Post</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, RefreshCron: weekdayMornings})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	// The loop asks for a timer with each wait; the test answers by moving the
+	// clock forward by that wait and firing it. A wait request also means the
+	// previous round of refreshes has finished.
+	clock := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC) // Friday
+	var now atomic.Pointer[time.Time]
+	now.Store(&clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	waits := make(chan time.Duration)
+	fire := make(chan time.Time)
+	s.refreshScheduler.now = func() time.Time { return *now.Load() }
+	s.refreshScheduler.after = func(d time.Duration) <-chan time.Time {
+		select {
+		case waits <- d:
+		case <-ctx.Done():
+		}
+		return fire
+	}
+	s.StartRefreshSchedule(ctx)
+
+	// advance lets the loop sleep through one wait and returns the time it
+	// woke at, once that round's refreshes are done.
+	advance := func() time.Time {
+		t.Helper()
+		wait := <-waits
+		woke := now.Load().Add(wait)
+		now.Store(&woke)
+		fire <- woke
+		return woke
+	}
+
+	// Until 8am the loop only wakes every maxRefreshWait, without fetching.
+	var woke time.Time
+	for woke.Before(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)) {
+		woke = advance()
+		if woke.Before(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)) && requests.Load() != 0 {
+			t.Fatalf("feed fetched at %s, before its 8am refresh", woke.Format(time.Kitchen))
+		}
+	}
+	<-waits // the 8am round has finished
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("requests after the 8am refresh = %d, want 1", got)
+	}
+
+	// The refresh populated the cache: reads don't fetch again. The loadable
+	// cache stores loaded feeds asynchronously, so wait for the entry first.
+	for deadline := time.Now().Add(5 * time.Second); s.cachedItemCount(ctx, srv.URL) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("refreshed feed never reached the cache")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := s.GetFeedAndItems(ctx, model.GenerateFeedID(srv.URL)); err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests after reading the refreshed feed = %d, want 1", got)
+	}
+
+	// Skip the weekend: the next refresh is Monday at 8am.
+	monday := time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)
+	jump := monday.Add(-time.Minute)
+	now.Store(&jump)
+	fire <- jump
+	for woke = jump; woke.Before(monday); {
+		woke = advance()
+	}
+	<-waits
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests after Monday's refresh = %d, want 2", got)
+	}
+	if !woke.Equal(monday) {
+		t.Errorf("refresh ran at %s, want %s", woke, monday)
+	}
+}
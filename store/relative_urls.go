@@ -0,0 +1,126 @@
+package store
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// resolveRelativeURLs rewrites relative URLs in the feed's items as absolute
+// ones: item links, enclosure and image URLs against the feed's base, and
+// <img src> in item content against the item's (resolved) link. The base is
+// the feed's Link, else its FeedLink, else the URL it was fetched from; a
+// relative Link or FeedLink is itself resolved against the fetch URL first.
+// URLs that are already absolute, or don't parse, are left as they are.
+func resolveRelativeURLs(feed *gofeed.Feed, fetchURL string) {
+	fetched, err := url.Parse(fetchURL)
+	if err != nil {
+		return
+	}
+	base := fetched
+	for _, candidate := range []string{feed.Link, feed.FeedLink} {
+		ref, err := url.Parse(strings.TrimSpace(candidate))
+		if candidate == "" || err != nil {
+			continue
+		}
+		if abs := fetched.ResolveReference(ref); abs.Host != "" {
+			base = abs
+			break
+		}
+	}
+
+	for _, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		item.Link = resolveURL(base, item.Link)
+		for i, link := range item.Links {
+			item.Links[i] = resolveURL(base, link)
+		}
+		for _, enclosure := range item.Enclosures {
+			if enclosure != nil {
+				enclosure.URL = resolveURL(base, enclosure.URL)
+			}
+		}
+		if item.Image != nil {
+			item.Image.URL = resolveURL(base, item.Image.URL)
+		}
+
+		contentBase := base
+		if link, err := url.Parse(item.Link); err == nil && link.IsAbs() {
+			contentBase = link
+		}
+		item.Content = resolveContentImages(contentBase, item.Content)
+		item.Description = resolveContentImages(contentBase, item.Description)
+	}
+}
+
+// resolveURL resolves a relative reference against base, returning it
+// unchanged when it is empty, already absolute, or unparseable.
+func resolveURL(base *url.URL, ref string) string {
+	trimmed := strings.TrimSpace(ref)
+	if trimmed == "" {
+		return ref
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil || u.IsAbs() {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// resolveContentImages rewrites relative <img src> attributes in an HTML
+// fragment. Only the affected tags are re-serialized; the rest of the markup is
+// copied through byte for byte.
+func resolveContentImages(base *url.URL, fragment string) string {
+	if !strings.Contains(strings.ToLower(fragment), "<img") {
+		return fragment
+	}
+	var out strings.Builder
+	out.Grow(len(fragment))
+	changed := false
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			out.Write(tokenizer.Raw())
+			continue
+		}
+		// Token lowercases tag names in the tokenizer's buffer, so keep a copy
+		// of the raw tag to write back unchanged.
+		raw := slices.Clone(tokenizer.Raw())
+		token := tokenizer.Token()
+		if token.DataAtom != atom.Img || !resolveAttr(base, token.Attr, "src") {
+			out.Write(raw)
+			continue
+		}
+		changed = true
+		out.WriteString(token.String())
+	}
+	if !changed {
+		return fragment
+	}
+	return out.String()
+}
+
+// resolveAttr resolves the named attribute in place, reporting whether it
+// changed.
+func resolveAttr(base *url.URL, attrs []html.Attribute, name string) bool {
+	for i := range attrs {
+		if attrs[i].Namespace == "" && attrs[i].Key == name {
+			resolved := resolveURL(base, attrs[i].Val)
+			if resolved != attrs[i].Val {
+				attrs[i].Val = resolved
+				return true
+			}
+		}
+	}
+	return false
+}
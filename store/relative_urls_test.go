@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestResolveRelativeURLs(t *testing.T) {
+	feed := &gofeed.Feed{
+		Link: "https://blog.example.com/",
+		Items: []*gofeed.Item{
+			{
+				Link:        "/posts/hello",
+				Links:       []string{"/posts/hello", "https://other.example.org/x"},
+				Enclosures:  []*gofeed.Enclosure{{URL: "media/hello.mp3"}},
+				Image:       &gofeed.Image{URL: "//cdn.example.com/hello.png"},
+				Content:     `<p>Hi</p><IMG SRC="img/a.jpg" alt="A &amp; B"><img src="https://cdn.example.com/b.png">`,
+				Description: `<img src="/c.gif"/> text`,
+			},
+			{Link: "https://elsewhere.example.net/abs", Content: `<img src="d.jpg">`},
+			nil,
+		},
+	}
+	resolveRelativeURLs(feed, "https://feeds.example.com/blog.xml")
+
+	item := feed.Items[0]
+	checks := map[string][2]string{
+		"link":        {item.Link, "https://blog.example.com/posts/hello"},
+		"links[0]":    {item.Links[0], "https://blog.example.com/posts/hello"},
+		"links[1]":    {item.Links[1], "https://other.example.org/x"},
+		"enclosure":   {item.Enclosures[0].URL, "https://blog.example.com/media/hello.mp3"},
+		"image":       {item.Image.URL, "https://cdn.example.com/hello.png"},
+		"content":     {item.Content, `<p>Hi</p><img src="https://blog.example.com/posts/img/a.jpg" alt="A &amp; B"><img src="https://cdn.example.com/b.png">`},
+		"description": {item.Description, `<img src="https://blog.example.com/c.gif"/> text`},
+		"absolute":    {feed.Items[1].Content, `<img src="https://elsewhere.example.net/d.jpg">`},
+	}
+	for name, c := range checks {
+		if c[0] != c[1] {
+			t.Errorf("%s = %q, want %q", name, c[0], c[1])
+		}
+	}
+}
+
+func TestResolveRelativeURLs_Base(t *testing.T) {
+	tests := []struct {
+		name string
+		feed *gofeed.Feed
+		want string
+	}{
+		{"feed link", &gofeed.Feed{Link: "https://site.example.com/blog/", FeedLink: "https://feeds.example.com/"}, "https://site.example.com/blog/p/1"},
+		{"feed self link", &gofeed.Feed{FeedLink: "https://feeds.example.com/x/rss"}, "https://feeds.example.com/x/p/1"},
+		{"relative feed link", &gofeed.Feed{Link: "/blog/"}, "https://fetch.example.com/blog/p/1"},
+		{"fetch URL", &gofeed.Feed{}, "https://fetch.example.com/feeds/p/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.feed.Items = []*gofeed.Item{{Link: "p/1"}}
+			resolveRelativeURLs(tt.feed, "https://fetch.example.com/feeds/rss.xml")
+			if got := tt.feed.Items[0].Link; got != tt.want {
+				t.Errorf("link = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_ResolveRelativeURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Rel</title><link>https://rel.example.com/</link>` +
+			`<item><title>Post</title><link>/2024/post</link><enclosure url="/audio/post.mp3" type="audio/mpeg" length="1"/></item>` +
+			`</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	fetch := func(resolve *bool) *gofeed.Item {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, ResolveRelativeURLs: resolve})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+		if err != nil || len(result.Items) != 1 {
+			t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+		}
+		return result.Items[0]
+	}
+
+	item := fetch(nil)
+	if item.Link != "https://rel.example.com/2024/post" || item.Enclosures[0].URL != "https://rel.example.com/audio/post.mp3" {
+		t.Errorf("resolved link = %q, enclosure = %q", item.Link, item.Enclosures[0].URL)
+	}
+	if got := item.Custom[model.StableIDKey]; got != "link:rel.example.com/2024/post" {
+		t.Errorf("stable_id = %q, want it built from the resolved link", got)
+	}
+
+	disabled := false
+	if item := fetch(&disabled); item.Link != "/2024/post" {
+		t.Errorf("link with resolution disabled = %q, want /2024/post", item.Link)
+	}
+}
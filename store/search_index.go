@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// minIndexedQueryLen is the shortest query the search index can answer. Shorter
+// queries contain no trigram, so callers fall back to scanning.
+const minIndexedQueryLen = 3
+
+// searchIndex is an in-memory trigram index over item titles, descriptions,
+// and content, kept per feed and rebuilt whenever the feed is fetched. Because
+// every substring of length >= 3 is covered by the trigrams it contains, the
+// index yields a superset of the items matching a case-insensitive substring
+// query; each candidate is then verified, so results match a linear scan.
+type searchIndex struct {
+	feeds map[string]*feedSearchIndex // by feed URL
+	mu    sync.RWMutex
+}
+
+// feedSearchIndex indexes one feed's items.
+type feedSearchIndex struct {
+	items    []*gofeed.Item
+	fields   [][3]string        // lowercased title, description, content per item
+	postings map[string][]int32 // trigram -> ascending item positions
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{feeds: make(map[string]*feedSearchIndex)}
+}
+
+// update replaces the index for a feed with its freshly fetched items.
+func (si *searchIndex) update(feedURL string, items []*gofeed.Item) {
+	fi := &feedSearchIndex{
+		items:    items,
+		fields:   make([][3]string, len(items)),
+		postings: make(map[string][]int32),
+	}
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		fi.fields[i] = [3]string{strings.ToLower(item.Title), strings.ToLower(item.Description), strings.ToLower(item.Content)}
+		seen := make(map[string]bool)
+		for _, field := range fi.fields[i] {
+			for j := 0; j+minIndexedQueryLen <= len(field); j++ {
+				gram := field[j : j+minIndexedQueryLen]
+				if !seen[gram] {
+					seen[gram] = true
+					fi.postings[gram] = append(fi.postings[gram], int32(i))
+				}
+			}
+		}
+	}
+
+	si.mu.Lock()
+	si.feeds[feedURL] = fi
+	si.mu.Unlock()
+}
+
+// remove drops a feed from the index.
+func (si *searchIndex) remove(feedURL string) {
+	si.mu.Lock()
+	delete(si.feeds, feedURL)
+	si.mu.Unlock()
+}
+
+// search returns the feed's items whose title, description, or content
+// contains query (case-insensitively), in feed order. ok is false when the
+// feed isn't indexed or the query is too short to use the index.
+func (si *searchIndex) search(feedURL, query string) (matches []*gofeed.Item, ok bool) {
+	q := strings.ToLower(query)
+	if len(q) < minIndexedQueryLen {
+		return nil, false
+	}
+
+	si.mu.RLock()
+	fi := si.feeds[feedURL]
+	si.mu.RUnlock()
+	if fi == nil {
+		return nil, false
+	}
+
+	// Intersect the posting lists of the query's trigrams, shortest first.
+	var lists [][]int32
+	for j := 0; j+minIndexedQueryLen <= len(q); j++ {
+		postings, found := fi.postings[q[j:j+minIndexedQueryLen]]
+		if !found {
+			return []*gofeed.Item{}, true
+		}
+		lists = append(lists, postings)
+	}
+	slices.SortFunc(lists, func(a, b []int32) int { return len(a) - len(b) })
+	candidates := lists[0]
+	for _, list := range lists[1:] {
+		candidates = intersectPostings(candidates, list)
+	}
+
+	matches = make([]*gofeed.Item, 0, len(candidates))
+	for _, pos := range candidates {
+		fields := fi.fields[pos]
+		if strings.Contains(fields[0], q) || strings.Contains(fields[1], q) || strings.Contains(fields[2], q) {
+			matches = append(matches, fi.items[pos])
+		}
+	}
+	return matches, true
+}
+
+// intersectPostings intersects two ascending posting lists.
+func intersectPostings(a, b []int32) []int32 {
+	out := make([]int32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// SearchFeedItems implements mcpserver.ItemSearcher. It answers a
+// case-insensitive substring query over the feed's item titles, descriptions,
+// and content from the search index, loading the feed first if it isn't cached.
+// ok is false when the index is disabled or can't serve the query, in which
+// case the caller should scan the items itself.
+func (s *Store) SearchFeedItems(ctx context.Context, id, query string) ([]*gofeed.Item, bool, error) {
+	if s.searchIndex == nil {
+		return nil, false, nil
+	}
+	feedURL, exists := s.feedURL(id)
+	if !exists {
+		return nil, false, model.NewFeedError(model.ErrorTypeValidation, fmt.Sprintf("feed with ID %s not found", id)).
+			WithOperation("search_feed_items").
+			WithComponent("feed_store")
+	}
+	// Loading through the cache (re)builds the feed's index when it is missing
+	// or has expired.
+	if _, err := s.feedCacheManager.Get(ctx, feedURL); err != nil {
+		return nil, false, err
+	}
+	matches, ok := s.searchIndex.search(feedURL, query)
+	matches, _ = s.visibleItems(matches)
+	return matches, ok, nil
+}
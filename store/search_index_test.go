@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/richardwooding/feed-mcp/mcpserver"
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestSearchIndex_MatchesLinearScan(t *testing.T) {
+	items := []*gofeed.Item{
+		{Title: "Go 1.22 Released", Description: "Range over integers"},
+		{Title: "Rust news", Content: "<p>The borrow checker, explained</p>"},
+		{Title: "Weekly digest", Description: "Go, Rust, and Zig"},
+		nil,
+		{Title: "Ünïcödé Title", Description: "naïve café"},
+		{},
+	}
+	si := newSearchIndex()
+	si.update("feed", items)
+
+	for _, query := range []string{"go ", "RUST", "borrow checker", "café", "ünïcödé", "digest", "missing", "zig", "<p>"} {
+		got, ok := si.search("feed", query)
+		if !ok {
+			t.Fatalf("search(%q): index should serve queries of %d+ characters", query, minIndexedQueryLen)
+		}
+		nonNil := slices.DeleteFunc(slices.Clone(items), func(item *gofeed.Item) bool { return item == nil })
+		want := mcpserver.ApplyFilters(nonNil, &mcpserver.FilterParams{Search: query})
+		if !slices.Equal(got, want) {
+			t.Errorf("search(%q) = %d items, linear scan = %d items", query, len(got), len(want))
+		}
+	}
+}
+
+func TestSearchIndex_UnservableQueries(t *testing.T) {
+	si := newSearchIndex()
+	si.update("feed", []*gofeed.Item{{Title: "Go"}})
+
+	if _, ok := si.search("feed", "go"); ok {
+		t.Error("queries shorter than a trigram should fall back to scanning")
+	}
+	if _, ok := si.search("other", "golang"); ok {
+		t.Error("unindexed feeds should fall back to scanning")
+	}
+	si.remove("feed")
+	if _, ok := si.search("feed", "golang"); ok {
+		t.Error("removed feeds should fall back to scanning")
+	}
+}
+
+func TestStore_SearchFeedItems(t *testing.T) {
+	var version atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = fmt.Fprintf(w, `<rss version="2.0"><channel><title>Feed</title>
+<item><title>Edition %d</title><description>Kubernetes operators</description></item>
+<item><title>Unrelated</title></item></channel></rss>`, version.Load())
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	id := model.GenerateFeedID(srv.URL)
+
+	disabled, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if _, ok, err := disabled.SearchFeedItems(ctx, id, "kubernetes"); ok || err != nil {
+		t.Fatalf("disabled index: got ok=%v err=%v, want ok=false", ok, err)
+	}
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, EnableSearchIndex: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	items, ok, err := s.SearchFeedItems(ctx, id, "kubernetes")
+	if err != nil || !ok {
+		t.Fatalf("SearchFeedItems: ok=%v err=%v", ok, err)
+	}
+	if len(items) != 1 || items[0].Title != "Edition 0" {
+		t.Fatalf("expected the Edition 0 item, got %+v", items)
+	}
+
+	// A refetch rebuilds the feed's index. The loadable cache stores loaded
+	// feeds asynchronously, so evict until the refetch is observed.
+	version.Store(1)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_ = s.feedCacheManager.Delete(ctx, srv.URL)
+		if items, _, _ := s.SearchFeedItems(ctx, id, "edition 1"); len(items) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected refetched item to be searchable")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if items, _, _ := s.SearchFeedItems(ctx, id, "edition 0"); len(items) != 0 {
+		t.Errorf("expected stale item to be dropped from the index, got %d items", len(items))
+	}
+
+	if _, _, err := s.SearchFeedItems(ctx, "unknown", "kubernetes"); err == nil {
+		t.Error("expected error for unknown feed ID")
+	}
+}
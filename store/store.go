@@ -2,15 +2,18 @@
 package store
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
-	"math"
-	"math/rand"
+	"maps"
 	"net"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
@@ -35,6 +38,9 @@ type HTTPPoolConfig struct {
 	MaxConnsPerHost     int
 	MaxIdleConnsPerHost int
 	IdleConnTimeout     time.Duration
+	// MinTLSVersion is the oldest TLS version the transport will negotiate
+	// (a crypto/tls version constant). Zero means tls.VersionTLS12.
+	MinTLSVersion uint16
 }
 
 // Config holds configuration settings for the feed store
@@ -59,9 +65,155 @@ type Config struct {
 	CircuitBreakerMaxRequests      uint32
 	CircuitBreakerFailureThreshold uint32
 	RetryJitter                    bool
+	RetryParseErrors               bool   // Retry bodies that fail to parse because they were cut short (see errTruncatedBody), seen only with VerifyBodyCompleteness off; other parse failures never are
 	OPML                           string // OPML file path for metadata source detection
 	AllowPrivateIPs                bool   // Allow private IP addresses in URLs
 	AllowEmptyFeeds                bool   // Allow creating store with no initial feeds (used by DynamicStore)
+	// MissingDateStrategy controls how items without a publish date are treated
+	// when a feed is fetched. Zero means model.DefaultMissingDateStrategy.
+	MissingDateStrategy model.MissingDateStrategy
+	// StrictParsing rejects feeds that parse but are structurally degenerate
+	// (no title, no items or link, empty items), e.g. an HTML page served in
+	// place of a feed. See model.ValidateFeedStructure.
+	StrictParsing bool
+	// LenientXML retries a feed that fails to parse after repairing common XML
+	// defects: undeclared HTML entities, forbidden control characters, and
+	// invalid UTF-8. Repairs are recorded under XMLRecoveryMetadataKey.
+	LenientXML bool
+	// OverallFetchTimeout bounds the total time spent fetching one feed,
+	// including every retry attempt and the backoff between them. Timeout still
+	// applies to each attempt. Zero means no overall cap.
+	OverallFetchTimeout time.Duration
+	// RetryMaxElapsedTime stops retrying a feed once the time spent on its
+	// attempts and the backoff between them would pass this budget, even if
+	// attempts remain. Unlike OverallFetchTimeout it never cuts an attempt
+	// short: it is checked after each failed attempt, and no retry starts
+	// when waiting for it would end past the budget. Zero means no budget.
+	RetryMaxElapsedTime time.Duration
+	// ParseTimeout bounds parsing a feed body once it has been received, so a
+	// pathologically large feed can't tie up a fetch in the parser. Its clock
+	// starts when the body has been read; the attempt's Timeout still applies
+	// once parsing is bounded. A JSON Feed can't be interrupted while it
+	// decodes, so for one the timeout only stops the wait: decoding finishes
+	// in the background and its result is dropped. Zero means no cap.
+	ParseTimeout time.Duration
+	// AcceptedContentTypes, when set, rejects a fetch whose response
+	// Content-Type (ignoring parameters such as charset) isn't in the list,
+	// before the body is parsed, so a URL pointing at an HTML page or other
+	// non-feed resource fails with a clear error. The rejection isn't
+	// retried. DefaultAcceptedContentTypes is the usual list. Empty accepts
+	// any type.
+	AcceptedContentTypes []string
+	// FeedStoreFile, when set, persists runtime-added feeds (with their title,
+	// category, and description) to this JSON file so they survive restarts.
+	// Only used by DynamicStore.
+	FeedStoreFile string
+	// EnableSearchIndex keeps an in-memory index of item text, rebuilt on
+	// every fetch, that answers item search queries without scanning.
+	EnableSearchIndex bool
+	// FailedFeedBackoff escalates the wait before re-fetching a feed
+	// that keeps failing: after the Nth consecutive failure, loads fail fast
+	// until the Nth interval has passed (the last one repeats). Empty disables
+	// the backoff. See checkSchedule.
+	FailedFeedBackoff []time.Duration
+	// NormalizeCategories lowercases and trims item categories at fetch time
+	// so category filters and facets unify across feeds. CategorySynonyms maps
+	// aliases onto canonical categories and implies normalization. See
+	// model.CategoryNormalizer.
+	NormalizeCategories bool
+	CategorySynonyms    map[string]string
+	// StableIDChain is the fallback chain that assigns each item a stable ID
+	// at fetch time (see model.StableID). Empty means
+	// model.DefaultStableIDChain.
+	StableIDChain []model.StableIDSource
+	// ResolveRelativeURLs makes relative item links, enclosure URLs, and
+	// content image URLs absolute at fetch time (see resolveRelativeURLs).
+	// Nil means enabled.
+	ResolveRelativeURLs *bool
+	// DeduplicateWithinFeed drops items a feed repeats within one response,
+	// matched by stable ID (see deduplicateFeedItems). Nil means enabled.
+	DeduplicateWithinFeed *bool
+	// VerifyBodyCompleteness checks that each fetched body is complete (its
+	// Content-Length received, its JSON or XML document closed) before
+	// parsing it, failing incomplete ones with a retried truncation error
+	// instead of a parse error (see checkBodyComplete). Nil means enabled.
+	VerifyBodyCompleteness *bool
+	// VerifyEnclosures sends a HEAD request for each item's enclosures when a
+	// feed is fetched (up to maxEnclosureChecks per fetch) and records whether
+	// they are reachable, with their size and type. See verifyEnclosures.
+	VerifyEnclosures bool
+	// MaxFeeds caps how many feeds the store manages, counting startup and
+	// runtime-added feeds together. Zero means no cap.
+	MaxFeeds int
+	// KeepDuplicateFeeds registers every entry in Feeds even when two spell
+	// the same feed differently (see dedupeFeedURLs), giving each its own ID
+	// and fetches. By default NewStore keeps the first spelling only.
+	KeepDuplicateFeeds bool
+	// PerFeedHeaders maps a feed URL to extra request headers (an API key, an
+	// Accept override) sent when fetching that URL, and only that URL. Values
+	// may be secrets: they are never logged or included in errors.
+	PerFeedHeaders map[string]map[string]string
+	// UserAgents, when set, replaces the User-Agent of every request with one
+	// of these, chosen by UserAgentRotation, for hosts that throttle per
+	// User-Agent. A User-Agent in PerFeedHeaders still wins for its feed.
+	UserAgents []string
+	// UserAgentRotation picks the User-Agent for each request: round-robin
+	// (the default when empty) or random.
+	UserAgentRotation UserAgentRotation
+	// FallbackURLs maps a feed URL to alternative URLs for the same feed (say,
+	// its Atom and JSON Feed variants). When the feed URL fails after all
+	// retries, each fallback is tried in order before the fetch is recorded as
+	// failed; the URL that succeeded is recorded under FetchedURLMetadataKey.
+	FallbackURLs map[string][]string
+	// UpgradeInsecureFeeds fetches http:// feeds over https:// first, with a
+	// single attempt, falling back to the http:// URL (with its retries and
+	// fallback URLs) only when that fails. The URL used is recorded under
+	// FetchedURLMetadataKey.
+	UpgradeInsecureFeeds bool
+	// MinTLSVersion is the oldest TLS version feed fetches will negotiate, a
+	// crypto/tls version constant such as tls.VersionTLS13. Zero means
+	// tls.VersionTLS12. Ignored when HTTPClient is supplied.
+	MinTLSVersion uint16
+	// RefreshCron re-fetches every feed on a cron schedule, e.g.
+	// "0 8 * * 1-5" for weekdays at 8am; FeedRefreshCron overrides it per feed
+	// URL. Invalid expressions fail NewStore. Refreshing only starts once
+	// StartRefreshSchedule is called. See refreshScheduler.
+	RefreshCron     string
+	FeedRefreshCron map[string]string
+	// DuplicateFeedCheckInterval is how often feeds are compared for
+	// identical content, to flag feeds served under several URLs. Zero
+	// disables the check; it only starts once StartDuplicateFeedCheck is
+	// called. See duplicateFeedDetector.
+	DuplicateFeedCheckInterval time.Duration
+	// ContentCleaning maps a feed URL to rules that strip known cruft (ad
+	// blocks, "read more" footers) from its items' content and descriptions
+	// after parsing. A rule is "css:SELECTOR", removing the matching
+	// elements, or "regex:PATTERN", deleting the matching text from the
+	// HTML. Rules apply in order; invalid rules fail NewStore.
+	ContentCleaning map[string][]string
+	// FeedTags maps a feed URL to free-form tags, such as "priority:high",
+	// that bulk tools can select feeds by. Tags are lowercased and sorted;
+	// ones longer than model.MaxFeedTagLength or containing a comma fail
+	// NewStore.
+	FeedTags map[string][]string
+	// FeedPriorities maps a feed URL to its fetch priority; feeds without one
+	// have priority zero. When fetches wait for a slot of a fetch gate (this
+	// store's, see MaxConcurrentFetches, or the caller's, such as the
+	// resource manager's), higher-priority feeds are fetched first, and
+	// GetAllFeeds and scheduled refreshes start them first.
+	FeedPriorities map[string]int
+	// MaxConcurrentFetches bounds the upstream fetches GetAllFeeds and
+	// scheduled refreshes run at once, queued by FeedPriorities. A caller's
+	// own fetch gate, if its context carries one, applies instead. Zero means
+	// no bound.
+	MaxConcurrentFetches int
+	// HideFutureItems leaves items published more than FutureItemTolerance
+	// after the time of the read out of GetFeedAndItems and SearchFeedItems,
+	// such as scheduled posts or items from a feed with a skewed clock.
+	// GetFeedAndItems reports how many it hid. Zero FutureItemTolerance means
+	// model.DefaultFutureItemTolerance.
+	HideFutureItems     bool
+	FutureItemTolerance time.Duration
 }
 
 // RetryMetrics holds metrics for retry operations
@@ -71,6 +223,9 @@ type RetryMetrics struct {
 	SuccessfulFeeds  int64   // Number of feeds successfully fetched
 	FailedFeeds      int64   // Number of feeds that failed after all retries
 	RetrySuccessRate float64 // Percentage of feeds that succeeded after retrying
+	// ElapsedBudgetExceeded counts failed feeds whose retries stopped because
+	// Config.RetryMaxElapsedTime ran out, with attempts remaining.
+	ElapsedBudgetExceeded int64
 }
 
 // Store manages feed fetching, caching, and retrieval with retry logic
@@ -79,14 +234,63 @@ type Store struct {
 	feedCacheManager *cache.LoadableCache[*gofeed.Feed]
 	feedCache        *cache.Cache[*gofeed.Feed]
 	circuitBreakers  map[string]*gobreaker.CircuitBreaker
-	retryMetrics     *RetryMetrics
-	metricsMutex     sync.RWMutex
+	// newBreaker builds a closed circuit breaker for a feed URL with the
+	// configured settings; nil when circuit breaking is disabled.
+	newBreaker   func(url string) *gobreaker.CircuitBreaker
+	retryMetrics *RetryMetrics
+	metricsMutex sync.RWMutex
 	// feedsMu guards the feeds and circuitBreakers maps. The base Store only
 	// reads them after construction, but DynamicStore mutates them at runtime
 	// (add_feed / remove_feed) concurrently with reads here, so every access to
 	// either map — base or dynamic — must hold this lock. It is held only around
 	// the map operations themselves, never across a network fetch.
 	feedsMu sync.RWMutex
+	// httpClient is the rate-limited client used for feed fetches, shared by
+	// auxiliary lookups (e.g. icon resolution) so they obey the same limits.
+	httpClient *http.Client
+	// icons caches resolved feed icon URLs by feed URL; see ResolveFeedIcon.
+	icons   map[string]iconCacheEntry
+	iconTTL time.Duration
+	iconMu  sync.Mutex
+	// throttle is the adaptive, header-driven throttle inside httpClient; nil
+	// when the caller supplied its own client.
+	throttle *adaptiveThrottle
+	// searchIndex indexes item text for SearchFeedItems; nil unless
+	// Config.EnableSearchIndex is set.
+	searchIndex *searchIndex
+	// checkSchedule backs off fetches of failing feeds; nil unless
+	// Config.FailedFeedBackoff is set.
+	checkSchedule *checkSchedule
+	// categoryNormalizer rewrites item categories on fetch; nil unless
+	// category normalization is configured.
+	categoryNormalizer *model.CategoryNormalizer
+	// errorLog retains recent fetch errors for GetDiagnostics.
+	errorLog *errorLog
+	// fetchTimings records each feed's fetch durations for GetDiagnostics.
+	fetchTimings *fetchTimings
+	// history records a snapshot of each successful fetch for FeedHistory.
+	history *feedHistory
+	// duplicates fingerprints each fetch for the duplicate feed check; nil
+	// when Config.DuplicateFeedCheckInterval is zero.
+	duplicates *duplicateFeedDetector
+	// contentCleaners strips configured cruft from item content, by feed
+	// URL; nil when Config.ContentCleaning is empty.
+	contentCleaners map[string]*contentCleaner
+	// feedTags holds the normalized Config.FeedTags, by feed URL.
+	feedTags map[string][]string
+	// feedPriorities holds Config.FeedPriorities, by feed URL.
+	feedPriorities map[string]int
+	// fetchGate bounds the fetches of GetAllFeeds and scheduled refreshes;
+	// nil when Config.MaxConcurrentFetches is zero.
+	fetchGate *model.FetchGate
+	// generation counts changes to the feed data; see FeedGeneration.
+	generation atomic.Uint64
+	// refreshScheduler re-fetches feeds on cron schedules; nil unless
+	// Config.RefreshCron or Config.FeedRefreshCron is set.
+	refreshScheduler *refreshScheduler
+	// settings is the configuration in force, defaults applied; see
+	// EffectiveConfig.
+	settings Config
 }
 
 // feedEntry pairs a feed's ID with its URL for snapshotting the feeds map.
@@ -95,18 +299,29 @@ type feedEntry struct {
 	url string
 }
 
-// feedEntries returns a snapshot of the configured feeds, taken under the read
-// lock so iteration can proceed without holding the lock across network fetches.
+// feedEntries returns a snapshot of the configured feeds, sorted by feed ID,
+// taken under the read lock so iteration can proceed without holding the lock
+// across network fetches. The sort gives ListManagedFeeds, which lists feeds in
+// snapshot order, a stable order, and makes GetAllFeeds start its fetches in
+// the same order on every call.
 func (s *Store) feedEntries() []feedEntry {
 	s.feedsMu.RLock()
-	defer s.feedsMu.RUnlock()
 	entries := make([]feedEntry, 0, len(s.feeds))
 	for id, url := range s.feeds {
 		entries = append(entries, feedEntry{id: id, url: url})
 	}
+	s.feedsMu.RUnlock()
+	slices.SortFunc(entries, func(a, b feedEntry) int { return strings.Compare(a.id, b.id) })
 	return entries
 }
 
+// feedCount returns the number of managed feeds under the read lock.
+func (s *Store) feedCount() int {
+	s.feedsMu.RLock()
+	defer s.feedsMu.RUnlock()
+	return len(s.feeds)
+}
+
 // feedURL returns the URL for a feed ID under the read lock.
 func (s *Store) feedURL(id string) (string, bool) {
 	s.feedsMu.RLock()
@@ -132,15 +347,19 @@ func (s *Store) cachedItemCount(ctx context.Context, url string) int {
 	return 0
 }
 
+// refreshFeed drops a feed's cache entry and fetches it again.
+func (s *Store) refreshFeed(ctx context.Context, url string) (*gofeed.Feed, error) {
+	_ = s.feedCacheManager.Delete(ctx, url) // Cache deletion errors are not critical
+	return s.feedCacheManager.Get(ctx, url)
+}
+
 // urlRegistered reports whether a feed already uses the given URL, under the
-// read lock. Feed IDs are GenerateFeedID(url), so this is an O(1) lookup; the
-// value comparison guards against a hash collision mapping a different URL to
-// the same ID.
+// read lock. Feed IDs are GenerateFeedID(url) unless that collided with
+// another feed's (see assignFeedIDs), so this is usually an O(1) lookup.
 func (s *Store) urlRegistered(url string) bool {
-	id := model.GenerateFeedID(url)
 	s.feedsMu.RLock()
 	defer s.feedsMu.RUnlock()
-	existing, ok := s.feeds[id]
+	existing, ok := s.feeds[s.feedIDLocked(url)]
 	return ok && existing == url
 }
 
@@ -162,6 +381,14 @@ func (s *Store) circuitBreaker(url string) (*gobreaker.CircuitBreaker, bool) {
 	return cb, ok
 }
 
+// FeedGeneration implements mcpserver.FeedGenerationReporter. The counter
+// moves whenever a feed finishes a network fetch (a first load, a refresh, or
+// a reload after its cache entry expired), successful or not, and whenever a
+// feed is added or removed.
+func (s *Store) FeedGeneration() uint64 {
+	return s.generation.Load()
+}
+
 // putFeed registers a feed (and, when configured, its circuit breaker) under the
 // write lock.
 func (s *Store) putFeed(id, url string, cb *gobreaker.CircuitBreaker) {
@@ -171,6 +398,7 @@ func (s *Store) putFeed(id, url string, cb *gobreaker.CircuitBreaker) {
 	if cb != nil && s.circuitBreakers != nil {
 		s.circuitBreakers[url] = cb
 	}
+	s.generation.Add(1)
 }
 
 // deleteFeed removes a feed and its circuit breaker under the write lock.
@@ -181,6 +409,22 @@ func (s *Store) deleteFeed(id, url string) {
 	if s.circuitBreakers != nil {
 		delete(s.circuitBreakers, url)
 	}
+	s.generation.Add(1)
+
+	s.iconMu.Lock()
+	delete(s.icons, url)
+	s.iconMu.Unlock()
+
+	if s.searchIndex != nil {
+		s.searchIndex.remove(url)
+	}
+	if s.checkSchedule != nil {
+		s.checkSchedule.remove(url)
+	}
+	s.history.remove(url)
+	if s.duplicates != nil {
+		s.duplicates.remove(url)
+	}
 }
 
 // newPooledTransport builds an *http.Transport with the given connection pool
@@ -191,8 +435,15 @@ func (s *Store) deleteFeed(id, url string) {
 // blocks internal addresses. This is the backstop against DNS rebinding, where a
 // host passes up-front model.ValidateFeedURL as public but later resolves to an
 // internal address. When allowPrivateIPs is set, internal ranges are permitted.
+//
+// Servers that only speak TLS versions older than poolConfig.MinTLSVersion
+// fail the handshake, surfacing as model.ErrorTypeTLS.
 func newPooledTransport(poolConfig HTTPPoolConfig, allowPrivateIPs bool) *http.Transport {
 	guard := ssrfguard.New(ssrfguard.WithAllowPrivate(allowPrivateIPs))
+	minTLSVersion := poolConfig.MinTLSVersion
+	if minTLSVersion == 0 {
+		minTLSVersion = tls.VersionTLS12
+	}
 	return &http.Transport{
 		MaxIdleConns:        poolConfig.MaxIdleConns,
 		MaxConnsPerHost:     poolConfig.MaxConnsPerHost,
@@ -205,6 +456,7 @@ func newPooledTransport(poolConfig HTTPPoolConfig, allowPrivateIPs bool) *http.T
 			KeepAlive: 30 * time.Second,
 			Control:   guard.Control,
 		}).DialContext,
+		TLSClientConfig:       &tls.Config{MinVersion: minTLSVersion},
 		ForceAttemptHTTP2:     true,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
@@ -223,14 +475,23 @@ func newPooledTransport(poolConfig HTTPPoolConfig, allowPrivateIPs bool) *http.T
 // the client's lifetime — fine when the host set is small and fixed. It is
 // variadic so existing callers that don't configure eviction keep compiling.
 func NewRateLimitedHTTPClient(requestsPerSecond float64, burstCapacity int, poolConfig HTTPPoolConfig, allowPrivateIPs bool, idleTimeout ...time.Duration) *http.Client {
+	client, _ := newRateLimitedHTTPClient(requestsPerSecond, burstCapacity, poolConfig, allowPrivateIPs, idleTimeout...)
+	return client
+}
+
+// newRateLimitedHTTPClient builds the client returned by NewRateLimitedHTTPClient
+// and also returns its adaptive throttle, so the store can report the quotas it
+// has observed. The throttle sits beneath the fixed per-host limiter.
+func newRateLimitedHTTPClient(requestsPerSecond float64, burstCapacity int, poolConfig HTTPPoolConfig, allowPrivateIPs bool, idleTimeout ...time.Duration) (*http.Client, *adaptiveThrottle) {
 	var opts []hostrate.Option
 	if len(idleTimeout) > 0 && idleTimeout[0] > 0 {
 		// Only enable eviction for a positive timeout; a non-positive value means
 		// "no eviction", which is hostrate's default when the option is absent.
 		opts = append(opts, hostrate.WithIdleTimeout(idleTimeout[0]))
 	}
+	throttle := newAdaptiveThrottle(newPooledTransport(poolConfig, allowPrivateIPs))
 	transport := hostrate.New(
-		newPooledTransport(poolConfig, allowPrivateIPs),
+		throttle,
 		rate.Limit(requestsPerSecond),
 		burstCapacity,
 		opts...,
@@ -239,7 +500,7 @@ func NewRateLimitedHTTPClient(requestsPerSecond float64, burstCapacity int, pool
 	return &http.Client{
 		Transport: transport,
 		Timeout:   30 * time.Second, // Default timeout
-	}
+	}, throttle
 }
 
 // isRetryableError determines if an error should trigger a retry attempt.
@@ -264,6 +525,30 @@ func isRetryableError(err error) bool {
 		return false
 	}
 
+	// A failed TLS handshake (a protocol version below the minimum, a bad
+	// certificate) fails the same way on retry.
+	if model.IsTLSError(err) {
+		return false
+	}
+
+	// A feed rejected by strict parsing will parse the same way on retry.
+	var feedErr *model.FeedError
+	if errors.As(err, &feedErr) && feedErr.ErrorType == model.ErrorTypeInvalidFormat {
+		return false
+	}
+
+	// Most bodies that fail to parse fail the same way every time; truncated
+	// ones are retried only with Config.RetryParseErrors (see
+	// isRetryableFetchError).
+	if isParseFailure(err) {
+		return false
+	}
+
+	// A body cut short before it was parsed (see checkBodyComplete).
+	if errors.Is(err, errTruncatedBody) {
+		return true
+	}
+
 	// DNS and network errors are retryable
 	if strings.Contains(errStr, "no such host") ||
 		strings.Contains(errStr, "connection refused") ||
@@ -287,34 +572,10 @@ func isRetryableError(err error) bool {
 	return true
 }
 
-// calculateRetryDelay calculates the delay for the next retry using exponential backoff.
-// Uses formula: baseDelay * 2^(attempt-1), capped at maxDelay.
-// Applies jitter (±50% random variance) when useJitter is true to prevent thundering herd.
-func calculateRetryDelay(attempt int, baseDelay, maxDelay time.Duration, useJitter bool) time.Duration {
-	if attempt <= 0 {
-		return baseDelay
-	}
-
-	// Exponential backoff: baseDelay * 2^(attempt-1)
-	delay := min(
-		// Cap at maxDelay
-		time.Duration(float64(baseDelay)*math.Pow(2, float64(attempt-1))), maxDelay)
-
-	// Add jitter to avoid thundering herd
-	if useJitter && delay > 0 {
-		jitterRange := delay / 2
-		var jitter time.Duration
-		if jitterRange > 0 {
-			jitter = time.Duration(rand.Int63n(int64(jitterRange)))
-		} else {
-			jitter = 0
-		}
-		delay = max(
-			// Ensure delay is never negative
-			delay-jitterRange/2+jitter, 0)
-	}
-
-	return delay
+// isRetryableFetchError is isRetryableError, plus truncated-body parse
+// failures when retryParseErrors is set.
+func isRetryableFetchError(err error, retryParseErrors bool) bool {
+	return isRetryableError(err) || (retryParseErrors && errors.Is(err, errTruncatedBody))
 }
 
 // retryableFeedFetch performs feed fetching with retry logic and comprehensive metrics tracking.
@@ -323,6 +584,15 @@ func calculateRetryDelay(attempt int, baseDelay, maxDelay time.Duration, useJitt
 //
 //nolint:gocognit,gocyclo,gocritic // Function complexity is necessary for comprehensive retry logic with metrics and error handling
 func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser, config Config, metrics *RetryMetrics, metricsMutex *sync.RWMutex) (*gofeed.Feed, error) {
+	// Derive every attempt (and the backoff waits) from one context carrying the
+	// overall deadline, so retries can't stretch a fetch past the cap.
+	parentCtx := ctx
+	if config.OverallFetchTimeout > 0 {
+		var cancelOverall context.CancelFunc
+		ctx, cancelOverall = context.WithTimeout(ctx, config.OverallFetchTimeout)
+		defer cancelOverall()
+	}
+
 	var lastErr error
 	maxAttempts := config.RetryMaxAttempts
 	if maxAttempts <= 0 {
@@ -330,6 +600,8 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 	}
 
 	attemptCount := 0
+	start := time.Now()
+	opts := newParseOptions(&config)
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		attemptCount++
@@ -347,8 +619,11 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 		// Create timeout context for this attempt
 		attemptCtx, cancel := context.WithTimeout(ctx, config.Timeout)
 
-		feed, err := parser.ParseURLWithContext(url, attemptCtx)
+		feed, err := fetchAndParseFeed(attemptCtx, url, parser, opts)
 		cancel()
+		if err == nil && config.StrictParsing {
+			err = model.ValidateFeedStructure(feed, url)
+		}
 
 		// Success case
 		if err == nil {
@@ -384,6 +659,7 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 		}
 
 		lastErr = err
+		retryable := isRetryableFetchError(err, config.RetryParseErrors)
 
 		// Debug log the error
 		model.DebugLogWithContext(
@@ -393,13 +669,13 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 				keyAttempt:     attempt,
 				"max_attempts": maxAttempts,
 				statusError:    err.Error(),
-				"retryable":    isRetryableError(err),
+				"retryable":    retryable,
 			},
 		)
 
 		// Don't retry on the last attempt or non-retryable errors
-		if attempt >= maxAttempts || !isRetryableError(err) {
-			if !isRetryableError(err) {
+		if attempt >= maxAttempts || !retryable {
+			if !retryable {
 				model.DebugLogWithContext(
 					"Error is not retryable, stopping retry attempts",
 					"feed_fetcher", "retryable_fetch", url,
@@ -413,7 +689,23 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 		}
 
 		// Calculate delay and sleep before next attempt
-		delay := calculateRetryDelay(attempt, config.RetryBaseDelay, config.RetryMaxDelay, config.RetryJitter)
+		delay := model.RetryDelay(attempt, config.RetryBaseDelay, config.RetryMaxDelay, config.RetryJitter)
+
+		// Give up while attempts remain if waiting would overrun the budget
+		if config.RetryMaxElapsedTime > 0 && time.Since(start)+delay > config.RetryMaxElapsedTime {
+			model.DebugLogWithContext(
+				"Retry elapsed-time budget exceeded, stopping retry attempts",
+				"feed_fetcher", "retryable_fetch", url,
+				map[string]any{
+					keyAttempt:   attempt,
+					"elapsed_ms": time.Since(start).Milliseconds(),
+					"delay_ms":   delay.Milliseconds(),
+				},
+			)
+			recordFailedFeed(metrics, metricsMutex)
+			recordElapsedBudgetExceeded(metrics, metricsMutex)
+			return nil, elapsedBudgetExceededError(lastErr, url, config.RetryMaxElapsedTime, attemptCount, maxAttempts)
+		}
 
 		model.DebugLogWithContext(
 			fmt.Sprintf("Retrying in %v", delay),
@@ -427,38 +719,101 @@ func retryableFeedFetch(ctx context.Context, url string, parser *gofeed.Parser,
 
 		select {
 		case <-ctx.Done():
+			if overallDeadlineExceeded(parentCtx, ctx) {
+				recordFailedFeed(metrics, metricsMutex)
+				return nil, overallFetchTimeoutError(lastErr, url, config.OverallFetchTimeout, attemptCount, maxAttempts)
+			}
 			return nil, ctx.Err()
 		case <-time.After(delay):
 			// Continue to next attempt
 		}
 	}
 
-	// Track failed feed
-	if metrics != nil && metricsMutex != nil {
-		metricsMutex.Lock()
-		metrics.FailedFeeds++
-		// Update success rate
-		totalFeeds := metrics.SuccessfulFeeds + metrics.FailedFeeds
-		if totalFeeds > 0 {
-			metrics.RetrySuccessRate = float64(metrics.SuccessfulFeeds) / float64(totalFeeds) * 100
-		}
-		metricsMutex.Unlock()
+	recordFailedFeed(metrics, metricsMutex)
+
+	if overallDeadlineExceeded(parentCtx, ctx) {
+		return nil, overallFetchTimeoutError(lastErr, url, config.OverallFetchTimeout, attemptCount, maxAttempts)
 	}
 
 	// Create a comprehensive error with retry context
 	return nil, model.CreateRetryError(lastErr, url, attemptCount, maxAttempts)
 }
 
+// recordFailedFeed counts a feed whose fetch ultimately failed and updates the
+// success rate.
+func recordFailedFeed(metrics *RetryMetrics, metricsMutex *sync.RWMutex) {
+	if metrics == nil || metricsMutex == nil {
+		return
+	}
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+	metrics.FailedFeeds++
+	totalFeeds := metrics.SuccessfulFeeds + metrics.FailedFeeds
+	if totalFeeds > 0 {
+		metrics.RetrySuccessRate = float64(metrics.SuccessfulFeeds) / float64(totalFeeds) * 100
+	}
+}
+
+// recordElapsedBudgetExceeded counts a feed whose retries stopped because
+// Config.RetryMaxElapsedTime ran out.
+func recordElapsedBudgetExceeded(metrics *RetryMetrics, metricsMutex *sync.RWMutex) {
+	if metrics == nil || metricsMutex == nil {
+		return
+	}
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+	metrics.ElapsedBudgetExceeded++
+}
+
+// elapsedBudgetExceededError reports a fetch whose retries stopped because
+// the elapsed-time budget ran out. Like an exhausted retry error it takes the
+// last attempt's error type, since that is why the feed failed.
+func elapsedBudgetExceededError(lastErr error, url string, budget time.Duration, attempt, maxAttempts int) *model.FeedError {
+	err := model.CreateRetryError(lastErr, url, attempt, maxAttempts)
+	err.Message = fmt.Sprintf("Retry elapsed budget of %v exceeded after %d of %d attempts", budget, attempt, maxAttempts)
+	return err.WithSuggestion("Raise the retry elapsed-time budget, or check why the feed keeps failing")
+}
+
+// overallDeadlineExceeded reports whether fetchCtx ended because of the
+// OverallFetchTimeout deadline rather than because the caller's context did.
+func overallDeadlineExceeded(parentCtx, fetchCtx context.Context) bool {
+	return parentCtx.Err() == nil && errors.Is(fetchCtx.Err(), context.DeadlineExceeded)
+}
+
+// overallFetchTimeoutError reports a fetch abandoned because the overall
+// deadline passed, keeping the last attempt's error as the cause.
+func overallFetchTimeoutError(lastErr error, url string, timeout time.Duration, attempt, maxAttempts int) *model.FeedError {
+	if lastErr == nil {
+		lastErr = context.DeadlineExceeded
+	}
+	return model.NewFeedErrorWithCause(model.ErrorTypeTimeout, fmt.Sprintf("Overall fetch timeout of %v exceeded", timeout), lastErr).
+		WithURL(url).
+		WithOperation("retry_fetch").
+		WithComponent("retry_manager").
+		WithRetryContext(attempt, maxAttempts, 0)
+}
+
 // NewStore creates a new feed store with the given configuration.
 // Uses pointer to avoid copying large Config struct (192 bytes).
 func NewStore(config *Config) (*Store, error) {
-	if len(config.Feeds) == 0 && !config.AllowEmptyFeeds {
+	feeds := config.Feeds
+	if !config.KeepDuplicateFeeds {
+		feeds = dedupeFeedURLs(feeds)
+	}
+	if len(feeds) == 0 && !config.AllowEmptyFeeds {
 		return nil, model.NewFeedError(model.ErrorTypeConfiguration, "at least one feed must be specified").
 			WithOperation("create_store").
 			WithComponent("store_manager")
 	}
+	if config.MaxFeeds > 0 && len(feeds) > config.MaxFeeds {
+		return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("%d feeds configured but the feed limit is %d", len(feeds), config.MaxFeeds)).
+			WithOperation("create_store").
+			WithComponent("store_manager")
+	}
 
-	return newStoreInternal(*config)
+	deduped := *config
+	deduped.Feeds = feeds
+	return newStoreInternal(deduped)
 }
 
 // newStoreInternal contains the core store initialization logic.
@@ -468,14 +823,36 @@ func newStoreInternal(config Config) (*Store, error) {
 	applyConfigDefaults(&config)
 
 	// Create rate-limited HTTP client with connection pooling if not provided
+	var throttle *adaptiveThrottle
 	if config.HTTPClient == nil {
 		poolConfig := HTTPPoolConfig{
 			MaxIdleConns:        config.MaxIdleConns,
 			MaxConnsPerHost:     config.MaxConnsPerHost,
 			MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
 			IdleConnTimeout:     config.IdleConnTimeout,
+			MinTLSVersion:       config.MinTLSVersion,
+		}
+		config.HTTPClient, throttle = newRateLimitedHTTPClient(config.RequestsPerSecond, config.BurstCapacity, poolConfig, config.AllowPrivateIPs, config.RateLimiterIdleTimeout)
+	}
+	if len(config.PerFeedHeaders) > 0 {
+		// Copy the client rather than changing one the caller passed in.
+		client := *config.HTTPClient
+		perFeedHeaders := config.PerFeedHeaders
+		if config.UpgradeInsecureFeeds {
+			perFeedHeaders = withUpgradedHeaderURLs(perFeedHeaders)
+		}
+		client.Transport = newFeedHeaderTransport(client.Transport, perFeedHeaders)
+		config.HTTPClient = &client
+	}
+	if len(config.UserAgents) > 0 {
+		// Outside the feed header transport, so per-feed User-Agents win.
+		transport, err := newUserAgentTransport(config.HTTPClient.Transport, config.UserAgents, config.UserAgentRotation)
+		if err != nil {
+			return nil, err
 		}
-		config.HTTPClient = NewRateLimitedHTTPClient(config.RequestsPerSecond, config.BurstCapacity, poolConfig, config.AllowPrivateIPs, config.RateLimiterIdleTimeout)
+		client := *config.HTTPClient
+		client.Transport = transport
+		config.HTTPClient = &client
 	}
 
 	ristrettoCache, err := ristretto.NewCache[string, *gofeed.Feed](&ristretto.Config[string, *gofeed.Feed]{
@@ -496,8 +873,46 @@ func newStoreInternal(config Config) (*Store, error) {
 	s := &Store{
 		feeds:           make(map[string]string, len(config.Feeds)),
 		circuitBreakers: circuitBreakers,
+		newBreaker:      circuitBreakerFactory(&config, circuitBreakerEnabled),
 		retryMetrics:    &RetryMetrics{},
 		metricsMutex:    sync.RWMutex{},
+		httpClient:      config.HTTPClient,
+		throttle:        throttle,
+		icons:           make(map[string]iconCacheEntry),
+		iconTTL:         config.ExpireAfter,
+		errorLog:        newErrorLog(errorLogCapacity),
+		fetchTimings:    newFetchTimings(),
+		history:         newFeedHistory(),
+		settings:        config,
+	}
+	if config.EnableSearchIndex {
+		s.searchIndex = newSearchIndex()
+	}
+	if config.DuplicateFeedCheckInterval > 0 {
+		s.duplicates = newDuplicateFeedDetector(config.DuplicateFeedCheckInterval)
+	}
+	if len(config.FailedFeedBackoff) > 0 {
+		s.checkSchedule = newCheckSchedule(config.FailedFeedBackoff)
+	}
+	if config.NormalizeCategories || len(config.CategorySynonyms) > 0 {
+		s.categoryNormalizer = model.NewCategoryNormalizer(config.CategorySynonyms)
+	}
+	if s.refreshScheduler, err = newRefreshScheduler(&config); err != nil {
+		return nil, err
+	}
+	if s.contentCleaners, err = newContentCleaners(&config); err != nil {
+		return nil, err
+	}
+	if s.feedTags, err = newFeedTags(&config); err != nil {
+		return nil, err
+	}
+	s.feedPriorities = maps.Clone(config.FeedPriorities)
+	if config.MaxConcurrentFetches > 0 {
+		s.fetchGate = model.NewFetchGate(config.MaxConcurrentFetches)
+	}
+	if s.refreshScheduler != nil {
+		s.refreshScheduler.feeds = s.feedURLs
+		s.refreshScheduler.refresh = s.scheduledRefresh
 	}
 
 	// Keep a reference to the inner (non-loadable) cache so callers can peek it
@@ -513,12 +928,7 @@ func newStoreInternal(config Config) (*Store, error) {
 	// loader above. Pre-fetching here previously blocked NewStore for ~(n/rps)
 	// seconds with a global rate limiter and caused MCP initialize timeouts on
 	// large feed lists (issue #114).
-	feeds := make(map[string]string, len(config.Feeds))
-	for _, feedURL := range config.Feeds {
-		feeds[model.GenerateFeedID(feedURL)] = feedURL
-	}
-
-	s.feeds = feeds
+	s.feeds = assignFeedIDs(config.Feeds)
 	return s, nil
 }
 
@@ -538,6 +948,15 @@ func applyConfigDefaults(config *Config) {
 	if config.BurstCapacity <= 0 {
 		config.BurstCapacity = 5 // Allow burst of 5 requests by default
 	}
+	if config.MissingDateStrategy == "" {
+		config.MissingDateStrategy = model.DefaultMissingDateStrategy
+	}
+	if config.FutureItemTolerance == 0 {
+		config.FutureItemTolerance = model.DefaultFutureItemTolerance
+	}
+	if len(config.StableIDChain) == 0 {
+		config.StableIDChain = model.DefaultStableIDChain
+	}
 	if config.RateLimiterIdleTimeout == 0 {
 		// Evict a host's limiter after an hour idle so a long-running store with
 		// runtime feed churn (add_feed/remove_feed across many hosts) can't grow
@@ -604,20 +1023,36 @@ func buildCircuitBreakers(config *Config, enabled bool) map[string]*gobreaker.Ci
 		return nil
 	}
 
+	newBreaker := circuitBreakerFactory(config, enabled)
 	circuitBreakers := make(map[string]*gobreaker.CircuitBreaker, len(config.Feeds))
 	for _, feedURL := range config.Feeds {
-		settings := gobreaker.Settings{
-			Name:        fmt.Sprintf("feed-%s", feedURL),
-			MaxRequests: config.CircuitBreakerMaxRequests,
-			Interval:    config.CircuitBreakerInterval,
-			Timeout:     config.CircuitBreakerTimeout,
+		circuitBreakers[feedURL] = newBreaker(feedURL)
+	}
+	return circuitBreakers
+}
+
+// circuitBreakerFactory returns a function building a closed circuit breaker
+// for a feed URL from the configured settings, or nil when circuit breaking is
+// disabled.
+func circuitBreakerFactory(config *Config, enabled bool) func(url string) *gobreaker.CircuitBreaker {
+	if !enabled {
+		return nil
+	}
+	maxRequests := config.CircuitBreakerMaxRequests
+	interval := config.CircuitBreakerInterval
+	timeout := config.CircuitBreakerTimeout
+	threshold := config.CircuitBreakerFailureThreshold
+	return func(url string) *gobreaker.CircuitBreaker {
+		return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Name:        fmt.Sprintf("feed-%s", url),
+			MaxRequests: maxRequests,
+			Interval:    interval,
+			Timeout:     timeout,
 			ReadyToTrip: func(counts gobreaker.Counts) bool {
-				return counts.ConsecutiveFailures >= config.CircuitBreakerFailureThreshold
+				return counts.ConsecutiveFailures >= threshold
 			},
-		}
-		circuitBreakers[feedURL] = gobreaker.NewCircuitBreaker(settings)
+		})
 	}
-	return circuitBreakers
 }
 
 // makeFeedLoader returns the LoadableCache loader that fetches and parses a feed
@@ -634,6 +1069,12 @@ func (s *Store) makeFeedLoader(
 				WithComponent("cache_manager")
 		}
 
+		if s.checkSchedule != nil {
+			if err := s.checkSchedule.due(url); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		// Create parser with HTTP client
 		fp := gofeed.NewParser()
 		if config.HTTPClient != nil {
@@ -642,22 +1083,77 @@ func (s *Store) makeFeedLoader(
 
 		opts := []store.Option{store.WithExpiration(config.ExpireAfter)}
 
-		// Use circuit breaker if enabled and configured for this URL.
-		if circuitBreakerEnabled {
-			if cb, exists := s.circuitBreaker(url); exists {
-				feed, err := s.fetchWithCircuitBreaker(ctx, url, fp, config, cb)
-				if err != nil {
-					return nil, nil, err
-				}
-				return feed, opts, nil
+		// Hold a slot of the caller's fetch gate (if any) for the network
+		// fetch only, so the bound counts fetches in flight; the feed's
+		// priority orders it among the fetches waiting for one.
+		release, err := model.AcquirePriorityFetchSlot(ctx, s.feedPriority(url))
+		if err != nil {
+			return nil, nil, err
+		}
+		start := time.Now()
+		feed, fetchedURL, err := s.fetchFeed(ctx, url, fp, config, circuitBreakerEnabled)
+		elapsed := time.Since(start)
+		release()
+		// A caller giving up says nothing about the feed's health.
+		if err == nil || ctx.Err() == nil {
+			s.fetchTimings.record(url, start, elapsed, err != nil)
+			// A failure changes what the feed reports too (its fetch error).
+			defer s.generation.Add(1)
+		}
+		if err != nil && ctx.Err() == nil {
+			s.errorLog.record(s.feedIDFor(url), url, err)
+		}
+		if s.checkSchedule != nil {
+			switch {
+			case err == nil:
+				s.checkSchedule.recordSuccess(url)
+			case ctx.Err() == nil:
+				s.checkSchedule.recordFailure(url, err)
 			}
 		}
-
-		// Fallback to direct retryable parsing if circuit breaker not enabled or URL not found
-		feed, err := retryableFeedFetch(ctx, url, fp, *config, s.retryMetrics, &s.metricsMutex)
 		if err != nil {
 			return nil, nil, err
 		}
+
+		if len(config.FallbackURLs[url]) > 0 || (config.UpgradeInsecureFeeds && isInsecureURL(url)) {
+			if feed.Custom == nil {
+				feed.Custom = make(map[string]string)
+			}
+			feed.Custom[FetchedURLMetadataKey] = fetchedURL
+		}
+		if cleaner := s.contentCleaners[url]; cleaner != nil {
+			cleaner.apply(feed.Items)
+		}
+		if config.ResolveRelativeURLs == nil || *config.ResolveRelativeURLs {
+			resolveRelativeURLs(feed, fetchedURL)
+		}
+		model.ApplyDublinCoreFallbacks(feed.Items)
+		// Stable IDs come from the item as published: a date synthesized by the
+		// missing-date strategy (use_now) would change the hash on every fetch.
+		model.AssignStableIDs(feed.Items, config.StableIDChain)
+		model.AssignItemLanguages(feed.Items, feed.Language)
+		// Normalize undated items once, at fetch time, so every consumer of the
+		// cached feed (sorting, date filters, export) treats them the same way.
+		feed.Items = model.ApplyMissingDateStrategy(feed.Items, config.MissingDateStrategy, time.Now())
+		if s.categoryNormalizer != nil {
+			s.categoryNormalizer.Apply(feed.Items)
+			if len(feed.Categories) > 0 {
+				feed.Categories = s.categoryNormalizer.NormalizeAll(feed.Categories)
+			}
+		}
+		if config.DeduplicateWithinFeed == nil || *config.DeduplicateWithinFeed {
+			deduplicateFeedItems(feed)
+		}
+		if config.VerifyEnclosures {
+			verifyEnclosures(ctx, config.HTTPClient, feed.Items)
+		}
+		if s.searchIndex != nil {
+			s.searchIndex.update(url, feed.Items)
+		}
+		s.history.record(url, start, feed.Items)
+		if s.duplicates != nil {
+			s.duplicates.record(url, feed.Items)
+		}
 		return feed, opts, nil
 	}
 }
@@ -694,10 +1190,12 @@ func (s *Store) fetchWithCircuitBreaker(
 		WithComponent("circuit_breaker")
 }
 
-// GetAllFeeds returns all configured feeds with their current status
+// GetAllFeeds returns all configured feeds with their current status, sorted
+// by title (see compareFeedResults)
 func (s *Store) GetAllFeeds(ctx context.Context) ([]*model.FeedResult, error) {
 	// Snapshot the feeds under the read lock so the fetches below don't hold it.
-	entries := s.feedEntries()
+	entries := s.byPriority(s.feedEntries())
+	ctx = model.WithDefaultFetchGate(ctx, s.fetchGate)
 	results := make([]*model.FeedResult, len(entries))
 	wg := &sync.WaitGroup{}
 	for idx, entry := range entries {
@@ -727,9 +1225,23 @@ func (s *Store) GetAllFeeds(ctx context.Context) ([]*model.FeedResult, error) {
 		}(idx, entry.id, entry.url)
 	}
 	wg.Wait()
+	slices.SortFunc(results, compareFeedResults)
 	return results, nil
 }
 
+// compareFeedResults orders feeds by title, case-insensitively, so listings
+// don't follow map iteration order. A feed without a title (one that failed to
+// fetch, say) sorts by its ID instead; ties fall back to the ID.
+func compareFeedResults(a, b *model.FeedResult) int {
+	sortKey := func(f *model.FeedResult) string {
+		if f.Title != "" {
+			return strings.ToLower(f.Title)
+		}
+		return strings.ToLower(f.ID)
+	}
+	return cmp.Or(strings.Compare(sortKey(a), sortKey(b)), strings.Compare(a.ID, b.ID))
+}
+
 // GetFeedAndItems returns a specific feed with all its items
 func (s *Store) GetFeedAndItems(ctx context.Context, id string) (*model.FeedAndItemsResult, error) {
 	if url, exists := s.feedURL(id); exists {
@@ -752,7 +1264,7 @@ func (s *Store) GetFeedAndItems(ctx context.Context, id string) (*model.FeedAndI
 
 		result.Title = feed.Title
 		result.Feed = model.FromGoFeed(feed)
-		result.Items = feed.Items
+		result.Items, result.HiddenFutureItems = s.visibleItems(feed.Items)
 
 		return result, nil
 	}
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mmcdole/gofeed"
 	"github.com/richardwooding/hostrate"
 	"github.com/richardwooding/ssrfguard"
+
+	"github.com/richardwooding/feed-mcp/model"
 )
 
 func mockFeedServer(t *testing.T, title string) *httptest.Server {
@@ -1050,6 +1055,121 @@ func TestRetryMechanism_ExhaustsRetries(t *testing.T) {
 	}
 }
 
+// TestRetryMechanism_OverallFetchTimeout verifies that OverallFetchTimeout caps
+// the total time spent on a failing feed regardless of the retry count, and that
+// hitting the cap is reported as a timeout error.
+func TestRetryMechanism_OverallFetchTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer slow.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	const overall = 300 * time.Millisecond
+	tests := []struct {
+		name        string
+		url         string
+		maxAttempts int
+	}{
+		{"deadline during an attempt", slow.URL, 3},
+		{"deadline during backoff", failing.URL, 3},
+		{"many retries", failing.URL, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				Timeout:             5 * time.Second,
+				OverallFetchTimeout: overall,
+				RetryMaxAttempts:    tt.maxAttempts,
+				RetryBaseDelay:      200 * time.Millisecond,
+				RetryMaxDelay:       time.Second,
+				HTTPClient:          NewRateLimitedHTTPClient(100, 100, HTTPPoolConfig{}, true),
+			}
+			parser := gofeed.NewParser()
+			parser.Client = config.HTTPClient
+			metrics := &RetryMetrics{}
+			var mu sync.RWMutex
+
+			start := time.Now()
+			_, err := retryableFeedFetch(context.Background(), tt.url, parser, config, metrics, &mu)
+			elapsed := time.Since(start)
+
+			if elapsed > overall+500*time.Millisecond {
+				t.Errorf("fetch took %v, want at most about %v", elapsed, overall)
+			}
+			var feedErr *model.FeedError
+			if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeTimeout {
+				t.Fatalf("expected timeout FeedError, got %v", err)
+			}
+			if metrics.FailedFeeds != 1 {
+				t.Errorf("FailedFeeds = %d, want 1", metrics.FailedFeeds)
+			}
+		})
+	}
+}
+
+// TestRetryMechanism_RetryMaxElapsedTime verifies that retries against a slow,
+// failing feed stop once the elapsed-time budget would be overrun, even though
+// attempts remain, and that the outcome is reported and counted.
+func TestRetryMechanism_RetryMaxElapsedTime(t *testing.T) {
+	var requests atomic.Int64
+	slowFailing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(150 * time.Millisecond)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer slowFailing.Close()
+
+	const budget = 450 * time.Millisecond
+	disabled := false
+	config := Config{
+		Timeout:               5 * time.Second,
+		RetryMaxAttempts:      10,
+		RetryBaseDelay:        100 * time.Millisecond,
+		RetryMaxDelay:         100 * time.Millisecond,
+		RetryMaxElapsedTime:   budget,
+		CircuitBreakerEnabled: &disabled,
+		HTTPClient:            NewRateLimitedHTTPClient(100, 100, HTTPPoolConfig{}, true),
+	}
+	parser := gofeed.NewParser()
+	parser.Client = config.HTTPClient
+	metrics := &RetryMetrics{}
+	var mu sync.RWMutex
+
+	start := time.Now()
+	_, err := retryableFeedFetch(context.Background(), slowFailing.URL, parser, config, metrics, &mu)
+	elapsed := time.Since(start)
+
+	// Each attempt takes 150ms and each backoff 100ms, so a third attempt
+	// would start past the budget.
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2 of the 10 allowed", got)
+	}
+	if elapsed > budget+300*time.Millisecond {
+		t.Errorf("fetch took %v, want about %v", elapsed, budget)
+	}
+	var feedErr *model.FeedError
+	if !errors.As(err, &feedErr) {
+		t.Fatalf("expected a FeedError, got %v", err)
+	}
+	if !strings.Contains(feedErr.Message, "elapsed budget") {
+		t.Errorf("Message = %q, want it to report the elapsed budget", feedErr.Message)
+	}
+	if feedErr.Attempt != 2 || feedErr.MaxAttempts != 10 {
+		t.Errorf("retry context = %d/%d, want 2/10", feedErr.Attempt, feedErr.MaxAttempts)
+	}
+	if metrics.FailedFeeds != 1 || metrics.ElapsedBudgetExceeded != 1 {
+		t.Errorf("FailedFeeds = %d, ElapsedBudgetExceeded = %d; want 1 and 1", metrics.FailedFeeds, metrics.ElapsedBudgetExceeded)
+	}
+}
+
 func TestRetryMechanism_NonRetryableError(t *testing.T) {
 	var requestCount int64
 
@@ -1274,35 +1394,6 @@ func (e *testError) Error() string {
 	return e.msg
 }
 
-func TestCalculateRetryDelay(t *testing.T) {
-	tests := []struct {
-		name        string
-		attempt     int
-		baseDelay   time.Duration
-		maxDelay    time.Duration
-		useJitter   bool
-		minExpected time.Duration
-		maxExpected time.Duration
-	}{
-		{"first attempt", 1, 100 * time.Millisecond, 10 * time.Second, false, 100 * time.Millisecond, 100 * time.Millisecond},
-		{"second attempt", 2, 100 * time.Millisecond, 10 * time.Second, false, 200 * time.Millisecond, 200 * time.Millisecond},
-		{"third attempt", 3, 100 * time.Millisecond, 10 * time.Second, false, 400 * time.Millisecond, 400 * time.Millisecond},
-		{"capped by max delay", 10, 100 * time.Millisecond, 1 * time.Second, false, 1 * time.Second, 1 * time.Second},
-		{"zero attempt", 0, 100 * time.Millisecond, 10 * time.Second, false, 100 * time.Millisecond, 100 * time.Millisecond},
-		{"with jitter", 2, 100 * time.Millisecond, 10 * time.Second, true, 100 * time.Millisecond, 300 * time.Millisecond},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			delay := calculateRetryDelay(tt.attempt, tt.baseDelay, tt.maxDelay, tt.useJitter)
-
-			if delay < tt.minExpected || delay > tt.maxExpected {
-				t.Errorf("expected delay between %v and %v, got %v", tt.minExpected, tt.maxExpected, delay)
-			}
-		})
-	}
-}
-
 func TestRetryMechanism_DefaultConfiguration(t *testing.T) {
 	var requestCount int64
 
@@ -1639,3 +1730,414 @@ func TestNewStore_LazyStartup(t *testing.T) {
 // Per-host rate-limiting behavior (cross-host parallelism and same-host
 // throttling) is now provided and tested by github.com/richardwooding/hostrate.
 // See TestPerHostIsolation in that module.
+
+// TestStore_MissingDateStrategy verifies the configured strategy is applied to
+// fetched items: undated items are kept by default and dropped with "exclude".
+func TestStore_MissingDateStrategy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Dates</title>
+			<item><title>Dated</title><pubDate>Mon, 01 Jan 2024 00:00:00 GMT</pubDate></item>
+			<item><title>Undated</title></item>
+		</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		strategy  model.MissingDateStrategy
+		wantItems int
+	}{
+		{"", 2},
+		{model.MissingDateInclude, 2},
+		{model.MissingDateExclude, 1},
+	}
+	for _, tt := range tests {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, MissingDateStrategy: tt.strategy})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+		if err != nil {
+			t.Fatalf("GetFeedAndItems failed: %v", err)
+		}
+		if len(result.Items) != tt.wantItems {
+			t.Errorf("strategy %q: got %d items, want %d", tt.strategy, len(result.Items), tt.wantItems)
+		}
+	}
+}
+
+// TestStore_StrictParsing verifies that strict parsing rejects a degenerate feed
+// (which lenient mode accepts) while a minimally-valid feed passes either way.
+func TestStore_StrictParsing(t *testing.T) {
+	feeds := map[string]string{
+		"/valid":      `<rss version="2.0"><channel><title>Valid</title><item><title>Post</title></item></channel></rss>`,
+		"/degenerate": `<rss version="2.0"><channel><item></item></channel></rss>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(feeds[r.URL.Path]))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path    string
+		strict  bool
+		wantErr bool
+	}{
+		{"/valid", false, false},
+		{"/valid", true, false},
+		{"/degenerate", false, false},
+		{"/degenerate", true, true},
+	}
+	for _, tt := range tests {
+		feedURL := srv.URL + tt.path
+		s, err := NewStore(&Config{Feeds: []string{feedURL}, AllowPrivateIPs: true, StrictParsing: tt.strict, RetryMaxAttempts: 3})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		_, err = s.feedCacheManager.Get(context.Background(), feedURL)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s strict=%v: got err %v, wantErr %v", tt.path, tt.strict, err, tt.wantErr)
+		}
+		if err == nil {
+			continue
+		}
+		var feedErr *model.FeedError
+		if !errors.As(err, &feedErr) || feedErr.ErrorType != model.ErrorTypeInvalidFormat {
+			t.Errorf("%s: expected invalid_format FeedError, got %v", tt.path, err)
+		}
+		if metrics := s.GetRetryMetrics(); metrics.TotalRetries != 0 {
+			t.Errorf("%s: strict rejection should not be retried, got %d retries", tt.path, metrics.TotalRetries)
+		}
+	}
+}
+
+func TestStore_CategoryNormalization(t *testing.T) {
+	feeds := map[string]string{
+		"/a": `<rss version="2.0"><channel><title>A</title><item><title>A1</title><category>Tech</category><category>Go</category></item></channel></rss>`,
+		"/b": `<rss version="2.0"><channel><title>B</title><category> Tech </category><item><title>B1</title><category>TECHNOLOGY</category></item></channel></rss>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(feeds[r.URL.Path]))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{
+		Feeds:            []string{srv.URL + "/a", srv.URL + "/b"},
+		AllowPrivateIPs:  true,
+		CategorySynonyms: map[string]string{"tech": "technology"},
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	var categories [][]string
+	for _, path := range []string{"/a", "/b"} {
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL+path))
+		if err != nil || len(result.Items) != 1 {
+			t.Fatalf("%s: GetFeedAndItems = %v, %v", path, result, err)
+		}
+		categories = append(categories, result.Items[0].Categories)
+		if path == "/a" && !slices.Equal(model.OriginalCategories(result.Items[0]), []string{"Tech", "Go"}) {
+			t.Errorf("original categories = %v, want [Tech Go]", model.OriginalCategories(result.Items[0]))
+		}
+		if path == "/b" && !slices.Equal(result.Feed.Categories, []string{"technology"}) {
+			t.Errorf("feed-level categories = %v, want [technology]", result.Feed.Categories)
+		}
+	}
+	if !slices.Equal(categories[0], []string{"technology", "go"}) || !slices.Equal(categories[1], []string{"technology"}) {
+		t.Errorf("normalized categories = %v, want [[technology go] [technology]]", categories)
+	}
+}
+
+func TestStore_StableIDs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>IDs</title>` +
+			`<item><title>With GUID</title><guid>urn:post:1</guid><link>https://example.com/1</link></item>` +
+			`<item><title>Link only</title><link>https://example.com/2?utm_medium=rss</link></item>` +
+			`<item><title>Bare</title><pubDate>Fri, 01 Mar 2024 09:00:00 GMT</pubDate></item>` +
+			`</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	stableIDs := func(chain []model.StableIDSource) []string {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, StableIDChain: chain})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+		if err != nil || len(result.Items) != 3 {
+			t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+		}
+		var ids []string
+		for _, item := range result.Items {
+			ids = append(ids, item.Custom[model.StableIDKey])
+		}
+		return ids
+	}
+
+	ids := stableIDs(nil)
+	if ids[0] != "guid:urn:post:1" || ids[1] != "link:example.com/2" || !strings.HasPrefix(ids[2], "hash:") {
+		t.Errorf("stable IDs = %v", ids)
+	}
+	if again := stableIDs(nil); !slices.Equal(again, ids) {
+		t.Errorf("refetch assigned %v, want %v", again, ids)
+	}
+	if linkFirst := stableIDs([]model.StableIDSource{model.StableIDLink, model.StableIDGUID}); linkFirst[0] != "link:example.com/1" || linkFirst[2] != "" {
+		t.Errorf("link-first stable IDs = %v", linkFirst)
+	}
+}
+
+func TestStore_StableIDsIgnoreSynthesizedDates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Undated</title><item><title>No GUID, link, or date</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	// The ID an undated item hashes to; a use_now stamp must not leak into it.
+	want := model.StableID(&gofeed.Item{Title: "No GUID, link, or date"}, model.DefaultStableIDChain)
+	for fetch := range 2 {
+		s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true, MissingDateStrategy: model.MissingDateUseNow})
+		if err != nil {
+			t.Fatalf("NewStore failed: %v", err)
+		}
+		result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+		if err != nil || len(result.Items) != 1 {
+			t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+		}
+		item := result.Items[0]
+		if item.PublishedParsed == nil {
+			t.Fatal("use_now left the item undated")
+		}
+		if got := item.Custom[model.StableIDKey]; got != want {
+			t.Errorf("fetch %d: stable ID = %q, want %q", fetch+1, got, want)
+		}
+	}
+}
+
+func TestStore_ItemLanguages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/"><channel><title>Mixed</title><language>en</language>` +
+			`<item><title>Declared</title><dc:language>fr</dc:language></item>` +
+			`<item><title>Las noticias del día</title><description>Una mirada a los mercados para este año, con más datos por región.</description></item>` +
+			`<item><title>Untitled</title></item>` +
+			`</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+	if err != nil || len(result.Items) != 3 {
+		t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+	}
+	var languages []string
+	for _, item := range result.Items {
+		languages = append(languages, item.Custom[model.ItemLanguageKey])
+	}
+	// Declared, detected, then the feed's language as the fallback.
+	if want := []string{"fr", "es", "en"}; !slices.Equal(languages, want) {
+		t.Errorf("item languages = %v, want %v", languages, want)
+	}
+}
+
+func TestStore_GetAllFeedsSortedByTitle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		titles := map[string]string{"/b": "beta", "/a": "Alpha", "/c": "gamma", "/a2": "alpha"}
+		title, ok := titles[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>` + title + `</title><item><title>x</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	urls := []string{srv.URL + "/c", srv.URL + "/b", srv.URL + "/broken", srv.URL + "/a2", srv.URL + "/a"}
+	disabled := false
+	s, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, CircuitBreakerEnabled: &disabled, RetryMaxAttempts: 1, RequestsPerSecond: 1000, BurstCapacity: 1000})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	// The untitled broken feed sorts by its ID, which starts with the host's
+	// digits; equal titles fall back to the ID.
+	alpha, alpha2 := model.GenerateFeedID(srv.URL+"/a"), model.GenerateFeedID(srv.URL+"/a2")
+	if alpha2 < alpha {
+		alpha, alpha2 = alpha2, alpha
+	}
+	want := []string{model.GenerateFeedID(srv.URL + "/broken"), alpha, alpha2, model.GenerateFeedID(srv.URL + "/b"), model.GenerateFeedID(srv.URL + "/c")}
+	for range 3 {
+		feeds, err := s.GetAllFeeds(context.Background())
+		if err != nil {
+			t.Fatalf("GetAllFeeds failed: %v", err)
+		}
+		ids := make([]string, len(feeds))
+		for i, feed := range feeds {
+			ids[i] = feed.ID
+		}
+		if !slices.Equal(ids, want) {
+			t.Fatalf("feed order = %v, want %v", ids, want)
+		}
+	}
+}
+
+func TestStore_GetAllFeedsStableOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 20)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/feed%d", srv.URL, i)
+	}
+	disabled := false
+	s, err := NewStore(&Config{Feeds: urls, AllowPrivateIPs: true, CircuitBreakerEnabled: &disabled, RetryMaxAttempts: 1, RequestsPerSecond: 1000, BurstCapacity: 1000})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	// Every fetch fails, so no feed has a title and the order rests on IDs
+	// alone; it must not follow map iteration order between calls.
+	var first []string
+	for range 10 {
+		feeds, err := s.GetAllFeeds(context.Background())
+		if err != nil {
+			t.Fatalf("GetAllFeeds failed: %v", err)
+		}
+		ids := make([]string, len(feeds))
+		for i, feed := range feeds {
+			ids[i] = feed.ID
+		}
+		if first == nil {
+			first = ids
+			if !slices.IsSorted(first) {
+				t.Fatalf("feeds not sorted by ID: %v", first)
+			}
+			continue
+		}
+		if !slices.Equal(ids, first) {
+			t.Fatalf("feed order changed between calls: %v, then %v", first, ids)
+		}
+	}
+}
+
+func TestStore_FeedGeneration(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Gen</title><item><title>One</title></item></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+
+	start := s.FeedGeneration()
+	if _, err := s.GetFeedAndItems(ctx, feedID); err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	loaded := s.FeedGeneration()
+	if loaded == start {
+		t.Fatal("first load did not move the generation")
+	}
+	time.Sleep(10 * time.Millisecond) // let ristretto apply the cache write
+	if _, err := s.GetFeedAndItems(ctx, feedID); err != nil {
+		t.Fatalf("GetFeedAndItems: %v", err)
+	}
+	if got := s.FeedGeneration(); got != loaded {
+		t.Errorf("cache hit moved the generation from %d to %d", loaded, got)
+	}
+	if _, err := s.refreshFeed(ctx, srv.URL); err != nil {
+		t.Fatalf("refreshFeed: %v", err)
+	}
+	if got := s.FeedGeneration(); got == loaded {
+		t.Error("refresh did not move the generation")
+	}
+}
+
+func TestStore_ContentHashAcrossFetches(t *testing.T) {
+	var body atomic.Value
+	body.Store(`<rss version="2.0"><channel><title>Hash</title>` +
+		`<item><guid>urn:1</guid><title>One</title><pubDate>Mon, 02 Mar 2026 09:00:00 GMT</pubDate></item></channel></rss>`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(body.Load().(string)))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	ctx := context.Background()
+	feedID := model.GenerateFeedID(srv.URL)
+	hash := func() string {
+		t.Helper()
+		if _, err := s.refreshFeed(ctx, srv.URL); err != nil {
+			t.Fatalf("refreshFeed: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond) // let ristretto apply the cache write
+		result, err := s.GetFeedAndItems(ctx, feedID)
+		if err != nil {
+			t.Fatalf("GetFeedAndItems: %v", err)
+		}
+		return result.ToMetadata().ContentHash
+	}
+
+	first := hash()
+	if first == "" {
+		t.Fatal("no content hash")
+	}
+	if second := hash(); second != first {
+		t.Errorf("identical fetches hashed to %q and %q", first, second)
+	}
+	body.Store(`<rss version="2.0"><channel><title>Hash</title>` +
+		`<item><guid>urn:2</guid><title>Two</title></item>` +
+		`<item><guid>urn:1</guid><title>One</title><pubDate>Mon, 02 Mar 2026 09:00:00 GMT</pubDate></item></channel></rss>`)
+	if changed := hash(); changed == first {
+		t.Error("a new item left the content hash unchanged")
+	}
+}
+
+func TestStore_DublinCoreFallbacks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		_, _ = w.Write([]byte(`<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/"><title>DC</title>` +
+			`<entry><title>Only Dublin Core</title><dc:creator>Jane Doe</dc:creator><dc:date>2024-03-01T10:00:00Z</dc:date></entry>` +
+			`<entry><title>Own fields</title><author><name>John Roe</name></author><published>2024-02-01T08:00:00Z</published>` +
+			`<dc:creator>Ignored</dc:creator><dc:date>2020-01-01</dc:date></entry>` +
+			`</feed>`))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(&Config{Feeds: []string{srv.URL}, AllowPrivateIPs: true})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+	if err != nil || len(result.Items) != 2 {
+		t.Fatalf("GetFeedAndItems = %v, %v", result, err)
+	}
+	dc, own := result.Items[0], result.Items[1]
+	if dc.Author == nil || dc.Author.Name != "Jane Doe" {
+		t.Errorf("author = %+v, want Jane Doe from dc:creator", dc.Author)
+	}
+	if want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC); dc.PublishedParsed == nil || !dc.PublishedParsed.Equal(want) {
+		t.Errorf("published = %v, want %v from dc:date", dc.PublishedParsed, want)
+	}
+	if own.Author.Name != "John Roe" || own.PublishedParsed.Year() != 2024 {
+		t.Errorf("own fields replaced: author %q, published %v", own.Author.Name, own.PublishedParsed)
+	}
+}
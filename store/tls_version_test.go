@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// newTLS12Server serves selectionRSSBody over TLS 1.2 at most.
+func newTLS12Server(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // rejected handshakes are expected
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPooledTransport_MinTLSVersion(t *testing.T) {
+	srv := newTLS12Server(t)
+
+	fetch := func(minVersion uint16) error {
+		transport := newPooledTransport(HTTPPoolConfig{MinTLSVersion: minVersion}, true)
+		// Trust the test server's certificate, keeping the configured minimum.
+		transport.TLSClientConfig.RootCAs = srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+		resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	if err := fetch(0); err != nil {
+		t.Fatalf("default minimum (TLS 1.2): %v", err)
+	}
+	err := fetch(tls.VersionTLS13)
+	if err == nil {
+		t.Fatal("expected a TLS 1.2 server to be rejected with a TLS 1.3 minimum")
+	}
+	if !model.IsTLSError(err) {
+		t.Errorf("IsTLSError(%v) = false, want true", err)
+	}
+}
+
+func TestStore_MinTLSVersionRejectsOldServer(t *testing.T) {
+	srv := newTLS12Server(t)
+
+	disabled := false
+	s, err := NewStore(&Config{
+		Feeds:                 []string{srv.URL},
+		AllowPrivateIPs:       true,
+		MinTLSVersion:         tls.VersionTLS13,
+		CircuitBreakerEnabled: &disabled,
+		RetryMaxAttempts:      3,
+		RetryBaseDelay:        time.Millisecond,
+		RequestsPerSecond:     1000,
+		BurstCapacity:         1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	result, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(srv.URL))
+	if err != nil || result.FetchError == "" {
+		t.Fatalf("GetFeedAndItems = %+v, %v; want a fetch error", result, err)
+	}
+	recent := s.errorLog.recent()
+	if len(recent) != 1 || recent[0].ErrorType != model.ErrorTypeTLS {
+		t.Fatalf("recorded errors = %+v, want one TLS error", recent)
+	}
+	// A version mismatch isn't transient, so it isn't retried.
+	if !strings.Contains(recent[0].Message, "(1/3)") {
+		t.Errorf("message = %q, want a single attempt", recent[0].Message)
+	}
+}
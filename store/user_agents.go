@@ -0,0 +1,79 @@
+package store
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/net/http/httpguts"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+// UserAgentRotation is how the store picks a User-Agent from
+// Config.UserAgents for each request.
+type UserAgentRotation string
+
+const (
+	// UserAgentRoundRobin cycles through the User-Agents in order.
+	UserAgentRoundRobin UserAgentRotation = "round-robin"
+	// UserAgentRandom picks a User-Agent at random for each request.
+	UserAgentRandom UserAgentRotation = "random"
+)
+
+// userAgentTransport sets the User-Agent of every request to one of a list,
+// so hosts that throttle per User-Agent don't see a single one for every
+// fetch. It covers all requests made through the store's client, including
+// icon lookups and enclosure checks.
+type userAgentTransport struct {
+	base     http.RoundTripper
+	agents   []string
+	rotation UserAgentRotation
+	next     atomic.Uint64
+}
+
+// newUserAgentTransport wraps base so that requests carry one of agents,
+// chosen by rotation ("" means UserAgentRoundRobin). It rejects an unknown
+// rotation and blank or malformed User-Agents. A nil base means
+// http.DefaultTransport.
+func newUserAgentTransport(base http.RoundTripper, agents []string, rotation UserAgentRotation) (*userAgentTransport, error) {
+	switch rotation {
+	case "":
+		rotation = UserAgentRoundRobin
+	case UserAgentRoundRobin, UserAgentRandom:
+	default:
+		return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("invalid User-Agent rotation %q", rotation)).
+			WithOperation("create_store").
+			WithComponent("store_manager").
+			WithSuggestion(fmt.Sprintf("Use %q or %q", UserAgentRoundRobin, UserAgentRandom))
+	}
+	for i, agent := range agents {
+		if strings.TrimSpace(agent) == "" || !httpguts.ValidHeaderFieldValue(agent) {
+			return nil, model.NewFeedError(model.ErrorTypeConfiguration, fmt.Sprintf("User-Agent #%d is empty or contains invalid characters", i+1)).
+				WithOperation("create_store").
+				WithComponent("store_manager")
+		}
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &userAgentTransport{base: base, agents: agents, rotation: rotation}, nil
+}
+
+// userAgent returns the User-Agent for the next request.
+func (t *userAgentTransport) userAgent() string {
+	if t.rotation == UserAgentRandom {
+		return t.agents[rand.IntN(len(t.agents))] //nolint:gosec // G404: spreads load, not security sensitive
+	}
+	return t.agents[(t.next.Add(1)-1)%uint64(len(t.agents))]
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent())
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/richardwooding/feed-mcp/model"
+)
+
+func TestStore_UserAgentRoundRobin(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.UserAgent())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	feeds := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c", srv.URL + "/d"}
+	s, err := NewStore(&Config{
+		Feeds:             feeds,
+		AllowPrivateIPs:   true,
+		UserAgents:        []string{"agent-one", "agent-two", "agent-three"},
+		RequestsPerSecond: 1000,
+		BurstCapacity:     1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	for _, feedURL := range feeds {
+		if _, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(feedURL)); err != nil {
+			t.Fatalf("GetFeedAndItems(%s) failed: %v", feedURL, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"agent-one", "agent-two", "agent-three", "agent-one"}
+	if !slices.Equal(received, want) {
+		t.Errorf("User-Agents = %v, want %v", received, want)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestUserAgentTransport(t *testing.T) {
+	var received []string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		received = append(received, req.UserAgent())
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	agents := []string{"a", "b"}
+
+	send := func(transport http.RoundTripper, n int) {
+		t.Helper()
+		for range n {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com/feed", http.NoBody)
+			req.Header.Set("User-Agent", "Gofeed/1.0")
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			if req.UserAgent() != "Gofeed/1.0" {
+				t.Fatalf("RoundTrip modified the caller's request")
+			}
+		}
+	}
+
+	roundRobin, err := newUserAgentTransport(base, agents, "")
+	if err != nil {
+		t.Fatalf("newUserAgentTransport: %v", err)
+	}
+	send(roundRobin, 5)
+	if want := []string{"a", "b", "a", "b", "a"}; !slices.Equal(received, want) {
+		t.Errorf("round-robin User-Agents = %v, want %v", received, want)
+	}
+
+	received = nil
+	random, err := newUserAgentTransport(base, agents, UserAgentRandom)
+	if err != nil {
+		t.Fatalf("newUserAgentTransport: %v", err)
+	}
+	send(random, 50)
+	for _, agent := range received {
+		if !slices.Contains(agents, agent) {
+			t.Fatalf("random User-Agent %q not in %v", agent, agents)
+		}
+	}
+	if !slices.Contains(received, "a") || !slices.Contains(received, "b") {
+		t.Errorf("random rotation never picked one of %v in 50 requests", agents)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		agents   []string
+		rotation UserAgentRotation
+	}{
+		{"unknown rotation", agents, "sequential"},
+		{"blank agent", []string{"a", " "}, UserAgentRoundRobin},
+		{"newline in agent", []string{"a\r\nX-Injected: 1"}, UserAgentRoundRobin},
+	} {
+		if _, err := newUserAgentTransport(base, tt.agents, tt.rotation); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestStore_PerFeedUserAgentWins(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.UserAgent()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(selectionRSSBody))
+	}))
+	defer srv.Close()
+
+	custom, plain := srv.URL+"/custom", srv.URL+"/plain"
+	s, err := NewStore(&Config{
+		Feeds:             []string{custom, plain},
+		AllowPrivateIPs:   true,
+		UserAgents:        []string{"rotated"},
+		PerFeedHeaders:    map[string]map[string]string{custom: {"User-Agent": "per-feed"}},
+		RequestsPerSecond: 1000,
+		BurstCapacity:     1000,
+	})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	for _, feedURL := range []string{custom, plain} {
+		if _, err := s.GetFeedAndItems(context.Background(), model.GenerateFeedID(feedURL)); err != nil {
+			t.Fatalf("GetFeedAndItems(%s) failed: %v", feedURL, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if received["/custom"] != "per-feed" || received["/plain"] != "rotated" {
+		t.Errorf("User-Agents = %v, want per-feed for /custom and rotated for /plain", received)
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// XMLRecoveryMetadataKey is the gofeed.Feed.Custom key listing the repairs
+// made by lenient XML parsing (comma-separated xmlFix values). It is absent when
+// the feed parsed as served.
+const XMLRecoveryMetadataKey = "feed_mcp_xml_recovery"
+
+// Repairs recorded under XMLRecoveryMetadataKey.
+const (
+	xmlFixEntities     = "entities"
+	xmlFixControlChars = "control_chars"
+	xmlFixInvalidUTF8  = "invalid_utf8"
+)
+
+// xmlEncodingDecl matches the encoding in an XML declaration.
+var xmlEncodingDecl = regexp.MustCompile(`^\s*<\?xml[^>]*encoding\s*=\s*["']([^"']+)["']`)
+
+// xmlPredefinedEntities are the entities every XML parser knows; they are left
+// as they are.
+var xmlPredefinedEntities = map[string]bool{"amp": true, "lt": true, "gt": true, "quot": true, "apos": true}
+
+// sanitizeXML repairs common defects that make an XML feed unparseable:
+// undeclared HTML entities (&nbsp;, &eacute;, ...) are rewritten as numeric
+// character references, characters XML forbids (most C0 controls) are
+// stripped, and, for UTF-8 documents, invalid byte sequences are replaced with
+// U+FFFD. CDATA sections keep their entities verbatim. It returns the repaired
+// body and the repairs made; no repairs means body was returned unchanged.
+func sanitizeXML(body []byte) ([]byte, []string) {
+	utf8Doc := true
+	if m := xmlEncodingDecl.FindSubmatch(body); m != nil {
+		enc := strings.ToLower(string(m[1]))
+		utf8Doc = enc == "utf-8" || enc == "utf8"
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(body))
+	fixed := make(map[string]bool)
+
+	inCDATA := false
+	for i := 0; i < len(body); {
+		switch {
+		case !inCDATA && bytes.HasPrefix(body[i:], []byte("<![CDATA[")):
+			inCDATA = true
+			out.WriteString("<![CDATA[")
+			i += len("<![CDATA[")
+			continue
+		case inCDATA && bytes.HasPrefix(body[i:], []byte("]]>")):
+			inCDATA = false
+			out.WriteString("]]>")
+			i += len("]]>")
+			continue
+		case !inCDATA && body[i] == '&':
+			if replacement, n, ok := replaceEntity(body[i:]); ok {
+				out.WriteString(replacement)
+				i += n
+				fixed[xmlFixEntities] = true
+				continue
+			}
+		}
+
+		if !utf8Doc || body[i] < utf8.RuneSelf {
+			if b := body[i]; b < 0x20 && b != '\t' && b != '\n' && b != '\r' {
+				fixed[xmlFixControlChars] = true
+			} else {
+				out.WriteByte(b)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(body[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			out.WriteRune(utf8.RuneError)
+			fixed[xmlFixInvalidUTF8] = true
+		case !isXMLChar(r):
+			fixed[xmlFixControlChars] = true
+		default:
+			out.Write(body[i : i+size])
+		}
+		i += size
+	}
+
+	if len(fixed) == 0 {
+		return body, nil
+	}
+	var fixes []string
+	for _, fix := range []string{xmlFixEntities, xmlFixControlChars, xmlFixInvalidUTF8} {
+		if fixed[fix] {
+			fixes = append(fixes, fix)
+		}
+	}
+	return out.Bytes(), fixes
+}
+
+// replaceEntity rewrites the entity reference at the start of b when it names
+// an HTML entity XML doesn't predefine. It returns the numeric character
+// references to write and the number of bytes consumed.
+func replaceEntity(b []byte) (string, int, bool) {
+	end := bytes.IndexByte(b, ';')
+	if end < 2 || end > 32 {
+		return "", 0, false
+	}
+	name := string(b[1:end])
+	if name[0] == '#' || xmlPredefinedEntities[name] {
+		return "", 0, false
+	}
+	ref := string(b[:end+1])
+	decoded := html.UnescapeString(ref)
+	if decoded == ref {
+		return "", 0, false
+	}
+	var refs strings.Builder
+	for _, r := range decoded {
+		fmt.Fprintf(&refs, "&#%d;", r)
+	}
+	return refs.String(), end + 1, true
+}
+
+// isXMLChar reports whether r may appear in an XML 1.0 document.
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		(r >= 0x20 && r <= 0xD7FF) ||
+		(r >= 0xE000 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0x10FFFF)
+}
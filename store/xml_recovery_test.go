@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestSanitizeXML(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      string
+		wantFixes []string
+	}{
+		{
+			name: "well-formed body is untouched",
+			body: `<rss><channel><title>A &amp; B &#160;</title></channel></rss>`,
+			want: `<rss><channel><title>A &amp; B &#160;</title></channel></rss>`,
+		},
+		{
+			name:      "undeclared entities become character references",
+			body:      `<title>A&nbsp;B&eacute; &bogus; &lt;</title>`,
+			want:      `<title>A&#160;B&#233; &bogus; &lt;</title>`,
+			wantFixes: []string{xmlFixEntities},
+		},
+		{
+			name:      "CDATA keeps its entities",
+			body:      "<description><![CDATA[<p>a&nbsp;b</p>]]>&copy;</description>",
+			want:      "<description><![CDATA[<p>a&nbsp;b</p>]]>&#169;</description>",
+			wantFixes: []string{xmlFixEntities},
+		},
+		{
+			name:      "forbidden control characters are stripped",
+			body:      "<title>A\x01B\x0b\tC\ufffe</title>",
+			want:      "<title>AB\tC</title>",
+			wantFixes: []string{xmlFixControlChars},
+		},
+		{
+			name:      "invalid UTF-8 is replaced",
+			body:      "<title>caf\xe9</title>",
+			want:      "<title>caf\ufffd</title>",
+			wantFixes: []string{xmlFixInvalidUTF8},
+		},
+		{
+			name: "non-UTF-8 documents keep their bytes",
+			body: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><title>caf\xe9</title>",
+			want: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><title>caf\xe9</title>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fixes := sanitizeXML([]byte(tt.body))
+			if string(got) != tt.want {
+				t.Errorf("sanitizeXML() = %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(fixes, tt.wantFixes) {
+				t.Errorf("fixes = %v, want %v", fixes, tt.wantFixes)
+			}
+		})
+	}
+}
+
+func TestFetchAndParseFeed_LenientXML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte("<rss version=\"2.0\"><channel><title>Caf&eacute;&nbsp;News</title>" +
+			"<item><title>Broken\x01 item</title></item></channel></rss>"))
+	}))
+	defer srv.Close()
+
+	if _, err := fetchAndParseFeed(context.Background(), srv.URL, gofeed.NewParser(), parseOptions{verifyComplete: true}); err == nil {
+		t.Fatal("expected the malformed feed to fail without lenient parsing")
+	}
+
+	feed, err := fetchAndParseFeed(context.Background(), srv.URL, gofeed.NewParser(), parseOptions{lenientXML: true, verifyComplete: true})
+	if err != nil {
+		t.Fatalf("lenient parse failed: %v", err)
+	}
+	if feed.Title != "Caf\u00e9\u00a0News" || len(feed.Items) != 1 || feed.Items[0].Title != "Broken item" {
+		t.Errorf("unexpected recovered feed: title %q, items %d", feed.Title, len(feed.Items))
+	}
+	if got := feed.Custom[XMLRecoveryMetadataKey]; got != "entities,control_chars" {
+		t.Errorf("%s = %q, want entities,control_chars", XMLRecoveryMetadataKey, got)
+	}
+}